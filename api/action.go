@@ -0,0 +1,128 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/containerd/errdefs"
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type ActionHandler struct {
+	containerService   services.IContainerService
+	healthcheckService services.IHealthcheckService
+	jwtMiddleware      middlewares.IJWTMiddleware
+}
+
+func NewActionHandler(containerService services.IContainerService, healthcheckService services.IHealthcheckService, jwtMiddleware middlewares.IJWTMiddleware) *ActionHandler {
+	return &ActionHandler{containerService, healthcheckService, jwtMiddleware}
+}
+
+func (h *ActionHandler) SetupRoutes(r *gin.Engine) {
+	actionRoutes := r.Group("/containers", h.jwtMiddleware.RequireScope("container:update"))
+	{
+		actionRoutes.POST("/:id/actions/:action", requireOwnership(h.containerService), h.Action)
+	}
+}
+
+// Action godoc
+// @Summary Run a container action
+// @Description Restart (with an optional stop timeout), pause, unpause or kill (with an optional signal) a container. The action is recorded in the healthcheck history, a failure to record it being reported in the error of a successful response.
+// @Tags containers
+// @Produce json
+// @Param id path string true "Container ID"
+// @Param action path string true "Action to run" Enums(restart, pause, unpause, kill)
+// @Param timeout query int false "Seconds to wait for the container to stop before killing it (restart only)"
+// @Param signal query string false "Signal to send, defaults to SIGKILL (kill only)"
+// @Success 200 {object} dto.APIResponse{data=dto.ActionResponse} "Container action completed successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user"
+// @Failure 404 {object} dto.APIResponse "Container not found"
+// @Failure 409 {object} dto.APIResponse "Action conflicts with the container state"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/{id}/actions/{action} [post]
+func (h *ActionHandler) Action(c *gin.Context) {
+	action := dto.ContainerAction(c.Param("action"))
+	switch action {
+	case dto.ActionRestart, dto.ActionPause, dto.ActionUnpause, dto.ActionKill:
+	default:
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid container action",
+			Error:   "unknown action: " + string(action),
+		})
+		return
+	}
+
+	var query dto.ActionQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid action parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	containerId := c.Param("id")
+	status, err := h.containerService.RunAction(c.Request.Context(), containerId, action, query)
+	if errors.Is(err, services.ErrContainerNotFound) {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_FOUND",
+			Message: "Container not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrContainerConflict) {
+		c.JSON(http.StatusConflict, dto.APIResponse{
+			Success: false,
+			Code:    "CONFLICT",
+			Message: "Action conflicts with the container state",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errdefs.IsInvalidArgument(err) {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid action parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to run container action",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	response := dto.APIResponse{
+		Success: true,
+		Code:    "CONTAINER_ACTION_COMPLETED",
+		Message: "Container action completed successfully",
+		Data: dto.ActionResponse{
+			ContainerId: containerId,
+			Action:      action,
+			Status:      status,
+		},
+	}
+	// The action already took effect, so a failure to record it, logged by the service, must not have it retried.
+	if err := h.healthcheckService.RecordAction(c.Request.Context(), containerId, status, action); err != nil {
+		response.Message = "Container action completed but could not be recorded"
+		response.Error = err.Error()
+	}
+	c.JSON(http.StatusOK, response)
+}
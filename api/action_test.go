@@ -0,0 +1,194 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/containerd/errdefs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+	usecases "github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type ActionHandlerSuite struct {
+	suite.Suite
+	ctrl                   *gomock.Controller
+	mockContainerService   *services.MockIContainerService
+	mockHealthcheckService *services.MockIHealthcheckService
+	mockJWTMiddleware      *middlewares.MockIJWTMiddleware
+	handler                *ActionHandler
+	router                 *gin.Engine
+}
+
+func (s *ActionHandlerSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockContainerService = services.NewMockIContainerService(s.ctrl)
+	s.mockHealthcheckService = services.NewMockIHealthcheckService(s.ctrl)
+	s.mockJWTMiddleware = middlewares.NewMockIJWTMiddleware(s.ctrl)
+
+	s.mockJWTMiddleware.EXPECT().
+		RequireScope(gomock.Any()).
+		Return(func(c *gin.Context) {
+			c.Set("userId", "user-id")
+			c.Next()
+		}).
+		AnyTimes()
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil).
+		AnyTimes()
+
+	s.handler = NewActionHandler(s.mockContainerService, s.mockHealthcheckService, s.mockJWTMiddleware)
+
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.handler.SetupRoutes(s.router)
+}
+
+func (s *ActionHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestActionHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ActionHandlerSuite))
+}
+
+func (s *ActionHandlerSuite) TestRestart() {
+	timeout := 5
+	s.mockContainerService.EXPECT().
+		RunAction(gomock.Any(), "container-id", dto.ActionRestart, dto.ActionQuery{Timeout: &timeout}).
+		Return(entities.ContainerOn, nil)
+	s.mockHealthcheckService.EXPECT().
+		RecordAction(gomock.Any(), "container-id", entities.ContainerOn, dto.ActionRestart).
+		Return(nil)
+
+	req := httptest.NewRequest("POST", "/containers/container-id/actions/restart?timeout=5", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response struct {
+		dto.APIResponse
+		Data dto.ActionResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("CONTAINER_ACTION_COMPLETED", response.Code)
+	s.Equal(dto.ActionResponse{ContainerId: "container-id", Action: dto.ActionRestart, Status: entities.ContainerOn}, response.Data)
+}
+
+func (s *ActionHandlerSuite) TestKill() {
+	s.mockContainerService.EXPECT().
+		RunAction(gomock.Any(), "container-id", dto.ActionKill, dto.ActionQuery{Signal: "SIGTERM"}).
+		Return(entities.ContainerOff, nil)
+	s.mockHealthcheckService.EXPECT().
+		RecordAction(gomock.Any(), "container-id", entities.ContainerOff, dto.ActionKill).
+		Return(nil)
+
+	req := httptest.NewRequest("POST", "/containers/container-id/actions/kill?signal=SIGTERM", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *ActionHandlerSuite) TestUnknownAction() {
+	req := httptest.NewRequest("POST", "/containers/container-id/actions/explode", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ActionHandlerSuite) TestInvalidTimeout() {
+	req := httptest.NewRequest("POST", "/containers/container-id/actions/restart?timeout=-1", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ActionHandlerSuite) TestInvalidSignal() {
+	s.mockContainerService.EXPECT().
+		RunAction(gomock.Any(), "container-id", dto.ActionKill, gomock.Any()).
+		Return(entities.ContainerStatus(""), errdefs.ErrInvalidArgument)
+
+	req := httptest.NewRequest("POST", "/containers/container-id/actions/kill?signal=SIGNOPE", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ActionHandlerSuite) TestNotFound() {
+	s.mockContainerService.EXPECT().
+		RunAction(gomock.Any(), "container-id", dto.ActionPause, gomock.Any()).
+		Return(entities.ContainerStatus(""), fmt.Errorf("%w: container-id", usecases.ErrContainerNotFound))
+
+	req := httptest.NewRequest("POST", "/containers/container-id/actions/pause", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *ActionHandlerSuite) TestConflict() {
+	s.mockContainerService.EXPECT().
+		RunAction(gomock.Any(), "container-id", dto.ActionUnpause, gomock.Any()).
+		Return(entities.ContainerStatus(""), fmt.Errorf("%w: container is not paused", usecases.ErrContainerConflict))
+
+	req := httptest.NewRequest("POST", "/containers/container-id/actions/unpause", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusConflict, w.Code)
+}
+
+func (s *ActionHandlerSuite) TestServiceError() {
+	s.mockContainerService.EXPECT().
+		RunAction(gomock.Any(), "container-id", dto.ActionPause, gomock.Any()).
+		Return(entities.ContainerStatus(""), errors.New("service error"))
+
+	req := httptest.NewRequest("POST", "/containers/container-id/actions/pause", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *ActionHandlerSuite) TestRecordActionError() {
+	s.mockContainerService.EXPECT().
+		RunAction(gomock.Any(), "container-id", dto.ActionPause, gomock.Any()).
+		Return(entities.ContainerPaused, nil)
+	s.mockHealthcheckService.EXPECT().
+		RecordAction(gomock.Any(), "container-id", entities.ContainerPaused, dto.ActionPause).
+		Return(errors.New("es error"))
+
+	req := httptest.NewRequest("POST", "/containers/container-id/actions/pause", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response struct {
+		dto.APIResponse
+		Data dto.ActionResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.True(response.Success)
+	s.Equal("Container action completed but could not be recorded", response.Message)
+	s.Equal("es error", response.Error)
+	s.Equal(entities.ContainerPaused, response.Data.Status)
+}
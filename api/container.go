@@ -1,9 +1,17 @@
 package api
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
+	"mime/multipart"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/containerd/errdefs"
 	"github.com/gin-gonic/gin"
 	"github.com/vnFuhung2903/vcs-sms/dto"
 	"github.com/vnFuhung2903/vcs-sms/pkg/middlewares"
@@ -26,35 +34,141 @@ func (h *ContainerHandler) SetupRoutes(r *gin.Engine) {
 		{
 			createGroup.POST("/create", h.Create)
 			createGroup.POST("/import", h.Import)
+			createGroup.POST("/:id/snapshot", requireOwnership(h.containerService), h.Snapshot)
+			createGroup.POST("/:id/clone", requireOwnership(h.containerService), h.Clone)
 		}
 
 		viewGroup := containerRoutes.Group("", h.jwtMiddleware.RequireScope("container:view"))
 		{
 			viewGroup.GET("/view", h.View)
 			viewGroup.GET("/export", h.Export)
+			viewGroup.GET("/:id/snapshots", requireOwnership(h.containerService), h.Snapshots)
+			viewGroup.GET("/:id/inspect", requireOwnership(h.containerService), h.Inspect)
+			viewGroup.GET("/:id/top", requireOwnership(h.containerService), h.Top)
 		}
 
 		modifyGroup := containerRoutes.Group("", h.jwtMiddleware.RequireScope("container:update"))
 		{
-			modifyGroup.PUT("/update/:id", h.Update)
+			modifyGroup.PUT("/update/:id", requireOwnership(h.containerService), h.Update)
+			modifyGroup.PATCH("/:id", requireOwnership(h.containerService), h.Update)
+			modifyGroup.PUT("/transfer/:id", requireOwnership(h.containerService), h.Transfer)
 		}
 
 		deleteGroup := containerRoutes.Group("", h.jwtMiddleware.RequireScope("container:delete"))
 		{
-			deleteGroup.DELETE("/delete/:id", h.Delete)
+			deleteGroup.DELETE("/delete/:id", requireOwnership(h.containerService), h.Delete)
 		}
+
+		logsGroup := containerRoutes.Group("", h.jwtMiddleware.RequireScope("container:logs"))
+		{
+			logsGroup.GET("/:id/logs", requireOwnership(h.containerService), h.Logs)
+		}
+	}
+}
+
+func isContainerAdmin(c *gin.Context) bool {
+	return slices.Contains(c.GetStringSlice("scopes"), "container:admin")
+}
+
+func requireOwnership(containerService services.IContainerService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if isContainerAdmin(c) {
+			c.Next()
+			return
+		}
+
+		container, err := containerService.FindById(c.Request.Context(), c.Param("id"))
+		if errors.Is(err, services.ErrContainerNotFound) {
+			c.AbortWithStatusJSON(http.StatusNotFound, dto.APIResponse{
+				Success: false,
+				Code:    "NOT_FOUND",
+				Message: "Container not found",
+				Error:   err.Error(),
+			})
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, dto.APIResponse{
+				Success: false,
+				Code:    "INTERNAL_SERVER_ERROR",
+				Message: "Failed to retrieve container",
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		if container.OwnerId != c.GetString("userId") {
+			c.AbortWithStatusJSON(http.StatusForbidden, dto.APIResponse{
+				Success: false,
+				Code:    "FORBIDDEN",
+				Message: "Container is owned by another user",
+				Error:   "forbidden",
+			})
+			return
+		}
+		c.Next()
 	}
 }
 
+// bindContainerFilter reads the container filter shared by the endpoints listing containers.
+func bindContainerFilter(c *gin.Context) (dto.ContainerFilter, error) {
+	var query dto.ContainerQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return dto.ContainerFilter{}, err
+	}
+	return query.Filter()
+}
+
+// importFormat picks the format of an uploaded file from the format query parameter, then the Content-Type of the file part,
+// then its extension, falling back to XLSX.
+func importFormat(c *gin.Context, header *multipart.FileHeader) (dto.FileFormat, error) {
+	var query dto.FormatQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return "", err
+	}
+	if query.Format != "" {
+		return query.Format, nil
+	}
+	if format, ok := dto.FormatFromContentType(header.Header.Get("Content-Type")); ok {
+		return format, nil
+	}
+	if format, ok := dto.FormatFromFilename(header.Filename); ok {
+		return format, nil
+	}
+	return dto.FormatXLSX, nil
+}
+
+// exportFormat picks the format of an export from the format query parameter, then the Accept header, XLSX being preferred.
+// It returns an empty format when the Accept header allows none of them.
+func exportFormat(c *gin.Context) (dto.FileFormat, error) {
+	var query dto.FormatQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return "", err
+	}
+	if query.Format != "" {
+		return query.Format, nil
+	}
+
+	offered := make([]string, 0, len(dto.FileFormats))
+	for _, format := range dto.FileFormats {
+		offered = append(offered, format.ContentType())
+	}
+	format, _ := dto.FormatFromContentType(c.NegotiateFormat(offered...))
+	return format, nil
+}
+
 // Create godoc
 // @Summary Create a new container
-// @Description Create a container with name and image
+// @Description Create a container with name, image and an optional spec (ports, env, volumes, command, labels, restart policy).
+// @Description An ephemeral container expires at expires_at or after ttl (e.g. 2h30m), its owner being notified before it is deleted.
+// @Description Bind mounts are only allowed from the host directories configured in CONTAINER_BIND_SOURCES.
 // @Tags containers
 // @Accept json
 // @Produce json
 // @Param body body dto.CreateRequest true "Container creation request"
 // @Success 201 {object} dto.APIResponse "Container created successfully"
 // @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Quota exceeded, image denied by the registry policy or bind mount not allowed"
 // @Failure 500 {object} dto.APIResponse "Internal server error"
 // @Security BearerAuth
 // @Router /containers/create [post]
@@ -70,7 +184,55 @@ func (h *ContainerHandler) Create(c *gin.Context) {
 		return
 	}
 
-	_, err := h.containerService.Create(c.Request.Context(), req.ContainerName, req.ImageName)
+	expiresAt, err := req.Resolve(time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid expiry",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	userId := c.GetString("userId")
+	_, err = h.containerService.Create(c.Request.Context(), req.ContainerName, req.ImageName, req.ContainerSpec, userId, expiresAt)
+	if errors.Is(err, services.ErrQuotaExceeded) {
+		c.JSON(http.StatusForbidden, dto.APIResponse{
+			Success: false,
+			Code:    "QUOTA_EXCEEDED",
+			Message: "Container quota exceeded",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrImageNotAllowed) {
+		c.JSON(http.StatusForbidden, dto.APIResponse{
+			Success: false,
+			Code:    "IMAGE_NOT_ALLOWED",
+			Message: "Image denied by the registry policy",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrMountNotAllowed) {
+		c.JSON(http.StatusForbidden, dto.APIResponse{
+			Success: false,
+			Code:    "MOUNT_NOT_ALLOWED",
+			Message: "Bind mount outside the allowed host directories",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errdefs.IsInvalidArgument(err) {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid image reference",
+			Error:   err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.APIResponse{
 			Success: false,
@@ -90,18 +252,30 @@ func (h *ContainerHandler) Create(c *gin.Context) {
 
 // View godoc
 // @Summary View containers
-// @Description Retrieve a list of containers with optional pagination, filtering, and sorting
+// @Description Retrieve a list of containers with optional pagination, filtering, and sorting.
+// @Description Pages are selected by keyset when cursor or limit is set, following the next and prev cursors of the previous page, and by from/to offsets otherwise.
 // @Tags containers
 // @Produce json
-// @Param from query int false "From index (default 1)" default(1)
-// @Param to query int false "To index (default -1 for all)" default(-1)
+// @Param from query int false "From index (default 1), offset mode only" default(1)
+// @Param to query int false "To index (default -1 for all), offset mode only" default(-1)
+// @Param cursor query string false "Opaque next or prev cursor of a previous page, made for the same sort"
+// @Param limit query int false "Page size in keyset mode (default 50)" minimum(1) maximum(1000)
+// @Param with_total query bool false "Count the matching containers in keyset mode"
 // @Param container_id query string false "Filter by ContainerId"
-// @Param container_name query string false "Filter by ContainerName"
-// @Param status query string false "Filter by Status" Enums(ON, OFF)
-// @Param ipv4 query string false "Filter by IPv4"
+// @Param container_name query string false "Filter by part of the ContainerName"
+// @Param image_name query string false "Filter by part of the ImageName"
+// @Param status query string false "Filter by comma separated statuses among CREATING, ON, OFF, STARTING, HEALTHY, UNHEALTHY, RESTARTING, PAUSED, EXITED, MISSING, UNKNOWN (e.g. ON,OFF)"
+// @Param ipv4 query string false "Filter by IPv4 address or CIDR block (e.g. 172.17.0.0/16)"
+// @Param owner_id query string false "Filter by owner (container:admin only, others only see their own containers)"
+// @Param created_after query string false "Created at or after this RFC 3339 time"
+// @Param created_before query string false "Created at or before this RFC 3339 time"
+// @Param updated_after query string false "Updated at or after this RFC 3339 time"
+// @Param updated_before query string false "Updated at or before this RFC 3339 time"
+// @Param labels query string false "Comma separated label selector of key=value, key!=value, key and !key requirements (e.g. env=prod,team!=infra)"
+// @Param q query string false "Free text matched against the id, name, image and IPv4"
 // @Param field query string true "Sort by field" Enums(container_id, container_name, status, ipv4, created_at, updated_at)
 // @Param order query string true "Sort order" Enums(asc, desc)
-// @Success 200 {object} dto.APIResponse "Successful response with container list"
+// @Success 200 {object} dto.APIResponse{data=dto.PageResponse} "Successful response with container list, a dto.ViewResponse in offset mode"
 // @Failure 400 {object} dto.APIResponse "Bad request"
 // @Failure 500 {object} dto.APIResponse "Internal server error"
 // @Security BearerAuth
@@ -128,9 +302,8 @@ func (h *ContainerHandler) View(c *gin.Context) {
 		return
 	}
 
-	var filter dto.ContainerFilter
-	var sort dto.ContainerSort
-	if err := c.ShouldBindQuery(&filter); err != nil {
+	filter, err := bindContainerFilter(c)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, dto.APIResponse{
 			Success: false,
 			Code:    "BAD_REQUEST",
@@ -140,6 +313,7 @@ func (h *ContainerHandler) View(c *gin.Context) {
 		return
 	}
 
+	var sort dto.ContainerSort
 	if err := c.ShouldBindQuery(&sort); err != nil {
 		c.JSON(http.StatusBadRequest, dto.APIResponse{
 			Success: false,
@@ -149,6 +323,14 @@ func (h *ContainerHandler) View(c *gin.Context) {
 		})
 		return
 	}
+	if !isContainerAdmin(c) {
+		filter.OwnerId = c.GetString("userId")
+	}
+
+	if c.Query("cursor") != "" || c.Query("limit") != "" {
+		h.page(c, filter, sort)
+		return
+	}
 
 	containers, total, err := h.containerService.View(c.Request.Context(), filter, from, to, sort)
 	if err != nil {
@@ -172,9 +354,50 @@ func (h *ContainerHandler) View(c *gin.Context) {
 	})
 }
 
+// page answers View in keyset mode, with a page of containers and the cursors around it.
+func (h *ContainerHandler) page(c *gin.Context, filter dto.ContainerFilter, sort dto.ContainerSort) {
+	var query dto.PageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	page, err := h.containerService.Page(c.Request.Context(), filter, query, sort)
+	if errdefs.IsInvalidArgument(err) {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to retrieve containers",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "CONTAINERS_RETRIEVED",
+		Message: "Containers retrieved successfully",
+		Data:    page,
+	})
+}
+
 // Update godoc
 // @Summary Update a container
-// @Description Update container information by container ID
+// @Description Update the fields set in the payload: start or stop the container, which is recorded in the healthcheck history as an operator action, rename it in docker and in the database together, or edit its description and database labels. Labels are merged into the existing ones, a null value removing the label. Label filters match the database labels first and the docker labels of the container otherwise.
 // @Tags containers
 // @Accept json
 // @Produce json
@@ -182,8 +405,12 @@ func (h *ContainerHandler) View(c *gin.Context) {
 // @Param body body dto.ContainerUpdate true "Container update payload"
 // @Success 200 {object} dto.APIResponse "Container updated successfully"
 // @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user"
+// @Failure 404 {object} dto.APIResponse "Container not found"
+// @Failure 409 {object} dto.APIResponse "Container name already in use"
 // @Failure 500 {object} dto.APIResponse "Internal server error"
 // @Security BearerAuth
+// @Router /containers/{id} [patch]
 // @Router /containers/update/{id} [put]
 func (h *ContainerHandler) Update(c *gin.Context) {
 	containerId := c.Param("id")
@@ -198,8 +425,44 @@ func (h *ContainerHandler) Update(c *gin.Context) {
 		})
 		return
 	}
+	if err := updateData.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
 
 	err := h.containerService.Update(c.Request.Context(), containerId, updateData)
+	if errors.Is(err, services.ErrContainerNotFound) {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_FOUND",
+			Message: "Container not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrContainerNameTaken) {
+		c.JSON(http.StatusConflict, dto.APIResponse{
+			Success: false,
+			Code:    "CONFLICT",
+			Message: "Container name already in use",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errdefs.IsInvalidArgument(err) {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid container name",
+			Error:   err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.APIResponse{
 			Success: false,
@@ -217,13 +480,267 @@ func (h *ContainerHandler) Update(c *gin.Context) {
 	})
 }
 
+// Transfer godoc
+// @Summary Transfer a container
+// @Description Transfer ownership of a container to another user
+// @Tags containers
+// @Accept json
+// @Produce json
+// @Param id path string true "Container ID"
+// @Param body body dto.TransferRequest true "New owner"
+// @Success 200 {object} dto.APIResponse "Container transferred successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user or new owner's quota exceeded"
+// @Failure 404 {object} dto.APIResponse "Container or new owner not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/transfer/{id} [put]
+func (h *ContainerHandler) Transfer(c *gin.Context) {
+	containerId := c.Param("id")
+
+	var req dto.TransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	err := h.containerService.Transfer(c.Request.Context(), containerId, req.OwnerId)
+	if errors.Is(err, services.ErrContainerNotFound) {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_FOUND",
+			Message: "Container not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrUserNotFound) {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_FOUND",
+			Message: "New owner not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrQuotaExceeded) {
+		c.JSON(http.StatusForbidden, dto.APIResponse{
+			Success: false,
+			Code:    "QUOTA_EXCEEDED",
+			Message: "Container quota exceeded",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to transfer container",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "CONTAINER_TRANSFERRED",
+		Message: "Container transferred successfully",
+	})
+}
+
+// Snapshot godoc
+// @Summary Snapshot a container
+// @Description Commit the filesystem of a container to a local image, pausing it meanwhile, and record the image with the container spec
+// @Tags containers
+// @Produce json
+// @Param id path string true "Container ID"
+// @Param tag query string false "Image tag (default the UTC time as 20060102150405)"
+// @Param comment query string false "Commit message"
+// @Success 201 {object} dto.APIResponse{data=entities.Snapshot} "Snapshot created successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user"
+// @Failure 404 {object} dto.APIResponse "Container not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/{id}/snapshot [post]
+func (h *ContainerHandler) Snapshot(c *gin.Context) {
+	var query dto.SnapshotQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	snapshot, err := h.containerService.Snapshot(c.Request.Context(), c.Param("id"), query)
+	if errors.Is(err, services.ErrContainerNotFound) {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_FOUND",
+			Message: "Container not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errdefs.IsInvalidArgument(err) {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid snapshot tag",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to snapshot container",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Code:    "CONTAINER_SNAPSHOT_CREATED",
+		Message: "Snapshot created successfully",
+		Data:    snapshot,
+	})
+}
+
+// Snapshots godoc
+// @Summary View container snapshots
+// @Description List the snapshots of a container, latest first
+// @Tags containers
+// @Produce json
+// @Param id path string true "Container ID"
+// @Success 200 {object} dto.APIResponse{data=[]entities.Snapshot} "Snapshots retrieved successfully"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user"
+// @Failure 404 {object} dto.APIResponse "Container not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/{id}/snapshots [get]
+func (h *ContainerHandler) Snapshots(c *gin.Context) {
+	snapshots, err := h.containerService.Snapshots(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to retrieve snapshots",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "SNAPSHOTS_RETRIEVED",
+		Message: "Snapshots retrieved successfully",
+		Data:    snapshots,
+	})
+}
+
+// Clone godoc
+// @Summary Clone a container
+// @Description Create a container for the caller from a snapshot of the container, or from its image and spec when no snapshot is given. Host ports are picked by docker.
+// @Tags containers
+// @Accept json
+// @Produce json
+// @Param id path string true "Container ID"
+// @Param body body dto.CloneRequest true "Clone name and optional snapshot"
+// @Success 201 {object} dto.APIResponse{data=entities.Container} "Container cloned successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user, quota exceeded, image denied by the registry policy or bind mount not allowed"
+// @Failure 404 {object} dto.APIResponse "Container or snapshot not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/{id}/clone [post]
+func (h *ContainerHandler) Clone(c *gin.Context) {
+	var req dto.CloneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	container, err := h.containerService.Clone(c.Request.Context(), c.Param("id"), req, c.GetString("userId"))
+	if errors.Is(err, services.ErrContainerNotFound) || errors.Is(err, services.ErrSnapshotNotFound) {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_FOUND",
+			Message: "Container or snapshot not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrQuotaExceeded) {
+		c.JSON(http.StatusForbidden, dto.APIResponse{
+			Success: false,
+			Code:    "QUOTA_EXCEEDED",
+			Message: "Container quota exceeded",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrImageNotAllowed) {
+		c.JSON(http.StatusForbidden, dto.APIResponse{
+			Success: false,
+			Code:    "IMAGE_NOT_ALLOWED",
+			Message: "Image denied by the registry policy",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrMountNotAllowed) {
+		c.JSON(http.StatusForbidden, dto.APIResponse{
+			Success: false,
+			Code:    "MOUNT_NOT_ALLOWED",
+			Message: "Bind mount outside the allowed host directories",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to clone container",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Code:    "CONTAINER_CLONED",
+		Message: "Container cloned successfully",
+		Data:    container,
+	})
+}
+
 // Delete godoc
 // @Summary Delete a container
-// @Description Delete a container by its ID
+// @Description Move a container to the trash by its ID, stopping it; it can be restored until it is purged at the end of the retention period
 // @Tags containers
 // @Produce json
 // @Param id path string true "Container ID"
 // @Success 200 {object} dto.APIResponse "Container deleted successfully"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user"
+// @Failure 404 {object} dto.APIResponse "Container not found"
 // @Failure 500 {object} dto.APIResponse "Internal server error"
 // @Security BearerAuth
 // @Router /containers/delete/{id} [delete]
@@ -247,20 +764,213 @@ func (h *ContainerHandler) Delete(c *gin.Context) {
 	})
 }
 
+// Inspect godoc
+// @Summary Inspect a container
+// @Description Read the docker inspect data of a container: image and its registry digest, command, mounts, networks, restart count, exit code, OOM kill and start/finish times
+// @Tags containers
+// @Produce json
+// @Param id path string true "Container ID"
+// @Success 200 {object} dto.APIResponse{data=dto.InspectResponse} "Container inspected successfully"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user"
+// @Failure 404 {object} dto.APIResponse "Container not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/{id}/inspect [get]
+func (h *ContainerHandler) Inspect(c *gin.Context) {
+	inspect, err := h.containerService.Inspect(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, services.ErrContainerNotFound) {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_FOUND",
+			Message: "Container not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to inspect container",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "CONTAINER_INSPECTED",
+		Message: "Container inspected successfully",
+		Data:    inspect,
+	})
+}
+
+// Top godoc
+// @Summary List container processes
+// @Description List the processes running in a container, as ps shows them
+// @Tags containers
+// @Produce json
+// @Param id path string true "Container ID"
+// @Success 200 {object} dto.APIResponse{data=dto.TopResponse} "Container processes retrieved successfully"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user"
+// @Failure 404 {object} dto.APIResponse "Container not found"
+// @Failure 409 {object} dto.APIResponse "Container is not running"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/{id}/top [get]
+func (h *ContainerHandler) Top(c *gin.Context) {
+	top, err := h.containerService.Top(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, services.ErrContainerNotFound) {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_FOUND",
+			Message: "Container not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrContainerNotRunning) {
+		c.JSON(http.StatusConflict, dto.APIResponse{
+			Success: false,
+			Code:    "CONFLICT",
+			Message: "Container is not running",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to list container processes",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "CONTAINER_PROCESSES_RETRIEVED",
+		Message: "Container processes retrieved successfully",
+		Data:    top,
+	})
+}
+
+// Logs godoc
+// @Summary Stream container logs
+// @Description Stream stdout/stderr of a container as chunked text, or as Server-Sent Events when the client accepts text/event-stream
+// @Tags containers
+// @Produce plain
+// @Produce text/event-stream
+// @Param id path string true "Container ID"
+// @Param follow query bool false "Keep the stream open for new output"
+// @Param tail query string false "Number of lines from the end of the logs, or all" default(all)
+// @Param since query string false "Show logs since a timestamp (RFC3339 or unix) or relative duration (e.g. 10m)"
+// @Param until query string false "Show logs before a timestamp (RFC3339 or unix) or relative duration (e.g. 10m)"
+// @Param timestamps query bool false "Prefix each line with its timestamp"
+// @Success 200 {string} string "Log stream"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user"
+// @Failure 404 {object} dto.APIResponse "Container not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/{id}/logs [get]
+func (h *ContainerHandler) Logs(c *gin.Context) {
+	var query dto.LogsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid logs parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	logs, err := h.containerService.Logs(c.Request.Context(), c.Param("id"), query)
+	if errors.Is(err, services.ErrContainerNotFound) {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_FOUND",
+			Message: "Container not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errdefs.IsInvalidArgument(err) {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid logs parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to stream container logs",
+			Error:   err.Error(),
+		})
+		return
+	}
+	defer logs.Close()
+
+	sse := strings.Contains(c.GetHeader("Accept"), "text/event-stream")
+	if sse {
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+	} else {
+		c.Header("Content-Type", "text/plain; charset=utf-8")
+		c.Header("X-Content-Type-Options", "nosniff")
+	}
+	c.Status(http.StatusOK)
+
+	scanner := bufio.NewScanner(logs)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if sse {
+			c.SSEvent("log", scanner.Text())
+		} else {
+			c.Writer.Write(append(scanner.Bytes(), '\n'))
+		}
+		c.Writer.Flush()
+	}
+	if err := scanner.Err(); err != nil && sse && c.Request.Context().Err() == nil {
+		c.SSEvent("error", err.Error())
+		c.Writer.Flush()
+	}
+}
+
 // Import godoc
-// @Summary Import containers from Excel
-// @Description Import containers using an Excel (.xlsx) file
+// @Summary Import containers
+// @Description Import containers from an Excel (.xlsx), CSV, JSON, NDJSON or YAML file, picked by the format parameter, the Content-Type or the extension of the file. Spreadsheets and CSV files need the "Container Name" and "Image Name" header columns, with the optional "Ports", "Env" and "Labels" columns holding entries separated by ";" (e.g. "8080:80/tcp", "KEY=value", "team=core"); JSON and YAML files hold a list of records with the same fields as the export. Every row is validated first and reported with its row number, column and error code, then each valid row is created on its own so a failing row never undoes the others. Rows naming a container already imported with the same image are skipped, so the same file can be imported again safely.
 // @Tags containers
 // @Accept multipart/form-data
 // @Produce json
-// @Param file formData file true "Excel file containing container data"
-// @Success 200 {object} dto.APIResponse "Import result with success and failure counts"
+// @Param file formData file true "File containing container data"
+// @Param format query string false "Format of the file" Enums(xlsx, csv, json, ndjson, yaml)
+// @Param dry_run query bool false "Only validate the file and report what would be imported"
+// @Success 200 {object} dto.APIResponse{data=dto.ImportResponse} "Import result with success, failure and skip counts"
 // @Failure 400 {object} dto.APIResponse "Bad request"
 // @Failure 500 {object} dto.APIResponse "Internal server error"
 // @Security BearerAuth
 // @Router /containers/import [post]
 func (h *ContainerHandler) Import(c *gin.Context) {
-	file, _, err := c.Request.FormFile("file")
+	var query dto.ImportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, dto.APIResponse{
 			Success: false,
@@ -272,7 +982,19 @@ func (h *ContainerHandler) Import(c *gin.Context) {
 	}
 	defer file.Close()
 
-	result, err := h.containerService.Import(c.Request.Context(), file)
+	format, err := importFormat(c, header)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	userId := c.GetString("userId")
+	result, err := h.containerService.Import(c.Request.Context(), file, format, userId, query.DryRun)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.APIResponse{
 			Success: false,
@@ -283,29 +1005,52 @@ func (h *ContainerHandler) Import(c *gin.Context) {
 		return
 	}
 
+	if query.DryRun {
+		c.JSON(http.StatusOK, dto.APIResponse{
+			Success: true,
+			Code:    "IMPORT_VALIDATED",
+			Message: "Import file validated successfully",
+			Data:    result,
+		})
+		return
+	}
 	c.JSON(http.StatusOK, dto.APIResponse{
 		Success: true,
 		Code:    "CONTAINERS_IMPORTED",
 		Message: "Containers imported successfully",
-		Data:    dto.ImportResponse(*result),
+		Data:    result,
 	})
 }
 
 // Export godoc
-// @Summary Export containers to Excel
-// @Description Export containers with optional filters and sorting to an Excel file
+// @Summary Export containers
+// @Description Export containers with optional filters and sorting to an Excel (.xlsx), CSV, JSON, NDJSON or YAML file, picked by the format parameter or the Accept header. Rows are streamed from the database as they are encoded, so an error past the first rows ends the download early instead of returning a 500.
 // @Tags containers
 // @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce text/csv
+// @Produce json
+// @Produce application/x-ndjson
+// @Produce application/yaml
 // @Param from query int false "From index (default 1)" default(1)
 // @Param to query int false "To index (default -1 for all)" default(-1)
 // @Param container_id query string false "Filter by ContainerId"
-// @Param container_name query string false "Filter by ContainerName"
-// @Param status query string false "Filter by Status" Enums(ON, OFF)
-// @Param ipv4 query string false "Filter by IPv4"
+// @Param container_name query string false "Filter by part of the ContainerName"
+// @Param image_name query string false "Filter by part of the ImageName"
+// @Param status query string false "Filter by comma separated statuses among CREATING, ON, OFF, STARTING, HEALTHY, UNHEALTHY, RESTARTING, PAUSED, EXITED, MISSING, UNKNOWN (e.g. ON,OFF)"
+// @Param ipv4 query string false "Filter by IPv4 address or CIDR block (e.g. 172.17.0.0/16)"
+// @Param owner_id query string false "Filter by owner (container:admin only, others only see their own containers)"
+// @Param created_after query string false "Created at or after this RFC 3339 time"
+// @Param created_before query string false "Created at or before this RFC 3339 time"
+// @Param updated_after query string false "Updated at or after this RFC 3339 time"
+// @Param updated_before query string false "Updated at or before this RFC 3339 time"
+// @Param labels query string false "Comma separated label selector of key=value, key!=value, key and !key requirements (e.g. env=prod,team!=infra)"
+// @Param q query string false "Free text matched against the id, name, image and IPv4"
 // @Param field query string true "Sort by field" Enums(container_id, container_name, status, ipv4, created_at, updated_at)
 // @Param order query string true "Sort order" Enums(asc, desc)
-// @Success 200 {file} file "Excel file containing container data"
+// @Param format query string false "Format of the file, overriding the Accept header" Enums(xlsx, csv, json, ndjson, yaml)
+// @Success 200 {file} file "File containing container data"
 // @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 406 {object} dto.APIResponse "No supported format accepted"
 // @Failure 500 {object} dto.APIResponse "Internal server error"
 // @Security BearerAuth
 // @Router /containers/export [get]
@@ -331,9 +1076,8 @@ func (h *ContainerHandler) Export(c *gin.Context) {
 		return
 	}
 
-	var filter dto.ContainerFilter
-	var sort dto.ContainerSort
-	if err := c.ShouldBindQuery(&filter); err != nil {
+	filter, err := bindContainerFilter(c)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, dto.APIResponse{
 			Success: false,
 			Code:    "BAD_REQUEST",
@@ -343,6 +1087,7 @@ func (h *ContainerHandler) Export(c *gin.Context) {
 		return
 	}
 
+	var sort dto.ContainerSort
 	if err := c.ShouldBindQuery(&sort); err != nil {
 		c.JSON(http.StatusBadRequest, dto.APIResponse{
 			Success: false,
@@ -352,9 +1097,42 @@ func (h *ContainerHandler) Export(c *gin.Context) {
 		})
 		return
 	}
+	if !isContainerAdmin(c) {
+		filter.OwnerId = c.GetString("userId")
+	}
 
-	data, err := h.containerService.Export(c.Request.Context(), filter, from, to, sort)
+	format, err := exportFormat(c)
 	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if format == "" {
+		c.JSON(http.StatusNotAcceptable, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_ACCEPTABLE",
+			Message: "Export format not supported",
+			Error:   "supported formats are xlsx, csv, json, ndjson and yaml",
+		})
+		return
+	}
+
+	c.Header("Content-Description", "File Transfer")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="containers.%s"`, format))
+	c.Header("Content-Type", format.ContentType())
+	if err := h.containerService.Export(c.Request.Context(), filter, from, to, sort, format, c.Writer); err != nil {
+		// Once rows are sent the status cannot change anymore, the client sees a truncated file.
+		if c.Writer.Written() {
+			c.Abort()
+			return
+		}
+		c.Writer.Header().Del("Content-Description")
+		c.Writer.Header().Del("Content-Disposition")
+		c.Writer.Header().Del("Content-Type")
 		c.JSON(http.StatusInternalServerError, dto.APIResponse{
 			Success: false,
 			Code:    "INTERNAL_SERVER_ERROR",
@@ -363,7 +1141,6 @@ func (h *ContainerHandler) Export(c *gin.Context) {
 		})
 		return
 	}
-	c.Header("Content-Description", "File Transfer")
-	c.Header("Content-Disposition", `attachment; filename="containers.xlsx"`)
-	c.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", data)
+	c.Status(http.StatusOK)
+	c.Writer.WriteHeaderNow()
 }
@@ -2,14 +2,21 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/containerd/errdefs"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
@@ -18,6 +25,7 @@ import (
 	"github.com/vnFuhung2903/vcs-sms/entities"
 	"github.com/vnFuhung2903/vcs-sms/mocks/middlewares"
 	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+	usecases "github.com/vnFuhung2903/vcs-sms/usecases/services"
 )
 
 type ContainerHandlerSuite struct {
@@ -37,6 +45,7 @@ func (s *ContainerHandlerSuite) SetupTest() {
 	s.mockJWTMiddleware.EXPECT().
 		RequireScope(gomock.Any()).
 		Return(func(c *gin.Context) {
+			c.Set("userId", "user-id")
 			c.Next()
 		}).
 		AnyTimes()
@@ -64,7 +73,7 @@ func (s *ContainerHandlerSuite) TestCreate() {
 	}
 
 	s.mockContainerService.EXPECT().
-		Create(gomock.Any(), "test-container", "nginx").
+		Create(gomock.Any(), "test-container", "nginx", entities.ContainerSpec{}, "user-id", (*time.Time)(nil)).
 		Return(container, nil)
 
 	reqBody := dto.CreateRequest{
@@ -86,6 +95,136 @@ func (s *ContainerHandlerSuite) TestCreate() {
 	s.Equal("CONTAINER_CREATED", response.Code)
 }
 
+func (s *ContainerHandlerSuite) TestCreateWithSpec() {
+	spec := entities.ContainerSpec{
+		Env:           []string{"KEY=value"},
+		Ports:         []entities.PortBinding{{ContainerPort: 80, HostPort: 8080, Protocol: "tcp"}},
+		Volumes:       []entities.VolumeMount{{Type: "volume", Source: "data", Target: "/data"}},
+		RestartPolicy: entities.RestartPolicy{Name: "always"},
+	}
+	s.mockContainerService.EXPECT().
+		Create(gomock.Any(), "test-container", "nginx", spec, "user-id", (*time.Time)(nil)).
+		Return(&entities.Container{ContainerId: "1", ContainerName: "test-container", Spec: spec}, nil)
+
+	reqBody := dto.CreateRequest{
+		ContainerName: "test-container",
+		ImageName:     "nginx",
+		ContainerSpec: spec,
+	}
+	jsonData, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest("POST", "/containers/create", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusCreated, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestCreateWithTTL() {
+	s.mockContainerService.EXPECT().
+		Create(gomock.Any(), "test-container", "nginx", entities.ContainerSpec{}, "user-id", gomock.Any()).
+		DoAndReturn(func(_ any, _ string, _ string, _ entities.ContainerSpec, _ string, expiresAt *time.Time) (*entities.Container, error) {
+			s.NotNil(expiresAt)
+			s.WithinDuration(time.Now().Add(2*time.Hour), *expiresAt, time.Minute)
+			return &entities.Container{ContainerId: "1", ExpiresAt: expiresAt}, nil
+		})
+
+	body := `{"container_name":"test-container","image_name":"nginx","ttl":"2h"}`
+	req := httptest.NewRequest("POST", "/containers/create", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusCreated, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestCreateInvalidExpiry() {
+	body := `{"container_name":"test-container","image_name":"nginx","ttl":"soon"}`
+	req := httptest.NewRequest("POST", "/containers/create", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("Invalid expiry", response.Message)
+}
+
+func (s *ContainerHandlerSuite) TestCreateImageNotAllowed() {
+	s.mockContainerService.EXPECT().
+		Create(gomock.Any(), "test-container", "nginx:latest", entities.ContainerSpec{}, "user-id", (*time.Time)(nil)).
+		Return(nil, fmt.Errorf("%w: tag latest is denied", usecases.ErrImageNotAllowed))
+
+	body := `{"container_name":"test-container","image_name":"nginx:latest"}`
+	req := httptest.NewRequest("POST", "/containers/create", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusForbidden, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("IMAGE_NOT_ALLOWED", response.Code)
+}
+
+func (s *ContainerHandlerSuite) TestCreateBindNotAllowed() {
+	spec := entities.ContainerSpec{
+		Volumes: []entities.VolumeMount{{Type: "bind", Source: "/var/run/docker.sock", Target: "/var/run/docker.sock"}},
+	}
+	s.mockContainerService.EXPECT().
+		Create(gomock.Any(), "test-container", "nginx", spec, "user-id", (*time.Time)(nil)).
+		Return(nil, fmt.Errorf("%w: bind source \"/var/run/docker.sock\" is outside the allowed host directories", usecases.ErrMountNotAllowed))
+
+	body := `{"container_name":"test-container","image_name":"nginx","volumes":[{"type":"bind","source":"/var/run/docker.sock","target":"/var/run/docker.sock"}]}`
+	req := httptest.NewRequest("POST", "/containers/create", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusForbidden, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("MOUNT_NOT_ALLOWED", response.Code)
+}
+
+func (s *ContainerHandlerSuite) TestCreateInvalidImage() {
+	s.mockContainerService.EXPECT().
+		Create(gomock.Any(), "test-container", "Invalid Image", entities.ContainerSpec{}, "user-id", (*time.Time)(nil)).
+		Return(nil, errdefs.ErrInvalidArgument)
+
+	body := `{"container_name":"test-container","image_name":"Invalid Image"}`
+	req := httptest.NewRequest("POST", "/containers/create", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestCreateInvalidSpec() {
+	body := `{"container_name":"test-container","image_name":"nginx","ports":[{"container_port":70000}],"restart_policy":{"name":"sometimes"}}`
+	req := httptest.NewRequest("POST", "/containers/create", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Contains(response.Error, "ContainerPort")
+	s.Contains(response.Error, "Name")
+}
+
 func (s *ContainerHandlerSuite) TestCreateInvalidRequestBody() {
 	req := httptest.NewRequest("POST", "/containers/create", strings.NewReader("invalid json"))
 	req.Header.Set("Content-Type", "application/json")
@@ -102,7 +241,7 @@ func (s *ContainerHandlerSuite) TestCreateInvalidRequestBody() {
 
 func (s *ContainerHandlerSuite) TestCreateServiceError() {
 	s.mockContainerService.EXPECT().
-		Create(gomock.Any(), "test-container", "nginx").
+		Create(gomock.Any(), "test-container", "nginx", entities.ContainerSpec{}, "user-id", (*time.Time)(nil)).
 		Return((*entities.Container)(nil), errors.New("service error"))
 
 	reqBody := dto.CreateRequest{
@@ -124,6 +263,30 @@ func (s *ContainerHandlerSuite) TestCreateServiceError() {
 	s.Equal("service error", response.Error)
 }
 
+func (s *ContainerHandlerSuite) TestCreateQuotaExceeded() {
+	s.mockContainerService.EXPECT().
+		Create(gomock.Any(), "test-container", "nginx", entities.ContainerSpec{}, "user-id", (*time.Time)(nil)).
+		Return(nil, fmt.Errorf("%w: at most 1 containers allowed", usecases.ErrQuotaExceeded))
+
+	reqBody := dto.CreateRequest{
+		ContainerName: "test-container",
+		ImageName:     "nginx",
+	}
+	jsonData, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest("POST", "/containers/create", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusForbidden, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("QUOTA_EXCEEDED", response.Code)
+}
+
 func (s *ContainerHandlerSuite) TestView() {
 	containers := []*entities.Container{
 		{ContainerId: "1", ContainerName: "container1", Status: entities.ContainerOn},
@@ -191,7 +354,7 @@ func (s *ContainerHandlerSuite) TestViewInvalidFilterParameter() {
 	var response dto.APIResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	s.NoError(err)
-	s.Contains(response.Error, "Field validation for 'Status' failed on the 'oneof' tag")
+	s.Contains(response.Error, "invalid status \"invalid-status\"")
 }
 
 func (s *ContainerHandlerSuite) TestViewInvalidSortParameter() {
@@ -225,6 +388,9 @@ func (s *ContainerHandlerSuite) TestViewServiceError() {
 }
 
 func (s *ContainerHandlerSuite) TestUpdate() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
 	s.mockContainerService.EXPECT().
 		Update(gomock.Any(), "container-id", gomock.Any()).
 		Return(nil)
@@ -248,6 +414,9 @@ func (s *ContainerHandlerSuite) TestUpdate() {
 }
 
 func (s *ContainerHandlerSuite) TestUpdateInvalidRequestBody() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
 	req := httptest.NewRequest("PUT", "/containers/update/container-id", strings.NewReader("invalid json"))
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
@@ -262,6 +431,9 @@ func (s *ContainerHandlerSuite) TestUpdateInvalidRequestBody() {
 }
 
 func (s *ContainerHandlerSuite) TestUpdateServiceError() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
 	s.mockContainerService.EXPECT().
 		Update(gomock.Any(), "container-id", gomock.Any()).
 		Return(errors.New("service error"))
@@ -285,6 +457,9 @@ func (s *ContainerHandlerSuite) TestUpdateServiceError() {
 }
 
 func (s *ContainerHandlerSuite) TestDelete() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
 	s.mockContainerService.EXPECT().
 		Delete(gomock.Any(), "container-id").
 		Return(nil)
@@ -302,6 +477,9 @@ func (s *ContainerHandlerSuite) TestDelete() {
 }
 
 func (s *ContainerHandlerSuite) TestDeleteServiceError() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
 	s.mockContainerService.EXPECT().
 		Delete(gomock.Any(), "container-id").
 		Return(errors.New("service error"))
@@ -319,136 +497,988 @@ func (s *ContainerHandlerSuite) TestDeleteServiceError() {
 	s.Equal("service error", response.Error)
 }
 
-func (s *ContainerHandlerSuite) TestExport() {
-	csvData := []byte("id,name,status\n1,container1,running")
+func (s *ContainerHandlerSuite) adminRouter() *gin.Engine {
+	jwtMiddleware := middlewares.NewMockIJWTMiddleware(s.ctrl)
+	jwtMiddleware.EXPECT().
+		RequireScope(gomock.Any()).
+		Return(func(c *gin.Context) {
+			c.Set("userId", "admin-id")
+			c.Set("scopes", []string{"container:admin"})
+			c.Next()
+		}).
+		AnyTimes()
 
+	router := gin.New()
+	NewContainerHandler(s.mockContainerService, jwtMiddleware).SetupRoutes(router)
+	return router
+}
+
+func (s *ContainerHandlerSuite) TestViewOwnedOnly() {
 	s.mockContainerService.EXPECT().
-		Export(gomock.Any(), gomock.Any(), 1, -1, gomock.Any()).
-		Return(csvData, nil)
+		View(gomock.Any(), dto.ContainerFilter{OwnerId: "user-id"}, 1, 10, gomock.Any()).
+		Return([]*entities.Container{}, int64(0), nil)
 
-	req := httptest.NewRequest("GET", "/containers/export?field=container_id&order=desc", nil)
+	req := httptest.NewRequest("GET", "/containers/view?from=1&to=10&field=container_id&order=desc&owner_id=other-id", nil)
 	w := httptest.NewRecorder()
 
 	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestViewAsAdmin() {
+	s.mockContainerService.EXPECT().
+		View(gomock.Any(), dto.ContainerFilter{OwnerId: "other-id"}, 1, 10, gomock.Any()).
+		Return([]*entities.Container{}, int64(0), nil)
+
+	req := httptest.NewRequest("GET", "/containers/view?from=1&to=10&field=container_id&order=desc&owner_id=other-id", nil)
+	w := httptest.NewRecorder()
 
+	s.adminRouter().ServeHTTP(w, req)
 	s.Equal(http.StatusOK, w.Code)
-	s.Equal("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", w.Header().Get("Content-Type"))
-	s.Equal("attachment; filename=\"containers.xlsx\"", w.Header().Get("Content-Disposition"))
-	s.Equal(csvData, w.Body.Bytes())
 }
 
-func (s *ContainerHandlerSuite) TestExportInvalidFromParameter() {
-	req := httptest.NewRequest("GET", "/containers/export?from=invalid", nil)
+func (s *ContainerHandlerSuite) TestViewWithQueryLanguage() {
+	s.mockContainerService.EXPECT().
+		View(gomock.Any(), dto.ContainerFilter{
+			Status:        []entities.ContainerStatus{entities.ContainerOn, entities.ContainerOff},
+			Ipv4:          "10.0.0.0/24",
+			OwnerId:       "user-id",
+			CreatedAfter:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			CreatedBefore: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			Labels: []dto.LabelRequirement{
+				{Key: "env", Operator: dto.LabelEquals, Value: "prod"},
+				{Key: "team", Operator: dto.LabelNotEquals, Value: "infra"},
+				{Key: "backup", Operator: dto.LabelNotExists},
+			},
+			Q: "web",
+		}, 1, 10, gomock.Any()).
+		Return([]*entities.Container{}, int64(0), nil)
+
+	query := url.Values{
+		"from":           {"1"},
+		"to":             {"10"},
+		"field":          {"container_id"},
+		"order":          {"desc"},
+		"status":         {"ON,off"},
+		"ipv4":           {"10.0.0.0/24"},
+		"created_after":  {"2024-01-01T00:00:00Z"},
+		"created_before": {"2024-02-01T00:00:00Z"},
+		"labels":         {"env=prod,team!=infra,!backup"},
+		"q":              {" web "},
+	}
+	req := httptest.NewRequest("GET", "/containers/view?"+query.Encode(), nil)
 	w := httptest.NewRecorder()
 
 	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestViewInvalidQueryLanguage() {
+	queries := map[string]string{
+		"labels":         "env=prod,=infra",
+		"ipv4":           "example.com",
+		"created_before": "not-a-time",
+		"q":              strings.Repeat("a", 101),
+	}
+	for key, value := range queries {
+		query := url.Values{"field": {"container_id"}, "order": {"desc"}, key: {value}}
+		req := httptest.NewRequest("GET", "/containers/view?"+query.Encode(), nil)
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, req)
+		s.Equal(http.StatusBadRequest, w.Code, key)
+
+		var response dto.APIResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		s.NoError(err)
+		s.NotContains(response.Error, "ContainerSort", key)
+	}
+}
+
+func (s *ContainerHandlerSuite) TestViewInvalidTimeRange() {
+	req := httptest.NewRequest("GET", "/containers/view?created_after=2024-02-01T00:00:00Z&created_before=2024-01-01T00:00:00Z", nil)
+	w := httptest.NewRecorder()
 
+	s.router.ServeHTTP(w, req)
 	s.Equal(http.StatusBadRequest, w.Code)
 
 	var response dto.APIResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	s.NoError(err)
-	s.Contains(response.Error, "invalid syntax")
+	s.Contains(response.Error, "Field validation for 'CreatedBefore' failed on the 'gtefield' tag")
 }
 
-func (s *ContainerHandlerSuite) TestExportInvalidToParameter() {
-	req := httptest.NewRequest("GET", "/containers/export?from=1&to=invalid-number", nil)
+func (s *ContainerHandlerSuite) TestViewByCursor() {
+	sort := dto.ContainerSort{Field: "container_id", Order: dto.Asc}
+	cursor := dto.ContainerCursor{Field: "container_id", Order: dto.Asc, Value: "b", ContainerId: "b"}.Encode()
+	s.mockContainerService.EXPECT().
+		Page(gomock.Any(), dto.ContainerFilter{OwnerId: "user-id"}, dto.PageQuery{Cursor: cursor, Limit: 2, WithTotal: true}, sort).
+		Return(&dto.PageResponse{Data: []*entities.Container{{ContainerId: "c"}}, Prev: "prev-cursor"}, nil)
+
+	query := url.Values{"cursor": {cursor}, "limit": {"2"}, "with_total": {"true"}, "field": {"container_id"}, "order": {"asc"}}
+	req := httptest.NewRequest("GET", "/containers/view?"+query.Encode(), nil)
 	w := httptest.NewRecorder()
 
 	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
 
-	s.Equal(http.StatusBadRequest, w.Code)
-
-	var response dto.APIResponse
+	var response struct {
+		Data dto.PageResponse `json:"data"`
+	}
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	s.NoError(err)
-	s.Contains(response.Error, "invalid syntax")
+	s.Equal("prev-cursor", response.Data.Prev)
+	s.Len(response.Data.Data, 1)
 }
 
-func (s *ContainerHandlerSuite) TestExportServiceError() {
+func (s *ContainerHandlerSuite) TestViewByCursorInvalidLimit() {
+	req := httptest.NewRequest("GET", "/containers/view?limit=1001&field=container_id&order=asc", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestViewByCursorInvalidCursor() {
 	s.mockContainerService.EXPECT().
-		Export(gomock.Any(), gomock.Any(), 1, -1, gomock.Any()).
-		Return([]byte{}, errors.New("service error"))
+		Page(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("%w: invalid cursor", errdefs.ErrInvalidArgument))
 
-	req := httptest.NewRequest("GET", "/containers/export?field=container_id&order=desc", nil)
+	req := httptest.NewRequest("GET", "/containers/view?cursor=abc&field=container_id&order=asc", nil)
 	w := httptest.NewRecorder()
 
 	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestViewByCursorServiceError() {
+	s.mockContainerService.EXPECT().
+		Page(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("db error"))
 
+	req := httptest.NewRequest("GET", "/containers/view?limit=10&field=container_id&order=asc", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
 	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestUpdateNotOwner() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "other-id"}, nil)
+
+	jsonData, _ := json.Marshal(dto.ContainerUpdate{Status: entities.ContainerOff})
+	req := httptest.NewRequest("PUT", "/containers/update/container-id", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusForbidden, w.Code)
 
 	var response dto.APIResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	s.NoError(err)
-	s.Equal("service error", response.Error)
+	s.Equal("FORBIDDEN", response.Code)
 }
 
-func (s *ContainerHandlerSuite) TestExportInvalidFilterParameter() {
-	req := httptest.NewRequest("GET", "/containers/export?status=invalid-status", nil)
+func (s *ContainerHandlerSuite) TestUpdateContainerNotFound() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(nil, fmt.Errorf("%w: container-id", usecases.ErrContainerNotFound))
+
+	jsonData, _ := json.Marshal(dto.ContainerUpdate{Status: entities.ContainerOff})
+	req := httptest.NewRequest("PUT", "/containers/update/container-id", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
 	s.router.ServeHTTP(w, req)
-
-	s.Equal(http.StatusBadRequest, w.Code)
+	s.Equal(http.StatusNotFound, w.Code)
 
 	var response dto.APIResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	s.NoError(err)
-	s.Contains(response.Error, "Field validation for 'Status' failed on the 'oneof' tag")
+	s.Equal("NOT_FOUND", response.Code)
 }
 
-func (s *ContainerHandlerSuite) TestExportInvalidSortParameter() {
-	req := httptest.NewRequest("GET", "/containers/export?order=invalid-order", nil)
+func (s *ContainerHandlerSuite) patch(body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("PATCH", "/containers/container-id", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
-
 	s.router.ServeHTTP(w, req)
+	return w
+}
 
-	s.Equal(http.StatusBadRequest, w.Code)
+func (s *ContainerHandlerSuite) TestPatch() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+	s.mockContainerService.EXPECT().
+		Update(gomock.Any(), "container-id", gomock.Any()).
+		DoAndReturn(func(_ any, _ string, updateData dto.ContainerUpdate) error {
+			s.Equal("web-2", updateData.ContainerName)
+			s.Equal("web frontend", *updateData.Description)
+			s.Equal("prod", *updateData.Labels["env"])
+			s.Contains(updateData.Labels, "stale")
+			s.Nil(updateData.Labels["stale"])
+			s.Empty(updateData.Status)
+			return nil
+		})
+
+	w := s.patch(`{"container_name":"web-2","description":"web frontend","labels":{"env":"prod","stale":null}}`)
+	s.Equal(http.StatusOK, w.Code)
 
 	var response dto.APIResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	s.NoError(err)
-	s.Contains(response.Error, "Field validation for 'Order' failed on the 'oneof' tag")
+	s.Equal("CONTAINER_UPDATED", response.Code)
 }
 
-func (s *ContainerHandlerSuite) TestImport() {
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-	part, _ := writer.CreateFormFile("file", "test.csv")
-	part.Write([]byte("id,name,status\n1,test,running"))
-	writer.Close()
+func (s *ContainerHandlerSuite) TestPatchInvalid() {
+	for _, body := range []string{`{}`, `{"labels":{"bad key":"x"}}`, `{"status":"PAUSED"}`} {
+		s.mockContainerService.EXPECT().
+			FindById(gomock.Any(), "container-id").
+			Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
 
-	result := &dto.ImportResponse{
-		SuccessCount:      1,
-		SuccessContainers: []string{"test"},
-		FailedCount:       0,
-		FailedContainers:  []string{},
+		w := s.patch(body)
+		s.Equal(http.StatusBadRequest, w.Code, body)
+	}
+}
+
+func (s *ContainerHandlerSuite) TestPatchErrors() {
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{fmt.Errorf("%w: container-id", usecases.ErrContainerNotFound), http.StatusNotFound},
+		{fmt.Errorf("%w: db", usecases.ErrContainerNameTaken), http.StatusConflict},
+		{fmt.Errorf("%w: container name must match", errdefs.ErrInvalidArgument), http.StatusBadRequest},
+		{errors.New("db error"), http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		s.mockContainerService.EXPECT().
+			FindById(gomock.Any(), "container-id").
+			Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+		s.mockContainerService.EXPECT().
+			Update(gomock.Any(), "container-id", gomock.Any()).
+			Return(test.err)
+
+		w := s.patch(`{"container_name":"db"}`)
+		s.Equal(test.code, w.Code, test.err.Error())
 	}
+}
 
+func (s *ContainerHandlerSuite) TestDeleteOwnershipLookupError() {
 	s.mockContainerService.EXPECT().
-		Import(gomock.Any(), gomock.Any()).
-		Return(result, nil)
+		FindById(gomock.Any(), "container-id").
+		Return(nil, errors.New("db error"))
 
-	req := httptest.NewRequest("POST", "/containers/import", body)
-	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req := httptest.NewRequest("DELETE", "/containers/delete/container-id", nil)
 	w := httptest.NewRecorder()
 
 	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestDeleteAsAdmin() {
+	s.mockContainerService.EXPECT().
+		Delete(gomock.Any(), "container-id").
+		Return(nil)
+
+	req := httptest.NewRequest("DELETE", "/containers/delete/container-id", nil)
+	w := httptest.NewRecorder()
+
+	s.adminRouter().ServeHTTP(w, req)
 	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestSnapshot() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+	s.mockContainerService.EXPECT().
+		Snapshot(gomock.Any(), "container-id", dto.SnapshotQuery{Tag: "v1", Comment: "before upgrade"}).
+		Return(&entities.Snapshot{ID: "snapshot-id", ImageName: "vcs-sms-snapshots/container-id:v1"}, nil)
+
+	req := httptest.NewRequest("POST", "/containers/container-id/snapshot?tag=v1&comment=before+upgrade", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusCreated, w.Code)
 
 	var response dto.APIResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	s.NoError(err)
-	s.Equal("CONTAINERS_IMPORTED", response.Code)
+	s.Equal("CONTAINER_SNAPSHOT_CREATED", response.Code)
+}
 
-	raw, err := json.Marshal(response.Data)
-	s.NoError(err)
+func (s *ContainerHandlerSuite) TestSnapshotInvalidTag() {
+	s.mockContainerService.EXPECT().
+		Snapshot(gomock.Any(), "container-id", dto.SnapshotQuery{Tag: "not a tag"}).
+		Return(nil, fmt.Errorf("%w: invalid reference format", errdefs.ErrInvalidArgument))
 
-	var data dto.ImportResponse
-	err = json.Unmarshal(raw, &data)
-	s.NoError(err)
-	s.Equal(1, data.SuccessCount)
-	s.Equal(0, data.FailedCount)
+	req := httptest.NewRequest("POST", "/containers/container-id/snapshot?tag=not+a+tag", nil)
+	w := httptest.NewRecorder()
+
+	s.adminRouter().ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestSnapshotServiceError() {
+	s.mockContainerService.EXPECT().
+		Snapshot(gomock.Any(), "container-id", dto.SnapshotQuery{}).
+		Return(nil, errors.New("commit failed"))
+
+	req := httptest.NewRequest("POST", "/containers/container-id/snapshot", nil)
+	w := httptest.NewRecorder()
+
+	s.adminRouter().ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestSnapshots() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+	s.mockContainerService.EXPECT().
+		Snapshots(gomock.Any(), "container-id").
+		Return([]*entities.Snapshot{{ID: "snapshot-id"}}, nil)
+
+	req := httptest.NewRequest("GET", "/containers/container-id/snapshots", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("SNAPSHOTS_RETRIEVED", response.Code)
+}
+
+func (s *ContainerHandlerSuite) TestClone() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+	s.mockContainerService.EXPECT().
+		Clone(gomock.Any(), "container-id", dto.CloneRequest{ContainerName: "clone", SnapshotId: "snapshot-id"}, "user-id").
+		Return(&entities.Container{ContainerId: "clone-id"}, nil)
+
+	jsonData, _ := json.Marshal(dto.CloneRequest{ContainerName: "clone", SnapshotId: "snapshot-id"})
+	req := httptest.NewRequest("POST", "/containers/container-id/clone", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusCreated, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("CONTAINER_CLONED", response.Code)
+}
+
+func (s *ContainerHandlerSuite) TestCloneInvalidRequestBody() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+
+	req := httptest.NewRequest("POST", "/containers/container-id/clone", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestCloneSnapshotNotFound() {
+	s.mockContainerService.EXPECT().
+		Clone(gomock.Any(), "container-id", dto.CloneRequest{ContainerName: "clone", SnapshotId: "missing"}, "admin-id").
+		Return(nil, fmt.Errorf("%w: missing", usecases.ErrSnapshotNotFound))
+
+	jsonData, _ := json.Marshal(dto.CloneRequest{ContainerName: "clone", SnapshotId: "missing"})
+	req := httptest.NewRequest("POST", "/containers/container-id/clone", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.adminRouter().ServeHTTP(w, req)
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestCloneQuotaExceeded() {
+	s.mockContainerService.EXPECT().
+		Clone(gomock.Any(), "container-id", dto.CloneRequest{ContainerName: "clone"}, "admin-id").
+		Return(nil, fmt.Errorf("%w: at most 1 containers allowed", usecases.ErrQuotaExceeded))
+
+	jsonData, _ := json.Marshal(dto.CloneRequest{ContainerName: "clone"})
+	req := httptest.NewRequest("POST", "/containers/container-id/clone", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.adminRouter().ServeHTTP(w, req)
+	s.Equal(http.StatusForbidden, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("QUOTA_EXCEEDED", response.Code)
+}
+
+func (s *ContainerHandlerSuite) TestTransfer() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+	s.mockContainerService.EXPECT().
+		Transfer(gomock.Any(), "container-id", "other-id").
+		Return(nil)
+
+	jsonData, _ := json.Marshal(dto.TransferRequest{OwnerId: "other-id"})
+	req := httptest.NewRequest("PUT", "/containers/transfer/container-id", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("CONTAINER_TRANSFERRED", response.Code)
+}
+
+func (s *ContainerHandlerSuite) TestTransferInvalidRequestBody() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+
+	req := httptest.NewRequest("PUT", "/containers/transfer/container-id", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestTransferQuotaExceeded() {
+	s.mockContainerService.EXPECT().
+		Transfer(gomock.Any(), "container-id", "other-id").
+		Return(fmt.Errorf("%w: at most 1 containers allowed", usecases.ErrQuotaExceeded))
+
+	jsonData, _ := json.Marshal(dto.TransferRequest{OwnerId: "other-id"})
+	req := httptest.NewRequest("PUT", "/containers/transfer/container-id", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.adminRouter().ServeHTTP(w, req)
+	s.Equal(http.StatusForbidden, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("QUOTA_EXCEEDED", response.Code)
+}
+
+func (s *ContainerHandlerSuite) TestTransferOwnerNotFound() {
+	s.mockContainerService.EXPECT().
+		Transfer(gomock.Any(), "container-id", "unknown-id").
+		Return(fmt.Errorf("%w: unknown-id", usecases.ErrUserNotFound))
+
+	jsonData, _ := json.Marshal(dto.TransferRequest{OwnerId: "unknown-id"})
+	req := httptest.NewRequest("PUT", "/containers/transfer/container-id", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.adminRouter().ServeHTTP(w, req)
+	s.Equal(http.StatusNotFound, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("New owner not found", response.Message)
+}
+
+func (s *ContainerHandlerSuite) TestTransferServiceError() {
+	s.mockContainerService.EXPECT().
+		Transfer(gomock.Any(), "container-id", "other-id").
+		Return(errors.New("service error"))
+
+	jsonData, _ := json.Marshal(dto.TransferRequest{OwnerId: "other-id"})
+	req := httptest.NewRequest("PUT", "/containers/transfer/container-id", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.adminRouter().ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestInspect() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+	s.mockContainerService.EXPECT().
+		Inspect(gomock.Any(), "container-id").
+		Return(&dto.InspectResponse{ContainerId: "container-id", Image: "nginx", OOMKilled: true}, nil)
+
+	req := httptest.NewRequest("GET", "/containers/container-id/inspect", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("CONTAINER_INSPECTED", response.Code)
+}
+
+func (s *ContainerHandlerSuite) TestInspectNotFound() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+	s.mockContainerService.EXPECT().
+		Inspect(gomock.Any(), "container-id").
+		Return(nil, fmt.Errorf("%w: container-id", usecases.ErrContainerNotFound))
+
+	req := httptest.NewRequest("GET", "/containers/container-id/inspect", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestInspectServiceError() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+	s.mockContainerService.EXPECT().
+		Inspect(gomock.Any(), "container-id").
+		Return(nil, errors.New("daemon error"))
+
+	req := httptest.NewRequest("GET", "/containers/container-id/inspect", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestTop() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+	s.mockContainerService.EXPECT().
+		Top(gomock.Any(), "container-id").
+		Return(&dto.TopResponse{Titles: []string{"PID", "CMD"}, Processes: [][]string{{"1", "nginx"}}}, nil)
+
+	req := httptest.NewRequest("GET", "/containers/container-id/top", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("CONTAINER_PROCESSES_RETRIEVED", response.Code)
+}
+
+func (s *ContainerHandlerSuite) TestTopNotRunning() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+	s.mockContainerService.EXPECT().
+		Top(gomock.Any(), "container-id").
+		Return(nil, fmt.Errorf("%w: container-id", usecases.ErrContainerNotRunning))
+
+	req := httptest.NewRequest("GET", "/containers/container-id/top", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusConflict, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestTopNotOwner() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "other-id"}, nil)
+
+	req := httptest.NewRequest("GET", "/containers/container-id/top", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusForbidden, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestLogs() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+	s.mockContainerService.EXPECT().
+		Logs(gomock.Any(), "container-id", dto.LogsQuery{Follow: true, Tail: "100", Timestamps: true}).
+		Return(io.NopCloser(strings.NewReader("line 1\nline 2\n")), nil)
+
+	req := httptest.NewRequest("GET", "/containers/container-id/logs?follow=true&tail=100&timestamps=true", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	s.Equal("line 1\nline 2\n", w.Body.String())
+}
+
+func (s *ContainerHandlerSuite) TestLogsServerSentEvents() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+	s.mockContainerService.EXPECT().
+		Logs(gomock.Any(), "container-id", dto.LogsQuery{}).
+		Return(io.NopCloser(strings.NewReader("line 1\nline 2\n")), nil)
+
+	req := httptest.NewRequest("GET", "/containers/container-id/logs", nil)
+	req.Header.Set("Accept", "text/event-stream")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("text/event-stream", w.Header().Get("Content-Type"))
+	s.Equal("event:log\ndata:line 1\n\nevent:log\ndata:line 2\n\n", w.Body.String())
+}
+
+func (s *ContainerHandlerSuite) TestLogsInvalidTail() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+
+	req := httptest.NewRequest("GET", "/containers/container-id/logs?tail=invalid", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestLogsInvalidArgument() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+	s.mockContainerService.EXPECT().
+		Logs(gomock.Any(), "container-id", gomock.Any()).
+		Return(nil, errdefs.ErrInvalidArgument)
+
+	req := httptest.NewRequest("GET", "/containers/container-id/logs?since=yesterday", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestLogsContainerNotFound() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+	s.mockContainerService.EXPECT().
+		Logs(gomock.Any(), "container-id", gomock.Any()).
+		Return(nil, usecases.ErrContainerNotFound)
+
+	req := httptest.NewRequest("GET", "/containers/container-id/logs", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestLogsServiceError() {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+	s.mockContainerService.EXPECT().
+		Logs(gomock.Any(), "container-id", gomock.Any()).
+		Return(nil, errors.New("service error"))
+
+	req := httptest.NewRequest("GET", "/containers/container-id/logs", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("service error", response.Error)
+}
+
+func (s *ContainerHandlerSuite) TestExport() {
+	csvData := []byte("id,name,status\n1,container1,running")
+
+	s.mockContainerService.EXPECT().
+		Export(gomock.Any(), gomock.Any(), 1, -1, gomock.Any(), dto.FormatXLSX, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, filter dto.ContainerFilter, from int, to int, sort dto.ContainerSort, format dto.FileFormat, w io.Writer) error {
+			_, err := w.Write(csvData)
+			return err
+		})
+
+	req := httptest.NewRequest("GET", "/containers/export?field=container_id&order=desc", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", w.Header().Get("Content-Type"))
+	s.Equal("attachment; filename=\"containers.xlsx\"", w.Header().Get("Content-Disposition"))
+	s.Equal(csvData, w.Body.Bytes())
+}
+
+func (s *ContainerHandlerSuite) TestExportFormatQuery() {
+	s.mockContainerService.EXPECT().
+		Export(gomock.Any(), gomock.Any(), 1, -1, gomock.Any(), dto.FormatCSV, gomock.Any()).
+		Return(nil)
+
+	req := httptest.NewRequest("GET", "/containers/export?field=container_id&order=desc&format=csv", nil)
+	req.Header.Set("Accept", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("text/csv", w.Header().Get("Content-Type"))
+	s.Equal("attachment; filename=\"containers.csv\"", w.Header().Get("Content-Disposition"))
+}
+
+func (s *ContainerHandlerSuite) TestExportAcceptHeader() {
+	s.mockContainerService.EXPECT().
+		Export(gomock.Any(), gomock.Any(), 1, -1, gomock.Any(), dto.FormatYAML, gomock.Any()).
+		Return(nil)
+
+	req := httptest.NewRequest("GET", "/containers/export?field=container_id&order=desc", nil)
+	req.Header.Set("Accept", "text/html, application/yaml;q=0.9")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("application/yaml", w.Header().Get("Content-Type"))
+}
+
+func (s *ContainerHandlerSuite) TestExportNotAcceptable() {
+	req := httptest.NewRequest("GET", "/containers/export?field=container_id&order=desc", nil)
+	req.Header.Set("Accept", "text/html")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusNotAcceptable, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestExportInvalidFormat() {
+	req := httptest.NewRequest("GET", "/containers/export?field=container_id&order=desc&format=xml", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestExportInvalidFromParameter() {
+	req := httptest.NewRequest("GET", "/containers/export?from=invalid", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Contains(response.Error, "invalid syntax")
+}
+
+func (s *ContainerHandlerSuite) TestExportInvalidToParameter() {
+	req := httptest.NewRequest("GET", "/containers/export?from=1&to=invalid-number", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Contains(response.Error, "invalid syntax")
+}
+
+func (s *ContainerHandlerSuite) TestExportServiceError() {
+	s.mockContainerService.EXPECT().
+		Export(gomock.Any(), gomock.Any(), 1, -1, gomock.Any(), dto.FormatXLSX, gomock.Any()).
+		Return(errors.New("service error"))
+
+	req := httptest.NewRequest("GET", "/containers/export?field=container_id&order=desc", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusInternalServerError, w.Code)
+	s.Equal("application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	s.Empty(w.Header().Get("Content-Disposition"))
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("service error", response.Error)
+}
+
+func (s *ContainerHandlerSuite) TestExportErrorWhileStreaming() {
+	s.mockContainerService.EXPECT().
+		Export(gomock.Any(), gomock.Any(), 1, -1, gomock.Any(), dto.FormatNDJSON, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, filter dto.ContainerFilter, from int, to int, sort dto.ContainerSort, format dto.FileFormat, w io.Writer) error {
+			w.Write([]byte("{\"container_name\":\"web\"}\n"))
+			return errors.New("connection lost")
+		})
+
+	req := httptest.NewRequest("GET", "/containers/export?field=container_id&order=desc&format=ndjson", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("application/x-ndjson", w.Header().Get("Content-Type"))
+	s.Equal("{\"container_name\":\"web\"}\n", w.Body.String())
+}
+
+func (s *ContainerHandlerSuite) TestExportInvalidFilterParameter() {
+	req := httptest.NewRequest("GET", "/containers/export?status=invalid-status", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Contains(response.Error, "invalid status \"invalid-status\"")
+}
+
+func (s *ContainerHandlerSuite) TestExportInvalidSortParameter() {
+	req := httptest.NewRequest("GET", "/containers/export?order=invalid-order", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Contains(response.Error, "Field validation for 'Order' failed on the 'oneof' tag")
+}
+
+func (s *ContainerHandlerSuite) TestImport() {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "test.csv")
+	part.Write([]byte("id,name,status\n1,test,running"))
+	writer.Close()
+
+	result := &dto.ImportResponse{
+		SuccessCount:      1,
+		SuccessContainers: []string{"test"},
+		FailedCount:       0,
+		FailedContainers:  []string{},
+	}
+
+	s.mockContainerService.EXPECT().
+		Import(gomock.Any(), gomock.Any(), dto.FormatCSV, "user-id", false).
+		Return(result, nil)
+
+	req := httptest.NewRequest("POST", "/containers/import", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("CONTAINERS_IMPORTED", response.Code)
+
+	raw, err := json.Marshal(response.Data)
+	s.NoError(err)
+
+	var data dto.ImportResponse
+	err = json.Unmarshal(raw, &data)
+	s.NoError(err)
+	s.Equal(1, data.SuccessCount)
+	s.Equal(0, data.FailedCount)
+}
+
+func (s *ContainerHandlerSuite) TestImportDryRun() {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "containers.xlsx")
+	part.Write([]byte("content"))
+	writer.Close()
+
+	s.mockContainerService.EXPECT().
+		Import(gomock.Any(), gomock.Any(), dto.FormatXLSX, "user-id", true).
+		Return(&dto.ImportResponse{DryRun: true, SuccessCount: 1, SuccessContainers: []string{"test"}}, nil)
+
+	req := httptest.NewRequest("POST", "/containers/import?dry_run=true", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("IMPORT_VALIDATED", response.Code)
+}
+
+func (s *ContainerHandlerSuite) TestImportFormat() {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="containers.txt"`)
+	header.Set("Content-Type", "application/x-ndjson")
+	part, _ := writer.CreatePart(header)
+	part.Write([]byte(`{"container_name":"test","image_name":"nginx"}`))
+	writer.Close()
+
+	s.mockContainerService.EXPECT().
+		Import(gomock.Any(), gomock.Any(), dto.FormatNDJSON, "user-id", false).
+		Return(&dto.ImportResponse{SuccessCount: 1}, nil)
+
+	req := httptest.NewRequest("POST", "/containers/import", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestImportFormatQuery() {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "containers.txt")
+	part.Write([]byte("container_name: test"))
+	writer.Close()
+
+	s.mockContainerService.EXPECT().
+		Import(gomock.Any(), gomock.Any(), dto.FormatYAML, "user-id", false).
+		Return(&dto.ImportResponse{}, nil)
+
+	req := httptest.NewRequest("POST", "/containers/import?format=yaml", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *ContainerHandlerSuite) TestImportInvalidDryRun() {
+	req := httptest.NewRequest("POST", "/containers/import?dry_run=maybe", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
 }
 
 func (s *ContainerHandlerSuite) TestImportMissingFile() {
@@ -474,7 +1504,7 @@ func (s *ContainerHandlerSuite) TestImportServiceError() {
 	writer.Close()
 
 	s.mockContainerService.EXPECT().
-		Import(gomock.Any(), gomock.Any()).
+		Import(gomock.Any(), gomock.Any(), dto.FormatCSV, "user-id", false).
 		Return((*dto.ImportResponse)(nil), errors.New("service error"))
 
 	req := httptest.NewRequest("POST", "/containers/import", body)
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type ExecHandler struct {
+	containerService services.IContainerService
+	execService      services.IExecService
+	jwtMiddleware    middlewares.IJWTMiddleware
+	upgrader         websocket.Upgrader
+}
+
+func NewExecHandler(containerService services.IContainerService, execService services.IExecService, jwtMiddleware middlewares.IJWTMiddleware) *ExecHandler {
+	return &ExecHandler{containerService, execService, jwtMiddleware, websocket.Upgrader{}}
+}
+
+func (h *ExecHandler) SetupRoutes(r *gin.Engine) {
+	execRoutes := r.Group("/containers", h.jwtMiddleware.RequireScope("container:exec"))
+	{
+		execRoutes.GET("/:id/exec", requireOwnership(h.containerService), h.Exec)
+	}
+}
+
+type execWriter struct {
+	conn   *websocket.Conn
+	stream dto.ExecMessageType
+}
+
+func (w *execWriter) Write(p []byte) (int, error) {
+	if err := w.conn.WriteJSON(dto.ExecMessage{Type: w.stream, Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Exec godoc
+// @Summary Exec into a container
+// @Description Upgrade to a WebSocket running a command inside the container. Clients send {"type":"stdin","data":...} and {"type":"resize","rows":...,"cols":...}; the server sends stdout, stderr and a final exit message. The data of stdin, stdout and stderr messages is base64 encoded.
+// @Tags containers
+// @Param id path string true "Container ID"
+// @Param cmd query []string false "Command to run (default /bin/sh)" collectionFormat(multi)
+// @Param tty query bool false "Allocate a TTY" default(true)
+// @Success 101 {string} string "Switching protocols"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user"
+// @Failure 404 {object} dto.APIResponse "Container not found"
+// @Failure 409 {object} dto.APIResponse "Container not running"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/{id}/exec [get]
+func (h *ExecHandler) Exec(c *gin.Context) {
+	var query dto.ExecQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid exec parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if len(query.Cmd) == 0 {
+		query.Cmd = []string{"/bin/sh"}
+	}
+
+	ctx := c.Request.Context()
+	session, err := h.execService.Start(ctx, c.GetString("userId"), c.Param("id"), query.Cmd, query.Tty)
+	if errors.Is(err, services.ErrContainerNotFound) {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_FOUND",
+			Message: "Container not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrContainerNotRunning) {
+		c.JSON(http.StatusConflict, dto.APIResponse{
+			Success: false,
+			Code:    "CONFLICT",
+			Message: "Container is not running",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to start exec session",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		h.execService.Finish(ctx, session)
+		return
+	}
+	defer conn.Close()
+
+	go func() {
+		for {
+			var msg dto.ExecMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				session.Stream.Close()
+				return
+			}
+			switch msg.Type {
+			case dto.ExecStdin:
+				if _, err := session.Stream.Conn.Write(msg.Data); err != nil {
+					return
+				}
+			case dto.ExecResize:
+				h.execService.Resize(ctx, session, msg.Rows, msg.Cols)
+			}
+		}
+	}()
+
+	stdout := &execWriter{conn, dto.ExecStdout}
+	if session.Tty {
+		io.Copy(stdout, session.Stream.Reader)
+	} else {
+		stdcopy.StdCopy(stdout, &execWriter{conn, dto.ExecStderr}, session.Stream.Reader)
+	}
+
+	exitCode, _ := h.execService.Finish(ctx, session)
+	conn.WriteJSON(dto.ExecMessage{Type: dto.ExecExit, ExitCode: exitCode})
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+}
@@ -0,0 +1,244 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+	usecases "github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type ExecHandlerSuite struct {
+	suite.Suite
+	ctrl                 *gomock.Controller
+	mockContainerService *services.MockIContainerService
+	mockExecService      *services.MockIExecService
+	mockJWTMiddleware    *middlewares.MockIJWTMiddleware
+	handler              *ExecHandler
+	router               *gin.Engine
+}
+
+func (s *ExecHandlerSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockContainerService = services.NewMockIContainerService(s.ctrl)
+	s.mockExecService = services.NewMockIExecService(s.ctrl)
+	s.mockJWTMiddleware = middlewares.NewMockIJWTMiddleware(s.ctrl)
+
+	s.mockJWTMiddleware.EXPECT().
+		RequireScope(gomock.Any()).
+		Return(func(c *gin.Context) {
+			c.Set("userId", "user-id")
+			c.Next()
+		}).
+		AnyTimes()
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil).
+		AnyTimes()
+
+	s.handler = NewExecHandler(s.mockContainerService, s.mockExecService, s.mockJWTMiddleware)
+
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.handler.SetupRoutes(s.router)
+}
+
+func (s *ExecHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestExecHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ExecHandlerSuite))
+}
+
+func (s *ExecHandlerSuite) dial(server *httptest.Server, query string) *websocket.Conn {
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/containers/container-id/exec" + query
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	s.Require().NoError(err)
+	return conn
+}
+
+func (s *ExecHandlerSuite) TestExecTty() {
+	serverConn, dockerConn := net.Pipe()
+	session := &dto.ExecSession{
+		ExecId: "exec-id",
+		Tty:    true,
+		Stream: types.HijackedResponse{Conn: serverConn, Reader: bufio.NewReader(serverConn)},
+	}
+	exitCode := 0
+
+	s.mockExecService.EXPECT().Start(gomock.Any(), "user-id", "container-id", []string{"/bin/sh"}, true).Return(session, nil)
+	resized := make(chan struct{})
+	s.mockExecService.EXPECT().Resize(gomock.Any(), session, uint(40), uint(120)).DoAndReturn(func(_, _, _, _ any) error {
+		close(resized)
+		return nil
+	})
+	s.mockExecService.EXPECT().Finish(gomock.Any(), session).Return(&exitCode, nil)
+
+	server := httptest.NewServer(s.router)
+	defer server.Close()
+	conn := s.dial(server, "")
+	defer conn.Close()
+
+	s.NoError(conn.WriteJSON(dto.ExecMessage{Type: dto.ExecResize, Rows: 40, Cols: 120}))
+	<-resized
+	s.NoError(conn.WriteJSON(dto.ExecMessage{Type: dto.ExecStdin, Data: []byte("echo hi\n")}))
+	stdin := make([]byte, len("echo hi\n"))
+	_, err := io.ReadFull(dockerConn, stdin)
+	s.NoError(err)
+	s.Equal("echo hi\n", string(stdin))
+
+	dockerConn.Write([]byte("hi\n"))
+	var msg dto.ExecMessage
+	s.NoError(conn.ReadJSON(&msg))
+	s.Equal(dto.ExecMessage{Type: dto.ExecStdout, Data: []byte("hi\n")}, msg)
+
+	dockerConn.Close()
+	msg = dto.ExecMessage{}
+	s.NoError(conn.ReadJSON(&msg))
+	s.Equal(dto.ExecExit, msg.Type)
+	s.Equal(0, *msg.ExitCode)
+}
+
+func (s *ExecHandlerSuite) TestExecWithoutTty() {
+	serverConn, dockerConn := net.Pipe()
+	session := &dto.ExecSession{
+		ExecId: "exec-id",
+		Stream: types.HijackedResponse{Conn: serverConn, Reader: bufio.NewReader(serverConn)},
+	}
+
+	s.mockExecService.EXPECT().Start(gomock.Any(), "user-id", "container-id", []string{"ls", "/missing"}, false).Return(session, nil)
+	s.mockExecService.EXPECT().Finish(gomock.Any(), session).Return(nil, nil)
+
+	server := httptest.NewServer(s.router)
+	defer server.Close()
+	conn := s.dial(server, "?cmd=ls&cmd=/missing&tty=false")
+	defer conn.Close()
+
+	go func() {
+		var frame bytes.Buffer
+		stdcopy.NewStdWriter(&frame, stdcopy.Stderr).Write([]byte("no such file\n"))
+		dockerConn.Write(frame.Bytes())
+		dockerConn.Close()
+	}()
+
+	var msg dto.ExecMessage
+	s.NoError(conn.ReadJSON(&msg))
+	s.Equal(dto.ExecMessage{Type: dto.ExecStderr, Data: []byte("no such file\n")}, msg)
+
+	msg = dto.ExecMessage{}
+	s.NoError(conn.ReadJSON(&msg))
+	s.Equal(dto.ExecExit, msg.Type)
+	s.Nil(msg.ExitCode)
+}
+
+func (s *ExecHandlerSuite) TestExecSplitOutput() {
+	serverConn, dockerConn := net.Pipe()
+	session := &dto.ExecSession{
+		ExecId: "exec-id",
+		Tty:    true,
+		Stream: types.HijackedResponse{Conn: serverConn, Reader: bufio.NewReader(serverConn)},
+	}
+
+	s.mockExecService.EXPECT().Start(gomock.Any(), "user-id", "container-id", []string{"/bin/sh"}, true).Return(session, nil)
+	s.mockExecService.EXPECT().Finish(gomock.Any(), session).Return(nil, nil)
+
+	server := httptest.NewServer(s.router)
+	defer server.Close()
+	conn := s.dial(server, "")
+	defer conn.Close()
+
+	// The euro sign split across two reads, then a byte that is not text at all.
+	euro := []byte("€")
+	dockerConn.Write(euro[:2])
+	_, raw, err := conn.ReadMessage()
+	s.NoError(err)
+	s.JSONEq(`{"type":"stdout","data":"`+base64.StdEncoding.EncodeToString(euro[:2])+`"}`, string(raw))
+
+	for _, chunk := range [][]byte{euro[2:], {0xff}} {
+		dockerConn.Write(chunk)
+		var msg dto.ExecMessage
+		s.NoError(conn.ReadJSON(&msg))
+		s.Equal(chunk, msg.Data)
+	}
+
+	dockerConn.Close()
+	var msg dto.ExecMessage
+	s.NoError(conn.ReadJSON(&msg))
+	s.Equal(dto.ExecExit, msg.Type)
+}
+
+func (s *ExecHandlerSuite) TestExecContainerNotFound() {
+	s.mockExecService.EXPECT().Start(gomock.Any(), "user-id", "container-id", gomock.Any(), true).Return(nil, usecases.ErrContainerNotFound)
+
+	req := httptest.NewRequest("GET", "/containers/container-id/exec", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *ExecHandlerSuite) TestExecContainerNotRunning() {
+	s.mockExecService.EXPECT().Start(gomock.Any(), "user-id", "container-id", gomock.Any(), true).Return(nil, usecases.ErrContainerNotRunning)
+
+	req := httptest.NewRequest("GET", "/containers/container-id/exec", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusConflict, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("CONFLICT", response.Code)
+}
+
+func (s *ExecHandlerSuite) TestExecServiceError() {
+	s.mockExecService.EXPECT().Start(gomock.Any(), "user-id", "container-id", gomock.Any(), true).Return(nil, errors.New("service error"))
+
+	req := httptest.NewRequest("GET", "/containers/container-id/exec", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *ExecHandlerSuite) TestExecInvalidQuery() {
+	req := httptest.NewRequest("GET", "/containers/container-id/exec?tty=maybe", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ExecHandlerSuite) TestExecUpgradeError() {
+	serverConn, _ := net.Pipe()
+	session := &dto.ExecSession{Stream: types.HijackedResponse{Conn: serverConn, Reader: bufio.NewReader(serverConn)}}
+	s.mockExecService.EXPECT().Start(gomock.Any(), "user-id", "container-id", gomock.Any(), true).Return(session, nil)
+	s.mockExecService.EXPECT().Finish(gomock.Any(), session).Return(nil, nil)
+
+	req := httptest.NewRequest("GET", "/containers/container-id/exec", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
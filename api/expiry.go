@@ -0,0 +1,95 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type ExpiryHandler struct {
+	containerService services.IContainerService
+	expiryService    services.IExpiryService
+	jwtMiddleware    middlewares.IJWTMiddleware
+}
+
+func NewExpiryHandler(containerService services.IContainerService, expiryService services.IExpiryService, jwtMiddleware middlewares.IJWTMiddleware) *ExpiryHandler {
+	return &ExpiryHandler{containerService, expiryService, jwtMiddleware}
+}
+
+func (h *ExpiryHandler) SetupRoutes(r *gin.Engine) {
+	expiryRoutes := r.Group("/containers/:id", h.jwtMiddleware.RequireScope("container:update"), requireOwnership(h.containerService))
+	{
+		expiryRoutes.POST("/extend", h.Extend)
+	}
+}
+
+// Extend godoc
+// @Summary Extend the TTL of a container
+// @Description Move the expiry of a container to a later time, or to a time to live from now, so the reaper does not delete it yet. Its owner is notified again before the new expiry. A container that did not expire becomes ephemeral.
+// @Tags containers
+// @Accept json
+// @Produce json
+// @Param id path string true "Container ID"
+// @Param body body dto.ExtendRequest true "New expiry, either expires_at or ttl"
+// @Success 200 {object} dto.APIResponse{data=dto.Expiry} "Container expiry extended successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user"
+// @Failure 404 {object} dto.APIResponse "Container not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/{id}/extend [post]
+func (h *ExpiryHandler) Extend(c *gin.Context) {
+	var req dto.ExtendRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	expiresAt, err := dto.Expiry{ExpiresAt: req.ExpiresAt, TTL: req.TTL}.Resolve(time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid expiry",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	err = h.expiryService.Extend(c.Request.Context(), c.Param("id"), *expiresAt)
+	if errors.Is(err, services.ErrContainerNotFound) {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_FOUND",
+			Message: "Container not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to extend container expiry",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "CONTAINER_EXTENDED",
+		Message: "Container expiry extended successfully",
+		Data:    dto.Expiry{ExpiresAt: expiresAt},
+	})
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+	usecases "github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type ExpiryHandlerSuite struct {
+	suite.Suite
+	ctrl                 *gomock.Controller
+	mockContainerService *services.MockIContainerService
+	mockExpiryService    *services.MockIExpiryService
+	router               *gin.Engine
+}
+
+func (s *ExpiryHandlerSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockContainerService = services.NewMockIContainerService(s.ctrl)
+	s.mockExpiryService = services.NewMockIExpiryService(s.ctrl)
+	s.router = s.newRouter("user-id")
+}
+
+func (s *ExpiryHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestExpiryHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ExpiryHandlerSuite))
+}
+
+// newRouter serves the extend route next to the container ones it shares its prefix with.
+func (s *ExpiryHandlerSuite) newRouter(userId string, scopes ...string) *gin.Engine {
+	jwtMiddleware := middlewares.NewMockIJWTMiddleware(s.ctrl)
+	jwtMiddleware.EXPECT().
+		RequireScope(gomock.Any()).
+		Return(func(c *gin.Context) {
+			c.Set("userId", userId)
+			c.Set("scopes", scopes)
+			c.Next()
+		}).
+		AnyTimes()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	NewContainerHandler(s.mockContainerService, jwtMiddleware).SetupRoutes(router)
+	NewExpiryHandler(s.mockContainerService, s.mockExpiryService, jwtMiddleware).SetupRoutes(router)
+	return router
+}
+
+func (s *ExpiryHandlerSuite) expectOwner(ownerId string) {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: ownerId}, nil)
+}
+
+func (s *ExpiryHandlerSuite) extend(body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/containers/container-id/extend", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *ExpiryHandlerSuite) TestExtendTTL() {
+	s.expectOwner("user-id")
+	s.mockExpiryService.EXPECT().
+		Extend(gomock.Any(), "container-id", gomock.Any()).
+		DoAndReturn(func(_ any, _ string, expiresAt time.Time) error {
+			s.WithinDuration(time.Now().Add(2*time.Hour), expiresAt, time.Minute)
+			return nil
+		})
+
+	w := s.extend(`{"ttl":"2h"}`)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("CONTAINER_EXTENDED", response.Code)
+}
+
+func (s *ExpiryHandlerSuite) TestExtendExpiresAt() {
+	expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	s.expectOwner("user-id")
+	s.mockExpiryService.EXPECT().
+		Extend(gomock.Any(), "container-id", expiresAt).
+		Return(nil)
+
+	w := s.extend(fmt.Sprintf(`{"expires_at":%q}`, expiresAt.Format(time.RFC3339)))
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *ExpiryHandlerSuite) TestExtendInvalidRequestBody() {
+	s.expectOwner("user-id")
+
+	w := s.extend(`{}`)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ExpiryHandlerSuite) TestExtendBothSet() {
+	s.expectOwner("user-id")
+
+	w := s.extend(`{"expires_at":"2030-01-01T00:00:00Z","ttl":"2h"}`)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ExpiryHandlerSuite) TestExtendInvalidExpiry() {
+	s.expectOwner("user-id")
+
+	w := s.extend(`{"expires_at":"2020-01-01T00:00:00Z"}`)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("Invalid expiry", response.Message)
+}
+
+func (s *ExpiryHandlerSuite) TestExtendNotOwner() {
+	s.expectOwner("other-id")
+
+	w := s.extend(`{"ttl":"2h"}`)
+	s.Equal(http.StatusForbidden, w.Code)
+}
+
+func (s *ExpiryHandlerSuite) TestExtendAsAdminContainerNotFound() {
+	s.mockExpiryService.EXPECT().
+		Extend(gomock.Any(), "container-id", gomock.Any()).
+		Return(fmt.Errorf("%w: container-id", usecases.ErrContainerNotFound))
+
+	req := httptest.NewRequest("POST", "/containers/container-id/extend", strings.NewReader(`{"ttl":"2h"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.newRouter("admin-id", "container:admin").ServeHTTP(w, req)
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *ExpiryHandlerSuite) TestExtendServiceError() {
+	s.expectOwner("user-id")
+	s.mockExpiryService.EXPECT().
+		Extend(gomock.Any(), "container-id", gomock.Any()).
+		Return(errors.New("db error"))
+
+	w := s.extend(`{"ttl":"2h"}`)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
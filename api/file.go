@@ -0,0 +1,157 @@
+package api
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/containerd/errdefs"
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type FileHandler struct {
+	containerService services.IContainerService
+	fileService      services.IFileService
+	jwtMiddleware    middlewares.IJWTMiddleware
+}
+
+func NewFileHandler(containerService services.IContainerService, fileService services.IFileService, jwtMiddleware middlewares.IJWTMiddleware) *FileHandler {
+	return &FileHandler{containerService, fileService, jwtMiddleware}
+}
+
+func (h *FileHandler) SetupRoutes(r *gin.Engine) {
+	fileRoutes := r.Group("/containers", h.jwtMiddleware.RequireScope("container:files"))
+	{
+		fileRoutes.GET("/:id/files", requireOwnership(h.containerService), h.Download)
+		fileRoutes.PUT("/:id/files", requireOwnership(h.containerService), h.Upload)
+	}
+}
+
+// fileError writes the response of a failed file transfer.
+func fileError(c *gin.Context, err error, message string) {
+	if errors.Is(err, services.ErrFileNotFound) {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_FOUND",
+			Message: "Container or file not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrFileTooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, dto.APIResponse{
+			Success: false,
+			Code:    "FILE_TOO_LARGE",
+			Message: "File exceeds the size limit",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errdefs.IsInvalidArgument(err) {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid path",
+			Error:   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, dto.APIResponse{
+		Success: false,
+		Code:    "INTERNAL_SERVER_ERROR",
+		Message: message,
+		Error:   err.Error(),
+	})
+}
+
+// Download godoc
+// @Summary Download a file from a container
+// @Description Download the file at an absolute path in the container, or a tar archive of it when archive is set or the path is a directory. Files over the download size limit are refused, archives are cut once they go over it. Every download is recorded in the audit log.
+// @Tags containers
+// @Produce octet-stream
+// @Produce application/x-tar
+// @Param id path string true "Container ID"
+// @Param path query string true "Absolute path in the container"
+// @Param archive query bool false "Download a tar archive even for a single file"
+// @Success 200 {file} file "File or tar archive"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user"
+// @Failure 404 {object} dto.APIResponse "Container or file not found"
+// @Failure 413 {object} dto.APIResponse "File exceeds the size limit"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/{id}/files [get]
+func (h *FileHandler) Download(c *gin.Context) {
+	var query dto.FileQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid file parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	download, err := h.fileService.Download(ctx, c.GetString("userId"), c.Param("id"), query)
+	if err != nil {
+		fileError(c, err, "Failed to download file")
+		return
+	}
+	defer h.fileService.Finish(ctx, download)
+
+	contentType := "application/octet-stream"
+	if download.Archive {
+		contentType = "application/x-tar"
+	}
+	c.DataFromReader(http.StatusOK, download.Size, contentType, download.Content, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": download.Name}),
+	})
+}
+
+// Upload godoc
+// @Summary Upload a file to a container
+// @Description Write the request body as the file at an absolute path in the container, replacing it if it exists, or extract it into the directory at the path when archive is set and the body is a tar archive. The parent directory must exist. Bodies over the upload size limit are refused. Every upload is recorded in the audit log.
+// @Tags containers
+// @Accept octet-stream
+// @Accept application/x-tar
+// @Produce json
+// @Param id path string true "Container ID"
+// @Param path query string true "Absolute path in the container"
+// @Param archive query bool false "The body is a tar archive to extract into the directory at path"
+// @Param body body string true "File content or tar archive"
+// @Success 200 {object} dto.APIResponse "File uploaded successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user"
+// @Failure 404 {object} dto.APIResponse "Container or directory not found"
+// @Failure 413 {object} dto.APIResponse "File exceeds the size limit"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/{id}/files [put]
+func (h *FileHandler) Upload(c *gin.Context) {
+	var query dto.FileQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid file parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := h.fileService.Upload(c.Request.Context(), c.GetString("userId"), c.Param("id"), query, c.Request.Body, c.Request.ContentLength); err != nil {
+		fileError(c, err, "Failed to upload file")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "FILE_UPLOADED",
+		Message: "File uploaded successfully",
+	})
+}
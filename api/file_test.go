@@ -0,0 +1,214 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/containerd/errdefs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+	usecases "github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type FileHandlerSuite struct {
+	suite.Suite
+	ctrl                 *gomock.Controller
+	mockContainerService *services.MockIContainerService
+	mockFileService      *services.MockIFileService
+	router               *gin.Engine
+}
+
+func (s *FileHandlerSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockContainerService = services.NewMockIContainerService(s.ctrl)
+	s.mockFileService = services.NewMockIFileService(s.ctrl)
+	s.router = s.newRouter("user-id")
+}
+
+func (s *FileHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestFileHandlerSuite(t *testing.T) {
+	suite.Run(t, new(FileHandlerSuite))
+}
+
+// newRouter serves the file routes next to the container ones they share their prefix with.
+func (s *FileHandlerSuite) newRouter(userId string, scopes ...string) *gin.Engine {
+	jwtMiddleware := middlewares.NewMockIJWTMiddleware(s.ctrl)
+	jwtMiddleware.EXPECT().
+		RequireScope(gomock.Any()).
+		Return(func(c *gin.Context) {
+			c.Set("userId", userId)
+			c.Set("scopes", scopes)
+			c.Next()
+		}).
+		AnyTimes()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	NewContainerHandler(s.mockContainerService, jwtMiddleware).SetupRoutes(router)
+	NewFileHandler(s.mockContainerService, s.mockFileService, jwtMiddleware).SetupRoutes(router)
+	return router
+}
+
+func (s *FileHandlerSuite) expectOwner(ownerId string) {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: ownerId}, nil)
+}
+
+func (s *FileHandlerSuite) serve(method string, target string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *FileHandlerSuite) TestDownloadFile() {
+	download := &dto.FileDownload{Name: "app.log", Size: 5, Content: io.NopCloser(strings.NewReader("hello"))}
+	s.expectOwner("user-id")
+	s.mockFileService.EXPECT().
+		Download(gomock.Any(), "user-id", "container-id", dto.FileQuery{Path: "/var/log/app.log"}).
+		Return(download, nil)
+	s.mockFileService.EXPECT().Finish(gomock.Any(), download).Return(nil)
+
+	w := s.serve("GET", "/containers/container-id/files?path=/var/log/app.log", "")
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("hello", w.Body.String())
+	s.Equal("application/octet-stream", w.Header().Get("Content-Type"))
+	s.Equal("5", w.Header().Get("Content-Length"))
+	s.Equal("attachment; filename=app.log", w.Header().Get("Content-Disposition"))
+}
+
+func (s *FileHandlerSuite) TestDownloadArchive() {
+	download := &dto.FileDownload{Name: "app.tar", Size: -1, Archive: true, Content: io.NopCloser(strings.NewReader("tar"))}
+	s.expectOwner("user-id")
+	s.mockFileService.EXPECT().
+		Download(gomock.Any(), "user-id", "container-id", dto.FileQuery{Path: "/etc/app", Archive: true}).
+		Return(download, nil)
+	s.mockFileService.EXPECT().Finish(gomock.Any(), download).Return(nil)
+
+	w := s.serve("GET", "/containers/container-id/files?path=/etc/app&archive=true", "")
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("application/x-tar", w.Header().Get("Content-Type"))
+	s.Empty(w.Header().Get("Content-Length"))
+	s.Equal("attachment; filename=app.tar", w.Header().Get("Content-Disposition"))
+}
+
+func (s *FileHandlerSuite) TestDownloadMissingPath() {
+	s.expectOwner("user-id")
+
+	w := s.serve("GET", "/containers/container-id/files", "")
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("Invalid file parameters", response.Message)
+}
+
+func (s *FileHandlerSuite) TestDownloadNotOwner() {
+	s.expectOwner("other-id")
+
+	w := s.serve("GET", "/containers/container-id/files?path=/etc/hosts", "")
+	s.Equal(http.StatusForbidden, w.Code)
+}
+
+func (s *FileHandlerSuite) TestDownloadErrors() {
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{fmt.Errorf("%w: /missing", usecases.ErrFileNotFound), http.StatusNotFound},
+		{fmt.Errorf("%w: 1048576 bytes", usecases.ErrFileTooLarge), http.StatusRequestEntityTooLarge},
+		{fmt.Errorf("%w: path is not absolute", errdefs.ErrInvalidArgument), http.StatusBadRequest},
+		{errors.New("docker error"), http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		s.expectOwner("user-id")
+		s.mockFileService.EXPECT().
+			Download(gomock.Any(), "user-id", "container-id", gomock.Any()).
+			Return(nil, test.err)
+
+		w := s.serve("GET", "/containers/container-id/files?path=/missing", "")
+		s.Equal(test.code, w.Code, test.err.Error())
+	}
+}
+
+func (s *FileHandlerSuite) TestDownloadAsAdmin() {
+	download := &dto.FileDownload{Name: "hosts", Size: 5, Content: io.NopCloser(strings.NewReader("hello"))}
+	s.mockFileService.EXPECT().
+		Download(gomock.Any(), "admin-id", "container-id", dto.FileQuery{Path: "/etc/hosts"}).
+		Return(download, nil)
+	s.mockFileService.EXPECT().Finish(gomock.Any(), download).Return(nil)
+
+	req := httptest.NewRequest("GET", "/containers/container-id/files?path=/etc/hosts", nil)
+	w := httptest.NewRecorder()
+	s.newRouter("admin-id", "container:admin").ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *FileHandlerSuite) TestUploadFile() {
+	s.expectOwner("user-id")
+	s.mockFileService.EXPECT().
+		Upload(gomock.Any(), "user-id", "container-id", dto.FileQuery{Path: "/etc/app.yml"}, gomock.Any(), int64(4)).
+		DoAndReturn(func(_ any, _ string, _ string, _ dto.FileQuery, content io.Reader, _ int64) error {
+			data, err := io.ReadAll(content)
+			s.NoError(err)
+			s.Equal("a: 1", string(data))
+			return nil
+		})
+
+	w := s.serve("PUT", "/containers/container-id/files?path=/etc/app.yml", "a: 1")
+	s.Equal(http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("FILE_UPLOADED", response.Code)
+}
+
+func (s *FileHandlerSuite) TestUploadMissingPath() {
+	s.expectOwner("user-id")
+
+	w := s.serve("PUT", "/containers/container-id/files", "a: 1")
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *FileHandlerSuite) TestUploadTooLarge() {
+	s.expectOwner("user-id")
+	s.mockFileService.EXPECT().
+		Upload(gomock.Any(), "user-id", "container-id", gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(fmt.Errorf("%w: more than 4 bytes", usecases.ErrFileTooLarge))
+
+	w := s.serve("PUT", "/containers/container-id/files?path=/etc/app.yml", "a: 1")
+	s.Equal(http.StatusRequestEntityTooLarge, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("FILE_TOO_LARGE", response.Code)
+}
+
+func (s *FileHandlerSuite) TestUploadServiceError() {
+	s.expectOwner("user-id")
+	s.mockFileService.EXPECT().
+		Upload(gomock.Any(), "user-id", "container-id", gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(errors.New("docker error"))
+
+	w := s.serve("PUT", "/containers/container-id/files?path=/etc/app.yml", "a: 1")
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
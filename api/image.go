@@ -0,0 +1,350 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type ImageHandler struct {
+	imageService  services.IImageService
+	jobService    services.IJobService
+	jwtMiddleware middlewares.IJWTMiddleware
+}
+
+func NewImageHandler(imageService services.IImageService, jobService services.IJobService, jwtMiddleware middlewares.IJWTMiddleware) *ImageHandler {
+	return &ImageHandler{imageService, jobService, jwtMiddleware}
+}
+
+func (h *ImageHandler) SetupRoutes(r *gin.Engine) {
+	imageRoutes := r.Group("/images")
+	{
+		viewGroup := imageRoutes.Group("", h.jwtMiddleware.RequireScope("image:view"))
+		{
+			viewGroup.GET("", h.List)
+			viewGroup.GET("/inspect", h.Inspect)
+		}
+
+		pullGroup := imageRoutes.Group("", h.jwtMiddleware.RequireScope("image:pull"))
+		{
+			pullGroup.POST("/pull", h.Pull)
+			pullGroup.GET("/pull/:id", h.PullStatus)
+		}
+
+		manageGroup := imageRoutes.Group("", h.jwtMiddleware.RequireScope("image:manage"))
+		{
+			manageGroup.DELETE("/remove", h.Remove)
+			manageGroup.POST("/prune", h.Prune)
+		}
+	}
+}
+
+// List godoc
+// @Summary List images
+// @Description List the images available on the docker daemon
+// @Tags images
+// @Produce json
+// @Success 200 {object} dto.APIResponse{data=[]dto.ImageSummary} "Images listed successfully"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /images [get]
+func (h *ImageHandler) List(c *gin.Context) {
+	images, err := h.imageService.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to list images",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "IMAGES_LISTED",
+		Message: "Images listed successfully",
+		Data:    images,
+	})
+}
+
+// Inspect godoc
+// @Summary Inspect an image
+// @Description Retrieve the details of a local image
+// @Tags images
+// @Produce json
+// @Param image query string true "Image reference or ID"
+// @Success 200 {object} dto.APIResponse{data=dto.ImageInspect} "Image inspected successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Image not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /images/inspect [get]
+func (h *ImageHandler) Inspect(c *gin.Context) {
+	var query dto.ImageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	inspect, err := h.imageService.Inspect(c.Request.Context(), query.Image)
+	if errors.Is(err, services.ErrImageNotFound) {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_FOUND",
+			Message: "Image not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to inspect image",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "IMAGE_INSPECTED",
+		Message: "Image inspected successfully",
+		Data:    inspect,
+	})
+}
+
+// Pull godoc
+// @Summary Pull an image in the background
+// @Description Check an image against the registry policy and queue its pull, returning the job tracking it. The progress of the pull and its outcome are polled from /images/pull/{id}.
+// @Tags images
+// @Accept json
+// @Produce json
+// @Param body body dto.ImagePullRequest true "Image to pull"
+// @Success 202 {object} dto.APIResponse{data=dto.JobResponse} "Pull submitted successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Image denied by the registry policy"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /images/pull [post]
+func (h *ImageHandler) Pull(c *gin.Context) {
+	var req dto.ImagePullRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	err := h.imageService.CheckPolicy(req.Image)
+	if errors.Is(err, services.ErrImageNotAllowed) {
+		c.JSON(http.StatusForbidden, dto.APIResponse{
+			Success: false,
+			Code:    "IMAGE_NOT_ALLOWED",
+			Message: "Image denied by the registry policy",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid image reference",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	job, err := h.jobService.Submit(c.Request.Context(), entities.JobPull, c.GetString("userId"), []entities.JobItem{{ImageName: req.Image}})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to submit image pull",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.APIResponse{
+		Success: true,
+		Code:    "PULL_SUBMITTED",
+		Message: "Pull submitted successfully",
+		Data:    toJobResponse(job, true),
+	})
+}
+
+// PullStatus godoc
+// @Summary Get the status of an image pull
+// @Description Retrieve the pull job, its item holding the latest progress message of the pull and, once it failed, the error docker reported
+// @Tags images
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} dto.APIResponse{data=dto.JobResponse} "Pull retrieved successfully"
+// @Failure 403 {object} dto.APIResponse "Pull submitted by another user"
+// @Failure 404 {object} dto.APIResponse "Pull not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /images/pull/{id} [get]
+func (h *ImageHandler) PullStatus(c *gin.Context) {
+	job, err := h.jobService.FindById(c.Request.Context(), c.Param("id"))
+	if err == nil && job.Type != entities.JobPull {
+		err = fmt.Errorf("%w: %s is not a pull", services.ErrJobNotFound, job.ID)
+	}
+	if errors.Is(err, services.ErrJobNotFound) {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_FOUND",
+			Message: "Pull not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to retrieve pull",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if !isContainerAdmin(c) && job.OwnerId != c.GetString("userId") {
+		c.JSON(http.StatusForbidden, dto.APIResponse{
+			Success: false,
+			Code:    "FORBIDDEN",
+			Message: "Pull is owned by another user",
+			Error:   "forbidden",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "PULL_RETRIEVED",
+		Message: "Pull retrieved successfully",
+		Data:    toJobResponse(job, true),
+	})
+}
+
+// Remove godoc
+// @Summary Remove an image
+// @Description Remove a local image, untagging it first when it has several tags
+// @Tags images
+// @Produce json
+// @Param image query string true "Image reference or ID"
+// @Param force query bool false "Remove the image even if it is used by stopped containers"
+// @Success 200 {object} dto.APIResponse{data=[]string} "Image removed successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 404 {object} dto.APIResponse "Image not found"
+// @Failure 409 {object} dto.APIResponse "Image in use"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /images/remove [delete]
+func (h *ImageHandler) Remove(c *gin.Context) {
+	var query dto.ImageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	removed, err := h.imageService.Remove(c.Request.Context(), query.Image, query.Force)
+	if errors.Is(err, services.ErrImageNotFound) {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_FOUND",
+			Message: "Image not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrImageInUse) {
+		c.JSON(http.StatusConflict, dto.APIResponse{
+			Success: false,
+			Code:    "CONFLICT",
+			Message: "Image is used by a container",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to remove image",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "IMAGE_REMOVED",
+		Message: "Image removed successfully",
+		Data:    removed,
+	})
+}
+
+// Prune godoc
+// @Summary Prune images
+// @Description Remove dangling images, or every image not used by a container
+// @Tags images
+// @Produce json
+// @Param all query bool false "Remove all unused images, not only dangling ones"
+// @Success 200 {object} dto.APIResponse{data=dto.ImagePruneResponse} "Images pruned successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /images/prune [post]
+func (h *ImageHandler) Prune(c *gin.Context) {
+	var query dto.ImagePruneQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	report, err := h.imageService.Prune(c.Request.Context(), query.All)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to prune images",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "IMAGES_PRUNED",
+		Message: "Images pruned successfully",
+		Data:    report,
+	})
+}
@@ -0,0 +1,291 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/containerd/errdefs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+	usecases "github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type ImageHandlerSuite struct {
+	suite.Suite
+	ctrl              *gomock.Controller
+	mockImageService  *services.MockIImageService
+	mockJobService    *services.MockIJobService
+	mockJWTMiddleware *middlewares.MockIJWTMiddleware
+	handler           *ImageHandler
+	router            *gin.Engine
+}
+
+func (s *ImageHandlerSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockImageService = services.NewMockIImageService(s.ctrl)
+	s.mockJobService = services.NewMockIJobService(s.ctrl)
+	s.mockJWTMiddleware = middlewares.NewMockIJWTMiddleware(s.ctrl)
+
+	s.mockJWTMiddleware.EXPECT().
+		RequireScope(gomock.Any()).
+		Return(func(c *gin.Context) {
+			c.Set("userId", "user-id")
+			c.Next()
+		}).
+		AnyTimes()
+
+	s.handler = NewImageHandler(s.mockImageService, s.mockJobService, s.mockJWTMiddleware)
+
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.handler.SetupRoutes(s.router)
+}
+
+func (s *ImageHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestImageHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ImageHandlerSuite))
+}
+
+func (s *ImageHandlerSuite) TestList() {
+	images := []dto.ImageSummary{{Id: "sha256:abc", RepoTags: []string{"nginx:1.27"}}}
+	s.mockImageService.EXPECT().List(gomock.Any()).Return(images, nil)
+
+	req := httptest.NewRequest("GET", "/images", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response struct {
+		dto.APIResponse
+		Data []dto.ImageSummary `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("IMAGES_LISTED", response.Code)
+	s.Equal(images, response.Data)
+}
+
+func (s *ImageHandlerSuite) TestListError() {
+	s.mockImageService.EXPECT().List(gomock.Any()).Return(nil, errors.New("daemon error"))
+
+	req := httptest.NewRequest("GET", "/images", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *ImageHandlerSuite) TestInspect() {
+	s.mockImageService.EXPECT().Inspect(gomock.Any(), "ghcr.io/org/app:v1").Return(&dto.ImageInspect{Id: "sha256:abc"}, nil)
+
+	req := httptest.NewRequest("GET", "/images/inspect?image=ghcr.io/org/app:v1", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *ImageHandlerSuite) TestInspectMissingImage() {
+	req := httptest.NewRequest("GET", "/images/inspect", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ImageHandlerSuite) TestInspectNotFound() {
+	s.mockImageService.EXPECT().Inspect(gomock.Any(), "nginx:1.27").Return(nil, fmt.Errorf("%w: nginx:1.27", usecases.ErrImageNotFound))
+
+	req := httptest.NewRequest("GET", "/images/inspect?image=nginx:1.27", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *ImageHandlerSuite) TestPull() {
+	s.mockImageService.EXPECT().CheckPolicy("nginx:1.27").Return(nil)
+	s.mockJobService.EXPECT().
+		Submit(gomock.Any(), entities.JobPull, "user-id", []entities.JobItem{{ImageName: "nginx:1.27"}}).
+		Return(&entities.Job{ID: "job-id", Type: entities.JobPull, Status: entities.JobPending, Items: []entities.JobItem{{ImageName: "nginx:1.27", Status: entities.JobPending}}}, nil)
+
+	req := httptest.NewRequest("POST", "/images/pull", strings.NewReader(`{"image":"nginx:1.27"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusAccepted, w.Code)
+
+	var response struct {
+		dto.APIResponse
+		Data dto.JobResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("PULL_SUBMITTED", response.Code)
+	s.Equal("job-id", response.Data.Id)
+	s.Equal(entities.JobPull, response.Data.Type)
+	s.Equal("nginx:1.27", response.Data.Items[0].ImageName)
+}
+
+func (s *ImageHandlerSuite) TestPullErrors() {
+	cases := []struct {
+		err  error
+		code int
+	}{
+		{fmt.Errorf("%w: tag latest is denied", usecases.ErrImageNotAllowed), http.StatusForbidden},
+		{errdefs.ErrInvalidArgument, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		s.mockImageService.EXPECT().CheckPolicy("nginx:latest").Return(tc.err)
+
+		req := httptest.NewRequest("POST", "/images/pull", strings.NewReader(`{"image":"nginx:latest"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, req)
+		s.Equal(tc.code, w.Code)
+	}
+}
+
+func (s *ImageHandlerSuite) TestPullSubmitError() {
+	s.mockImageService.EXPECT().CheckPolicy("nginx:1.27").Return(nil)
+	s.mockJobService.EXPECT().Submit(gomock.Any(), entities.JobPull, "user-id", gomock.Any()).Return(nil, errors.New("db error"))
+
+	req := httptest.NewRequest("POST", "/images/pull", strings.NewReader(`{"image":"nginx:1.27"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *ImageHandlerSuite) TestPullStatus() {
+	s.mockJobService.EXPECT().FindById(gomock.Any(), "job-id").Return(&entities.Job{
+		ID:      "job-id",
+		Type:    entities.JobPull,
+		Status:  entities.JobRunning,
+		OwnerId: "user-id",
+		Items:   []entities.JobItem{{ImageName: "nginx:1.27", Status: entities.JobRunning, Progress: "a1b2c3: Downloading 42%"}},
+	}, nil)
+
+	req := httptest.NewRequest("GET", "/images/pull/job-id", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response struct {
+		dto.APIResponse
+		Data dto.JobResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("PULL_RETRIEVED", response.Code)
+	s.Equal("a1b2c3: Downloading 42%", response.Data.Items[0].Progress)
+}
+
+func (s *ImageHandlerSuite) TestPullStatusErrors() {
+	cases := []struct {
+		job  *entities.Job
+		err  error
+		code int
+	}{
+		{nil, fmt.Errorf("%w: job-id", usecases.ErrJobNotFound), http.StatusNotFound},
+		{&entities.Job{ID: "job-id", Type: entities.JobCreate, OwnerId: "user-id"}, nil, http.StatusNotFound},
+		{&entities.Job{ID: "job-id", Type: entities.JobPull, OwnerId: "other-id"}, nil, http.StatusForbidden},
+		{nil, errors.New("db error"), http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		s.mockJobService.EXPECT().FindById(gomock.Any(), "job-id").Return(tc.job, tc.err)
+
+		req := httptest.NewRequest("GET", "/images/pull/job-id", nil)
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, req)
+		s.Equal(tc.code, w.Code)
+	}
+}
+
+func (s *ImageHandlerSuite) TestPullInvalidBody() {
+	req := httptest.NewRequest("POST", "/images/pull", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ImageHandlerSuite) TestRemove() {
+	s.mockImageService.EXPECT().Remove(gomock.Any(), "nginx:1.27", true).Return([]string{"nginx:1.27", "sha256:abc"}, nil)
+
+	req := httptest.NewRequest("DELETE", "/images/remove?image=nginx:1.27&force=true", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response struct {
+		dto.APIResponse
+		Data []string `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("IMAGE_REMOVED", response.Code)
+	s.Equal([]string{"nginx:1.27", "sha256:abc"}, response.Data)
+}
+
+func (s *ImageHandlerSuite) TestRemoveErrors() {
+	cases := []struct {
+		err  error
+		code int
+	}{
+		{fmt.Errorf("%w: nginx:1.27", usecases.ErrImageNotFound), http.StatusNotFound},
+		{fmt.Errorf("%w: conflict", usecases.ErrImageInUse), http.StatusConflict},
+		{errors.New("daemon error"), http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		s.mockImageService.EXPECT().Remove(gomock.Any(), "nginx:1.27", false).Return(nil, tc.err)
+
+		req := httptest.NewRequest("DELETE", "/images/remove?image=nginx:1.27", nil)
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, req)
+		s.Equal(tc.code, w.Code)
+	}
+}
+
+func (s *ImageHandlerSuite) TestPrune() {
+	s.mockImageService.EXPECT().Prune(gomock.Any(), true).Return(&dto.ImagePruneResponse{Deleted: []string{"sha256:abc"}, SpaceReclaimed: 2048}, nil)
+
+	req := httptest.NewRequest("POST", "/images/prune?all=true", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *ImageHandlerSuite) TestPruneError() {
+	s.mockImageService.EXPECT().Prune(gomock.Any(), false).Return(nil, errors.New("prune error"))
+
+	req := httptest.NewRequest("POST", "/images/prune", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,361 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/containerd/errdefs"
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type JobHandler struct {
+	containerService services.IContainerService
+	jobService       services.IJobService
+	jwtMiddleware    middlewares.IJWTMiddleware
+}
+
+func NewJobHandler(containerService services.IContainerService, jobService services.IJobService, jwtMiddleware middlewares.IJWTMiddleware) *JobHandler {
+	return &JobHandler{containerService, jobService, jwtMiddleware}
+}
+
+func (h *JobHandler) SetupRoutes(r *gin.Engine) {
+	jobRoutes := r.Group("/jobs")
+	{
+		createGroup := jobRoutes.Group("", h.jwtMiddleware.RequireScope("container:create"))
+		{
+			createGroup.POST("/create", h.Create)
+			createGroup.POST("/import", h.Import)
+			createGroup.POST("/:id/cancel", h.Cancel)
+		}
+
+		viewGroup := jobRoutes.Group("", h.jwtMiddleware.RequireScope("container:view"))
+		{
+			viewGroup.GET("/view", h.View)
+			viewGroup.GET("/:id", h.Get)
+		}
+	}
+}
+
+// Create godoc
+// @Summary Create a container in the background
+// @Description Queue the creation of a container, ephemeral when expires_at or ttl is set, and return the job tracking it
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Param body body dto.CreateRequest true "Container creation request"
+// @Success 202 {object} dto.APIResponse{data=dto.JobResponse} "Job submitted successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /jobs/create [post]
+func (h *JobHandler) Create(c *gin.Context) {
+	var req dto.CreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	expiresAt, err := req.Resolve(time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid expiry",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	h.submit(c, entities.JobCreate, []entities.JobItem{{
+		ContainerName: req.ContainerName,
+		ImageName:     req.ImageName,
+		Spec:          req.ContainerSpec,
+		ExpiresAt:     expiresAt,
+	}})
+}
+
+// Import godoc
+// @Summary Import containers in the background
+// @Description Validate an Excel (.xlsx), CSV, JSON, NDJSON or YAML file, in the same layout as /containers/import, and queue the creation of one container per valid row, returning the job tracking them; invalid rows are failed items and containers already imported are skipped items
+// @Tags jobs
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "File containing container data"
+// @Param format query string false "Format of the file" Enums(xlsx, csv, json, ndjson, yaml)
+// @Success 202 {object} dto.APIResponse{data=dto.JobResponse} "Job submitted successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /jobs/import [post]
+func (h *JobHandler) Import(c *gin.Context) {
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+	defer file.Close()
+
+	format, err := importFormat(c, header)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	rows, err := h.containerService.ReadImport(c.Request.Context(), file, format, c.GetString("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid import file",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	items := make([]entities.JobItem, 0, len(rows))
+	for _, row := range rows {
+		item := entities.JobItem{ContainerName: row.ContainerName, ImageName: row.ImageName, Spec: row.Spec, ExpiresAt: row.ExpiresAt}
+		if row.Skipped {
+			item.Status = entities.JobSkipped
+		}
+		messages := make([]string, 0, len(row.Errors))
+		for _, importErr := range row.Errors {
+			messages = append(messages, importErr.Message)
+		}
+		item.Error = strings.Join(messages, "; ")
+		items = append(items, item)
+	}
+	h.submit(c, entities.JobImport, items)
+}
+
+func (h *JobHandler) submit(c *gin.Context, jobType entities.JobType, items []entities.JobItem) {
+	job, err := h.jobService.Submit(c.Request.Context(), jobType, c.GetString("userId"), items)
+	if errdefs.IsInvalidArgument(err) {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid job",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to submit job",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.APIResponse{
+		Success: true,
+		Code:    "JOB_SUBMITTED",
+		Message: "Job submitted successfully",
+		Data:    toJobResponse(job, false),
+	})
+}
+
+// View godoc
+// @Summary View jobs
+// @Description List the background jobs newest first, without their items (container:admin sees every user's jobs)
+// @Tags jobs
+// @Produce json
+// @Success 200 {object} dto.APIResponse{data=[]dto.JobResponse} "Jobs retrieved successfully"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /jobs/view [get]
+func (h *JobHandler) View(c *gin.Context) {
+	ownerId := c.GetString("userId")
+	if isContainerAdmin(c) {
+		ownerId = ""
+	}
+
+	jobs, err := h.jobService.View(c.Request.Context(), ownerId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to retrieve jobs",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	responses := make([]dto.JobResponse, 0, len(jobs))
+	for _, job := range jobs {
+		responses = append(responses, toJobResponse(job, false))
+	}
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "JOBS_RETRIEVED",
+		Message: "Jobs retrieved successfully",
+		Data:    responses,
+	})
+}
+
+// Get godoc
+// @Summary Get a job
+// @Description Retrieve the progress of a background job and the result of each of its items
+// @Tags jobs
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} dto.APIResponse{data=dto.JobResponse} "Job retrieved successfully"
+// @Failure 403 {object} dto.APIResponse "Job submitted by another user"
+// @Failure 404 {object} dto.APIResponse "Job not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /jobs/{id} [get]
+func (h *JobHandler) Get(c *gin.Context) {
+	job, ok := h.findOwnedJob(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "JOB_RETRIEVED",
+		Message: "Job retrieved successfully",
+		Data:    toJobResponse(job, true),
+	})
+}
+
+// Cancel godoc
+// @Summary Cancel a job
+// @Description Cancel a background job; a running job stops after the item in progress and its remaining items are canceled
+// @Tags jobs
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} dto.APIResponse "Job cancellation requested"
+// @Failure 403 {object} dto.APIResponse "Job submitted by another user"
+// @Failure 404 {object} dto.APIResponse "Job not found"
+// @Failure 409 {object} dto.APIResponse "Job already finished"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /jobs/{id}/cancel [post]
+func (h *JobHandler) Cancel(c *gin.Context) {
+	job, ok := h.findOwnedJob(c)
+	if !ok {
+		return
+	}
+
+	err := h.jobService.Cancel(c.Request.Context(), job.ID)
+	if errors.Is(err, services.ErrJobFinished) {
+		c.JSON(http.StatusConflict, dto.APIResponse{
+			Success: false,
+			Code:    "CONFLICT",
+			Message: "Job already finished",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to cancel job",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "JOB_CANCEL_REQUESTED",
+		Message: "Job cancellation requested",
+	})
+}
+
+// findOwnedJob loads the job of the request, writing the error response when it is missing or someone else's.
+func (h *JobHandler) findOwnedJob(c *gin.Context) (*entities.Job, bool) {
+	job, err := h.jobService.FindById(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, services.ErrJobNotFound) {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_FOUND",
+			Message: "Job not found",
+			Error:   err.Error(),
+		})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to retrieve job",
+			Error:   err.Error(),
+		})
+		return nil, false
+	}
+
+	if !isContainerAdmin(c) && job.OwnerId != c.GetString("userId") {
+		c.JSON(http.StatusForbidden, dto.APIResponse{
+			Success: false,
+			Code:    "FORBIDDEN",
+			Message: "Job is owned by another user",
+			Error:   "forbidden",
+		})
+		return nil, false
+	}
+	return job, true
+}
+
+func toJobResponse(job *entities.Job, withItems bool) dto.JobResponse {
+	response := dto.JobResponse{
+		Id:              job.ID,
+		Type:            job.Type,
+		Status:          job.Status,
+		OwnerId:         job.OwnerId,
+		CancelRequested: job.CancelRequested,
+		Total:           len(job.Items),
+		CreatedAt:       job.CreatedAt,
+		StartedAt:       job.StartedAt,
+		FinishedAt:      job.FinishedAt,
+	}
+	for _, item := range job.Items {
+		if item.Status.IsFinished() {
+			response.Completed++
+		}
+		switch item.Status {
+		case entities.JobSucceeded:
+			response.Succeeded++
+		case entities.JobFailed:
+			response.Failed++
+		case entities.JobSkipped:
+			response.Skipped++
+		}
+		if withItems {
+			response.Items = append(response.Items, dto.JobItemResponse{
+				ContainerName: item.ContainerName,
+				ImageName:     item.ImageName,
+				Status:        item.Status,
+				ContainerId:   item.ContainerId,
+				Progress:      item.Progress,
+				Error:         item.Error,
+			})
+		}
+	}
+	return response
+}
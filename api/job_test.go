@@ -0,0 +1,332 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/containerd/errdefs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+	usecases "github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type JobHandlerSuite struct {
+	suite.Suite
+	ctrl                 *gomock.Controller
+	mockContainerService *services.MockIContainerService
+	mockJobService       *services.MockIJobService
+	mockJWTMiddleware    *middlewares.MockIJWTMiddleware
+	handler              *JobHandler
+	router               *gin.Engine
+	scopes               []string
+}
+
+func (s *JobHandlerSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockContainerService = services.NewMockIContainerService(s.ctrl)
+	s.mockJobService = services.NewMockIJobService(s.ctrl)
+	s.mockJWTMiddleware = middlewares.NewMockIJWTMiddleware(s.ctrl)
+	s.scopes = nil
+
+	s.mockJWTMiddleware.EXPECT().
+		RequireScope(gomock.Any()).
+		Return(func(c *gin.Context) {
+			c.Set("userId", "user-id")
+			c.Set("scopes", s.scopes)
+			c.Next()
+		}).
+		AnyTimes()
+
+	s.handler = NewJobHandler(s.mockContainerService, s.mockJobService, s.mockJWTMiddleware)
+
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.handler.SetupRoutes(s.router)
+}
+
+func (s *JobHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestJobHandlerSuite(t *testing.T) {
+	suite.Run(t, new(JobHandlerSuite))
+}
+
+func (s *JobHandlerSuite) TestCreate() {
+	s.mockJobService.EXPECT().
+		Submit(gomock.Any(), entities.JobCreate, "user-id", []entities.JobItem{{ContainerName: "web", ImageName: "nginx"}}).
+		Return(&entities.Job{ID: "job-id", Type: entities.JobCreate, Status: entities.JobPending, Items: []entities.JobItem{{Status: entities.JobPending}}}, nil)
+
+	req := httptest.NewRequest("POST", "/jobs/create", strings.NewReader(`{"container_name":"web","image_name":"nginx"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusAccepted, w.Code)
+
+	var response struct {
+		dto.APIResponse
+		Data dto.JobResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("JOB_SUBMITTED", response.Code)
+	s.Equal("job-id", response.Data.Id)
+	s.Equal(1, response.Data.Total)
+	s.Empty(response.Data.Items)
+}
+
+func (s *JobHandlerSuite) TestCreateInvalidRequest() {
+	req := httptest.NewRequest("POST", "/jobs/create", strings.NewReader(`{"container_name":"web"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *JobHandlerSuite) TestCreateServiceError() {
+	s.mockJobService.EXPECT().
+		Submit(gomock.Any(), entities.JobCreate, "user-id", gomock.Any()).
+		Return(nil, errors.New("service error"))
+
+	req := httptest.NewRequest("POST", "/jobs/create", strings.NewReader(`{"container_name":"web","image_name":"nginx"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *JobHandlerSuite) newImportRequest() *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "containers.xlsx")
+	part.Write([]byte("content"))
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/jobs/import", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func (s *JobHandlerSuite) TestImport() {
+	s.mockContainerService.EXPECT().
+		ReadImport(gomock.Any(), gomock.Any(), dto.FormatXLSX, "user-id").
+		Return([]dto.ImportRow{
+			{Row: 2, ContainerName: "web", ImageName: "nginx", Spec: entities.ContainerSpec{Env: []string{"A=1"}}},
+			{Row: 3, ContainerName: "db", ImageName: "postgres", Skipped: true},
+			{Row: 4, ContainerName: "bad name", ImageName: "", Errors: []dto.ImportError{
+				{Row: 4, Column: "Container Name", Code: dto.ImportInvalidName, Message: "invalid name"},
+				{Row: 4, Column: "Image Name", Code: dto.ImportMissingValue, Message: "image name is required"},
+			}},
+		}, nil)
+	s.mockJobService.EXPECT().
+		Submit(gomock.Any(), entities.JobImport, "user-id", []entities.JobItem{
+			{ContainerName: "web", ImageName: "nginx", Spec: entities.ContainerSpec{Env: []string{"A=1"}}},
+			{ContainerName: "db", ImageName: "postgres", Status: entities.JobSkipped},
+			{ContainerName: "bad name", Error: "invalid name; image name is required"},
+		}).
+		Return(&entities.Job{ID: "job-id"}, nil)
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, s.newImportRequest())
+	s.Equal(http.StatusAccepted, w.Code)
+}
+
+func (s *JobHandlerSuite) TestImportMissingFile() {
+	req := httptest.NewRequest("POST", "/jobs/import", nil)
+	req.Header.Set("Content-Type", "multipart/form-data")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *JobHandlerSuite) TestImportInvalidFile() {
+	s.mockContainerService.EXPECT().
+		ReadImport(gomock.Any(), gomock.Any(), dto.FormatXLSX, "user-id").
+		Return(nil, errors.New("invalid header row"))
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, s.newImportRequest())
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *JobHandlerSuite) TestImportNoRows() {
+	s.mockContainerService.EXPECT().
+		ReadImport(gomock.Any(), gomock.Any(), dto.FormatXLSX, "user-id").
+		Return([]dto.ImportRow{}, nil)
+	s.mockJobService.EXPECT().
+		Submit(gomock.Any(), entities.JobImport, "user-id", []entities.JobItem{}).
+		Return(nil, fmt.Errorf("%w: job has no items", errdefs.ErrInvalidArgument))
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, s.newImportRequest())
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *JobHandlerSuite) TestView() {
+	s.mockJobService.EXPECT().
+		View(gomock.Any(), "user-id").
+		Return([]*entities.Job{{ID: "job-id", Items: []entities.JobItem{{Status: entities.JobSucceeded}}}}, nil)
+
+	req := httptest.NewRequest("GET", "/jobs/view", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response struct {
+		dto.APIResponse
+		Data []dto.JobResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("JOBS_RETRIEVED", response.Code)
+	s.Len(response.Data, 1)
+	s.Empty(response.Data[0].Items)
+}
+
+func (s *JobHandlerSuite) TestViewAdmin() {
+	s.scopes = []string{"container:admin"}
+	s.mockJobService.EXPECT().View(gomock.Any(), "").Return([]*entities.Job{}, nil)
+
+	req := httptest.NewRequest("GET", "/jobs/view", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *JobHandlerSuite) TestViewServiceError() {
+	s.mockJobService.EXPECT().View(gomock.Any(), "user-id").Return(nil, errors.New("service error"))
+
+	req := httptest.NewRequest("GET", "/jobs/view", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *JobHandlerSuite) TestGet() {
+	s.mockJobService.EXPECT().
+		FindById(gomock.Any(), "job-id").
+		Return(&entities.Job{
+			ID:      "job-id",
+			Status:  entities.JobRunning,
+			OwnerId: "user-id",
+			Items: []entities.JobItem{
+				{ContainerName: "web", Status: entities.JobSucceeded, ContainerId: "container-id"},
+				{ContainerName: "db", Status: entities.JobFailed, Error: "quota exceeded"},
+				{ContainerName: "cache", Status: entities.JobRunning},
+			},
+		}, nil)
+
+	req := httptest.NewRequest("GET", "/jobs/job-id", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response struct {
+		dto.APIResponse
+		Data dto.JobResponse `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal(3, response.Data.Total)
+	s.Equal(2, response.Data.Completed)
+	s.Equal(1, response.Data.Succeeded)
+	s.Equal(1, response.Data.Failed)
+	s.Len(response.Data.Items, 3)
+	s.Equal("container-id", response.Data.Items[0].ContainerId)
+}
+
+func (s *JobHandlerSuite) TestGetNotFound() {
+	s.mockJobService.EXPECT().
+		FindById(gomock.Any(), "job-id").
+		Return(nil, fmt.Errorf("%w: job-id", usecases.ErrJobNotFound))
+
+	req := httptest.NewRequest("GET", "/jobs/job-id", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *JobHandlerSuite) TestGetOtherOwner() {
+	s.mockJobService.EXPECT().
+		FindById(gomock.Any(), "job-id").
+		Return(&entities.Job{ID: "job-id", OwnerId: "other-id"}, nil)
+
+	req := httptest.NewRequest("GET", "/jobs/job-id", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusForbidden, w.Code)
+}
+
+func (s *JobHandlerSuite) TestGetServiceError() {
+	s.mockJobService.EXPECT().
+		FindById(gomock.Any(), "job-id").
+		Return(nil, errors.New("service error"))
+
+	req := httptest.NewRequest("GET", "/jobs/job-id", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *JobHandlerSuite) TestCancel() {
+	s.scopes = []string{"container:admin"}
+	s.mockJobService.EXPECT().FindById(gomock.Any(), "job-id").Return(&entities.Job{ID: "job-id", OwnerId: "other-id"}, nil)
+	s.mockJobService.EXPECT().Cancel(gomock.Any(), "job-id").Return(nil)
+
+	req := httptest.NewRequest("POST", "/jobs/job-id/cancel", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("JOB_CANCEL_REQUESTED", response.Code)
+}
+
+func (s *JobHandlerSuite) TestCancelFinished() {
+	s.mockJobService.EXPECT().FindById(gomock.Any(), "job-id").Return(&entities.Job{ID: "job-id", OwnerId: "user-id"}, nil)
+	s.mockJobService.EXPECT().Cancel(gomock.Any(), "job-id").Return(fmt.Errorf("%w: job-id", usecases.ErrJobFinished))
+
+	req := httptest.NewRequest("POST", "/jobs/job-id/cancel", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusConflict, w.Code)
+}
+
+func (s *JobHandlerSuite) TestCancelServiceError() {
+	s.mockJobService.EXPECT().FindById(gomock.Any(), "job-id").Return(&entities.Job{ID: "job-id", OwnerId: "user-id"}, nil)
+	s.mockJobService.EXPECT().Cancel(gomock.Any(), "job-id").Return(errors.New("service error"))
+
+	req := httptest.NewRequest("POST", "/jobs/job-id/cancel", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
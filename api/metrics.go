@@ -0,0 +1,107 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+// maxMetricsBuckets bounds how many buckets a metrics query may split its range into.
+const maxMetricsBuckets = 1440
+
+type MetricsHandler struct {
+	containerService services.IContainerService
+	metricsService   services.IMetricsService
+	jwtMiddleware    middlewares.IJWTMiddleware
+}
+
+func NewMetricsHandler(containerService services.IContainerService, metricsService services.IMetricsService, jwtMiddleware middlewares.IJWTMiddleware) *MetricsHandler {
+	return &MetricsHandler{containerService, metricsService, jwtMiddleware}
+}
+
+func (h *MetricsHandler) SetupRoutes(r *gin.Engine) {
+	metricsRoutes := r.Group("/containers", h.jwtMiddleware.RequireScope("container:view"))
+	{
+		metricsRoutes.GET("/:id/metrics", requireOwnership(h.containerService), h.Metrics)
+	}
+}
+
+// Metrics godoc
+// @Summary Get container resource metrics
+// @Description Retrieve CPU, memory, network and block I/O samples of a container downsampled into buckets of the given step. Counters (network, block I/O) are cumulative, CPU and memory usage are averaged per bucket.
+// @Tags containers
+// @Produce json
+// @Param id path string true "Container ID"
+// @Param from query string false "Start time in RFC3339 (defaults to one hour before to)"
+// @Param to query string false "End time in RFC3339 (defaults to now)"
+// @Param step query string false "Bucket size as a duration, at least 1s and no more than 1440 buckets over the range" default(1m)
+// @Success 200 {object} dto.APIResponse{data=[]dto.MetricsPoint} "Container metrics retrieved successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user"
+// @Failure 404 {object} dto.APIResponse "Container not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/{id}/metrics [get]
+func (h *MetricsHandler) Metrics(c *gin.Context) {
+	var query dto.MetricsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid metrics parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if query.To.IsZero() {
+		query.To = time.Now()
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-time.Hour)
+	}
+	if query.Step == 0 {
+		query.Step = time.Minute
+	}
+	if !query.From.Before(query.To) {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid metrics parameters",
+			Error:   "from must be before to",
+		})
+		return
+	}
+	if buckets := (query.To.Sub(query.From)-1)/query.Step + 1; buckets > maxMetricsBuckets {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid metrics parameters",
+			Error:   fmt.Sprintf("range and step make more than %d buckets", maxMetricsBuckets),
+		})
+		return
+	}
+
+	points, err := h.metricsService.GetMetrics(c.Request.Context(), c.Param("id"), query.From, query.To, query.Step)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to retrieve container metrics",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "METRICS_RETRIEVED",
+		Message: "Container metrics retrieved successfully",
+		Data:    points,
+	})
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+)
+
+type MetricsHandlerSuite struct {
+	suite.Suite
+	ctrl                 *gomock.Controller
+	mockContainerService *services.MockIContainerService
+	mockMetricsService   *services.MockIMetricsService
+	mockJWTMiddleware    *middlewares.MockIJWTMiddleware
+	handler              *MetricsHandler
+	router               *gin.Engine
+}
+
+func (s *MetricsHandlerSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockContainerService = services.NewMockIContainerService(s.ctrl)
+	s.mockMetricsService = services.NewMockIMetricsService(s.ctrl)
+	s.mockJWTMiddleware = middlewares.NewMockIJWTMiddleware(s.ctrl)
+
+	s.mockJWTMiddleware.EXPECT().
+		RequireScope(gomock.Any()).
+		Return(func(c *gin.Context) {
+			c.Set("userId", "user-id")
+			c.Next()
+		}).
+		AnyTimes()
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil).
+		AnyTimes()
+
+	s.handler = NewMetricsHandler(s.mockContainerService, s.mockMetricsService, s.mockJWTMiddleware)
+
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.handler.SetupRoutes(s.router)
+}
+
+func (s *MetricsHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestMetricsHandlerSuite(t *testing.T) {
+	suite.Run(t, new(MetricsHandlerSuite))
+}
+
+func (s *MetricsHandlerSuite) TestMetrics() {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+	points := []dto.MetricsPoint{{Timestamp: from, Samples: 6, CpuPercent: 12.5, MemoryUsage: 1024}}
+
+	s.mockMetricsService.EXPECT().
+		GetMetrics(gomock.Any(), "container-id", from, to, 5*time.Minute).
+		Return(points, nil)
+
+	req := httptest.NewRequest("GET", "/containers/container-id/metrics?from=2025-01-01T00:00:00Z&to=2025-01-01T01:00:00Z&step=5m", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response struct {
+		dto.APIResponse
+		Data []dto.MetricsPoint `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("METRICS_RETRIEVED", response.Code)
+	s.Equal(points, response.Data)
+}
+
+func (s *MetricsHandlerSuite) TestMetricsDefaults() {
+	s.mockMetricsService.EXPECT().
+		GetMetrics(gomock.Any(), "container-id", gomock.Any(), gomock.Any(), time.Minute).
+		DoAndReturn(func(_, _ any, from time.Time, to time.Time, _ time.Duration) ([]dto.MetricsPoint, error) {
+			s.Equal(time.Hour, to.Sub(from))
+			return []dto.MetricsPoint{}, nil
+		})
+
+	req := httptest.NewRequest("GET", "/containers/container-id/metrics", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *MetricsHandlerSuite) TestMetricsInvalidStep() {
+	req := httptest.NewRequest("GET", "/containers/container-id/metrics?step=100ms", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *MetricsHandlerSuite) TestMetricsInvalidTime() {
+	req := httptest.NewRequest("GET", "/containers/container-id/metrics?from=yesterday", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *MetricsHandlerSuite) TestMetricsInvalidRange() {
+	req := httptest.NewRequest("GET", "/containers/container-id/metrics?from=2025-01-01T01:00:00Z&to=2025-01-01T00:00:00Z", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("from must be before to", response.Error)
+}
+
+func (s *MetricsHandlerSuite) TestMetricsTooManyBuckets() {
+	req := httptest.NewRequest("GET", "/containers/container-id/metrics?from=2025-01-01T00:00:00Z&to=2025-01-02T00:00:01Z&step=1m", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("range and step make more than 1440 buckets", response.Error)
+}
+
+func (s *MetricsHandlerSuite) TestMetricsServiceError() {
+	s.mockMetricsService.EXPECT().
+		GetMetrics(gomock.Any(), "container-id", gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("service error"))
+
+	req := httptest.NewRequest("GET", "/containers/container-id/metrics", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type QuotaHandler struct {
+	quotaService  services.IQuotaService
+	jwtMiddleware middlewares.IJWTMiddleware
+}
+
+func NewQuotaHandler(quotaService services.IQuotaService, jwtMiddleware middlewares.IJWTMiddleware) *QuotaHandler {
+	return &QuotaHandler{quotaService, jwtMiddleware}
+}
+
+func (h *QuotaHandler) SetupRoutes(r *gin.Engine) {
+	quotaRoutes := r.Group("/quotas", h.jwtMiddleware.RequireScope("user:manager"))
+	{
+		quotaRoutes.GET("/view", h.View)
+		quotaRoutes.PUT("/update", h.Update)
+		quotaRoutes.DELETE("/delete", h.Delete)
+	}
+}
+
+// View godoc
+// @Summary View quotas
+// @Description List container quotas set on users and roles
+// @Tags quotas
+// @Produce json
+// @Success 200 {object} dto.APIResponse "Successful response with quota list"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /quotas/view [get]
+func (h *QuotaHandler) View(c *gin.Context) {
+	quotas, err := h.quotaService.View(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to retrieve quotas",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "QUOTAS_RETRIEVED",
+		Message: "Quotas retrieved successfully",
+		Data:    quotas,
+	})
+}
+
+// Update godoc
+// @Summary Set a quota
+// @Description Create or replace the container quota of a user or a role (0 means unlimited)
+// @Tags quotas
+// @Accept json
+// @Produce json
+// @Param body body dto.QuotaRequest true "Quota subject and limits"
+// @Success 200 {object} dto.APIResponse "Quota updated successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /quotas/update [put]
+func (h *QuotaHandler) Update(c *gin.Context) {
+	var req dto.QuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	err := h.quotaService.Set(c.Request.Context(), &entities.Quota{
+		SubjectType:   req.SubjectType,
+		Subject:       req.Subject,
+		MaxContainers: req.MaxContainers,
+		MaxMemory:     req.MaxMemory,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to update quota",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "QUOTA_UPDATED",
+		Message: "Quota updated successfully",
+	})
+}
+
+// Delete godoc
+// @Summary Delete a quota
+// @Description Remove the container quota of a user or a role
+// @Tags quotas
+// @Accept json
+// @Produce json
+// @Param body body dto.QuotaDeleteRequest true "Quota subject"
+// @Success 200 {object} dto.APIResponse "Quota deleted successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /quotas/delete [delete]
+func (h *QuotaHandler) Delete(c *gin.Context) {
+	var req dto.QuotaDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := h.quotaService.Delete(c.Request.Context(), req.SubjectType, req.Subject); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to delete quota",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "QUOTA_DELETED",
+		Message: "Quota deleted successfully",
+	})
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+)
+
+type QuotaHandlerSuite struct {
+	suite.Suite
+	ctrl              *gomock.Controller
+	mockQuotaService  *services.MockIQuotaService
+	mockJWTMiddleware *middlewares.MockIJWTMiddleware
+	handler           *QuotaHandler
+	router            *gin.Engine
+}
+
+func (s *QuotaHandlerSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockQuotaService = services.NewMockIQuotaService(s.ctrl)
+	s.mockJWTMiddleware = middlewares.NewMockIJWTMiddleware(s.ctrl)
+
+	s.mockJWTMiddleware.EXPECT().
+		RequireScope(gomock.Any()).
+		Return(func(c *gin.Context) {
+			c.Set("userId", "test-user-id")
+			c.Next()
+		}).
+		AnyTimes()
+
+	s.handler = NewQuotaHandler(s.mockQuotaService, s.mockJWTMiddleware)
+
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.handler.SetupRoutes(s.router)
+}
+
+func (s *QuotaHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestQuotaHandlerSuite(t *testing.T) {
+	suite.Run(t, new(QuotaHandlerSuite))
+}
+
+func (s *QuotaHandlerSuite) TestView() {
+	s.mockQuotaService.EXPECT().
+		View(gomock.Any()).
+		Return([]*entities.Quota{{SubjectType: entities.QuotaRole, Subject: "developer", MaxContainers: 5}}, nil)
+
+	req := httptest.NewRequest("GET", "/quotas/view", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("QUOTAS_RETRIEVED", response.Code)
+}
+
+func (s *QuotaHandlerSuite) TestViewServiceError() {
+	s.mockQuotaService.EXPECT().
+		View(gomock.Any()).
+		Return(nil, errors.New("service error"))
+
+	req := httptest.NewRequest("GET", "/quotas/view", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *QuotaHandlerSuite) TestUpdate() {
+	s.mockQuotaService.EXPECT().
+		Set(gomock.Any(), &entities.Quota{SubjectType: entities.QuotaUser, Subject: "user-id", MaxContainers: 3, MaxMemory: 1 << 30}).
+		Return(nil)
+
+	reqBody := dto.QuotaRequest{
+		SubjectType:   entities.QuotaUser,
+		Subject:       "user-id",
+		MaxContainers: 3,
+		MaxMemory:     1 << 30,
+	}
+	jsonData, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest("PUT", "/quotas/update", bytes.NewBuffer(jsonData))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("QUOTA_UPDATED", response.Code)
+}
+
+func (s *QuotaHandlerSuite) TestUpdateInvalidSubjectType() {
+	req := httptest.NewRequest("PUT", "/quotas/update", strings.NewReader(`{"subject_type":"group","subject":"x"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *QuotaHandlerSuite) TestUpdateServiceError() {
+	s.mockQuotaService.EXPECT().
+		Set(gomock.Any(), gomock.Any()).
+		Return(errors.New("service error"))
+
+	req := httptest.NewRequest("PUT", "/quotas/update", strings.NewReader(`{"subject_type":"role","subject":"developer","max_containers":5}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *QuotaHandlerSuite) TestDelete() {
+	s.mockQuotaService.EXPECT().
+		Delete(gomock.Any(), entities.QuotaRole, "developer").
+		Return(nil)
+
+	req := httptest.NewRequest("DELETE", "/quotas/delete", strings.NewReader(`{"subject_type":"role","subject":"developer"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *QuotaHandlerSuite) TestDeleteInvalidRequestBody() {
+	req := httptest.NewRequest("DELETE", "/quotas/delete", strings.NewReader("invalid json"))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *QuotaHandlerSuite) TestDeleteServiceError() {
+	s.mockQuotaService.EXPECT().
+		Delete(gomock.Any(), entities.QuotaRole, "developer").
+		Return(errors.New("service error"))
+
+	req := httptest.NewRequest("DELETE", "/quotas/delete", strings.NewReader(`{"subject_type":"role","subject":"developer"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
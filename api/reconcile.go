@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type ReconcileHandler struct {
+	reconcileService services.IReconcileService
+	jwtMiddleware    middlewares.IJWTMiddleware
+}
+
+func NewReconcileHandler(reconcileService services.IReconcileService, jwtMiddleware middlewares.IJWTMiddleware) *ReconcileHandler {
+	return &ReconcileHandler{reconcileService, jwtMiddleware}
+}
+
+func (h *ReconcileHandler) SetupRoutes(r *gin.Engine) {
+	adminRoutes := r.Group("/admin", h.jwtMiddleware.RequireScope("container:admin"))
+	{
+		adminRoutes.GET("/reconcile", h.Reconcile)
+	}
+}
+
+// Reconcile godoc
+// @Summary Preview database and docker daemon reconciliation
+// @Description Dry-run the reconciler: list the containers found on the daemon but not in the database (untracked) and those in the database but not on the daemon (missing), with the action the configured policy would take on each.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} dto.APIResponse{data=dto.ReconcileReport} "Reconcile report retrieved successfully"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/reconcile [get]
+func (h *ReconcileHandler) Reconcile(c *gin.Context) {
+	report, err := h.reconcileService.Reconcile(c.Request.Context(), true)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to reconcile containers",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "RECONCILE_REPORT_RETRIEVED",
+		Message: "Reconcile report retrieved successfully",
+		Data:    report,
+	})
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+)
+
+type ReconcileHandlerSuite struct {
+	suite.Suite
+	ctrl                 *gomock.Controller
+	mockReconcileService *services.MockIReconcileService
+	mockJWTMiddleware    *middlewares.MockIJWTMiddleware
+	handler              *ReconcileHandler
+	router               *gin.Engine
+}
+
+func (s *ReconcileHandlerSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockReconcileService = services.NewMockIReconcileService(s.ctrl)
+	s.mockJWTMiddleware = middlewares.NewMockIJWTMiddleware(s.ctrl)
+
+	s.mockJWTMiddleware.EXPECT().
+		RequireScope("container:admin").
+		Return(func(c *gin.Context) {
+			c.Next()
+		}).
+		AnyTimes()
+
+	s.handler = NewReconcileHandler(s.mockReconcileService, s.mockJWTMiddleware)
+
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.handler.SetupRoutes(s.router)
+}
+
+func (s *ReconcileHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestReconcileHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ReconcileHandlerSuite))
+}
+
+func (s *ReconcileHandlerSuite) TestReconcile() {
+	report := &dto.ReconcileReport{
+		DryRun:    true,
+		Untracked: []dto.ReconcileItem{{ContainerId: "managed", ContainerName: "managed", Action: dto.ReconcileAdopt}},
+		Missing:   []dto.ReconcileItem{},
+	}
+	s.mockReconcileService.EXPECT().
+		Reconcile(gomock.Any(), true).
+		Return(report, nil)
+
+	req := httptest.NewRequest("GET", "/admin/reconcile", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response struct {
+		dto.APIResponse
+		Data dto.ReconcileReport `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("RECONCILE_REPORT_RETRIEVED", response.Code)
+	s.Equal(*report, response.Data)
+}
+
+func (s *ReconcileHandlerSuite) TestReconcileServiceError() {
+	s.mockReconcileService.EXPECT().
+		Reconcile(gomock.Any(), true).
+		Return(nil, errors.New("daemon unavailable"))
+
+	req := httptest.NewRequest("GET", "/admin/reconcile", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type RegistryHandler struct {
+	registryService services.IRegistryService
+	jwtMiddleware   middlewares.IJWTMiddleware
+}
+
+func NewRegistryHandler(registryService services.IRegistryService, jwtMiddleware middlewares.IJWTMiddleware) *RegistryHandler {
+	return &RegistryHandler{registryService, jwtMiddleware}
+}
+
+func (h *RegistryHandler) SetupRoutes(r *gin.Engine) {
+	registryRoutes := r.Group("/registries", h.jwtMiddleware.RequireScope("registry:manage"))
+	{
+		registryRoutes.GET("/view", h.View)
+		registryRoutes.PUT("/update", h.Update)
+		registryRoutes.DELETE("/delete", h.Delete)
+	}
+}
+
+// View godoc
+// @Summary View registry credentials
+// @Description List the private registries with stored credentials, secrets are never returned
+// @Tags registries
+// @Produce json
+// @Success 200 {object} dto.APIResponse{data=[]dto.RegistryResponse} "Successful response with registry list"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /registries/view [get]
+func (h *RegistryHandler) View(c *gin.Context) {
+	registries, err := h.registryService.View(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to retrieve registry credentials",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "REGISTRIES_RETRIEVED",
+		Message: "Registry credentials retrieved successfully",
+		Data:    registries,
+	})
+}
+
+// Update godoc
+// @Summary Set registry credentials
+// @Description Create or replace the credentials used to pull from a private registry, either a username and password or an identity token
+// @Tags registries
+// @Accept json
+// @Produce json
+// @Param body body dto.RegistryRequest true "Registry host and credentials"
+// @Success 200 {object} dto.APIResponse "Registry credentials updated successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Failure 503 {object} dto.APIResponse "Credentials encryption not configured"
+// @Security BearerAuth
+// @Router /registries/update [put]
+func (h *RegistryHandler) Update(c *gin.Context) {
+	var req dto.RegistryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	err := h.registryService.Set(c.Request.Context(), req)
+	if errors.Is(err, services.ErrRegistryKeyMissing) {
+		c.JSON(http.StatusServiceUnavailable, dto.APIResponse{
+			Success: false,
+			Code:    "SERVICE_UNAVAILABLE",
+			Message: "Registry credentials cannot be stored",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to update registry credentials",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "REGISTRY_UPDATED",
+		Message: "Registry credentials updated successfully",
+	})
+}
+
+// Delete godoc
+// @Summary Delete registry credentials
+// @Description Remove the credentials stored for a private registry
+// @Tags registries
+// @Accept json
+// @Produce json
+// @Param body body dto.RegistryDeleteRequest true "Registry host"
+// @Success 200 {object} dto.APIResponse "Registry credentials deleted successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /registries/delete [delete]
+func (h *RegistryHandler) Delete(c *gin.Context) {
+	var req dto.RegistryDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := h.registryService.Delete(c.Request.Context(), req.Registry); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to delete registry credentials",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "REGISTRY_DELETED",
+		Message: "Registry credentials deleted successfully",
+	})
+}
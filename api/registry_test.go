@@ -0,0 +1,193 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+	usecases "github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type RegistryHandlerSuite struct {
+	suite.Suite
+	ctrl                *gomock.Controller
+	mockRegistryService *services.MockIRegistryService
+	mockJWTMiddleware   *middlewares.MockIJWTMiddleware
+	handler             *RegistryHandler
+	router              *gin.Engine
+}
+
+func (s *RegistryHandlerSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRegistryService = services.NewMockIRegistryService(s.ctrl)
+	s.mockJWTMiddleware = middlewares.NewMockIJWTMiddleware(s.ctrl)
+
+	s.mockJWTMiddleware.EXPECT().
+		RequireScope("registry:manage").
+		Return(func(c *gin.Context) {
+			c.Set("userId", "test-user-id")
+			c.Next()
+		}).
+		AnyTimes()
+
+	s.handler = NewRegistryHandler(s.mockRegistryService, s.mockJWTMiddleware)
+
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.handler.SetupRoutes(s.router)
+}
+
+func (s *RegistryHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestRegistryHandlerSuite(t *testing.T) {
+	suite.Run(t, new(RegistryHandlerSuite))
+}
+
+func (s *RegistryHandlerSuite) TestView() {
+	s.mockRegistryService.EXPECT().
+		View(gomock.Any()).
+		Return([]dto.RegistryResponse{{Registry: "ghcr.io", Username: "user", HasPassword: true}}, nil)
+
+	req := httptest.NewRequest("GET", "/registries/view", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("REGISTRIES_RETRIEVED", response.Code)
+	s.NotContains(w.Body.String(), "\"password\"")
+}
+
+func (s *RegistryHandlerSuite) TestViewServiceError() {
+	s.mockRegistryService.EXPECT().
+		View(gomock.Any()).
+		Return(nil, errors.New("service error"))
+
+	req := httptest.NewRequest("GET", "/registries/view", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *RegistryHandlerSuite) TestUpdate() {
+	s.mockRegistryService.EXPECT().
+		Set(gomock.Any(), dto.RegistryRequest{Registry: "registry.example.com:5000", Username: "user", Password: "password"}).
+		Return(nil)
+
+	req := httptest.NewRequest("PUT", "/registries/update", strings.NewReader(`{"registry":"registry.example.com:5000","username":"user","password":"password"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("REGISTRY_UPDATED", response.Code)
+}
+
+func (s *RegistryHandlerSuite) TestUpdateToken() {
+	s.mockRegistryService.EXPECT().
+		Set(gomock.Any(), dto.RegistryRequest{Registry: "ghcr.io", Token: "token"}).
+		Return(nil)
+
+	req := httptest.NewRequest("PUT", "/registries/update", strings.NewReader(`{"registry":"ghcr.io","token":"token"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *RegistryHandlerSuite) TestUpdateInvalidRequest() {
+	for _, body := range []string{
+		`{"registry":"ghcr.io"}`,
+		`{"registry":"ghcr.io","password":"password"}`,
+		`{"registry":"https://ghcr.io","token":"token"}`,
+	} {
+		req := httptest.NewRequest("PUT", "/registries/update", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, req)
+		s.Equal(http.StatusBadRequest, w.Code, body)
+	}
+}
+
+func (s *RegistryHandlerSuite) TestUpdateKeyMissing() {
+	s.mockRegistryService.EXPECT().
+		Set(gomock.Any(), gomock.Any()).
+		Return(usecases.ErrRegistryKeyMissing)
+
+	req := httptest.NewRequest("PUT", "/registries/update", strings.NewReader(`{"registry":"ghcr.io","token":"token"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusServiceUnavailable, w.Code)
+}
+
+func (s *RegistryHandlerSuite) TestUpdateServiceError() {
+	s.mockRegistryService.EXPECT().
+		Set(gomock.Any(), gomock.Any()).
+		Return(errors.New("service error"))
+
+	req := httptest.NewRequest("PUT", "/registries/update", strings.NewReader(`{"registry":"ghcr.io","token":"token"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *RegistryHandlerSuite) TestDelete() {
+	s.mockRegistryService.EXPECT().
+		Delete(gomock.Any(), "ghcr.io").
+		Return(nil)
+
+	req := httptest.NewRequest("DELETE", "/registries/delete", strings.NewReader(`{"registry":"ghcr.io"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *RegistryHandlerSuite) TestDeleteInvalidRequestBody() {
+	req := httptest.NewRequest("DELETE", "/registries/delete", strings.NewReader("invalid json"))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *RegistryHandlerSuite) TestDeleteServiceError() {
+	s.mockRegistryService.EXPECT().
+		Delete(gomock.Any(), "ghcr.io").
+		Return(errors.New("service error"))
+
+	req := httptest.NewRequest("DELETE", "/registries/delete", strings.NewReader(`{"registry":"ghcr.io"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
@@ -30,12 +30,24 @@ func (h *ReportHandler) SetupRoutes(r *gin.Engine) {
 
 // SendEmail godoc
 // @Summary Send container status report via email
-// @Description Generates a container uptime/downtime report and sends it to the provided email address
+// @Description Generates a container uptime/downtime report, on the containers matching the same filters as /containers/view, and sends it to the provided email address
 // @Tags Report
 // @Produce json
 // @Param email query string true "Recipient email address"
 // @Param start_time query string true "Start date (e.g. 2006-01-02)"
 // @Param end_time query string false "End date (defaults to current time)"
+// @Param container_id query string false "Filter by ContainerId"
+// @Param container_name query string false "Filter by part of the ContainerName"
+// @Param image_name query string false "Filter by part of the ImageName"
+// @Param status query string false "Filter by comma separated statuses (e.g. ON,OFF)"
+// @Param ipv4 query string false "Filter by IPv4 address or CIDR block (e.g. 172.17.0.0/16)"
+// @Param owner_id query string false "Filter by owner (container:admin only, others only see their own containers)"
+// @Param created_after query string false "Created at or after this RFC 3339 time"
+// @Param created_before query string false "Created at or before this RFC 3339 time"
+// @Param updated_after query string false "Updated at or after this RFC 3339 time"
+// @Param updated_before query string false "Updated at or before this RFC 3339 time"
+// @Param labels query string false "Comma separated label selector (e.g. env=prod,team!=infra)"
+// @Param q query string false "Free text matched against the id, name, image and IPv4"
 // @Success 200 {object} dto.APIResponse "Report emailed successfully"
 // @Failure 400 {object} dto.APIResponse "Invalid input or time range"
 // @Failure 500 {object} dto.APIResponse "Failed to retrieve data or send email"
@@ -53,6 +65,20 @@ func (h *ReportHandler) SendEmail(c *gin.Context) {
 		return
 	}
 
+	filter, err := bindContainerFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if !isContainerAdmin(c) {
+		filter.OwnerId = c.GetString("userId")
+	}
+
 	startTime, err := time.Parse(time.RFC3339, req.StartTime+"T00:00:00Z")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, dto.APIResponse{
@@ -90,7 +116,7 @@ func (h *ReportHandler) SendEmail(c *gin.Context) {
 		return
 	}
 
-	containers, total, err := h.containerService.View(c.Request.Context(), dto.ContainerFilter{}, 1, -1, dto.ContainerSort{
+	containers, total, err := h.containerService.View(c.Request.Context(), filter, 1, -1, dto.ContainerSort{
 		Field: "container_id", Order: dto.Asc,
 	})
 	if err != nil {
@@ -175,6 +175,72 @@ func (s *ReportHandlerSuite) TestSendEmailContainerServiceError() {
 	s.Equal("container service error", response.Error)
 }
 
+func (s *ReportHandlerSuite) TestSendEmailWithContainerFilter() {
+	filter := dto.ContainerFilter{
+		Status: []entities.ContainerStatus{entities.ContainerOn},
+		Labels: []dto.LabelRequirement{{Key: "env", Operator: dto.LabelEquals, Value: "prod"}},
+	}
+	s.mockContainerService.EXPECT().
+		View(gomock.Any(), filter, 1, -1, dto.ContainerSort{Field: "container_id", Order: dto.Asc}).
+		Return([]*entities.Container{}, int64(0), errors.New("container service error"))
+
+	req := httptest.NewRequest("GET", "/report/mail?email=test@example.com&start_time=2023-01-01&status=on&labels=env%3Dprod", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+// serveAs sends the request through a router that authenticates it as userId with the given scopes.
+func (s *ReportHandlerSuite) serveAs(target string, userId string, scopes ...string) *httptest.ResponseRecorder {
+	jwtMiddleware := middlewares.NewMockIJWTMiddleware(s.ctrl)
+	jwtMiddleware.EXPECT().
+		RequireScope("report:mail").
+		Return(func(c *gin.Context) {
+			c.Set("userId", userId)
+			c.Set("scopes", scopes)
+			c.Next()
+		})
+
+	router := gin.New()
+	NewReportHandler(s.mockContainerService, s.mockHealthcheckService, s.mockReportService, jwtMiddleware).SetupRoutes(router)
+	req := httptest.NewRequest("GET", target, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *ReportHandlerSuite) TestSendEmailOnlyOwnContainers() {
+	s.mockContainerService.EXPECT().
+		View(gomock.Any(), dto.ContainerFilter{OwnerId: "user-id"}, 1, -1, dto.ContainerSort{Field: "container_id", Order: dto.Asc}).
+		Return([]*entities.Container{}, int64(0), errors.New("container service error"))
+
+	w := s.serveAs("/report/mail?email=test@example.com&start_time=2023-01-01&owner_id=other-id", "user-id")
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *ReportHandlerSuite) TestSendEmailAsAdmin() {
+	s.mockContainerService.EXPECT().
+		View(gomock.Any(), dto.ContainerFilter{OwnerId: "other-id"}, 1, -1, dto.ContainerSort{Field: "container_id", Order: dto.Asc}).
+		Return([]*entities.Container{}, int64(0), errors.New("container service error"))
+
+	w := s.serveAs("/report/mail?email=test@example.com&start_time=2023-01-01&owner_id=other-id", "admin-id", "container:admin")
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *ReportHandlerSuite) TestSendEmailInvalidContainerFilter() {
+	req := httptest.NewRequest("GET", "/report/mail?email=test@example.com&start_time=2023-01-01&status=sleeping", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("invalid status \"sleeping\"", response.Error)
+}
+
 func (s *ReportHandlerSuite) TestSendEmailHealthcheckServiceError() {
 	baseTime := time.Now()
 	endTime := baseTime
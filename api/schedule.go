@@ -0,0 +1,269 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/containerd/errdefs"
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type ScheduleHandler struct {
+	containerService services.IContainerService
+	scheduleService  services.IScheduleService
+	jwtMiddleware    middlewares.IJWTMiddleware
+}
+
+func NewScheduleHandler(containerService services.IContainerService, scheduleService services.IScheduleService, jwtMiddleware middlewares.IJWTMiddleware) *ScheduleHandler {
+	return &ScheduleHandler{containerService, scheduleService, jwtMiddleware}
+}
+
+func (h *ScheduleHandler) SetupRoutes(r *gin.Engine) {
+	scheduleRoutes := r.Group("/containers/:id/schedules")
+	{
+		viewGroup := scheduleRoutes.Group("", h.jwtMiddleware.RequireScope("container:view"), requireOwnership(h.containerService))
+		{
+			viewGroup.GET("", h.View)
+			viewGroup.GET("/:scheduleId", h.Get)
+			viewGroup.GET("/:scheduleId/runs", h.Runs)
+		}
+
+		modifyGroup := scheduleRoutes.Group("", h.jwtMiddleware.RequireScope("container:update"), requireOwnership(h.containerService))
+		{
+			modifyGroup.POST("", h.Create)
+			modifyGroup.PUT("/:scheduleId", h.Update)
+			modifyGroup.DELETE("/:scheduleId", h.Delete)
+		}
+	}
+}
+
+// scheduleError writes the response of a failed schedule operation.
+func scheduleError(c *gin.Context, err error, message string) {
+	if errors.Is(err, services.ErrContainerNotFound) {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_FOUND",
+			Message: "Container not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, services.ErrScheduleNotFound) {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_FOUND",
+			Message: "Schedule not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errdefs.IsInvalidArgument(err) {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid cron expression or timezone",
+			Error:   err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, dto.APIResponse{
+		Success: false,
+		Code:    "INTERNAL_SERVER_ERROR",
+		Message: message,
+		Error:   err.Error(),
+	})
+}
+
+// Create godoc
+// @Summary Schedule a container action
+// @Description Start, stop or restart a container at the times of a cron expression read in a timezone (e.g. stop at "0 20 * * 1-5" in Asia/Ho_Chi_Minh). Runs missed while the scheduler was down are caught up once.
+// @Tags schedules
+// @Accept json
+// @Produce json
+// @Param id path string true "Container ID"
+// @Param body body dto.ScheduleRequest true "Schedule"
+// @Success 201 {object} dto.APIResponse{data=entities.Schedule} "Schedule created successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user"
+// @Failure 404 {object} dto.APIResponse "Container not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/{id}/schedules [post]
+func (h *ScheduleHandler) Create(c *gin.Context) {
+	var req dto.ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	schedule, err := h.scheduleService.Create(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		scheduleError(c, err, "Failed to create schedule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Code:    "SCHEDULE_CREATED",
+		Message: "Schedule created successfully",
+		Data:    schedule,
+	})
+}
+
+// View godoc
+// @Summary View container schedules
+// @Description List the schedules of a container along with their next and last runs
+// @Tags schedules
+// @Produce json
+// @Param id path string true "Container ID"
+// @Success 200 {object} dto.APIResponse{data=[]entities.Schedule} "Schedules retrieved successfully"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user"
+// @Failure 404 {object} dto.APIResponse "Container not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/{id}/schedules [get]
+func (h *ScheduleHandler) View(c *gin.Context) {
+	schedules, err := h.scheduleService.View(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		scheduleError(c, err, "Failed to retrieve schedules")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "SCHEDULES_RETRIEVED",
+		Message: "Schedules retrieved successfully",
+		Data:    schedules,
+	})
+}
+
+// Get godoc
+// @Summary Get a container schedule
+// @Tags schedules
+// @Produce json
+// @Param id path string true "Container ID"
+// @Param scheduleId path string true "Schedule ID"
+// @Success 200 {object} dto.APIResponse{data=entities.Schedule} "Schedule retrieved successfully"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user"
+// @Failure 404 {object} dto.APIResponse "Container or schedule not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/{id}/schedules/{scheduleId} [get]
+func (h *ScheduleHandler) Get(c *gin.Context) {
+	schedule, err := h.scheduleService.FindById(c.Request.Context(), c.Param("id"), c.Param("scheduleId"))
+	if err != nil {
+		scheduleError(c, err, "Failed to retrieve schedule")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "SCHEDULE_RETRIEVED",
+		Message: "Schedule retrieved successfully",
+		Data:    schedule,
+	})
+}
+
+// Update godoc
+// @Summary Update a container schedule
+// @Description Replace the action, cron expression, timezone and enabled flag of a schedule, planning its next run again
+// @Tags schedules
+// @Accept json
+// @Produce json
+// @Param id path string true "Container ID"
+// @Param scheduleId path string true "Schedule ID"
+// @Param body body dto.ScheduleRequest true "Schedule"
+// @Success 200 {object} dto.APIResponse{data=entities.Schedule} "Schedule updated successfully"
+// @Failure 400 {object} dto.APIResponse "Bad request"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user"
+// @Failure 404 {object} dto.APIResponse "Container or schedule not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/{id}/schedules/{scheduleId} [put]
+func (h *ScheduleHandler) Update(c *gin.Context) {
+	var req dto.ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	schedule, err := h.scheduleService.Update(c.Request.Context(), c.Param("id"), c.Param("scheduleId"), req)
+	if err != nil {
+		scheduleError(c, err, "Failed to update schedule")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "SCHEDULE_UPDATED",
+		Message: "Schedule updated successfully",
+		Data:    schedule,
+	})
+}
+
+// Delete godoc
+// @Summary Delete a container schedule
+// @Description Delete a schedule along with the record of its runs
+// @Tags schedules
+// @Produce json
+// @Param id path string true "Container ID"
+// @Param scheduleId path string true "Schedule ID"
+// @Success 200 {object} dto.APIResponse "Schedule deleted successfully"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user"
+// @Failure 404 {object} dto.APIResponse "Container or schedule not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/{id}/schedules/{scheduleId} [delete]
+func (h *ScheduleHandler) Delete(c *gin.Context) {
+	if err := h.scheduleService.Delete(c.Request.Context(), c.Param("id"), c.Param("scheduleId")); err != nil {
+		scheduleError(c, err, "Failed to delete schedule")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "SCHEDULE_DELETED",
+		Message: "Schedule deleted successfully",
+	})
+}
+
+// Runs godoc
+// @Summary View the runs of a container schedule
+// @Description List the latest 50 runs of a schedule with their outcome, newest first
+// @Tags schedules
+// @Produce json
+// @Param id path string true "Container ID"
+// @Param scheduleId path string true "Schedule ID"
+// @Success 200 {object} dto.APIResponse{data=[]entities.ScheduleRun} "Schedule runs retrieved successfully"
+// @Failure 403 {object} dto.APIResponse "Container is owned by another user"
+// @Failure 404 {object} dto.APIResponse "Container or schedule not found"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/{id}/schedules/{scheduleId}/runs [get]
+func (h *ScheduleHandler) Runs(c *gin.Context) {
+	runs, err := h.scheduleService.Runs(c.Request.Context(), c.Param("id"), c.Param("scheduleId"))
+	if err != nil {
+		scheduleError(c, err, "Failed to retrieve schedule runs")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "SCHEDULE_RUNS_RETRIEVED",
+		Message: "Schedule runs retrieved successfully",
+		Data:    runs,
+	})
+}
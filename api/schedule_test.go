@@ -0,0 +1,285 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/containerd/errdefs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+	usecases "github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type ScheduleHandlerSuite struct {
+	suite.Suite
+	ctrl                 *gomock.Controller
+	mockContainerService *services.MockIContainerService
+	mockScheduleService  *services.MockIScheduleService
+	router               *gin.Engine
+}
+
+func (s *ScheduleHandlerSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockContainerService = services.NewMockIContainerService(s.ctrl)
+	s.mockScheduleService = services.NewMockIScheduleService(s.ctrl)
+	s.router = s.newRouter("user-id")
+}
+
+func (s *ScheduleHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestScheduleHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ScheduleHandlerSuite))
+}
+
+// newRouter serves the schedule routes next to the container ones they share their prefix with.
+func (s *ScheduleHandlerSuite) newRouter(userId string, scopes ...string) *gin.Engine {
+	jwtMiddleware := middlewares.NewMockIJWTMiddleware(s.ctrl)
+	jwtMiddleware.EXPECT().
+		RequireScope(gomock.Any()).
+		Return(func(c *gin.Context) {
+			c.Set("userId", userId)
+			c.Set("scopes", scopes)
+			c.Next()
+		}).
+		AnyTimes()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	NewContainerHandler(s.mockContainerService, jwtMiddleware).SetupRoutes(router)
+	NewScheduleHandler(s.mockContainerService, s.mockScheduleService, jwtMiddleware).SetupRoutes(router)
+	return router
+}
+
+func (s *ScheduleHandlerSuite) expectOwner(ownerId string) {
+	s.mockContainerService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: ownerId}, nil)
+}
+
+func (s *ScheduleHandlerSuite) TestCreate() {
+	req := dto.ScheduleRequest{Action: entities.ScheduleStop, Cron: "0 20 * * 1-5", Timezone: "Asia/Ho_Chi_Minh"}
+	s.expectOwner("user-id")
+	s.mockScheduleService.EXPECT().
+		Create(gomock.Any(), "container-id", req).
+		Return(&entities.Schedule{ID: "schedule-id", ContainerId: "container-id"}, nil)
+
+	jsonData, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest("POST", "/containers/container-id/schedules", bytes.NewBuffer(jsonData))
+	httpReq.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, httpReq)
+	s.Equal(http.StatusCreated, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("SCHEDULE_CREATED", response.Code)
+}
+
+func (s *ScheduleHandlerSuite) TestCreateInvalidAction() {
+	s.expectOwner("user-id")
+
+	httpReq := httptest.NewRequest("POST", "/containers/container-id/schedules", strings.NewReader(`{"action":"pause","cron":"@daily"}`))
+	httpReq.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, httpReq)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ScheduleHandlerSuite) TestCreateInvalidCron() {
+	s.expectOwner("user-id")
+	s.mockScheduleService.EXPECT().
+		Create(gomock.Any(), "container-id", gomock.Any()).
+		Return(nil, fmt.Errorf("%w: expected exactly 5 fields", errdefs.ErrInvalidArgument))
+
+	httpReq := httptest.NewRequest("POST", "/containers/container-id/schedules", strings.NewReader(`{"action":"stop","cron":"0 20 *"}`))
+	httpReq.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, httpReq)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ScheduleHandlerSuite) TestCreateNotOwner() {
+	s.expectOwner("other-id")
+
+	httpReq := httptest.NewRequest("POST", "/containers/container-id/schedules", strings.NewReader(`{"action":"stop","cron":"@daily"}`))
+	httpReq.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, httpReq)
+	s.Equal(http.StatusForbidden, w.Code)
+}
+
+func (s *ScheduleHandlerSuite) TestCreateAsAdminContainerNotFound() {
+	s.mockScheduleService.EXPECT().
+		Create(gomock.Any(), "container-id", gomock.Any()).
+		Return(nil, fmt.Errorf("%w: container-id", usecases.ErrContainerNotFound))
+
+	httpReq := httptest.NewRequest("POST", "/containers/container-id/schedules", strings.NewReader(`{"action":"stop","cron":"@daily"}`))
+	httpReq.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.newRouter("admin-id", "container:admin").ServeHTTP(w, httpReq)
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *ScheduleHandlerSuite) TestView() {
+	s.expectOwner("user-id")
+	s.mockScheduleService.EXPECT().
+		View(gomock.Any(), "container-id").
+		Return([]*entities.Schedule{{ID: "schedule-id"}}, nil)
+
+	req := httptest.NewRequest("GET", "/containers/container-id/schedules", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("SCHEDULES_RETRIEVED", response.Code)
+}
+
+func (s *ScheduleHandlerSuite) TestViewServiceError() {
+	s.expectOwner("user-id")
+	s.mockScheduleService.EXPECT().
+		View(gomock.Any(), "container-id").
+		Return(nil, errors.New("db error"))
+
+	req := httptest.NewRequest("GET", "/containers/container-id/schedules", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *ScheduleHandlerSuite) TestGet() {
+	s.expectOwner("user-id")
+	s.mockScheduleService.EXPECT().
+		FindById(gomock.Any(), "container-id", "schedule-id").
+		Return(&entities.Schedule{ID: "schedule-id"}, nil)
+
+	req := httptest.NewRequest("GET", "/containers/container-id/schedules/schedule-id", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *ScheduleHandlerSuite) TestGetNotFound() {
+	s.expectOwner("user-id")
+	s.mockScheduleService.EXPECT().
+		FindById(gomock.Any(), "container-id", "schedule-id").
+		Return(nil, fmt.Errorf("%w: schedule-id", usecases.ErrScheduleNotFound))
+
+	req := httptest.NewRequest("GET", "/containers/container-id/schedules/schedule-id", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusNotFound, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("Schedule not found", response.Message)
+}
+
+func (s *ScheduleHandlerSuite) TestUpdate() {
+	enabled := false
+	req := dto.ScheduleRequest{Action: entities.ScheduleStart, Cron: "0 8 * * 1-5", Enabled: &enabled}
+	s.expectOwner("user-id")
+	s.mockScheduleService.EXPECT().
+		Update(gomock.Any(), "container-id", "schedule-id", req).
+		Return(&entities.Schedule{ID: "schedule-id"}, nil)
+
+	jsonData, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest("PUT", "/containers/container-id/schedules/schedule-id", bytes.NewBuffer(jsonData))
+	httpReq.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, httpReq)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("SCHEDULE_UPDATED", response.Code)
+}
+
+func (s *ScheduleHandlerSuite) TestUpdateInvalidRequestBody() {
+	s.expectOwner("user-id")
+
+	httpReq := httptest.NewRequest("PUT", "/containers/container-id/schedules/schedule-id", strings.NewReader("{}"))
+	httpReq.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, httpReq)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *ScheduleHandlerSuite) TestDelete() {
+	s.expectOwner("user-id")
+	s.mockScheduleService.EXPECT().
+		Delete(gomock.Any(), "container-id", "schedule-id").
+		Return(nil)
+
+	req := httptest.NewRequest("DELETE", "/containers/container-id/schedules/schedule-id", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("SCHEDULE_DELETED", response.Code)
+}
+
+func (s *ScheduleHandlerSuite) TestDeleteServiceError() {
+	s.expectOwner("user-id")
+	s.mockScheduleService.EXPECT().
+		Delete(gomock.Any(), "container-id", "schedule-id").
+		Return(errors.New("db error"))
+
+	req := httptest.NewRequest("DELETE", "/containers/container-id/schedules/schedule-id", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *ScheduleHandlerSuite) TestRuns() {
+	s.expectOwner("user-id")
+	s.mockScheduleService.EXPECT().
+		Runs(gomock.Any(), "container-id", "schedule-id").
+		Return([]*entities.ScheduleRun{{ID: "run-id", Status: entities.ScheduleRunFailed, Error: "daemon error"}}, nil)
+
+	req := httptest.NewRequest("GET", "/containers/container-id/schedules/schedule-id/runs", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("SCHEDULE_RUNS_RETRIEVED", response.Code)
+}
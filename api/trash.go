@@ -0,0 +1,139 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/pkg/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type TrashHandler struct {
+	trashService  services.ITrashService
+	jwtMiddleware middlewares.IJWTMiddleware
+}
+
+func NewTrashHandler(trashService services.ITrashService, jwtMiddleware middlewares.IJWTMiddleware) *TrashHandler {
+	return &TrashHandler{trashService, jwtMiddleware}
+}
+
+func (h *TrashHandler) SetupRoutes(r *gin.Engine) {
+	trashRoutes := r.Group("/containers")
+	{
+		viewGroup := trashRoutes.Group("", h.jwtMiddleware.RequireScope("container:view"))
+		{
+			viewGroup.GET("/trash", h.View)
+		}
+
+		deleteGroup := trashRoutes.Group("", h.jwtMiddleware.RequireScope("container:delete"))
+		{
+			deleteGroup.POST("/:id/restore", h.Restore)
+		}
+	}
+}
+
+// View godoc
+// @Summary View deleted containers
+// @Description List the containers in the trash, latest deleted first, until they are purged at the end of the retention period (container:admin sees every user's containers)
+// @Tags containers
+// @Produce json
+// @Success 200 {object} dto.APIResponse{data=[]entities.Container} "Deleted containers retrieved successfully"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/trash [get]
+func (h *TrashHandler) View(c *gin.Context) {
+	ownerId := c.GetString("userId")
+	if isContainerAdmin(c) {
+		ownerId = ""
+	}
+
+	containers, err := h.trashService.View(c.Request.Context(), ownerId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to retrieve deleted containers",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "TRASH_RETRIEVED",
+		Message: "Deleted containers retrieved successfully",
+		Data:    containers,
+	})
+}
+
+// Restore godoc
+// @Summary Restore a deleted container
+// @Description Take a container out of the trash; it comes back stopped and counts towards the quota again
+// @Tags containers
+// @Produce json
+// @Param id path string true "Container ID"
+// @Success 200 {object} dto.APIResponse "Container restored successfully"
+// @Failure 403 {object} dto.APIResponse "Container owned by another user or quota exceeded"
+// @Failure 404 {object} dto.APIResponse "Container not in the trash"
+// @Failure 500 {object} dto.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /containers/{id}/restore [post]
+func (h *TrashHandler) Restore(c *gin.Context) {
+	container, err := h.trashService.FindById(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, services.ErrContainerNotFound) {
+		c.JSON(http.StatusNotFound, dto.APIResponse{
+			Success: false,
+			Code:    "NOT_FOUND",
+			Message: "Container not in the trash",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to retrieve container",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if !isContainerAdmin(c) && container.OwnerId != c.GetString("userId") {
+		c.JSON(http.StatusForbidden, dto.APIResponse{
+			Success: false,
+			Code:    "FORBIDDEN",
+			Message: "Container is owned by another user",
+			Error:   "forbidden",
+		})
+		return
+	}
+
+	err = h.trashService.Restore(c.Request.Context(), container.ContainerId)
+	if errors.Is(err, services.ErrQuotaExceeded) {
+		c.JSON(http.StatusForbidden, dto.APIResponse{
+			Success: false,
+			Code:    "QUOTA_EXCEEDED",
+			Message: "Container quota exceeded",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Code:    "INTERNAL_SERVER_ERROR",
+			Message: "Failed to restore container",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Code:    "CONTAINER_RESTORED",
+		Message: "Container restored successfully",
+	})
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/middlewares"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+	usecases "github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type TrashHandlerSuite struct {
+	suite.Suite
+	ctrl             *gomock.Controller
+	mockTrashService *services.MockITrashService
+	router           *gin.Engine
+}
+
+func (s *TrashHandlerSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockTrashService = services.NewMockITrashService(s.ctrl)
+	s.router = s.newRouter("user-id")
+}
+
+func (s *TrashHandlerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestTrashHandlerSuite(t *testing.T) {
+	suite.Run(t, new(TrashHandlerSuite))
+}
+
+// newRouter serves the trash routes next to the container ones they share their prefix with.
+func (s *TrashHandlerSuite) newRouter(userId string, scopes ...string) *gin.Engine {
+	jwtMiddleware := middlewares.NewMockIJWTMiddleware(s.ctrl)
+	jwtMiddleware.EXPECT().
+		RequireScope(gomock.Any()).
+		Return(func(c *gin.Context) {
+			c.Set("userId", userId)
+			c.Set("scopes", scopes)
+			c.Next()
+		}).
+		AnyTimes()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	NewContainerHandler(services.NewMockIContainerService(s.ctrl), jwtMiddleware).SetupRoutes(router)
+	NewTrashHandler(s.mockTrashService, jwtMiddleware).SetupRoutes(router)
+	return router
+}
+
+func (s *TrashHandlerSuite) TestView() {
+	s.mockTrashService.EXPECT().
+		View(gomock.Any(), "user-id").
+		Return([]*entities.Container{{ContainerId: "container-id"}}, nil)
+
+	req := httptest.NewRequest("GET", "/containers/trash", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("TRASH_RETRIEVED", response.Code)
+}
+
+func (s *TrashHandlerSuite) TestViewAsAdmin() {
+	s.mockTrashService.EXPECT().
+		View(gomock.Any(), "").
+		Return([]*entities.Container{}, nil)
+
+	req := httptest.NewRequest("GET", "/containers/trash", nil)
+	w := httptest.NewRecorder()
+
+	s.newRouter("admin-id", "container:admin").ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *TrashHandlerSuite) TestViewServiceError() {
+	s.mockTrashService.EXPECT().
+		View(gomock.Any(), "user-id").
+		Return(nil, errors.New("db error"))
+
+	req := httptest.NewRequest("GET", "/containers/trash", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *TrashHandlerSuite) TestRestore() {
+	s.mockTrashService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+	s.mockTrashService.EXPECT().
+		Restore(gomock.Any(), "container-id").
+		Return(nil)
+
+	req := httptest.NewRequest("POST", "/containers/container-id/restore", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("CONTAINER_RESTORED", response.Code)
+}
+
+func (s *TrashHandlerSuite) TestRestoreNotInTrash() {
+	s.mockTrashService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(nil, fmt.Errorf("%w: container-id", usecases.ErrContainerNotFound))
+
+	req := httptest.NewRequest("POST", "/containers/container-id/restore", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *TrashHandlerSuite) TestRestoreNotOwner() {
+	s.mockTrashService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "other-id"}, nil)
+
+	req := httptest.NewRequest("POST", "/containers/container-id/restore", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusForbidden, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("FORBIDDEN", response.Code)
+}
+
+func (s *TrashHandlerSuite) TestRestoreAsAdmin() {
+	s.mockTrashService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "other-id"}, nil)
+	s.mockTrashService.EXPECT().
+		Restore(gomock.Any(), "container-id").
+		Return(nil)
+
+	req := httptest.NewRequest("POST", "/containers/container-id/restore", nil)
+	w := httptest.NewRecorder()
+
+	s.newRouter("admin-id", "container:admin").ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *TrashHandlerSuite) TestRestoreQuotaExceeded() {
+	s.mockTrashService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(&entities.Container{ContainerId: "container-id", OwnerId: "user-id"}, nil)
+	s.mockTrashService.EXPECT().
+		Restore(gomock.Any(), "container-id").
+		Return(fmt.Errorf("%w: containers", usecases.ErrQuotaExceeded))
+
+	req := httptest.NewRequest("POST", "/containers/container-id/restore", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusForbidden, w.Code)
+
+	var response dto.APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	s.NoError(err)
+	s.Equal("QUOTA_EXCEEDED", response.Code)
+}
+
+func (s *TrashHandlerSuite) TestRestoreServiceError() {
+	s.mockTrashService.EXPECT().
+		FindById(gomock.Any(), "container-id").
+		Return(nil, errors.New("db error"))
+
+	req := httptest.NewRequest("POST", "/containers/container-id/restore", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
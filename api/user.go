@@ -19,7 +19,7 @@ func NewUserHandler(userService services.IUserService, jwtMiddleware middlewares
 }
 
 func (h *UserHandler) SetupRoutes(r *gin.Engine) {
-	userRoutes := r.Group("/users", h.jwtMiddleware.RequireScope("user:manage"))
+	userRoutes := r.Group("/users", h.jwtMiddleware.RequireScope("user:manager"))
 	{
 		userRoutes.PUT("/update/role", h.UpdateRole)
 		userRoutes.PUT("/update/scope", h.UpdateScope)
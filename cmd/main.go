@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"os/signal"
@@ -47,7 +48,7 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to create docker client: %v", err)
 	}
-	postgresDb.AutoMigrate(&entities.Container{}, &entities.User{})
+	postgresDb.AutoMigrate(&entities.Container{}, &entities.User{}, &entities.Quota{}, &entities.AuditLog{}, &entities.RegistryCredential{}, &entities.Job{}, &entities.Snapshot{}, &entities.Schedule{}, &entities.ScheduleRun{})
 
 	esRawClient, err := databases.NewElasticsearchFactory(env.ElasticsearchEnv).ConnectElasticsearch()
 	if err != nil {
@@ -58,35 +59,115 @@ func main() {
 	redisRawClient := databases.NewRedisFactory(env.RedisEnv).ConnectRedis()
 	redisClient := interfaces.NewRedisClient(redisRawClient)
 
-	dockerClient, err := docker.NewDockerClient()
+	auditRepository := repositories.NewAuditRepository(postgresDb)
+	containerRepository := repositories.NewContainerRepository(postgresDb)
+	jobRepository := repositories.NewJobRepository(postgresDb)
+	quotaRepository := repositories.NewQuotaRepository(postgresDb)
+	registryRepository := repositories.NewRegistryRepository(postgresDb)
+	scheduleRepository := repositories.NewScheduleRepository(postgresDb)
+	snapshotRepository := repositories.NewSnapshotRepository(postgresDb)
+	userRepository := repositories.NewUserRepository(postgresDb)
+
+	registryService := services.NewRegistryService(registryRepository, logger, env.RegistryEnv)
+	dockerClient, err := docker.NewDockerClient(registryService)
 	if err != nil {
 		log.Fatalf("Failed to create docker client: %v", err)
 	}
 	jwtMiddleware := middlewares.NewJWTMiddleware(env.AuthEnv)
 
-	containerRepository := repositories.NewContainerRepository(postgresDb)
-	userRepository := repositories.NewUserRepository(postgresDb)
-
+	auditService := services.NewAuditService(auditRepository, logger)
 	authService := services.NewAuthService(userRepository, redisClient, logger, env.AuthEnv)
-	containerService := services.NewContainerService(containerRepository, dockerClient, logger)
+	quotaService := services.NewQuotaService(quotaRepository, containerRepository, userRepository, logger)
+	imageService := services.NewImageService(dockerClient, logger, env.ImageEnv)
 	healthcheckService := services.NewHealthcheckService(esClient, logger)
+	containerService := services.NewContainerService(containerRepository, snapshotRepository, dockerClient, quotaService, imageService, healthcheckService, logger, env.ContainerEnv)
+	execService := services.NewExecService(dockerClient, auditService, logger)
+	fileService := services.NewFileService(dockerClient, auditService, logger, env.FilesEnv)
+	jobService := services.NewJobService(jobRepository, containerService, imageService, logger)
+	metricsService := services.NewMetricsService(esClient, logger)
+	migrationService := services.NewMigrationService(userRepository, containerRepository, logger, env.MigrationEnv)
+	reconcileService := services.NewReconcileService(containerRepository, userRepository, dockerClient, logger, env.ReconcileEnv)
 	reportService := services.NewReportService(logger, env.GomailEnv)
+	expiryService := services.NewExpiryService(containerRepository, userRepository, containerService, reportService, logger, env.ExpiryEnv)
+	scheduleService := services.NewScheduleService(scheduleRepository, containerService, logger)
+	trashService := services.NewTrashService(containerRepository, dockerClient, quotaService, logger, env.TrashEnv)
 	userService := services.NewUserService(userRepository, redisClient, logger)
 
+	if err := migrationService.Run(context.Background()); err != nil {
+		log.Fatalf("Failed to migrate existing records: %v", err)
+	}
+
+	actionHandler := api.NewActionHandler(containerService, healthcheckService, jwtMiddleware)
 	authHandler := api.NewAuthHandler(authService, jwtMiddleware)
 	containerHandler := api.NewContainerHandler(containerService, jwtMiddleware)
+	expiryHandler := api.NewExpiryHandler(containerService, expiryService, jwtMiddleware)
+	execHandler := api.NewExecHandler(containerService, execService, jwtMiddleware)
+	fileHandler := api.NewFileHandler(containerService, fileService, jwtMiddleware)
+	imageHandler := api.NewImageHandler(imageService, jobService, jwtMiddleware)
+	jobHandler := api.NewJobHandler(containerService, jobService, jwtMiddleware)
+	metricsHandler := api.NewMetricsHandler(containerService, metricsService, jwtMiddleware)
+	quotaHandler := api.NewQuotaHandler(quotaService, jwtMiddleware)
+	reconcileHandler := api.NewReconcileHandler(reconcileService, jwtMiddleware)
+	registryHandler := api.NewRegistryHandler(registryService, jwtMiddleware)
 	reportHandler := api.NewReportHandler(containerService, healthcheckService, reportService, jwtMiddleware)
+	scheduleHandler := api.NewScheduleHandler(containerService, scheduleService, jwtMiddleware)
+	trashHandler := api.NewTrashHandler(trashService, jwtMiddleware)
 	userHandler := api.NewUserHandler(userService, jwtMiddleware)
 
 	healthcheckWorker := workers.NewHealthcheckWorker(
 		dockerClient,
 		containerService,
 		healthcheckService,
+		metricsService,
 		logger,
-		10*time.Second,
+		time.Minute,
 	)
 	healthcheckWorker.Start(1)
 
+	eventWorker := workers.NewEventWorker(
+		dockerClient,
+		containerService,
+		healthcheckService,
+		logger,
+		5*time.Second,
+	)
+	eventWorker.Start(1)
+
+	jobWorker := workers.NewJobWorker(
+		jobService,
+		logger,
+		time.Second,
+	)
+	jobWorker.Start(4)
+
+	reconcileWorker := workers.NewReconcileWorker(
+		reconcileService,
+		logger,
+		5*time.Minute,
+	)
+	reconcileWorker.Start(1)
+
+	purgeWorker := workers.NewPurgeWorker(
+		trashService,
+		logger,
+		time.Hour,
+	)
+	purgeWorker.Start(1)
+
+	scheduleWorker := workers.NewScheduleWorker(
+		scheduleService,
+		logger,
+		time.Minute,
+	)
+	scheduleWorker.Start(1)
+
+	reaperWorker := workers.NewReaperWorker(
+		expiryService,
+		logger,
+		time.Minute,
+	)
+	reaperWorker.Start(1)
+
 	reportWorker := workers.NewReportkWorker(
 		containerService,
 		healthcheckService,
@@ -98,9 +179,21 @@ func main() {
 	reportWorker.Start(1)
 
 	r := gin.Default()
+	actionHandler.SetupRoutes(r)
 	authHandler.SetupRoutes(r)
 	containerHandler.SetupRoutes(r)
+	execHandler.SetupRoutes(r)
+	expiryHandler.SetupRoutes(r)
+	fileHandler.SetupRoutes(r)
+	imageHandler.SetupRoutes(r)
+	jobHandler.SetupRoutes(r)
+	metricsHandler.SetupRoutes(r)
+	quotaHandler.SetupRoutes(r)
+	reconcileHandler.SetupRoutes(r)
+	registryHandler.SetupRoutes(r)
 	reportHandler.SetupRoutes(r)
+	scheduleHandler.SetupRoutes(r)
+	trashHandler.SetupRoutes(r)
 	userHandler.SetupRoutes(r)
 	r.GET("/swagger/*any", swagger.WrapHandler(swaggerFiles.Handler))
 
@@ -110,8 +203,14 @@ func main() {
 		<-quit
 
 		logger.Info("Shutting down...")
+		eventWorker.Stop()
 		healthcheckWorker.Stop()
+		jobWorker.Stop()
+		purgeWorker.Stop()
+		reaperWorker.Stop()
+		reconcileWorker.Stop()
 		reportWorker.Stop()
+		scheduleWorker.Stop()
 		os.Exit(0)
 	}()
 	if err := r.Run(":8080"); err != nil {
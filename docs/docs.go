@@ -15,6 +15,49 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/reconcile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Dry-run the reconciler: list the containers found on the daemon but not in the database (untracked) and those in the database but not on the daemon (missing), with the action the configured policy would take on each.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Preview database and docker daemon reconciliation",
+                "responses": {
+                    "200": {
+                        "description": "Reconcile report retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ReconcileReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/login": {
             "post": {
                 "description": "Login and receive JWT token",
@@ -199,7 +242,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a container with name and image",
+                "description": "Create a container with name, image and an optional spec (ports, env, volumes, command, labels, restart policy).\nAn ephemeral container expires at expires_at or after ttl (e.g. 2h30m), its owner being notified before it is deleted.\nBind mounts are only allowed from the host directories configured in CONTAINER_BIND_SOURCES.",
                 "consumes": [
                     "application/json"
                 ],
@@ -234,6 +277,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.APIResponse"
                         }
                     },
+                    "403": {
+                        "description": "Quota exceeded, image denied by the registry policy or bind mount not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -250,7 +299,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a container by its ID",
+                "description": "Move a container to the trash by its ID, stopping it; it can be restored until it is purged at the end of the retention period",
                 "produces": [
                     "application/json"
                 ],
@@ -274,6 +323,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.APIResponse"
                         }
                     },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -290,14 +351,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Export containers with optional filters and sorting to an Excel file",
+                "description": "Export containers with optional filters and sorting to an Excel (.xlsx), CSV, JSON, NDJSON or YAML file, picked by the format parameter or the Accept header. Rows are streamed from the database as they are encoded, so an error past the first rows ends the download early instead of returning a 500.",
                 "produces": [
-                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "text/csv",
+                    "application/json",
+                    "application/x-ndjson",
+                    "application/yaml"
                 ],
                 "tags": [
                     "containers"
                 ],
-                "summary": "Export containers to Excel",
+                "summary": "Export containers",
                 "parameters": [
                     {
                         "type": "integer",
@@ -321,26 +386,70 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Filter by ContainerName",
+                        "description": "Filter by part of the ContainerName",
                         "name": "container_name",
                         "in": "query"
                     },
                     {
-                        "enum": [
-                            "ON",
-                            "OFF"
-                        ],
                         "type": "string",
-                        "description": "Filter by Status",
+                        "description": "Filter by part of the ImageName",
+                        "name": "image_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by comma separated statuses among CREATING, ON, OFF, STARTING, HEALTHY, UNHEALTHY, RESTARTING, PAUSED, EXITED, MISSING, UNKNOWN (e.g. ON,OFF)",
                         "name": "status",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by IPv4",
+                        "description": "Filter by IPv4 address or CIDR block (e.g. 172.17.0.0/16)",
                         "name": "ipv4",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Filter by owner (container:admin only, others only see their own containers)",
+                        "name": "owner_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after this RFC 3339 time",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or before this RFC 3339 time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Updated at or after this RFC 3339 time",
+                        "name": "updated_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Updated at or before this RFC 3339 time",
+                        "name": "updated_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated label selector of key=value, key!=value, key and !key requirements (e.g. env=prod,team!=infra)",
+                        "name": "labels",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Free text matched against the id, name, image and IPv4",
+                        "name": "q",
+                        "in": "query"
+                    },
                     {
                         "enum": [
                             "container_id",
@@ -366,11 +475,24 @@ const docTemplate = `{
                         "name": "order",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "xlsx",
+                            "csv",
+                            "json",
+                            "ndjson",
+                            "yaml"
+                        ],
+                        "type": "string",
+                        "description": "Format of the file, overriding the Accept header",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Excel file containing container data",
+                        "description": "File containing container data",
                         "schema": {
                             "type": "file"
                         }
@@ -381,6 +503,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.APIResponse"
                         }
                     },
+                    "406": {
+                        "description": "No supported format accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -397,7 +525,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Import containers using an Excel (.xlsx) file",
+                "description": "Import containers from an Excel (.xlsx), CSV, JSON, NDJSON or YAML file, picked by the format parameter, the Content-Type or the extension of the file. Spreadsheets and CSV files need the \"Container Name\" and \"Image Name\" header columns, with the optional \"Ports\", \"Env\" and \"Labels\" columns holding entries separated by \";\" (e.g. \"8080:80/tcp\", \"KEY=value\", \"team=core\"); JSON and YAML files hold a list of records with the same fields as the export. Every row is validated first and reported with its row number, column and error code, then each valid row is created on its own so a failing row never undoes the others. Rows naming a container already imported with the same image are skipped, so the same file can be imported again safely.",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -407,19 +535,108 @@ const docTemplate = `{
                 "tags": [
                     "containers"
                 ],
-                "summary": "Import containers from Excel",
+                "summary": "Import containers",
                 "parameters": [
                     {
                         "type": "file",
-                        "description": "Excel file containing container data",
+                        "description": "File containing container data",
                         "name": "file",
                         "in": "formData",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "xlsx",
+                            "csv",
+                            "json",
+                            "ndjson",
+                            "yaml"
+                        ],
+                        "type": "string",
+                        "description": "Format of the file",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate the file and report what would be imported",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Import result with success, failure and skip counts",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ImportResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/containers/transfer/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Transfer ownership of a container to another user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "containers"
+                ],
+                "summary": "Transfer a container",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New owner",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Import result with success and failure counts",
+                        "description": "Container transferred successfully",
                         "schema": {
                             "$ref": "#/definitions/dto.APIResponse"
                         }
@@ -430,6 +647,64 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.APIResponse"
                         }
                     },
+                    "403": {
+                        "description": "Container is owned by another user or new owner's quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container or new owner not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/containers/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the containers in the trash, latest deleted first, until they are purged at the end of the retention period (container:admin sees every user's containers)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "containers"
+                ],
+                "summary": "View deleted containers",
+                "responses": {
+                    "200": {
+                        "description": "Deleted containers retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entities.Container"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -446,7 +721,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update container information by container ID",
+                "description": "Update the fields set in the payload: start or stop the container, which is recorded in the healthcheck history as an operator action, rename it in docker and in the database together, or edit its description and database labels. Labels are merged into the existing ones, a null value removing the label. Label filters match the database labels first and the docker labels of the container otherwise.",
                 "consumes": [
                     "application/json"
                 ],
@@ -488,6 +763,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.APIResponse"
                         }
                     },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Container name already in use",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -504,7 +797,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a list of containers with optional pagination, filtering, and sorting",
+                "description": "Retrieve a list of containers with optional pagination, filtering, and sorting.\nPages are selected by keyset when cursor or limit is set, following the next and prev cursors of the previous page, and by from/to offsets otherwise.",
                 "produces": [
                     "application/json"
                 ],
@@ -516,17 +809,37 @@ const docTemplate = `{
                     {
                         "type": "integer",
                         "default": 1,
-                        "description": "From index (default 1)",
+                        "description": "From index (default 1), offset mode only",
                         "name": "from",
                         "in": "query"
                     },
                     {
                         "type": "integer",
                         "default": -1,
-                        "description": "To index (default -1 for all)",
+                        "description": "To index (default -1 for all), offset mode only",
                         "name": "to",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Opaque next or prev cursor of a previous page, made for the same sort",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Page size in keyset mode (default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the matching containers in keyset mode",
+                        "name": "with_total",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Filter by ContainerId",
@@ -535,26 +848,70 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Filter by ContainerName",
+                        "description": "Filter by part of the ContainerName",
                         "name": "container_name",
                         "in": "query"
                     },
                     {
-                        "enum": [
-                            "ON",
-                            "OFF"
-                        ],
                         "type": "string",
-                        "description": "Filter by Status",
+                        "description": "Filter by part of the ImageName",
+                        "name": "image_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by comma separated statuses among CREATING, ON, OFF, STARTING, HEALTHY, UNHEALTHY, RESTARTING, PAUSED, EXITED, MISSING, UNKNOWN (e.g. ON,OFF)",
                         "name": "status",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by IPv4",
+                        "description": "Filter by IPv4 address or CIDR block (e.g. 172.17.0.0/16)",
                         "name": "ipv4",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Filter by owner (container:admin only, others only see their own containers)",
+                        "name": "owner_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after this RFC 3339 time",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or before this RFC 3339 time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Updated at or after this RFC 3339 time",
+                        "name": "updated_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Updated at or before this RFC 3339 time",
+                        "name": "updated_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated label selector of key=value, key!=value, key and !key requirements (e.g. env=prod,team!=infra)",
+                        "name": "labels",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Free text matched against the id, name, image and IPv4",
+                        "name": "q",
+                        "in": "query"
+                    },
                     {
                         "enum": [
                             "container_id",
@@ -584,9 +941,21 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response with container list",
+                        "description": "Successful response with container list, a dto.ViewResponse in offset mode",
                         "schema": {
-                            "$ref": "#/definitions/dto.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.PageResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -604,58 +973,75 @@ const docTemplate = `{
                 }
             }
         },
-        "/report/mail": {
-            "get": {
+        "/containers/{id}": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Generates a container uptime/downtime report and sends it to the provided email address",
+                "description": "Update the fields set in the payload: start or stop the container, which is recorded in the healthcheck history as an operator action, rename it in docker and in the database together, or edit its description and database labels. Labels are merged into the existing ones, a null value removing the label. Label filters match the database labels first and the docker labels of the container otherwise.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Report"
+                    "containers"
                 ],
-                "summary": "Send container status report via email",
+                "summary": "Update a container",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Recipient email address",
-                        "name": "email",
-                        "in": "query",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Start date (e.g. 2006-01-02)",
-                        "name": "start_time",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (defaults to current time)",
-                        "name": "end_time",
-                        "in": "query"
+                        "description": "Container update payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ContainerUpdate"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Report emailed successfully",
+                        "description": "Container updated successfully",
                         "schema": {
                             "$ref": "#/definitions/dto.APIResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid input or time range",
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Container name already in use",
                         "schema": {
                             "$ref": "#/definitions/dto.APIResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve data or send email",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.APIResponse"
                         }
@@ -663,40 +1049,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/delete": {
-            "delete": {
+        "/containers/{id}/actions/{action}": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove a user from the system (admin only)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Restart (with an optional stop timeout), pause, unpause or kill (with an optional signal) a container. The action is recorded in the healthcheck history, a failure to record it being reported in the error of a successful response.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "containers"
                 ],
-                "summary": "Delete a user",
+                "summary": "Run a container action",
                 "parameters": [
                     {
-                        "description": "User ID to delete",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.DeleteRequest"
-                        }
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "restart",
+                            "pause",
+                            "unpause",
+                            "kill"
+                        ],
+                        "type": "string",
+                        "description": "Action to run",
+                        "name": "action",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Seconds to wait for the container to stop before killing it (restart only)",
+                        "name": "timeout",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signal to send, defaults to SIGKILL (kill only)",
+                        "name": "signal",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User deleted successfully",
+                        "description": "Container action completed successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ActionResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -705,6 +1123,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.APIResponse"
                         }
                     },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Action conflicts with the container state",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -714,14 +1150,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/update/role": {
-            "put": {
+        "/containers/{id}/clone": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update role of a user (admin only)",
+                "description": "Create a container for the caller from a snapshot of the container, or from its image and spec when no snapshot is given. Host ports are picked by docker.",
                 "consumes": [
                     "application/json"
                 ],
@@ -729,26 +1165,117 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "containers"
                 ],
-                "summary": "Update a user's role",
+                "summary": "Clone a container",
                 "parameters": [
                     {
-                        "description": "User ID and new role",
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Clone name and optional snapshot",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateRoleRequest"
+                            "$ref": "#/definitions/dto.CloneRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Role updated successfully",
+                    "201": {
+                        "description": "Container cloned successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entities.Container"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Container is owned by another user, quota exceeded, image denied by the registry policy or bind mount not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container or snapshot not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.APIResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/containers/{id}/exec": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upgrade to a WebSocket running a command inside the container. Clients send {\"type\":\"stdin\",\"data\":...} and {\"type\":\"resize\",\"rows\":...,\"cols\":...}; the server sends stdout, stderr and a final exit message. The data of stdin, stdout and stderr messages is base64 encoded.",
+                "tags": [
+                    "containers"
+                ],
+                "summary": "Exec into a container",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Command to run (default /bin/sh)",
+                        "name": "cmd",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Allocate a TTY",
+                        "name": "tty",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching protocols",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     "400": {
                         "description": "Bad request",
@@ -756,6 +1283,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.APIResponse"
                         }
                     },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Container not running",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -765,14 +1310,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/update/scope": {
-            "put": {
+        "/containers/{id}/extend": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update permission scope of a user (admin only)",
+                "description": "Move the expiry of a container to a later time, or to a time to live from now, so the reaper does not delete it yet. Its owner is notified again before the new expiry. A container that did not expire becomes ephemeral.",
                 "consumes": [
                     "application/json"
                 ],
@@ -780,25 +1325,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "containers"
                 ],
-                "summary": "Update a user's scope",
+                "summary": "Extend the TTL of a container",
                 "parameters": [
                     {
-                        "description": "User ID, scopes, and whether to add or remove",
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New expiry, either expires_at or ttl",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateScopeRequest"
+                            "$ref": "#/definitions/dto.ExtendRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Scope updated successfully",
+                        "description": "Container expiry extended successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Expiry"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -807,6 +1371,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.APIResponse"
                         }
                     },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -815,106 +1391,3241 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.APIResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "string"
-                },
-                "data": {},
-                "error": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
-                },
-                "success": {
-                    "type": "boolean"
-                }
-            }
         },
-        "dto.ContainerUpdate": {
-            "type": "object",
-            "required": [
-                "status"
-            ],
-            "properties": {
-                "status": {
-                    "enum": [
-                        "ON",
-                        "OFF"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/entities.ContainerStatus"
+        "/containers/{id}/files": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the file at an absolute path in the container, or a tar archive of it when archive is set or the path is a directory. Files over the download size limit are refused, archives are cut once they go over it. Every download is recorded in the audit log.",
+                "produces": [
+                    "application/octet-stream",
+                    "application/x-tar"
+                ],
+                "tags": [
+                    "containers"
+                ],
+                "summary": "Download a file from a container",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Absolute path in the container",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Download a tar archive even for a single file",
+                        "name": "archive",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File or tar archive",
+                        "schema": {
+                            "type": "file"
                         }
-                    ]
-                }
-            }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container or file not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "File exceeds the size limit",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Write the request body as the file at an absolute path in the container, replacing it if it exists, or extract it into the directory at the path when archive is set and the body is a tar archive. The parent directory must exist. Bodies over the upload size limit are refused. Every upload is recorded in the audit log.",
+                "consumes": [
+                    "application/octet-stream",
+                    "application/x-tar"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "containers"
+                ],
+                "summary": "Upload a file to a container",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Absolute path in the container",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "The body is a tar archive to extract into the directory at path",
+                        "name": "archive",
+                        "in": "query"
+                    },
+                    {
+                        "description": "File content or tar archive",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File uploaded successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container or directory not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "File exceeds the size limit",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/containers/{id}/inspect": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Read the docker inspect data of a container: image and its registry digest, command, mounts, networks, restart count, exit code, OOM kill and start/finish times",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "containers"
+                ],
+                "summary": "Inspect a container",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Container inspected successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.InspectResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/containers/{id}/logs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stream stdout/stderr of a container as chunked text, or as Server-Sent Events when the client accepts text/event-stream",
+                "produces": [
+                    "text/plain",
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "containers"
+                ],
+                "summary": "Stream container logs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Keep the stream open for new output",
+                        "name": "follow",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "all",
+                        "description": "Number of lines from the end of the logs, or all",
+                        "name": "tail",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Show logs since a timestamp (RFC3339 or unix) or relative duration (e.g. 10m)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Show logs before a timestamp (RFC3339 or unix) or relative duration (e.g. 10m)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Prefix each line with its timestamp",
+                        "name": "timestamps",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Log stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/containers/{id}/metrics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve CPU, memory, network and block I/O samples of a container downsampled into buckets of the given step. Counters (network, block I/O) are cumulative, CPU and memory usage are averaged per bucket.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "containers"
+                ],
+                "summary": "Get container resource metrics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start time in RFC3339 (defaults to one hour before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End time in RFC3339 (defaults to now)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "1m",
+                        "description": "Bucket size as a duration, at least 1s and no more than 1440 buckets over the range",
+                        "name": "step",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Container metrics retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.MetricsPoint"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/containers/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Take a container out of the trash; it comes back stopped and counts towards the quota again",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "containers"
+                ],
+                "summary": "Restore a deleted container",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Container restored successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Container owned by another user or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container not in the trash",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/containers/{id}/schedules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the schedules of a container along with their next and last runs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "View container schedules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedules retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entities.Schedule"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start, stop or restart a container at the times of a cron expression read in a timezone (e.g. stop at \"0 20 * * 1-5\" in Asia/Ho_Chi_Minh). Runs missed while the scheduler was down are caught up once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Schedule a container action",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Schedule created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entities.Schedule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/containers/{id}/schedules/{scheduleId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Get a container schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "scheduleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entities.Schedule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container or schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the action, cron expression, timezone and enabled flag of a schedule, planning its next run again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Update a container schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "scheduleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entities.Schedule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container or schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a schedule along with the record of its runs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Delete a container schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "scheduleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container or schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/containers/{id}/schedules/{scheduleId}/runs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the latest 50 runs of a schedule with their outcome, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "View the runs of a container schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "scheduleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule runs retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entities.ScheduleRun"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container or schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/containers/{id}/snapshot": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Commit the filesystem of a container to a local image, pausing it meanwhile, and record the image with the container spec",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "containers"
+                ],
+                "summary": "Snapshot a container",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image tag (default the UTC time as 20060102150405)",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Commit message",
+                        "name": "comment",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Snapshot created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entities.Snapshot"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/containers/{id}/snapshots": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the snapshots of a container, latest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "containers"
+                ],
+                "summary": "View container snapshots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshots retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entities.Snapshot"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/containers/{id}/top": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the processes running in a container, as ps shows them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "containers"
+                ],
+                "summary": "List container processes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Container ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Container processes retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TopResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Container is owned by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Container not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Container is not running",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/images": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the images available on the docker daemon",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "summary": "List images",
+                "responses": {
+                    "200": {
+                        "description": "Images listed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.ImageSummary"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/images/inspect": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the details of a local image",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "summary": "Inspect an image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Image reference or ID",
+                        "name": "image",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Image inspected successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ImageInspect"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Image not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/images/prune": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove dangling images, or every image not used by a container",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "summary": "Prune images",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Remove all unused images, not only dangling ones",
+                        "name": "all",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Images pruned successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ImagePruneResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/images/pull": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Check an image against the registry policy and queue its pull, returning the job tracking it. The progress of the pull and its outcome are polled from /images/pull/{id}.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "summary": "Pull an image in the background",
+                "parameters": [
+                    {
+                        "description": "Image to pull",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImagePullRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Pull submitted successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.JobResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Image denied by the registry policy",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/images/pull/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the pull job, its item holding the latest progress message of the pull and, once it failed, the error docker reported",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "summary": "Get the status of an image pull",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pull retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.JobResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Pull submitted by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Pull not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/images/remove": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a local image, untagging it first when it has several tags",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "summary": "Remove an image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Image reference or ID",
+                        "name": "image",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Remove the image even if it is used by stopped containers",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Image removed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Image not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Image in use",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue the creation of a container, ephemeral when expires_at or ttl is set, and return the job tracking it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Create a container in the background",
+                "parameters": [
+                    {
+                        "description": "Container creation request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Job submitted successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.JobResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Validate an Excel (.xlsx), CSV, JSON, NDJSON or YAML file, in the same layout as /containers/import, and queue the creation of one container per valid row, returning the job tracking them; invalid rows are failed items and containers already imported are skipped items",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Import containers in the background",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File containing container data",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "xlsx",
+                            "csv",
+                            "json",
+                            "ndjson",
+                            "yaml"
+                        ],
+                        "type": "string",
+                        "description": "Format of the file",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Job submitted successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.JobResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/view": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the background jobs newest first, without their items (container:admin sees every user's jobs)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "View jobs",
+                "responses": {
+                    "200": {
+                        "description": "Jobs retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.JobResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the progress of a background job and the result of each of its items",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Get a job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.JobResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Job submitted by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a background job; a running job stops after the item in progress and its remaining items are canceled",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Cancel a job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job cancellation requested",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Job submitted by another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Job already finished",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/quotas/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove the container quota of a user or a role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotas"
+                ],
+                "summary": "Delete a quota",
+                "parameters": [
+                    {
+                        "description": "Quota subject",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.QuotaDeleteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Quota deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/quotas/update": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create or replace the container quota of a user or a role (0 means unlimited)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotas"
+                ],
+                "summary": "Set a quota",
+                "parameters": [
+                    {
+                        "description": "Quota subject and limits",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.QuotaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Quota updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/quotas/view": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List container quotas set on users and roles",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotas"
+                ],
+                "summary": "View quotas",
+                "responses": {
+                    "200": {
+                        "description": "Successful response with quota list",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/registries/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove the credentials stored for a private registry",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registries"
+                ],
+                "summary": "Delete registry credentials",
+                "parameters": [
+                    {
+                        "description": "Registry host",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegistryDeleteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Registry credentials deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/registries/update": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create or replace the credentials used to pull from a private registry, either a username and password or an identity token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registries"
+                ],
+                "summary": "Set registry credentials",
+                "parameters": [
+                    {
+                        "description": "Registry host and credentials",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegistryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Registry credentials updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Credentials encryption not configured",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/registries/view": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the private registries with stored credentials, secrets are never returned",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registries"
+                ],
+                "summary": "View registry credentials",
+                "responses": {
+                    "200": {
+                        "description": "Successful response with registry list",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RegistryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/report/mail": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generates a container uptime/downtime report, on the containers matching the same filters as /containers/view, and sends it to the provided email address",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "Send container status report via email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipient email address",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (e.g. 2006-01-02)",
+                        "name": "start_time",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (defaults to current time)",
+                        "name": "end_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by ContainerId",
+                        "name": "container_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by part of the ContainerName",
+                        "name": "container_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by part of the ImageName",
+                        "name": "image_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by comma separated statuses (e.g. ON,OFF)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by IPv4 address or CIDR block (e.g. 172.17.0.0/16)",
+                        "name": "ipv4",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by owner (container:admin only, others only see their own containers)",
+                        "name": "owner_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after this RFC 3339 time",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or before this RFC 3339 time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Updated at or after this RFC 3339 time",
+                        "name": "updated_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Updated at or before this RFC 3339 time",
+                        "name": "updated_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated label selector (e.g. env=prod,team!=infra)",
+                        "name": "labels",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Free text matched against the id, name, image and IPv4",
+                        "name": "q",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Report emailed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or time range",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve data or send email",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a user from the system (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete a user",
+                "parameters": [
+                    {
+                        "description": "User ID to delete",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeleteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/update/role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update role of a user (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update a user's role",
+                "parameters": [
+                    {
+                        "description": "User ID and new role",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/update/scope": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update permission scope of a user (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update a user's scope",
+                "parameters": [
+                    {
+                        "description": "User ID, scopes, and whether to add or remove",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateScopeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Scope updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.APIResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "data": {},
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ActionResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/dto.ContainerAction"
+                },
+                "container_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/entities.ContainerStatus"
+                }
+            }
+        },
+        "dto.CloneRequest": {
+            "type": "object",
+            "required": [
+                "container_name"
+            ],
+            "properties": {
+                "container_name": {
+                    "type": "string"
+                },
+                "snapshot_id": {
+                    "description": "SnapshotId clones a snapshot of the container, the container is cloned from its image and spec otherwise.",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ContainerAction": {
+            "type": "string",
+            "enum": [
+                "start",
+                "stop",
+                "restart",
+                "pause",
+                "unpause",
+                "kill"
+            ],
+            "x-enum-varnames": [
+                "ActionStart",
+                "ActionStop",
+                "ActionRestart",
+                "ActionPause",
+                "ActionUnpause",
+                "ActionKill"
+            ]
+        },
+        "dto.ContainerUpdate": {
+            "type": "object",
+            "properties": {
+                "container_name": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 1024
+                },
+                "labels": {
+                    "description": "Labels are merged into the database labels of the container, a null value removing the label.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "enum": [
+                        "ON",
+                        "OFF"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entities.ContainerStatus"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.CreateRequest": {
+            "type": "object",
+            "required": [
+                "container_name",
+                "image_name"
+            ],
+            "properties": {
+                "command": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "container_name": {
+                    "type": "string"
+                },
+                "entrypoint": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "env": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "image_name": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "ports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.PortBinding"
+                    }
+                },
+                "resources": {
+                    "$ref": "#/definitions/entities.Resources"
+                },
+                "restart_policy": {
+                    "$ref": "#/definitions/entities.RestartPolicy"
+                },
+                "ttl": {
+                    "description": "TTL is a duration such as 2h30m.",
+                    "type": "string"
+                },
+                "volumes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.VolumeMount"
+                    }
+                }
+            }
+        },
+        "dto.DeleteRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.Expiry": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "ttl": {
+                    "description": "TTL is a duration such as 2h30m.",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExtendRequest": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "ttl": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ImageInspect": {
+            "type": "object",
+            "properties": {
+                "architecture": {
+                    "type": "string"
+                },
+                "created": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "os": {
+                    "type": "string"
+                },
+                "repo_digests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "repo_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ImagePruneResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "space_reclaimed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ImagePullRequest": {
+            "type": "object",
+            "required": [
+                "image"
+            ],
+            "properties": {
+                "image": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ImageSummary": {
+            "type": "object",
+            "properties": {
+                "containers": {
+                    "type": "integer"
+                },
+                "created": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "repo_digests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "repo_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ImportError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/dto.ImportErrorCode"
+                },
+                "column": {
+                    "description": "Column is empty when the error is not about a single cell.",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ImportErrorCode": {
+            "type": "string",
+            "enum": [
+                "MISSING_VALUE",
+                "INVALID_NAME",
+                "INVALID_IMAGE",
+                "IMAGE_NOT_ALLOWED",
+                "DUPLICATE_NAME",
+                "NAME_CONFLICT",
+                "INVALID_PORTS",
+                "INVALID_ENV",
+                "INVALID_LABELS",
+                "INVALID_EXPIRY",
+                "INVALID_MEMORY",
+                "QUOTA_EXCEEDED",
+                "CREATE_FAILED"
+            ],
+            "x-enum-varnames": [
+                "ImportMissingValue",
+                "ImportInvalidName",
+                "ImportInvalidImage",
+                "ImportImageNotAllowed",
+                "ImportDuplicateName",
+                "ImportNameConflict",
+                "ImportInvalidPorts",
+                "ImportInvalidEnv",
+                "ImportInvalidLabels",
+                "ImportInvalidExpiry",
+                "ImportInvalidMemory",
+                "ImportQuotaExceeded",
+                "ImportCreateFailed"
+            ]
+        },
+        "dto.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ImportError"
+                    }
+                },
+                "failed_containers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "failed_count": {
+                    "type": "integer"
+                },
+                "skipped_containers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "skipped_count": {
+                    "type": "integer"
+                },
+                "success_containers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "success_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.InspectMount": {
+            "type": "object",
+            "properties": {
+                "destination": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "read_only": {
+                    "type": "boolean"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
         },
-        "dto.CreateRequest": {
+        "dto.InspectNetwork": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "gateway": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "mac_address": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.InspectResponse": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "container_id": {
+                    "type": "string"
+                },
+                "exit_code": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "image_digest": {
+                    "description": "ImageDigest is the registry digest the image was pulled by, empty for an image built or committed locally.",
+                    "type": "string"
+                },
+                "image_id": {
+                    "type": "string"
+                },
+                "mounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.InspectMount"
+                    }
+                },
+                "networks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.InspectNetwork"
+                    }
+                },
+                "oom_killed": {
+                    "type": "boolean"
+                },
+                "restart_count": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.JobItemResponse": {
+            "type": "object",
+            "properties": {
+                "container_id": {
+                    "type": "string"
+                },
+                "container_name": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "image_name": {
+                    "type": "string"
+                },
+                "progress": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/entities.JobStatus"
+                }
+            }
+        },
+        "dto.JobResponse": {
+            "type": "object",
+            "properties": {
+                "cancel_requested": {
+                    "type": "boolean"
+                },
+                "completed": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.JobItemResponse"
+                    }
+                },
+                "owner_id": {
+                    "type": "string"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/entities.JobStatus"
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/entities.JobType"
+                }
+            }
+        },
+        "dto.LoginRequest": {
             "type": "object",
             "required": [
-                "container_name",
-                "image_name"
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MetricsPoint": {
+            "type": "object",
+            "properties": {
+                "block_read": {
+                    "type": "number"
+                },
+                "block_write": {
+                    "type": "number"
+                },
+                "cpu_percent": {
+                    "type": "number"
+                },
+                "memory_limit": {
+                    "type": "number"
+                },
+                "memory_usage": {
+                    "type": "number"
+                },
+                "network_rx": {
+                    "type": "number"
+                },
+                "network_tx": {
+                    "type": "number"
+                },
+                "samples": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PageResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Container"
+                    }
+                },
+                "next": {
+                    "type": "string"
+                },
+                "prev": {
+                    "type": "string"
+                },
+                "total": {
+                    "description": "Total is only counted when asked for, counting being as slow as an offset.",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.QuotaDeleteRequest": {
+            "type": "object",
+            "required": [
+                "subject",
+                "subject_type"
+            ],
+            "properties": {
+                "subject": {
+                    "type": "string"
+                },
+                "subject_type": {
+                    "enum": [
+                        "user",
+                        "role"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entities.QuotaSubjectType"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.QuotaRequest": {
+            "type": "object",
+            "required": [
+                "subject",
+                "subject_type"
+            ],
+            "properties": {
+                "max_containers": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "max_memory": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "subject_type": {
+                    "enum": [
+                        "user",
+                        "role"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entities.QuotaSubjectType"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.ReconcileAction": {
+            "type": "string",
+            "enum": [
+                "ignore",
+                "adopt",
+                "orphan",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "ReconcileIgnore",
+                "ReconcileAdopt",
+                "ReconcileOrphan",
+                "ReconcileDelete"
+            ]
+        },
+        "dto.ReconcileItem": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/dto.ReconcileAction"
+                },
+                "container_id": {
+                    "type": "string"
+                },
+                "container_name": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReconcileReport": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "missing": {
+                    "description": "Missing containers exist in the database but not on the daemon.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ReconcileItem"
+                    }
+                },
+                "untracked": {
+                    "description": "Untracked containers exist on the daemon but not in the database.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ReconcileItem"
+                    }
+                }
+            }
+        },
+        "dto.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "role",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/entities.UserRole"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RegistryDeleteRequest": {
+            "type": "object",
+            "required": [
+                "registry"
+            ],
+            "properties": {
+                "registry": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RegistryRequest": {
+            "type": "object",
+            "required": [
+                "registry"
             ],
             "properties": {
-                "container_name": {
+                "password": {
+                    "type": "string"
+                },
+                "registry": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RegistryResponse": {
+            "type": "object",
+            "properties": {
+                "has_password": {
+                    "type": "boolean"
+                },
+                "has_token": {
+                    "type": "boolean"
+                },
+                "registry": {
                     "type": "string"
                 },
-                "image_name": {
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "dto.DeleteRequest": {
+        "dto.ScheduleRequest": {
             "type": "object",
             "required": [
-                "user_id"
+                "action",
+                "cron"
             ],
             "properties": {
-                "user_id": {
+                "action": {
+                    "enum": [
+                        "start",
+                        "stop",
+                        "restart"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entities.ScheduleAction"
+                        }
+                    ]
+                },
+                "cron": {
+                    "description": "Cron is a standard 5 fields expression (e.g. \"0 20 * * 1-5\"), or a descriptor such as @daily.",
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "Enabled defaults to true, a disabled schedule is kept but not run.",
+                    "type": "boolean"
+                },
+                "timezone": {
+                    "description": "Timezone is an IANA name (e.g. Asia/Ho_Chi_Minh) the cron expression is read in, UTC by default.",
                     "type": "string"
                 }
             }
         },
-        "dto.LoginRequest": {
+        "dto.TopResponse": {
             "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
             "properties": {
-                "password": {
-                    "type": "string"
+                "processes": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
                 },
-                "username": {
-                    "type": "string"
+                "titles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "dto.RegisterRequest": {
+        "dto.TransferRequest": {
             "type": "object",
             "required": [
-                "email",
-                "password",
-                "role",
-                "username"
+                "owner_id"
             ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string"
-                },
-                "role": {
-                    "$ref": "#/definitions/entities.UserRole"
-                },
-                "username": {
+                "owner_id": {
                     "type": "string"
                 }
             }
@@ -966,17 +4677,367 @@ const docTemplate = `{
                 }
             }
         },
+        "entities.Container": {
+            "type": "object",
+            "properties": {
+                "containerId": {
+                    "type": "string"
+                },
+                "containerName": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "description": "DeletedAt is set while the container is in the trash, hiding it from every query but the trash ones.",
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "description": {
+                    "description": "Description and Labels are free-form metadata kept in the database only, editable for the life of the container\nunlike the docker labels of its spec.",
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "description": "ExpiresAt is set on ephemeral containers, the reaper deleting them once it has passed.",
+                    "type": "string"
+                },
+                "expiryNotifiedAt": {
+                    "description": "ExpiryNotifiedAt is when the owner was told the container is about to expire, reset whenever the expiry moves.",
+                    "type": "string"
+                },
+                "imageName": {
+                    "type": "string"
+                },
+                "ipv4": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "ownerId": {
+                    "type": "string"
+                },
+                "spec": {
+                    "$ref": "#/definitions/entities.ContainerSpec"
+                },
+                "status": {
+                    "$ref": "#/definitions/entities.ContainerStatus"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.ContainerSpec": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "entrypoint": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "env": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "ports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.PortBinding"
+                    }
+                },
+                "resources": {
+                    "$ref": "#/definitions/entities.Resources"
+                },
+                "restart_policy": {
+                    "$ref": "#/definitions/entities.RestartPolicy"
+                },
+                "volumes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.VolumeMount"
+                    }
+                }
+            }
+        },
         "entities.ContainerStatus": {
             "type": "string",
             "enum": [
+                "CREATING",
                 "ON",
-                "OFF"
+                "OFF",
+                "STARTING",
+                "HEALTHY",
+                "UNHEALTHY",
+                "RESTARTING",
+                "PAUSED",
+                "EXITED",
+                "MISSING",
+                "UNKNOWN"
             ],
             "x-enum-varnames": [
+                "ContainerCreating",
                 "ContainerOn",
-                "ContainerOff"
+                "ContainerOff",
+                "ContainerStarting",
+                "ContainerHealthy",
+                "ContainerUnhealthy",
+                "ContainerRestarting",
+                "ContainerPaused",
+                "ContainerExited",
+                "ContainerMissing",
+                "ContainerUnknown"
+            ]
+        },
+        "entities.JobStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "RUNNING",
+                "SUCCEEDED",
+                "FAILED",
+                "CANCELED",
+                "SKIPPED"
+            ],
+            "x-enum-varnames": [
+                "JobPending",
+                "JobRunning",
+                "JobSucceeded",
+                "JobFailed",
+                "JobCanceled",
+                "JobSkipped"
+            ]
+        },
+        "entities.JobType": {
+            "type": "string",
+            "enum": [
+                "create",
+                "import",
+                "pull"
+            ],
+            "x-enum-varnames": [
+                "JobCreate",
+                "JobImport",
+                "JobPull"
+            ]
+        },
+        "entities.PortBinding": {
+            "type": "object",
+            "required": [
+                "container_port"
+            ],
+            "properties": {
+                "container_port": {
+                    "type": "integer",
+                    "maximum": 65535,
+                    "minimum": 1
+                },
+                "host_ip": {
+                    "type": "string"
+                },
+                "host_port": {
+                    "type": "integer",
+                    "maximum": 65535,
+                    "minimum": 1
+                },
+                "protocol": {
+                    "type": "string",
+                    "enum": [
+                        "tcp",
+                        "udp",
+                        "sctp"
+                    ]
+                }
+            }
+        },
+        "entities.QuotaSubjectType": {
+            "type": "string",
+            "enum": [
+                "user",
+                "role"
+            ],
+            "x-enum-varnames": [
+                "QuotaUser",
+                "QuotaRole"
+            ]
+        },
+        "entities.Resources": {
+            "type": "object",
+            "properties": {
+                "cpu_period": {
+                    "type": "integer",
+                    "maximum": 1000000,
+                    "minimum": 1000
+                },
+                "cpu_quota": {
+                    "type": "integer",
+                    "minimum": 1000
+                },
+                "cpu_shares": {
+                    "type": "integer",
+                    "minimum": 2
+                },
+                "memory": {
+                    "type": "integer",
+                    "minimum": 6291456
+                },
+                "pids_limit": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "entities.RestartPolicy": {
+            "type": "object",
+            "properties": {
+                "maximum_retry_count": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "enum": [
+                        "no",
+                        "always",
+                        "on-failure",
+                        "unless-stopped"
+                    ]
+                }
+            }
+        },
+        "entities.Schedule": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/entities.ScheduleAction"
+                },
+                "containerId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "cron": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastRunAt": {
+                    "type": "string"
+                },
+                "nextRunAt": {
+                    "type": "string"
+                },
+                "ownerId": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.ScheduleAction": {
+            "type": "string",
+            "enum": [
+                "start",
+                "stop",
+                "restart"
+            ],
+            "x-enum-varnames": [
+                "ScheduleStart",
+                "ScheduleStop",
+                "ScheduleRestart"
+            ]
+        },
+        "entities.ScheduleRun": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/entities.ScheduleAction"
+                },
+                "containerId": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ranAt": {
+                    "type": "string"
+                },
+                "scheduleId": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/entities.ScheduleRunStatus"
+                }
+            }
+        },
+        "entities.ScheduleRunStatus": {
+            "type": "string",
+            "enum": [
+                "SUCCEEDED",
+                "FAILED"
+            ],
+            "x-enum-varnames": [
+                "ScheduleRunSucceeded",
+                "ScheduleRunFailed"
             ]
         },
+        "entities.Snapshot": {
+            "type": "object",
+            "properties": {
+                "containerId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imageId": {
+                    "type": "string"
+                },
+                "imageName": {
+                    "type": "string"
+                },
+                "ownerId": {
+                    "type": "string"
+                },
+                "spec": {
+                    "$ref": "#/definitions/entities.ContainerSpec"
+                }
+            }
+        },
         "entities.UserRole": {
             "type": "string",
             "enum": [
@@ -987,6 +5048,32 @@ const docTemplate = `{
                 "Manager",
                 "Developer"
             ]
+        },
+        "entities.VolumeMount": {
+            "type": "object",
+            "required": [
+                "target",
+                "type"
+            ],
+            "properties": {
+                "read_only": {
+                    "type": "boolean"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "bind",
+                        "volume",
+                        "tmpfs"
+                    ]
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -1,12 +1,46 @@
 package dto
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/vnFuhung2903/vcs-sms/entities"
 )
 
 type CreateRequest struct {
 	ContainerName string `json:"container_name" binding:"required"`
 	ImageName     string `json:"image_name" binding:"required"`
+	entities.ContainerSpec
+	Expiry
+}
+
+// Expiry makes a container ephemeral, either until a time or for a time to live from now.
+type Expiry struct {
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// TTL is a duration such as 2h30m.
+	TTL string `json:"ttl,omitempty" binding:"excluded_with=ExpiresAt"`
+}
+
+// Resolve is the time the container expires at, nil when it never expires.
+func (e Expiry) Resolve(now time.Time) (*time.Time, error) {
+	if e.TTL != "" {
+		ttl, err := time.ParseDuration(e.TTL)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("invalid ttl %q, expected a positive duration such as 2h30m", e.TTL)
+		}
+		expiresAt := now.Add(ttl)
+		return &expiresAt, nil
+	}
+	if e.ExpiresAt != nil && !e.ExpiresAt.After(now) {
+		return nil, errors.New("expires_at is not in the future")
+	}
+	return e.ExpiresAt, nil
+}
+
+type ExtendRequest struct {
+	ExpiresAt *time.Time `json:"expires_at" binding:"required_without=TTL"`
+	TTL       string     `json:"ttl" binding:"required_without=ExpiresAt,excluded_with=ExpiresAt"`
 }
 
 type ViewResponse struct {
@@ -14,22 +48,147 @@ type ViewResponse struct {
 	Total int64                 `json:"total"`
 }
 
+type ImportRow struct {
+	Row           int
+	ContainerName string
+	ImageName     string
+	Spec          entities.ContainerSpec
+	ExpiresAt     *time.Time
+	// Skipped rows name a container that already exists with the same image and owner.
+	Skipped bool
+	Errors  []ImportError
+}
+
+type ImportQuery struct {
+	DryRun bool `form:"dry_run"`
+}
+
+// ContainerRecord is the schema shared by every import and export format, one spreadsheet column per field.
+// Ports are written "[[host_ip:]host_port:]container_port[/protocol]" and labels "key=value" in the spreadsheet and CSV columns.
+type ContainerRecord struct {
+	ContainerId   string                   `json:"container_id,omitempty" yaml:"container_id,omitempty"`
+	ContainerName string                   `json:"container_name" yaml:"container_name"`
+	ImageName     string                   `json:"image_name" yaml:"image_name"`
+	Status        entities.ContainerStatus `json:"status,omitempty" yaml:"status,omitempty"`
+	Ipv4          string                   `json:"ipv4,omitempty" yaml:"ipv4,omitempty"`
+	Ports         []string                 `json:"ports,omitempty" yaml:"ports,omitempty"`
+	Env           []string                 `json:"env,omitempty" yaml:"env,omitempty"`
+	Labels        map[string]string        `json:"labels,omitempty" yaml:"labels,omitempty"`
+	CreatedAt     string                   `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	// ExpiresAt is an RFC 3339 time, TTL a duration from the import such as 2h30m, only one of them being set.
+	ExpiresAt   string `json:"expires_at,omitempty" yaml:"expires_at,omitempty"`
+	TTL         string `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Memory is the memory limit in bytes.
+	Memory int64 `json:"memory,omitempty" yaml:"memory,omitempty"`
+}
+
 type ImportResponse struct {
-	SuccessCount      int      `json:"success_count"`
-	SuccessContainers []string `json:"success_containers"`
-	FailedCount       int      `json:"failed_count"`
-	FailedContainers  []string `json:"failed_containers"`
+	DryRun            bool          `json:"dry_run"`
+	SuccessCount      int           `json:"success_count"`
+	SuccessContainers []string      `json:"success_containers"`
+	FailedCount       int           `json:"failed_count"`
+	FailedContainers  []string      `json:"failed_containers"`
+	SkippedCount      int           `json:"skipped_count"`
+	SkippedContainers []string      `json:"skipped_containers"`
+	Errors            []ImportError `json:"errors"`
 }
 
+type ImportError struct {
+	Row int `json:"row"`
+	// Column is empty when the error is not about a single cell.
+	Column  string          `json:"column,omitempty"`
+	Code    ImportErrorCode `json:"code"`
+	Message string          `json:"message"`
+}
+
+type ImportErrorCode string
+
+const (
+	ImportMissingValue    ImportErrorCode = "MISSING_VALUE"
+	ImportInvalidName     ImportErrorCode = "INVALID_NAME"
+	ImportInvalidImage    ImportErrorCode = "INVALID_IMAGE"
+	ImportImageNotAllowed ImportErrorCode = "IMAGE_NOT_ALLOWED"
+	ImportDuplicateName   ImportErrorCode = "DUPLICATE_NAME"
+	ImportNameConflict    ImportErrorCode = "NAME_CONFLICT"
+	ImportInvalidPorts    ImportErrorCode = "INVALID_PORTS"
+	ImportInvalidEnv      ImportErrorCode = "INVALID_ENV"
+	ImportInvalidLabels   ImportErrorCode = "INVALID_LABELS"
+	ImportInvalidExpiry   ImportErrorCode = "INVALID_EXPIRY"
+	ImportInvalidMemory   ImportErrorCode = "INVALID_MEMORY"
+	ImportQuotaExceeded   ImportErrorCode = "QUOTA_EXCEEDED"
+	ImportCreateFailed    ImportErrorCode = "CREATE_FAILED"
+)
+
+// ContainerUpdate changes the fields it sets and leaves the others as they are.
 type ContainerUpdate struct {
-	Status entities.ContainerStatus `json:"status" binding:"required,oneof=ON OFF"`
+	Status        entities.ContainerStatus `json:"status,omitempty" binding:"omitempty,oneof=ON OFF"`
+	ContainerName string                   `json:"container_name,omitempty" binding:"omitempty,max=128"`
+	Description   *string                  `json:"description,omitempty" binding:"omitempty,max=1024"`
+	// Labels are merged into the database labels of the container, a null value removing the label.
+	Labels map[string]*string `json:"labels,omitempty"`
+}
+
+// Validate checks the update sets a field and its label keys are valid selector keys.
+func (u ContainerUpdate) Validate() error {
+	if u.Status == "" && u.ContainerName == "" && u.Description == nil && len(u.Labels) == 0 {
+		return errors.New("no field to update")
+	}
+	for key, value := range u.Labels {
+		if !labelKeyPattern.MatchString(key) || len(key) > 128 {
+			return fmt.Errorf("invalid label key %q", key)
+		}
+		if value != nil && len(*value) > 256 {
+			return fmt.Errorf("label %q is longer than 256 characters", key)
+		}
+	}
+	return nil
+}
+
+type TransferRequest struct {
+	OwnerId string `json:"owner_id" binding:"required"`
+}
+
+type SnapshotQuery struct {
+	// Tag names the snapshot image, the time of the snapshot by default.
+	Tag     string `form:"tag" binding:"omitempty,max=128"`
+	Comment string `form:"comment" binding:"omitempty,max=256"`
+}
+
+type CloneRequest struct {
+	ContainerName string `json:"container_name" binding:"required"`
+	// SnapshotId clones a snapshot of the container, the container is cloned from its image and spec otherwise.
+	SnapshotId string `json:"snapshot_id" binding:"omitempty"`
+}
+
+type ActionQuery struct {
+	Timeout *int   `form:"timeout" binding:"omitempty,min=0"`
+	Signal  string `form:"signal" binding:"omitempty"`
+}
+
+type ActionResponse struct {
+	ContainerId string                   `json:"container_id"`
+	Action      ContainerAction          `json:"action"`
+	Status      entities.ContainerStatus `json:"status"`
 }
 
-type ContainerFilter struct {
-	ContainerId   string                   `form:"container_id" binding:"omitempty"`
-	Status        entities.ContainerStatus `form:"status" binding:"omitempty,oneof=ON OFF"`
-	ContainerName string                   `form:"container_name" binding:"omitempty"`
-	Ipv4          string                   `form:"ipv4" binding:"omitempty"`
+type ContainerAction string
+
+const (
+	ActionStart   ContainerAction = "start"
+	ActionStop    ContainerAction = "stop"
+	ActionRestart ContainerAction = "restart"
+	ActionPause   ContainerAction = "pause"
+	ActionUnpause ContainerAction = "unpause"
+	ActionKill    ContainerAction = "kill"
+)
+
+type LogsQuery struct {
+	Follow     bool   `form:"follow"`
+	Tail       string `form:"tail" binding:"omitempty,number|eq=all"`
+	Since      string `form:"since" binding:"omitempty"`
+	Until      string `form:"until" binding:"omitempty"`
+	Timestamps bool   `form:"timestamps"`
 }
 
 type ContainerSort struct {
@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+)
+
+// PageQuery selects a page of containers by keyset, starting after (or before) the row a cursor points at.
+type PageQuery struct {
+	Cursor    string `form:"cursor" binding:"omitempty"`
+	Limit     int    `form:"limit" binding:"omitempty,min=1,max=1000"`
+	WithTotal bool   `form:"with_total"`
+}
+
+type PageResponse struct {
+	Data []*entities.Container `json:"data"`
+	Next string                `json:"next,omitempty"`
+	Prev string                `json:"prev,omitempty"`
+	// Total is only counted when asked for, counting being as slow as an offset.
+	Total *int64 `json:"total,omitempty"`
+}
+
+// ContainerCursor is the position of a container in a sorted listing: the value of the sort field and the primary key
+// breaking its ties. Before cursors point backwards, selecting the rows preceding the container instead of following it.
+type ContainerCursor struct {
+	Field       string    `json:"f"`
+	Order       SortOrder `json:"o"`
+	Value       string    `json:"v"`
+	ContainerId string    `json:"id"`
+	Before      bool      `json:"b,omitempty"`
+}
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// NewContainerCursor points at the container in the sort order, times being kept to the nanosecond.
+func NewContainerCursor(container *entities.Container, sort ContainerSort, before bool) ContainerCursor {
+	cursor := ContainerCursor{Field: sort.Field, Order: sort.Order, ContainerId: container.ContainerId, Before: before}
+	switch sort.Field {
+	case "container_id":
+		cursor.Value = container.ContainerId
+	case "container_name":
+		cursor.Value = container.ContainerName
+	case "status":
+		cursor.Value = string(container.Status)
+	case "ipv4":
+		cursor.Value = container.Ipv4
+	case "created_at":
+		cursor.Value = container.CreatedAt.Format(time.RFC3339Nano)
+	case "updated_at":
+		cursor.Value = container.UpdatedAt.Format(time.RFC3339Nano)
+	}
+	return cursor
+}
+
+// Encode makes the cursor opaque to clients, who are only meant to pass it back.
+func (c ContainerCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// TimeValue reads the value of a cursor on created_at or updated_at.
+func (c ContainerCursor) TimeValue() (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, c.Value)
+}
+
+// DecodeContainerCursor reads a cursor made by Encode, rejecting it unless it was made for the same sort.
+func DecodeContainerCursor(encoded string, sort ContainerSort) (ContainerCursor, error) {
+	var cursor ContainerCursor
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor, errInvalidCursor
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ContainerId == "" {
+		return cursor, errInvalidCursor
+	}
+	if cursor.Field != sort.Field || cursor.Order != sort.Order {
+		return cursor, errors.New("cursor does not match the sort")
+	}
+	if cursor.Field == "created_at" || cursor.Field == "updated_at" {
+		if _, err := cursor.TimeValue(); err != nil {
+			return cursor, errInvalidCursor
+		}
+	}
+	return cursor, nil
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"github.com/docker/docker/api/types"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+)
+
+type ExecQuery struct {
+	Cmd []string `form:"cmd"`
+	Tty bool     `form:"tty,default=true"`
+}
+
+type ExecMessageType string
+
+const (
+	ExecStdin  ExecMessageType = "stdin"
+	ExecResize ExecMessageType = "resize"
+	ExecStdout ExecMessageType = "stdout"
+	ExecStderr ExecMessageType = "stderr"
+	ExecExit   ExecMessageType = "exit"
+)
+
+// ExecMessage is a frame of an exec WebSocket. Data holds the raw bytes of a stdin, stdout or stderr chunk, base64
+// encoded on the wire since a chunk may end inside a UTF-8 sequence or not be text at all.
+type ExecMessage struct {
+	Type     ExecMessageType `json:"type"`
+	Data     []byte          `json:"data,omitempty"`
+	Rows     uint            `json:"rows,omitempty"`
+	Cols     uint            `json:"cols,omitempty"`
+	ExitCode *int            `json:"exit_code,omitempty"`
+}
+
+type ExecSession struct {
+	ExecId   string
+	Tty      bool
+	Stream   types.HijackedResponse
+	AuditLog *entities.AuditLog
+}
@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"io"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+)
+
+type FileQuery struct {
+	Path string `form:"path" binding:"required"`
+	// Archive transfers a tar archive instead of a single file, which directories always are downloaded as.
+	Archive bool `form:"archive"`
+}
+
+type FileDownload struct {
+	Name string
+	// Size is the size of the content, -1 for an archive whose size is only known once streamed.
+	Size     int64
+	Archive  bool
+	Content  io.ReadCloser
+	AuditLog *entities.AuditLog
+}
@@ -0,0 +1,125 @@
+package dto
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+)
+
+// ContainerQuery is the query string of the endpoints listing containers, turned into a ContainerFilter by Filter.
+// Times are RFC 3339 and both bounds of a range are inclusive.
+type ContainerQuery struct {
+	ContainerId   string    `form:"container_id" binding:"omitempty"`
+	Status        string    `form:"status" binding:"omitempty"`
+	ContainerName string    `form:"container_name" binding:"omitempty"`
+	ImageName     string    `form:"image_name" binding:"omitempty"`
+	Ipv4          string    `form:"ipv4" binding:"omitempty,ipv4|cidrv4"`
+	OwnerId       string    `form:"owner_id" binding:"omitempty"`
+	CreatedAfter  time.Time `form:"created_after"`
+	CreatedBefore time.Time `form:"created_before" binding:"omitempty,gtefield=CreatedAfter"`
+	UpdatedAfter  time.Time `form:"updated_after"`
+	UpdatedBefore time.Time `form:"updated_before" binding:"omitempty,gtefield=UpdatedAfter"`
+	Labels        string    `form:"labels" binding:"omitempty"`
+	Q             string    `form:"q" binding:"omitempty,max=100"`
+}
+
+// ContainerFilter selects containers, every set field narrowing the selection.
+type ContainerFilter struct {
+	ContainerId   string
+	Status        []entities.ContainerStatus
+	ContainerName string
+	ImageName     string
+	// Ipv4 is either an address or a CIDR block.
+	Ipv4          string
+	OwnerId       string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	UpdatedAfter  time.Time
+	UpdatedBefore time.Time
+	Labels        []LabelRequirement
+	// Q is matched against the id, name, image and address of the container.
+	Q string
+}
+
+type LabelOperator string
+
+const (
+	LabelEquals    LabelOperator = "="
+	LabelNotEquals LabelOperator = "!="
+	LabelExists    LabelOperator = "exists"
+	LabelNotExists LabelOperator = "!exists"
+)
+
+type LabelRequirement struct {
+	Key      string
+	Operator LabelOperator
+	Value    string
+}
+
+var labelKeyPattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._/-]*[a-zA-Z0-9])?$`)
+
+// Filter validates the comma separated status list and label selector of the query.
+func (q ContainerQuery) Filter() (ContainerFilter, error) {
+	filter := ContainerFilter{
+		ContainerId:   q.ContainerId,
+		ContainerName: q.ContainerName,
+		ImageName:     q.ImageName,
+		Ipv4:          q.Ipv4,
+		OwnerId:       q.OwnerId,
+		CreatedAfter:  q.CreatedAfter,
+		CreatedBefore: q.CreatedBefore,
+		UpdatedAfter:  q.UpdatedAfter,
+		UpdatedBefore: q.UpdatedBefore,
+		Q:             strings.TrimSpace(q.Q),
+	}
+
+	for _, value := range strings.Split(q.Status, ",") {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		status := entities.ContainerStatus(strings.ToUpper(value))
+		if !status.IsValid() {
+			return ContainerFilter{}, fmt.Errorf("invalid status %q", value)
+		}
+		filter.Status = append(filter.Status, status)
+	}
+
+	labels, err := ParseLabelSelector(q.Labels)
+	if err != nil {
+		return ContainerFilter{}, err
+	}
+	filter.Labels = labels
+	return filter, nil
+}
+
+// ParseLabelSelector reads a comma separated selector of "key=value", "key!=value", "key" and "!key" requirements.
+func ParseLabelSelector(selector string) ([]LabelRequirement, error) {
+	var requirements []LabelRequirement
+	for _, term := range strings.Split(selector, ",") {
+		if term = strings.TrimSpace(term); term == "" {
+			continue
+		}
+
+		var requirement LabelRequirement
+		if key, value, ok := strings.Cut(term, "!="); ok {
+			requirement = LabelRequirement{Key: key, Operator: LabelNotEquals, Value: value}
+		} else if key, value, ok := strings.Cut(term, "="); ok {
+			requirement = LabelRequirement{Key: key, Operator: LabelEquals, Value: strings.TrimPrefix(value, "=")}
+		} else if key, ok := strings.CutPrefix(term, "!"); ok {
+			requirement = LabelRequirement{Key: key, Operator: LabelNotExists}
+		} else {
+			requirement = LabelRequirement{Key: term, Operator: LabelExists}
+		}
+
+		requirement.Key = strings.TrimSpace(requirement.Key)
+		requirement.Value = strings.TrimSpace(requirement.Value)
+		if !labelKeyPattern.MatchString(requirement.Key) {
+			return nil, fmt.Errorf("invalid label selector %q", term)
+		}
+		requirements = append(requirements, requirement)
+	}
+	return requirements, nil
+}
@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"mime"
+	"path/filepath"
+	"strings"
+)
+
+// FileFormat is an encoding accepted by the container import and export, all sharing the ContainerRecord schema.
+type FileFormat string
+
+const (
+	FormatXLSX   FileFormat = "xlsx"
+	FormatCSV    FileFormat = "csv"
+	FormatJSON   FileFormat = "json"
+	FormatNDJSON FileFormat = "ndjson"
+	FormatYAML   FileFormat = "yaml"
+)
+
+var formatContentTypes = map[FileFormat]string{
+	FormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	FormatCSV:    "text/csv",
+	FormatJSON:   "application/json",
+	FormatNDJSON: "application/x-ndjson",
+	FormatYAML:   "application/yaml",
+}
+
+var contentTypeFormats = map[string]FileFormat{
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": FormatXLSX,
+	"text/csv":             FormatCSV,
+	"application/json":     FormatJSON,
+	"application/x-ndjson": FormatNDJSON,
+	"application/ndjson":   FormatNDJSON,
+	"application/yaml":     FormatYAML,
+	"application/x-yaml":   FormatYAML,
+	"text/yaml":            FormatYAML,
+}
+
+var extensionFormats = map[string]FileFormat{
+	".xlsx":   FormatXLSX,
+	".csv":    FormatCSV,
+	".json":   FormatJSON,
+	".ndjson": FormatNDJSON,
+	".jsonl":  FormatNDJSON,
+	".yaml":   FormatYAML,
+	".yml":    FormatYAML,
+}
+
+// FileFormats lists the formats in order of preference.
+var FileFormats = []FileFormat{FormatXLSX, FormatCSV, FormatJSON, FormatNDJSON, FormatYAML}
+
+func (f FileFormat) ContentType() string {
+	return formatContentTypes[f]
+}
+
+// FormatFromContentType returns the format of a media type, parameters ignored, and false when it is not supported.
+func FormatFromContentType(contentType string) (FileFormat, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+	format, ok := contentTypeFormats[mediaType]
+	return format, ok
+}
+
+// FormatFromFilename returns the format matching the file extension, and false when it is not supported.
+func FormatFromFilename(filename string) (FileFormat, bool) {
+	format, ok := extensionFormats[strings.ToLower(filepath.Ext(filename))]
+	return format, ok
+}
+
+type FormatQuery struct {
+	Format FileFormat `form:"format" binding:"omitempty,oneof=xlsx csv json ndjson yaml"`
+}
@@ -0,0 +1,41 @@
+package dto
+
+import "time"
+
+type ImageSummary struct {
+	Id          string    `json:"id"`
+	RepoTags    []string  `json:"repo_tags"`
+	RepoDigests []string  `json:"repo_digests"`
+	Size        int64     `json:"size"`
+	Containers  int64     `json:"containers"`
+	Created     time.Time `json:"created"`
+}
+
+type ImageInspect struct {
+	Id           string            `json:"id"`
+	RepoTags     []string          `json:"repo_tags"`
+	RepoDigests  []string          `json:"repo_digests"`
+	Size         int64             `json:"size"`
+	Created      string            `json:"created"`
+	Architecture string            `json:"architecture"`
+	Os           string            `json:"os"`
+	Labels       map[string]string `json:"labels,omitempty"`
+}
+
+type ImageQuery struct {
+	Image string `form:"image" binding:"required"`
+	Force bool   `form:"force"`
+}
+
+type ImagePullRequest struct {
+	Image string `json:"image" binding:"required"`
+}
+
+type ImagePruneQuery struct {
+	All bool `form:"all"`
+}
+
+type ImagePruneResponse struct {
+	Deleted        []string `json:"deleted"`
+	SpaceReclaimed uint64   `json:"space_reclaimed"`
+}
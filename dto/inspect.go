@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+// InspectResponse is the part of the docker inspect data of a container worth showing to its owner.
+type InspectResponse struct {
+	ContainerId string `json:"container_id"`
+	Image       string `json:"image"`
+	ImageId     string `json:"image_id"`
+	// ImageDigest is the registry digest the image was pulled by, empty for an image built or committed locally.
+	ImageDigest  string           `json:"image_digest,omitempty"`
+	Command      []string         `json:"command"`
+	Mounts       []InspectMount   `json:"mounts"`
+	Networks     []InspectNetwork `json:"networks"`
+	State        string           `json:"state"`
+	RestartCount int              `json:"restart_count"`
+	ExitCode     int              `json:"exit_code"`
+	OOMKilled    bool             `json:"oom_killed"`
+	StartedAt    *time.Time       `json:"started_at,omitempty"`
+	FinishedAt   *time.Time       `json:"finished_at,omitempty"`
+}
+
+type InspectMount struct {
+	Type        string `json:"type"`
+	Name        string `json:"name,omitempty"`
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	ReadOnly    bool   `json:"read_only"`
+}
+
+type InspectNetwork struct {
+	Name       string   `json:"name"`
+	IPAddress  string   `json:"ip_address"`
+	Gateway    string   `json:"gateway"`
+	MacAddress string   `json:"mac_address"`
+	Aliases    []string `json:"aliases,omitempty"`
+}
+
+// TopResponse lists the processes running in a container, each one holding a value per title.
+type TopResponse struct {
+	Titles    []string   `json:"titles"`
+	Processes [][]string `json:"processes"`
+}
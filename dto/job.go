@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+)
+
+type JobResponse struct {
+	Id              string             `json:"id"`
+	Type            entities.JobType   `json:"type"`
+	Status          entities.JobStatus `json:"status"`
+	OwnerId         string             `json:"owner_id"`
+	CancelRequested bool               `json:"cancel_requested"`
+	Total           int                `json:"total"`
+	Completed       int                `json:"completed"`
+	Succeeded       int                `json:"succeeded"`
+	Failed          int                `json:"failed"`
+	Skipped         int                `json:"skipped"`
+	Items           []JobItemResponse  `json:"items,omitempty"`
+	CreatedAt       time.Time          `json:"created_at"`
+	StartedAt       *time.Time         `json:"started_at,omitempty"`
+	FinishedAt      *time.Time         `json:"finished_at,omitempty"`
+}
+
+type JobItemResponse struct {
+	ContainerName string             `json:"container_name"`
+	ImageName     string             `json:"image_name"`
+	Status        entities.JobStatus `json:"status"`
+	ContainerId   string             `json:"container_id,omitempty"`
+	Progress      string             `json:"progress,omitempty"`
+	Error         string             `json:"error,omitempty"`
+}
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+type MetricsQuery struct {
+	From time.Time     `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To   time.Time     `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Step time.Duration `form:"step" binding:"omitempty,min=1s"`
+}
+
+type MetricsPoint struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Samples     int64     `json:"samples"`
+	CpuPercent  float64   `json:"cpu_percent"`
+	MemoryUsage float64   `json:"memory_usage"`
+	MemoryLimit float64   `json:"memory_limit"`
+	NetworkRx   float64   `json:"network_rx"`
+	NetworkTx   float64   `json:"network_tx"`
+	BlockRead   float64   `json:"block_read"`
+	BlockWrite  float64   `json:"block_write"`
+}
+
+type EsMetrics struct {
+	ContainerId string    `json:"container_id"`
+	Timestamp   time.Time `json:"timestamp"`
+	CpuPercent  float64   `json:"cpu_percent"`
+	MemoryUsage uint64    `json:"memory_usage"`
+	MemoryLimit uint64    `json:"memory_limit"`
+	NetworkRx   uint64    `json:"network_rx"`
+	NetworkTx   uint64    `json:"network_tx"`
+	BlockRead   uint64    `json:"block_read"`
+	BlockWrite  uint64    `json:"block_write"`
+}
+
+type EsMetricsUpdate struct {
+	ContainerId string
+	Stats       container.StatsResponse
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "github.com/vnFuhung2903/vcs-sms/entities"
+
+type QuotaRequest struct {
+	SubjectType   entities.QuotaSubjectType `json:"subject_type" binding:"required,oneof=user role"`
+	Subject       string                    `json:"subject" binding:"required"`
+	MaxContainers int64                     `json:"max_containers" binding:"min=0"`
+	MaxMemory     int64                     `json:"max_memory" binding:"min=0"`
+}
+
+type QuotaDeleteRequest struct {
+	SubjectType entities.QuotaSubjectType `json:"subject_type" binding:"required,oneof=user role"`
+	Subject     string                    `json:"subject" binding:"required"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+type ReconcileAction string
+
+const (
+	ReconcileIgnore ReconcileAction = "ignore"
+	ReconcileAdopt  ReconcileAction = "adopt"
+	ReconcileOrphan ReconcileAction = "orphan"
+	ReconcileDelete ReconcileAction = "delete"
+)
+
+type ReconcileItem struct {
+	ContainerId   string          `json:"container_id"`
+	ContainerName string          `json:"container_name"`
+	Action        ReconcileAction `json:"action"`
+	Error         string          `json:"error,omitempty"`
+}
+
+type ReconcileReport struct {
+	DryRun bool `json:"dry_run"`
+	// Untracked containers exist on the daemon but not in the database.
+	Untracked []ReconcileItem `json:"untracked"`
+	// Missing containers exist in the database but not on the daemon.
+	Missing []ReconcileItem `json:"missing"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+type RegistryRequest struct {
+	Registry string `json:"registry" binding:"required,hostname_port|hostname"`
+	Username string `json:"username" binding:"required_with=Password"`
+	Password string `json:"password" binding:"required_without=Token"`
+	Token    string `json:"token"`
+}
+
+type RegistryDeleteRequest struct {
+	Registry string `json:"registry" binding:"required"`
+}
+
+type RegistryResponse struct {
+	Registry    string    `json:"registry"`
+	Username    string    `json:"username,omitempty"`
+	HasPassword bool      `json:"has_password"`
+	HasToken    bool      `json:"has_token"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -27,9 +27,11 @@ type EsStatus struct {
 	Uptime      int64                    `json:"uptime"`
 	LastUpdated time.Time                `json:"last_updated"`
 	Counter     int64                    `json:"counter"`
+	Action      ContainerAction          `json:"action,omitempty"`
 }
 
 type EsStatusUpdate struct {
 	ContainerId string                   `json:"container_id"`
 	Status      entities.ContainerStatus `json:"status"`
+	Action      ContainerAction          `json:"action,omitempty"`
 }
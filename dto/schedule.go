@@ -0,0 +1,13 @@
+package dto
+
+import "github.com/vnFuhung2903/vcs-sms/entities"
+
+type ScheduleRequest struct {
+	Action entities.ScheduleAction `json:"action" binding:"required,oneof=start stop restart"`
+	// Cron is a standard 5 fields expression (e.g. "0 20 * * 1-5"), or a descriptor such as @daily.
+	Cron string `json:"cron" binding:"required"`
+	// Timezone is an IANA name (e.g. Asia/Ho_Chi_Minh) the cron expression is read in, UTC by default.
+	Timezone string `json:"timezone"`
+	// Enabled defaults to true, a disabled schedule is kept but not run.
+	Enabled *bool `json:"enabled"`
+}
@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"time"
+)
+
+type AuditLog struct {
+	ID          uint        `gorm:"primaryKey"`
+	Action      AuditAction `gorm:"type:varchar(20);index;not null"`
+	UserId      string      `gorm:"index;not null"`
+	ContainerId string      `gorm:"index;not null"`
+	Detail      string      `gorm:"not null;default:''"`
+	StartedAt   time.Time   `gorm:"not null"`
+	EndedAt     *time.Time
+	ExitCode    *int
+	// Bytes is how much a file transfer moved before it ended, and Error why it failed if it did.
+	Bytes *int64
+	Error string `gorm:"not null;default:''"`
+}
+
+type AuditAction string
+
+const (
+	AuditExec         AuditAction = "exec"
+	AuditFileDownload AuditAction = "file_download"
+	AuditFileUpload   AuditAction = "file_upload"
+)
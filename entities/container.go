@@ -1,7 +1,10 @@
 package entities
 
 import (
+	"maps"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Container struct {
@@ -11,11 +14,113 @@ type Container struct {
 	UpdatedAt     time.Time       `gorm:"autoUpdateTime"`
 	ContainerName string          `gorm:"unique;not null"`
 	Ipv4          string          `gorm:"not null"`
+	ImageName     string          `gorm:"not null;default:''"`
+	OwnerId       string          `gorm:"index;not null;default:''"`
+	Spec          ContainerSpec   `gorm:"type:jsonb;serializer:json"`
+	// Description and Labels are free-form metadata kept in the database only, editable for the life of the container
+	// unlike the docker labels of its spec.
+	Description string            `gorm:"not null;default:''"`
+	Labels      map[string]string `gorm:"type:jsonb;serializer:json"`
+	// ExpiresAt is set on ephemeral containers, the reaper deleting them once it has passed.
+	ExpiresAt *time.Time `gorm:"index"`
+	// ExpiryNotifiedAt is when the owner was told the container is about to expire, reset whenever the expiry moves.
+	ExpiryNotifiedAt *time.Time
+	// DeletedAt is set while the container is in the trash, hiding it from every query but the trash ones.
+	DeletedAt gorm.DeletedAt `gorm:"index" swaggertype:"string" format:"date-time"`
+}
+
+// AllLabels is the docker labels of the container overridden by its database labels, the set label filters match.
+func (c *Container) AllLabels() map[string]string {
+	if len(c.Spec.Labels) == 0 && len(c.Labels) == 0 {
+		return nil
+	}
+	labels := maps.Clone(c.Spec.Labels)
+	if labels == nil {
+		labels = make(map[string]string, len(c.Labels))
+	}
+	maps.Copy(labels, c.Labels)
+	return labels
 }
 
 type ContainerStatus string
 
 const (
-	ContainerOn  ContainerStatus = "ON"
-	ContainerOff ContainerStatus = "OFF"
+	// ContainerCreating is the status of the row reserving a container while docker creates it.
+	ContainerCreating   ContainerStatus = "CREATING"
+	ContainerOn         ContainerStatus = "ON"
+	ContainerOff        ContainerStatus = "OFF"
+	ContainerStarting   ContainerStatus = "STARTING"
+	ContainerHealthy    ContainerStatus = "HEALTHY"
+	ContainerUnhealthy  ContainerStatus = "UNHEALTHY"
+	ContainerRestarting ContainerStatus = "RESTARTING"
+	ContainerPaused     ContainerStatus = "PAUSED"
+	ContainerExited     ContainerStatus = "EXITED"
+	ContainerMissing    ContainerStatus = "MISSING"
+	ContainerUnknown    ContainerStatus = "UNKNOWN"
 )
+
+// IsValid reports whether the status is one of the statuses above.
+func (s ContainerStatus) IsValid() bool {
+	switch s {
+	case ContainerCreating, ContainerOn, ContainerOff, ContainerStarting, ContainerHealthy, ContainerUnhealthy,
+		ContainerRestarting, ContainerPaused, ContainerExited, ContainerMissing, ContainerUnknown:
+		return true
+	}
+	return false
+}
+
+// IsRunning reports whether the container process is alive, healthy or not.
+func (s ContainerStatus) IsRunning() bool {
+	switch s {
+	case ContainerOn, ContainerStarting, ContainerHealthy, ContainerUnhealthy:
+		return true
+	}
+	return false
+}
+
+// IsUp reports whether the status counts towards uptime.
+func (s ContainerStatus) IsUp() bool {
+	switch s {
+	case ContainerOn, ContainerStarting, ContainerHealthy:
+		return true
+	}
+	return false
+}
+
+type ContainerSpec struct {
+	Command       []string          `json:"command,omitempty"`
+	Entrypoint    []string          `json:"entrypoint,omitempty"`
+	Env           []string          `json:"env,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	Ports         []PortBinding     `json:"ports,omitempty" binding:"omitempty,dive"`
+	Volumes       []VolumeMount     `json:"volumes,omitempty" binding:"omitempty,dive"`
+	RestartPolicy RestartPolicy     `json:"restart_policy,omitempty"`
+	Resources     Resources         `json:"resources,omitempty"`
+}
+
+type PortBinding struct {
+	ContainerPort int    `json:"container_port" binding:"required,min=1,max=65535"`
+	HostPort      int    `json:"host_port,omitempty" binding:"omitempty,min=1,max=65535"`
+	HostIp        string `json:"host_ip,omitempty" binding:"omitempty,ip"`
+	Protocol      string `json:"protocol,omitempty" binding:"omitempty,oneof=tcp udp sctp"`
+}
+
+type VolumeMount struct {
+	Type     string `json:"type" binding:"required,oneof=bind volume tmpfs"`
+	Source   string `json:"source,omitempty"`
+	Target   string `json:"target" binding:"required"`
+	ReadOnly bool   `json:"read_only,omitempty"`
+}
+
+type RestartPolicy struct {
+	Name              string `json:"name,omitempty" binding:"omitempty,oneof=no always on-failure unless-stopped"`
+	MaximumRetryCount int    `json:"maximum_retry_count,omitempty" binding:"omitempty,min=0"`
+}
+
+type Resources struct {
+	CpuShares int64 `json:"cpu_shares,omitempty" binding:"omitempty,min=2"`
+	CpuPeriod int64 `json:"cpu_period,omitempty" binding:"omitempty,min=1000,max=1000000"`
+	CpuQuota  int64 `json:"cpu_quota,omitempty" binding:"omitempty,min=1000"`
+	Memory    int64 `json:"memory,omitempty" binding:"omitempty,min=6291456"`
+	PidsLimit int64 `json:"pids_limit,omitempty" binding:"omitempty,min=1"`
+}
@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"time"
+)
+
+// Job is a long-running container or image operation processed by the job workers, one item per container or image.
+type Job struct {
+	ID              string    `gorm:"primaryKey"`
+	Type            JobType   `gorm:"type:varchar(10);not null"`
+	Status          JobStatus `gorm:"type:varchar(10);index;not null"`
+	OwnerId         string    `gorm:"index;not null"`
+	Items           []JobItem `gorm:"type:jsonb;serializer:json"`
+	CancelRequested bool      `gorm:"not null;default:false"`
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime"`
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	// ClaimedBy is the process running the job, which renews ClaimedAt for as long as it does.
+	ClaimedBy string `gorm:"index;not null;default:''"`
+	ClaimedAt *time.Time
+}
+
+type JobItem struct {
+	ContainerName string        `json:"container_name"`
+	ImageName     string        `json:"image_name"`
+	Spec          ContainerSpec `json:"spec"`
+	ExpiresAt     *time.Time    `json:"expires_at,omitempty"`
+	Status        JobStatus     `json:"status"`
+	ContainerId   string        `json:"container_id,omitempty"`
+	Progress      string        `json:"progress,omitempty"`
+	Error         string        `json:"error,omitempty"`
+}
+
+type JobType string
+
+const (
+	JobCreate JobType = "create"
+	JobImport JobType = "import"
+	JobPull   JobType = "pull"
+)
+
+type JobStatus string
+
+const (
+	JobPending   JobStatus = "PENDING"
+	JobRunning   JobStatus = "RUNNING"
+	JobSucceeded JobStatus = "SUCCEEDED"
+	JobFailed    JobStatus = "FAILED"
+	JobCanceled  JobStatus = "CANCELED"
+	JobSkipped   JobStatus = "SKIPPED"
+)
+
+// IsFinished reports whether the job or item will not change anymore.
+func (s JobStatus) IsFinished() bool {
+	switch s {
+	case JobSucceeded, JobFailed, JobCanceled, JobSkipped:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,15 @@
+package entities
+
+type Quota struct {
+	SubjectType   QuotaSubjectType `gorm:"primaryKey;type:varchar(10)"`
+	Subject       string           `gorm:"primaryKey"`
+	MaxContainers int64            `gorm:"not null;default:0"`
+	MaxMemory     int64            `gorm:"not null;default:0"`
+}
+
+type QuotaSubjectType string
+
+const (
+	QuotaUser QuotaSubjectType = "user"
+	QuotaRole QuotaSubjectType = "role"
+)
@@ -0,0 +1,14 @@
+package entities
+
+import (
+	"time"
+)
+
+// RegistryCredential holds the credentials of a private registry, every secret field is stored encrypted.
+type RegistryCredential struct {
+	Registry  string    `gorm:"primaryKey"`
+	Username  string    `gorm:"not null;default:''"`
+	Password  string    `gorm:"not null;default:''"`
+	Token     string    `gorm:"not null;default:''"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+)
+
+// Schedule runs an action on a container at the times of a standard 5 fields cron expression, read in the timezone.
+type Schedule struct {
+	ID          string         `gorm:"primaryKey"`
+	ContainerId string         `gorm:"index;not null"`
+	OwnerId     string         `gorm:"index;not null"`
+	Action      ScheduleAction `gorm:"type:varchar(10);not null"`
+	Cron        string         `gorm:"not null"`
+	Timezone    string         `gorm:"not null;default:UTC"`
+	Enabled     bool           `gorm:"not null"`
+	NextRunAt   time.Time      `gorm:"index;not null"`
+	LastRunAt   *time.Time
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
+
+// ScheduleRun is the outcome of a schedule executed by the scheduler worker.
+type ScheduleRun struct {
+	ID          string            `gorm:"primaryKey"`
+	ScheduleId  string            `gorm:"index;not null"`
+	ContainerId string            `gorm:"not null"`
+	Action      ScheduleAction    `gorm:"type:varchar(10);not null"`
+	Status      ScheduleRunStatus `gorm:"type:varchar(10);not null"`
+	Error       string
+	RanAt       time.Time `gorm:"not null"`
+}
+
+type ScheduleAction string
+
+const (
+	ScheduleStart   ScheduleAction = "start"
+	ScheduleStop    ScheduleAction = "stop"
+	ScheduleRestart ScheduleAction = "restart"
+)
+
+type ScheduleRunStatus string
+
+const (
+	ScheduleRunSucceeded ScheduleRunStatus = "SUCCEEDED"
+	ScheduleRunFailed    ScheduleRunStatus = "FAILED"
+)
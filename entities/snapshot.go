@@ -0,0 +1,16 @@
+package entities
+
+import (
+	"time"
+)
+
+// Snapshot is an image committed from a container, along with the spec the container ran with so that it can be cloned.
+type Snapshot struct {
+	ID          string        `gorm:"primaryKey"`
+	ContainerId string        `gorm:"index;not null"`
+	ImageId     string        `gorm:"not null"`
+	ImageName   string        `gorm:"not null"`
+	OwnerId     string        `gorm:"index;not null"`
+	Spec        ContainerSpec `gorm:"type:jsonb;serializer:json"`
+	CreatedAt   time.Time     `gorm:"autoCreateTime"`
+}
@@ -7,6 +7,9 @@ type User struct {
 	Role     UserRole `gorm:"type:varchar(10);not null"`
 	Email    string   `gorm:"type:varchar(100);unique;not null"`
 	Scopes   int64    `gorm:"not null;default:0"`
+	// ScopeVersion is the number of scopes the role defaults of the mask were granted for, users registered before it
+	// was tracked having the seven original scopes.
+	ScopeVersion int `gorm:"not null;default:7"`
 }
 
 type UserRole string
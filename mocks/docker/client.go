@@ -6,9 +6,13 @@ package docker
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
+	types "github.com/docker/docker/api/types"
 	container "github.com/docker/docker/api/types/container"
+	events "github.com/docker/docker/api/types/events"
+	image "github.com/docker/docker/api/types/image"
 	gomock "github.com/golang/mock/gomock"
 	entities "github.com/vnFuhung2903/vcs-sms/entities"
 )
@@ -36,19 +40,64 @@ func (m *MockIDockerClient) EXPECT() *MockIDockerClientMockRecorder {
 	return m.recorder
 }
 
+// Commit mocks base method.
+func (m *MockIDockerClient) Commit(ctx context.Context, containerID, imageName, comment string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commit", ctx, containerID, imageName, comment)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockIDockerClientMockRecorder) Commit(ctx, containerID, imageName, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockIDockerClient)(nil).Commit), ctx, containerID, imageName, comment)
+}
+
+// CopyFrom mocks base method.
+func (m *MockIDockerClient) CopyFrom(ctx context.Context, containerID, srcPath string) (io.ReadCloser, container.PathStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyFrom", ctx, containerID, srcPath)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(container.PathStat)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CopyFrom indicates an expected call of CopyFrom.
+func (mr *MockIDockerClientMockRecorder) CopyFrom(ctx, containerID, srcPath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyFrom", reflect.TypeOf((*MockIDockerClient)(nil).CopyFrom), ctx, containerID, srcPath)
+}
+
+// CopyTo mocks base method.
+func (m *MockIDockerClient) CopyTo(ctx context.Context, containerID, dstPath string, archive io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyTo", ctx, containerID, dstPath, archive)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyTo indicates an expected call of CopyTo.
+func (mr *MockIDockerClientMockRecorder) CopyTo(ctx, containerID, dstPath, archive interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyTo", reflect.TypeOf((*MockIDockerClient)(nil).CopyTo), ctx, containerID, dstPath, archive)
+}
+
 // Create mocks base method.
-func (m *MockIDockerClient) Create(ctx context.Context, name, imageName string) (*container.CreateResponse, error) {
+func (m *MockIDockerClient) Create(ctx context.Context, name, imageName string, spec entities.ContainerSpec) (*container.CreateResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, name, imageName)
+	ret := m.ctrl.Call(m, "Create", ctx, name, imageName, spec)
 	ret0, _ := ret[0].(*container.CreateResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIDockerClientMockRecorder) Create(ctx, name, imageName interface{}) *gomock.Call {
+func (mr *MockIDockerClientMockRecorder) Create(ctx, name, imageName, spec interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIDockerClient)(nil).Create), ctx, name, imageName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIDockerClient)(nil).Create), ctx, name, imageName, spec)
 }
 
 // Delete mocks base method.
@@ -65,6 +114,80 @@ func (mr *MockIDockerClientMockRecorder) Delete(ctx, containerID interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIDockerClient)(nil).Delete), ctx, containerID)
 }
 
+// Events mocks base method.
+func (m *MockIDockerClient) Events(ctx context.Context) (<-chan events.Message, <-chan error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Events", ctx)
+	ret0, _ := ret[0].(<-chan events.Message)
+	ret1, _ := ret[1].(<-chan error)
+	return ret0, ret1
+}
+
+// Events indicates an expected call of Events.
+func (mr *MockIDockerClientMockRecorder) Events(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Events", reflect.TypeOf((*MockIDockerClient)(nil).Events), ctx)
+}
+
+// ExecAttach mocks base method.
+func (m *MockIDockerClient) ExecAttach(ctx context.Context, execID string, options container.ExecAttachOptions) (types.HijackedResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecAttach", ctx, execID, options)
+	ret0, _ := ret[0].(types.HijackedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecAttach indicates an expected call of ExecAttach.
+func (mr *MockIDockerClientMockRecorder) ExecAttach(ctx, execID, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecAttach", reflect.TypeOf((*MockIDockerClient)(nil).ExecAttach), ctx, execID, options)
+}
+
+// ExecCreate mocks base method.
+func (m *MockIDockerClient) ExecCreate(ctx context.Context, containerID string, options container.ExecOptions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecCreate", ctx, containerID, options)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecCreate indicates an expected call of ExecCreate.
+func (mr *MockIDockerClientMockRecorder) ExecCreate(ctx, containerID, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecCreate", reflect.TypeOf((*MockIDockerClient)(nil).ExecCreate), ctx, containerID, options)
+}
+
+// ExecInspect mocks base method.
+func (m *MockIDockerClient) ExecInspect(ctx context.Context, execID string) (container.ExecInspect, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecInspect", ctx, execID)
+	ret0, _ := ret[0].(container.ExecInspect)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecInspect indicates an expected call of ExecInspect.
+func (mr *MockIDockerClientMockRecorder) ExecInspect(ctx, execID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecInspect", reflect.TypeOf((*MockIDockerClient)(nil).ExecInspect), ctx, execID)
+}
+
+// ExecResize mocks base method.
+func (m *MockIDockerClient) ExecResize(ctx context.Context, execID string, height, width uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecResize", ctx, execID, height, width)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecResize indicates an expected call of ExecResize.
+func (mr *MockIDockerClientMockRecorder) ExecResize(ctx, execID, height, width interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecResize", reflect.TypeOf((*MockIDockerClient)(nil).ExecResize), ctx, execID, height, width)
+}
+
 // GetIpv4 mocks base method.
 func (m *MockIDockerClient) GetIpv4(ctx context.Context, containerID string) string {
 	m.ctrl.T.Helper()
@@ -93,6 +216,182 @@ func (mr *MockIDockerClientMockRecorder) GetStatus(ctx, containerID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockIDockerClient)(nil).GetStatus), ctx, containerID)
 }
 
+// Inspect mocks base method.
+func (m *MockIDockerClient) Inspect(ctx context.Context, containerID string) (container.InspectResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Inspect", ctx, containerID)
+	ret0, _ := ret[0].(container.InspectResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Inspect indicates an expected call of Inspect.
+func (mr *MockIDockerClientMockRecorder) Inspect(ctx, containerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inspect", reflect.TypeOf((*MockIDockerClient)(nil).Inspect), ctx, containerID)
+}
+
+// InspectImage mocks base method.
+func (m *MockIDockerClient) InspectImage(ctx context.Context, imageName string) (image.InspectResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectImage", ctx, imageName)
+	ret0, _ := ret[0].(image.InspectResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectImage indicates an expected call of InspectImage.
+func (mr *MockIDockerClientMockRecorder) InspectImage(ctx, imageName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectImage", reflect.TypeOf((*MockIDockerClient)(nil).InspectImage), ctx, imageName)
+}
+
+// Kill mocks base method.
+func (m *MockIDockerClient) Kill(ctx context.Context, containerID, signal string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Kill", ctx, containerID, signal)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Kill indicates an expected call of Kill.
+func (mr *MockIDockerClientMockRecorder) Kill(ctx, containerID, signal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Kill", reflect.TypeOf((*MockIDockerClient)(nil).Kill), ctx, containerID, signal)
+}
+
+// List mocks base method.
+func (m *MockIDockerClient) List(ctx context.Context) ([]container.Summary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]container.Summary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIDockerClientMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIDockerClient)(nil).List), ctx)
+}
+
+// ListImages mocks base method.
+func (m *MockIDockerClient) ListImages(ctx context.Context) ([]image.Summary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImages", ctx)
+	ret0, _ := ret[0].([]image.Summary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImages indicates an expected call of ListImages.
+func (mr *MockIDockerClientMockRecorder) ListImages(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImages", reflect.TypeOf((*MockIDockerClient)(nil).ListImages), ctx)
+}
+
+// Logs mocks base method.
+func (m *MockIDockerClient) Logs(ctx context.Context, containerID string, options container.LogsOptions) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logs", ctx, containerID, options)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Logs indicates an expected call of Logs.
+func (mr *MockIDockerClientMockRecorder) Logs(ctx, containerID, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logs", reflect.TypeOf((*MockIDockerClient)(nil).Logs), ctx, containerID, options)
+}
+
+// Pause mocks base method.
+func (m *MockIDockerClient) Pause(ctx context.Context, containerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pause", ctx, containerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pause indicates an expected call of Pause.
+func (mr *MockIDockerClientMockRecorder) Pause(ctx, containerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockIDockerClient)(nil).Pause), ctx, containerID)
+}
+
+// PruneImages mocks base method.
+func (m *MockIDockerClient) PruneImages(ctx context.Context, all bool) (image.PruneReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneImages", ctx, all)
+	ret0, _ := ret[0].(image.PruneReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneImages indicates an expected call of PruneImages.
+func (mr *MockIDockerClientMockRecorder) PruneImages(ctx, all interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneImages", reflect.TypeOf((*MockIDockerClient)(nil).PruneImages), ctx, all)
+}
+
+// Pull mocks base method.
+func (m *MockIDockerClient) Pull(ctx context.Context, imageName string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pull", ctx, imageName)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pull indicates an expected call of Pull.
+func (mr *MockIDockerClientMockRecorder) Pull(ctx, imageName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pull", reflect.TypeOf((*MockIDockerClient)(nil).Pull), ctx, imageName)
+}
+
+// RemoveImage mocks base method.
+func (m *MockIDockerClient) RemoveImage(ctx context.Context, imageName string, force bool) ([]image.DeleteResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveImage", ctx, imageName, force)
+	ret0, _ := ret[0].([]image.DeleteResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveImage indicates an expected call of RemoveImage.
+func (mr *MockIDockerClientMockRecorder) RemoveImage(ctx, imageName, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveImage", reflect.TypeOf((*MockIDockerClient)(nil).RemoveImage), ctx, imageName, force)
+}
+
+// Rename mocks base method.
+func (m *MockIDockerClient) Rename(ctx context.Context, containerID, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", ctx, containerID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockIDockerClientMockRecorder) Rename(ctx, containerID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockIDockerClient)(nil).Rename), ctx, containerID, name)
+}
+
+// Restart mocks base method.
+func (m *MockIDockerClient) Restart(ctx context.Context, containerID string, timeout *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restart", ctx, containerID, timeout)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restart indicates an expected call of Restart.
+func (mr *MockIDockerClientMockRecorder) Restart(ctx, containerID, timeout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restart", reflect.TypeOf((*MockIDockerClient)(nil).Restart), ctx, containerID, timeout)
+}
+
 // Start mocks base method.
 func (m *MockIDockerClient) Start(ctx context.Context, containerID string) error {
 	m.ctrl.T.Helper()
@@ -107,6 +406,21 @@ func (mr *MockIDockerClientMockRecorder) Start(ctx, containerID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockIDockerClient)(nil).Start), ctx, containerID)
 }
 
+// Stats mocks base method.
+func (m *MockIDockerClient) Stats(ctx context.Context, containerID string) (*container.StatsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx, containerID)
+	ret0, _ := ret[0].(*container.StatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockIDockerClientMockRecorder) Stats(ctx, containerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockIDockerClient)(nil).Stats), ctx, containerID)
+}
+
 // Stop mocks base method.
 func (m *MockIDockerClient) Stop(ctx context.Context, containerID string) error {
 	m.ctrl.T.Helper()
@@ -120,3 +434,70 @@ func (mr *MockIDockerClientMockRecorder) Stop(ctx, containerID interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockIDockerClient)(nil).Stop), ctx, containerID)
 }
+
+// Top mocks base method.
+func (m *MockIDockerClient) Top(ctx context.Context, containerID string) (container.TopResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Top", ctx, containerID)
+	ret0, _ := ret[0].(container.TopResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Top indicates an expected call of Top.
+func (mr *MockIDockerClientMockRecorder) Top(ctx, containerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Top", reflect.TypeOf((*MockIDockerClient)(nil).Top), ctx, containerID)
+}
+
+// Unpause mocks base method.
+func (m *MockIDockerClient) Unpause(ctx context.Context, containerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unpause", ctx, containerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unpause indicates an expected call of Unpause.
+func (mr *MockIDockerClientMockRecorder) Unpause(ctx, containerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unpause", reflect.TypeOf((*MockIDockerClient)(nil).Unpause), ctx, containerID)
+}
+
+// MockICredentialStore is a mock of ICredentialStore interface.
+type MockICredentialStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockICredentialStoreMockRecorder
+}
+
+// MockICredentialStoreMockRecorder is the mock recorder for MockICredentialStore.
+type MockICredentialStoreMockRecorder struct {
+	mock *MockICredentialStore
+}
+
+// NewMockICredentialStore creates a new mock instance.
+func NewMockICredentialStore(ctrl *gomock.Controller) *MockICredentialStore {
+	mock := &MockICredentialStore{ctrl: ctrl}
+	mock.recorder = &MockICredentialStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICredentialStore) EXPECT() *MockICredentialStoreMockRecorder {
+	return m.recorder
+}
+
+// RegistryAuth mocks base method.
+func (m *MockICredentialStore) RegistryAuth(ctx context.Context, registry string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegistryAuth", ctx, registry)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegistryAuth indicates an expected call of RegistryAuth.
+func (mr *MockICredentialStoreMockRecorder) RegistryAuth(ctx, registry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegistryAuth", reflect.TypeOf((*MockICredentialStore)(nil).RegistryAuth), ctx, registry)
+}
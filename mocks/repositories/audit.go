@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/repositories/audit.go
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/vnFuhung2903/vcs-sms/entities"
+	repositories "github.com/vnFuhung2903/vcs-sms/usecases/repositories"
+	gorm "gorm.io/gorm"
+)
+
+// MockIAuditRepository is a mock of IAuditRepository interface.
+type MockIAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditRepositoryMockRecorder
+}
+
+// MockIAuditRepositoryMockRecorder is the mock recorder for MockIAuditRepository.
+type MockIAuditRepositoryMockRecorder struct {
+	mock *MockIAuditRepository
+}
+
+// NewMockIAuditRepository creates a new mock instance.
+func NewMockIAuditRepository(ctrl *gomock.Controller) *MockIAuditRepository {
+	mock := &MockIAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockIAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditRepository) EXPECT() *MockIAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BeginTransaction mocks base method.
+func (m *MockIAuditRepository) BeginTransaction(ctx context.Context) (*gorm.DB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTransaction", ctx)
+	ret0, _ := ret[0].(*gorm.DB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTransaction indicates an expected call of BeginTransaction.
+func (mr *MockIAuditRepositoryMockRecorder) BeginTransaction(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockIAuditRepository)(nil).BeginTransaction), ctx)
+}
+
+// Create mocks base method.
+func (m *MockIAuditRepository) Create(auditLog *entities.AuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", auditLog)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIAuditRepositoryMockRecorder) Create(auditLog interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIAuditRepository)(nil).Create), auditLog)
+}
+
+// Finish mocks base method.
+func (m *MockIAuditRepository) Finish(id uint, endedAt time.Time, exitCode *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", id, endedAt, exitCode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockIAuditRepositoryMockRecorder) Finish(id, endedAt, exitCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockIAuditRepository)(nil).Finish), id, endedAt, exitCode)
+}
+
+// FinishTransfer mocks base method.
+func (m *MockIAuditRepository) FinishTransfer(id uint, endedAt time.Time, bytes int64, errMessage string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishTransfer", id, endedAt, bytes, errMessage)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishTransfer indicates an expected call of FinishTransfer.
+func (mr *MockIAuditRepositoryMockRecorder) FinishTransfer(id, endedAt, bytes, errMessage interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishTransfer", reflect.TypeOf((*MockIAuditRepository)(nil).FinishTransfer), id, endedAt, bytes, errMessage)
+}
+
+// WithTransaction mocks base method.
+func (m *MockIAuditRepository) WithTransaction(tx *gorm.DB) repositories.IAuditRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTransaction", tx)
+	ret0, _ := ret[0].(repositories.IAuditRepository)
+	return ret0
+}
+
+// WithTransaction indicates an expected call of WithTransaction.
+func (mr *MockIAuditRepositoryMockRecorder) WithTransaction(tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTransaction", reflect.TypeOf((*MockIAuditRepository)(nil).WithTransaction), tx)
+}
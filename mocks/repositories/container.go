@@ -7,6 +7,7 @@ package repositories
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	dto "github.com/vnFuhung2903/vcs-sms/dto"
@@ -38,6 +39,36 @@ func (m *MockIContainerRepository) EXPECT() *MockIContainerRepositoryMockRecorde
 	return m.recorder
 }
 
+// Activate mocks base method.
+func (m *MockIContainerRepository) Activate(pendingId, containerId string, status entities.ContainerStatus, ipv4 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Activate", pendingId, containerId, status, ipv4)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Activate indicates an expected call of Activate.
+func (mr *MockIContainerRepositoryMockRecorder) Activate(pendingId, containerId, status, ipv4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Activate", reflect.TypeOf((*MockIContainerRepository)(nil).Activate), pendingId, containerId, status, ipv4)
+}
+
+// AssignUnowned mocks base method.
+func (m *MockIContainerRepository) AssignUnowned(ownerId string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignUnowned", ownerId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignUnowned indicates an expected call of AssignUnowned.
+func (mr *MockIContainerRepositoryMockRecorder) AssignUnowned(ownerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignUnowned", reflect.TypeOf((*MockIContainerRepository)(nil).AssignUnowned), ownerId)
+}
+
 // BeginTransaction mocks base method.
 func (m *MockIContainerRepository) BeginTransaction(ctx context.Context) (*gorm.DB, error) {
 	m.ctrl.T.Helper()
@@ -53,19 +84,33 @@ func (mr *MockIContainerRepositoryMockRecorder) BeginTransaction(ctx interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockIContainerRepository)(nil).BeginTransaction), ctx)
 }
 
-// Create mocks base method.
-func (m *MockIContainerRepository) Create(containerId, containerName string, status entities.ContainerStatus, ipv4 string) (*entities.Container, error) {
+// Count mocks base method.
+func (m *MockIContainerRepository) Count(filter dto.ContainerFilter) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", containerId, containerName, status, ipv4)
-	ret0, _ := ret[0].(*entities.Container)
+	ret := m.ctrl.Call(m, "Count", filter)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// Count indicates an expected call of Count.
+func (mr *MockIContainerRepositoryMockRecorder) Count(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockIContainerRepository)(nil).Count), filter)
+}
+
+// Create mocks base method.
+func (m *MockIContainerRepository) Create(container *entities.Container) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", container)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
 // Create indicates an expected call of Create.
-func (mr *MockIContainerRepositoryMockRecorder) Create(containerId, containerName, status, ipv4 interface{}) *gomock.Call {
+func (mr *MockIContainerRepositoryMockRecorder) Create(container interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIContainerRepository)(nil).Create), containerId, containerName, status, ipv4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIContainerRepository)(nil).Create), container)
 }
 
 // CreateInBatches mocks base method.
@@ -126,6 +171,92 @@ func (mr *MockIContainerRepositoryMockRecorder) FindByName(containerName interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockIContainerRepository)(nil).FindByName), containerName)
 }
 
+// FindDeletedById mocks base method.
+func (m *MockIContainerRepository) FindDeletedById(containerId string) (*entities.Container, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedById", containerId)
+	ret0, _ := ret[0].(*entities.Container)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedById indicates an expected call of FindDeletedById.
+func (mr *MockIContainerRepositoryMockRecorder) FindDeletedById(containerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedById", reflect.TypeOf((*MockIContainerRepository)(nil).FindDeletedById), containerId)
+}
+
+// MarkExpiryNotified mocks base method.
+func (m *MockIContainerRepository) MarkExpiryNotified(containerIds []string, notifiedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkExpiryNotified", containerIds, notifiedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkExpiryNotified indicates an expected call of MarkExpiryNotified.
+func (mr *MockIContainerRepositoryMockRecorder) MarkExpiryNotified(containerIds, notifiedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkExpiryNotified", reflect.TypeOf((*MockIContainerRepository)(nil).MarkExpiryNotified), containerIds, notifiedAt)
+}
+
+// Purge mocks base method.
+func (m *MockIContainerRepository) Purge(containerId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", containerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockIContainerRepositoryMockRecorder) Purge(containerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockIContainerRepository)(nil).Purge), containerId)
+}
+
+// Restore mocks base method.
+func (m *MockIContainerRepository) Restore(containerId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", containerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockIContainerRepositoryMockRecorder) Restore(containerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockIContainerRepository)(nil).Restore), containerId)
+}
+
+// Seek mocks base method.
+func (m *MockIContainerRepository) Seek(filter dto.ContainerFilter, cursor *dto.ContainerCursor, limit int, sort dto.ContainerSort) ([]*entities.Container, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seek", filter, cursor, limit, sort)
+	ret0, _ := ret[0].([]*entities.Container)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Seek indicates an expected call of Seek.
+func (mr *MockIContainerRepositoryMockRecorder) Seek(filter, cursor, limit, sort interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seek", reflect.TypeOf((*MockIContainerRepository)(nil).Seek), filter, cursor, limit, sort)
+}
+
+// Stream mocks base method.
+func (m *MockIContainerRepository) Stream(filter dto.ContainerFilter, from, limit int, sort dto.ContainerSort, fn func(*entities.Container) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", filter, from, limit, sort, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockIContainerRepositoryMockRecorder) Stream(filter, from, limit, sort, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockIContainerRepository)(nil).Stream), filter, from, limit, sort, fn)
+}
+
 // Update mocks base method.
 func (m *MockIContainerRepository) Update(containerId string, status entities.ContainerStatus, ipv4 string) error {
 	m.ctrl.T.Helper()
@@ -140,6 +271,78 @@ func (mr *MockIContainerRepositoryMockRecorder) Update(containerId, status, ipv4
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIContainerRepository)(nil).Update), containerId, status, ipv4)
 }
 
+// UpdateExpiry mocks base method.
+func (m *MockIContainerRepository) UpdateExpiry(containerId string, expiresAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExpiry", containerId, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateExpiry indicates an expected call of UpdateExpiry.
+func (mr *MockIContainerRepositoryMockRecorder) UpdateExpiry(containerId, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExpiry", reflect.TypeOf((*MockIContainerRepository)(nil).UpdateExpiry), containerId, expiresAt)
+}
+
+// UpdateMetadata mocks base method.
+func (m *MockIContainerRepository) UpdateMetadata(containerId, description string, labels map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMetadata", containerId, description, labels)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMetadata indicates an expected call of UpdateMetadata.
+func (mr *MockIContainerRepositoryMockRecorder) UpdateMetadata(containerId, description, labels interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetadata", reflect.TypeOf((*MockIContainerRepository)(nil).UpdateMetadata), containerId, description, labels)
+}
+
+// UpdateName mocks base method.
+func (m *MockIContainerRepository) UpdateName(containerId, containerName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateName", containerId, containerName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateName indicates an expected call of UpdateName.
+func (mr *MockIContainerRepositoryMockRecorder) UpdateName(containerId, containerName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateName", reflect.TypeOf((*MockIContainerRepository)(nil).UpdateName), containerId, containerName)
+}
+
+// UpdateOwner mocks base method.
+func (m *MockIContainerRepository) UpdateOwner(containerId, ownerId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOwner", containerId, ownerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOwner indicates an expected call of UpdateOwner.
+func (mr *MockIContainerRepositoryMockRecorder) UpdateOwner(containerId, ownerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOwner", reflect.TypeOf((*MockIContainerRepository)(nil).UpdateOwner), containerId, ownerId)
+}
+
+// Usage mocks base method.
+func (m *MockIContainerRepository) Usage(ownerId string) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Usage", ownerId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Usage indicates an expected call of Usage.
+func (mr *MockIContainerRepositoryMockRecorder) Usage(ownerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Usage", reflect.TypeOf((*MockIContainerRepository)(nil).Usage), ownerId)
+}
+
 // View mocks base method.
 func (m *MockIContainerRepository) View(filter dto.ContainerFilter, from, limit int, sort dto.ContainerSort) ([]*entities.Container, int64, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +359,36 @@ func (mr *MockIContainerRepositoryMockRecorder) View(filter, from, limit, sort i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "View", reflect.TypeOf((*MockIContainerRepository)(nil).View), filter, from, limit, sort)
 }
 
+// ViewDeleted mocks base method.
+func (m *MockIContainerRepository) ViewDeleted(ownerId string, deletedBefore time.Time) ([]*entities.Container, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewDeleted", ownerId, deletedBefore)
+	ret0, _ := ret[0].([]*entities.Container)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ViewDeleted indicates an expected call of ViewDeleted.
+func (mr *MockIContainerRepositoryMockRecorder) ViewDeleted(ownerId, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewDeleted", reflect.TypeOf((*MockIContainerRepository)(nil).ViewDeleted), ownerId, deletedBefore)
+}
+
+// ViewExpiring mocks base method.
+func (m *MockIContainerRepository) ViewExpiring(before time.Time) ([]*entities.Container, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewExpiring", before)
+	ret0, _ := ret[0].([]*entities.Container)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ViewExpiring indicates an expected call of ViewExpiring.
+func (mr *MockIContainerRepositoryMockRecorder) ViewExpiring(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewExpiring", reflect.TypeOf((*MockIContainerRepository)(nil).ViewExpiring), before)
+}
+
 // WithTransaction mocks base method.
 func (m *MockIContainerRepository) WithTransaction(tx *gorm.DB) repositories.IContainerRepository {
 	m.ctrl.T.Helper()
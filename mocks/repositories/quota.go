@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/repositories/quota.go
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/vnFuhung2903/vcs-sms/entities"
+	repositories "github.com/vnFuhung2903/vcs-sms/usecases/repositories"
+	gorm "gorm.io/gorm"
+)
+
+// MockIQuotaRepository is a mock of IQuotaRepository interface.
+type MockIQuotaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIQuotaRepositoryMockRecorder
+}
+
+// MockIQuotaRepositoryMockRecorder is the mock recorder for MockIQuotaRepository.
+type MockIQuotaRepositoryMockRecorder struct {
+	mock *MockIQuotaRepository
+}
+
+// NewMockIQuotaRepository creates a new mock instance.
+func NewMockIQuotaRepository(ctrl *gomock.Controller) *MockIQuotaRepository {
+	mock := &MockIQuotaRepository{ctrl: ctrl}
+	mock.recorder = &MockIQuotaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIQuotaRepository) EXPECT() *MockIQuotaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BeginTransaction mocks base method.
+func (m *MockIQuotaRepository) BeginTransaction(ctx context.Context) (*gorm.DB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTransaction", ctx)
+	ret0, _ := ret[0].(*gorm.DB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTransaction indicates an expected call of BeginTransaction.
+func (mr *MockIQuotaRepositoryMockRecorder) BeginTransaction(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockIQuotaRepository)(nil).BeginTransaction), ctx)
+}
+
+// Delete mocks base method.
+func (m *MockIQuotaRepository) Delete(subjectType entities.QuotaSubjectType, subject string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", subjectType, subject)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIQuotaRepositoryMockRecorder) Delete(subjectType, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIQuotaRepository)(nil).Delete), subjectType, subject)
+}
+
+// Find mocks base method.
+func (m *MockIQuotaRepository) Find(subjectType entities.QuotaSubjectType, subject string) (*entities.Quota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", subjectType, subject)
+	ret0, _ := ret[0].(*entities.Quota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockIQuotaRepositoryMockRecorder) Find(subjectType, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockIQuotaRepository)(nil).Find), subjectType, subject)
+}
+
+// Upsert mocks base method.
+func (m *MockIQuotaRepository) Upsert(quota *entities.Quota) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", quota)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockIQuotaRepositoryMockRecorder) Upsert(quota interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockIQuotaRepository)(nil).Upsert), quota)
+}
+
+// View mocks base method.
+func (m *MockIQuotaRepository) View() ([]*entities.Quota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "View")
+	ret0, _ := ret[0].([]*entities.Quota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// View indicates an expected call of View.
+func (mr *MockIQuotaRepositoryMockRecorder) View() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "View", reflect.TypeOf((*MockIQuotaRepository)(nil).View))
+}
+
+// WithTransaction mocks base method.
+func (m *MockIQuotaRepository) WithTransaction(tx *gorm.DB) repositories.IQuotaRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTransaction", tx)
+	ret0, _ := ret[0].(repositories.IQuotaRepository)
+	return ret0
+}
+
+// WithTransaction indicates an expected call of WithTransaction.
+func (mr *MockIQuotaRepositoryMockRecorder) WithTransaction(tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTransaction", reflect.TypeOf((*MockIQuotaRepository)(nil).WithTransaction), tx)
+}
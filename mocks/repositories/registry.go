@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/repositories/registry.go
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/vnFuhung2903/vcs-sms/entities"
+)
+
+// MockIRegistryRepository is a mock of IRegistryRepository interface.
+type MockIRegistryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRegistryRepositoryMockRecorder
+}
+
+// MockIRegistryRepositoryMockRecorder is the mock recorder for MockIRegistryRepository.
+type MockIRegistryRepositoryMockRecorder struct {
+	mock *MockIRegistryRepository
+}
+
+// NewMockIRegistryRepository creates a new mock instance.
+func NewMockIRegistryRepository(ctrl *gomock.Controller) *MockIRegistryRepository {
+	mock := &MockIRegistryRepository{ctrl: ctrl}
+	mock.recorder = &MockIRegistryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRegistryRepository) EXPECT() *MockIRegistryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockIRegistryRepository) Delete(registry string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", registry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIRegistryRepositoryMockRecorder) Delete(registry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIRegistryRepository)(nil).Delete), registry)
+}
+
+// Find mocks base method.
+func (m *MockIRegistryRepository) Find(registry string) (*entities.RegistryCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", registry)
+	ret0, _ := ret[0].(*entities.RegistryCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockIRegistryRepositoryMockRecorder) Find(registry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockIRegistryRepository)(nil).Find), registry)
+}
+
+// Upsert mocks base method.
+func (m *MockIRegistryRepository) Upsert(credential *entities.RegistryCredential) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", credential)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockIRegistryRepositoryMockRecorder) Upsert(credential interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockIRegistryRepository)(nil).Upsert), credential)
+}
+
+// View mocks base method.
+func (m *MockIRegistryRepository) View() ([]*entities.RegistryCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "View")
+	ret0, _ := ret[0].([]*entities.RegistryCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// View indicates an expected call of View.
+func (mr *MockIRegistryRepositoryMockRecorder) View() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "View", reflect.TypeOf((*MockIRegistryRepository)(nil).View))
+}
@@ -0,0 +1,167 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/repositories/schedule.go
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/vnFuhung2903/vcs-sms/entities"
+)
+
+// MockIScheduleRepository is a mock of IScheduleRepository interface.
+type MockIScheduleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIScheduleRepositoryMockRecorder
+}
+
+// MockIScheduleRepositoryMockRecorder is the mock recorder for MockIScheduleRepository.
+type MockIScheduleRepositoryMockRecorder struct {
+	mock *MockIScheduleRepository
+}
+
+// NewMockIScheduleRepository creates a new mock instance.
+func NewMockIScheduleRepository(ctrl *gomock.Controller) *MockIScheduleRepository {
+	mock := &MockIScheduleRepository{ctrl: ctrl}
+	mock.recorder = &MockIScheduleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIScheduleRepository) EXPECT() *MockIScheduleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockIScheduleRepository) Claim(schedule *entities.Schedule, nextRunAt, ranAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", schedule, nextRunAt, ranAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockIScheduleRepositoryMockRecorder) Claim(schedule, nextRunAt, ranAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockIScheduleRepository)(nil).Claim), schedule, nextRunAt, ranAt)
+}
+
+// Create mocks base method.
+func (m *MockIScheduleRepository) Create(schedule *entities.Schedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", schedule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIScheduleRepositoryMockRecorder) Create(schedule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIScheduleRepository)(nil).Create), schedule)
+}
+
+// CreateRun mocks base method.
+func (m *MockIScheduleRepository) CreateRun(run *entities.ScheduleRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRun", run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRun indicates an expected call of CreateRun.
+func (mr *MockIScheduleRepositoryMockRecorder) CreateRun(run interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRun", reflect.TypeOf((*MockIScheduleRepository)(nil).CreateRun), run)
+}
+
+// Delete mocks base method.
+func (m *MockIScheduleRepository) Delete(scheduleId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", scheduleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIScheduleRepositoryMockRecorder) Delete(scheduleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIScheduleRepository)(nil).Delete), scheduleId)
+}
+
+// FindById mocks base method.
+func (m *MockIScheduleRepository) FindById(scheduleId string) (*entities.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", scheduleId)
+	ret0, _ := ret[0].(*entities.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockIScheduleRepositoryMockRecorder) FindById(scheduleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockIScheduleRepository)(nil).FindById), scheduleId)
+}
+
+// Update mocks base method.
+func (m *MockIScheduleRepository) Update(schedule *entities.Schedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", schedule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIScheduleRepositoryMockRecorder) Update(schedule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIScheduleRepository)(nil).Update), schedule)
+}
+
+// View mocks base method.
+func (m *MockIScheduleRepository) View(containerId string) ([]*entities.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "View", containerId)
+	ret0, _ := ret[0].([]*entities.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// View indicates an expected call of View.
+func (mr *MockIScheduleRepositoryMockRecorder) View(containerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "View", reflect.TypeOf((*MockIScheduleRepository)(nil).View), containerId)
+}
+
+// ViewDue mocks base method.
+func (m *MockIScheduleRepository) ViewDue(now time.Time) ([]*entities.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewDue", now)
+	ret0, _ := ret[0].([]*entities.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ViewDue indicates an expected call of ViewDue.
+func (mr *MockIScheduleRepositoryMockRecorder) ViewDue(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewDue", reflect.TypeOf((*MockIScheduleRepository)(nil).ViewDue), now)
+}
+
+// ViewRuns mocks base method.
+func (m *MockIScheduleRepository) ViewRuns(scheduleId string, limit int) ([]*entities.ScheduleRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewRuns", scheduleId, limit)
+	ret0, _ := ret[0].([]*entities.ScheduleRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ViewRuns indicates an expected call of ViewRuns.
+func (mr *MockIScheduleRepositoryMockRecorder) ViewRuns(scheduleId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewRuns", reflect.TypeOf((*MockIScheduleRepository)(nil).ViewRuns), scheduleId, limit)
+}
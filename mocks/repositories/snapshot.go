@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/repositories/snapshot.go
+
+// Package repositories is a generated GoMock package.
+package repositories
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/vnFuhung2903/vcs-sms/entities"
+)
+
+// MockISnapshotRepository is a mock of ISnapshotRepository interface.
+type MockISnapshotRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockISnapshotRepositoryMockRecorder
+}
+
+// MockISnapshotRepositoryMockRecorder is the mock recorder for MockISnapshotRepository.
+type MockISnapshotRepositoryMockRecorder struct {
+	mock *MockISnapshotRepository
+}
+
+// NewMockISnapshotRepository creates a new mock instance.
+func NewMockISnapshotRepository(ctrl *gomock.Controller) *MockISnapshotRepository {
+	mock := &MockISnapshotRepository{ctrl: ctrl}
+	mock.recorder = &MockISnapshotRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISnapshotRepository) EXPECT() *MockISnapshotRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockISnapshotRepository) Create(snapshot *entities.Snapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", snapshot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockISnapshotRepositoryMockRecorder) Create(snapshot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockISnapshotRepository)(nil).Create), snapshot)
+}
+
+// FindById mocks base method.
+func (m *MockISnapshotRepository) FindById(snapshotId string) (*entities.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", snapshotId)
+	ret0, _ := ret[0].(*entities.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockISnapshotRepositoryMockRecorder) FindById(snapshotId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockISnapshotRepository)(nil).FindById), snapshotId)
+}
+
+// View mocks base method.
+func (m *MockISnapshotRepository) View(containerId string) ([]*entities.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "View", containerId)
+	ret0, _ := ret[0].([]*entities.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// View indicates an expected call of View.
+func (mr *MockISnapshotRepositoryMockRecorder) View(containerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "View", reflect.TypeOf((*MockISnapshotRepository)(nil).View), containerId)
+}
@@ -111,6 +111,21 @@ func (mr *MockIUserRepositoryMockRecorder) FindById(userId interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockIUserRepository)(nil).FindById), userId)
 }
 
+// FindByIdForUpdate mocks base method.
+func (m *MockIUserRepository) FindByIdForUpdate(userId string) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIdForUpdate", userId)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIdForUpdate indicates an expected call of FindByIdForUpdate.
+func (mr *MockIUserRepositoryMockRecorder) FindByIdForUpdate(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdForUpdate", reflect.TypeOf((*MockIUserRepository)(nil).FindByIdForUpdate), userId)
+}
+
 // FindByName mocks base method.
 func (m *MockIUserRepository) FindByName(username string) (*entities.User, error) {
 	m.ctrl.T.Helper()
@@ -168,6 +183,35 @@ func (mr *MockIUserRepositoryMockRecorder) UpdateScope(user, scopes interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScope", reflect.TypeOf((*MockIUserRepository)(nil).UpdateScope), user, scopes)
 }
 
+// UpgradeScopes mocks base method.
+func (m *MockIUserRepository) UpgradeScopes(user *entities.User, scopes int64, version int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeScopes", user, scopes, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpgradeScopes indicates an expected call of UpgradeScopes.
+func (mr *MockIUserRepositoryMockRecorder) UpgradeScopes(user, scopes, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeScopes", reflect.TypeOf((*MockIUserRepository)(nil).UpgradeScopes), user, scopes, version)
+}
+
+// ViewOutdatedScopes mocks base method.
+func (m *MockIUserRepository) ViewOutdatedScopes(version int) ([]*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewOutdatedScopes", version)
+	ret0, _ := ret[0].([]*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ViewOutdatedScopes indicates an expected call of ViewOutdatedScopes.
+func (mr *MockIUserRepositoryMockRecorder) ViewOutdatedScopes(version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewOutdatedScopes", reflect.TypeOf((*MockIUserRepository)(nil).ViewOutdatedScopes), version)
+}
+
 // WithTransaction mocks base method.
 func (m *MockIUserRepository) WithTransaction(tx *gorm.DB) repositories.IUserRepository {
 	m.ctrl.T.Helper()
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/audit.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/vnFuhung2903/vcs-sms/entities"
+)
+
+// MockIAuditService is a mock of IAuditService interface.
+type MockIAuditService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditServiceMockRecorder
+}
+
+// MockIAuditServiceMockRecorder is the mock recorder for MockIAuditService.
+type MockIAuditServiceMockRecorder struct {
+	mock *MockIAuditService
+}
+
+// NewMockIAuditService creates a new mock instance.
+func NewMockIAuditService(ctrl *gomock.Controller) *MockIAuditService {
+	mock := &MockIAuditService{ctrl: ctrl}
+	mock.recorder = &MockIAuditServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditService) EXPECT() *MockIAuditServiceMockRecorder {
+	return m.recorder
+}
+
+// Finish mocks base method.
+func (m *MockIAuditService) Finish(ctx context.Context, auditLog *entities.AuditLog, exitCode *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, auditLog, exitCode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockIAuditServiceMockRecorder) Finish(ctx, auditLog, exitCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockIAuditService)(nil).Finish), ctx, auditLog, exitCode)
+}
+
+// FinishTransfer mocks base method.
+func (m *MockIAuditService) FinishTransfer(ctx context.Context, auditLog *entities.AuditLog, bytes int64, transferErr error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishTransfer", ctx, auditLog, bytes, transferErr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishTransfer indicates an expected call of FinishTransfer.
+func (mr *MockIAuditServiceMockRecorder) FinishTransfer(ctx, auditLog, bytes, transferErr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishTransfer", reflect.TypeOf((*MockIAuditService)(nil).FinishTransfer), ctx, auditLog, bytes, transferErr)
+}
+
+// Start mocks base method.
+func (m *MockIAuditService) Start(ctx context.Context, action entities.AuditAction, userId, containerId, detail string) (*entities.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, action, userId, containerId, detail)
+	ret0, _ := ret[0].(*entities.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockIAuditServiceMockRecorder) Start(ctx, action, userId, containerId, detail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockIAuditService)(nil).Start), ctx, action, userId, containerId, detail)
+}
@@ -6,8 +6,10 @@ package services
 
 import (
 	context "context"
+	io "io"
 	multipart "mime/multipart"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	dto "github.com/vnFuhung2903/vcs-sms/dto"
@@ -37,19 +39,34 @@ func (m *MockIContainerService) EXPECT() *MockIContainerServiceMockRecorder {
 	return m.recorder
 }
 
+// Clone mocks base method.
+func (m *MockIContainerService) Clone(ctx context.Context, containerId string, req dto.CloneRequest, ownerId string) (*entities.Container, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clone", ctx, containerId, req, ownerId)
+	ret0, _ := ret[0].(*entities.Container)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Clone indicates an expected call of Clone.
+func (mr *MockIContainerServiceMockRecorder) Clone(ctx, containerId, req, ownerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clone", reflect.TypeOf((*MockIContainerService)(nil).Clone), ctx, containerId, req, ownerId)
+}
+
 // Create mocks base method.
-func (m *MockIContainerService) Create(ctx context.Context, containerName, imageName string) (*entities.Container, error) {
+func (m *MockIContainerService) Create(ctx context.Context, containerName, imageName string, spec entities.ContainerSpec, ownerId string, expiresAt *time.Time) (*entities.Container, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, containerName, imageName)
+	ret := m.ctrl.Call(m, "Create", ctx, containerName, imageName, spec, ownerId, expiresAt)
 	ret0, _ := ret[0].(*entities.Container)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIContainerServiceMockRecorder) Create(ctx, containerName, imageName interface{}) *gomock.Call {
+func (mr *MockIContainerServiceMockRecorder) Create(ctx, containerName, imageName, spec, ownerId, expiresAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIContainerService)(nil).Create), ctx, containerName, imageName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIContainerService)(nil).Create), ctx, containerName, imageName, spec, ownerId, expiresAt)
 }
 
 // Delete mocks base method.
@@ -67,33 +84,195 @@ func (mr *MockIContainerServiceMockRecorder) Delete(ctx, containerId interface{}
 }
 
 // Export mocks base method.
-func (m *MockIContainerService) Export(ctx context.Context, filter dto.ContainerFilter, from, to int, sort dto.ContainerSort) ([]byte, error) {
+func (m *MockIContainerService) Export(ctx context.Context, filter dto.ContainerFilter, from, to int, sort dto.ContainerSort, format dto.FileFormat, w io.Writer) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Export", ctx, filter, from, to, sort)
-	ret0, _ := ret[0].([]byte)
+	ret := m.ctrl.Call(m, "Export", ctx, filter, from, to, sort, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockIContainerServiceMockRecorder) Export(ctx, filter, from, to, sort, format, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockIContainerService)(nil).Export), ctx, filter, from, to, sort, format, w)
+}
+
+// FindById mocks base method.
+func (m *MockIContainerService) FindById(ctx context.Context, containerId string) (*entities.Container, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, containerId)
+	ret0, _ := ret[0].(*entities.Container)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Export indicates an expected call of Export.
-func (mr *MockIContainerServiceMockRecorder) Export(ctx, filter, from, to, sort interface{}) *gomock.Call {
+// FindById indicates an expected call of FindById.
+func (mr *MockIContainerServiceMockRecorder) FindById(ctx, containerId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockIContainerService)(nil).Export), ctx, filter, from, to, sort)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockIContainerService)(nil).FindById), ctx, containerId)
 }
 
 // Import mocks base method.
-func (m *MockIContainerService) Import(ctx context.Context, file multipart.File) (*dto.ImportResponse, error) {
+func (m *MockIContainerService) Import(ctx context.Context, file multipart.File, format dto.FileFormat, ownerId string, dryRun bool) (*dto.ImportResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Import", ctx, file)
+	ret := m.ctrl.Call(m, "Import", ctx, file, format, ownerId, dryRun)
 	ret0, _ := ret[0].(*dto.ImportResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Import indicates an expected call of Import.
-func (mr *MockIContainerServiceMockRecorder) Import(ctx, file interface{}) *gomock.Call {
+func (mr *MockIContainerServiceMockRecorder) Import(ctx, file, format, ownerId, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockIContainerService)(nil).Import), ctx, file, format, ownerId, dryRun)
+}
+
+// Inspect mocks base method.
+func (m *MockIContainerService) Inspect(ctx context.Context, containerId string) (*dto.InspectResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Inspect", ctx, containerId)
+	ret0, _ := ret[0].(*dto.InspectResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Inspect indicates an expected call of Inspect.
+func (mr *MockIContainerServiceMockRecorder) Inspect(ctx, containerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inspect", reflect.TypeOf((*MockIContainerService)(nil).Inspect), ctx, containerId)
+}
+
+// Logs mocks base method.
+func (m *MockIContainerService) Logs(ctx context.Context, containerId string, query dto.LogsQuery) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logs", ctx, containerId, query)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Logs indicates an expected call of Logs.
+func (mr *MockIContainerServiceMockRecorder) Logs(ctx, containerId, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logs", reflect.TypeOf((*MockIContainerService)(nil).Logs), ctx, containerId, query)
+}
+
+// Page mocks base method.
+func (m *MockIContainerService) Page(ctx context.Context, containerFilter dto.ContainerFilter, page dto.PageQuery, sort dto.ContainerSort) (*dto.PageResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Page", ctx, containerFilter, page, sort)
+	ret0, _ := ret[0].(*dto.PageResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Page indicates an expected call of Page.
+func (mr *MockIContainerServiceMockRecorder) Page(ctx, containerFilter, page, sort interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Page", reflect.TypeOf((*MockIContainerService)(nil).Page), ctx, containerFilter, page, sort)
+}
+
+// ReadImport mocks base method.
+func (m *MockIContainerService) ReadImport(ctx context.Context, file multipart.File, format dto.FileFormat, ownerId string) ([]dto.ImportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadImport", ctx, file, format, ownerId)
+	ret0, _ := ret[0].([]dto.ImportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadImport indicates an expected call of ReadImport.
+func (mr *MockIContainerServiceMockRecorder) ReadImport(ctx, file, format, ownerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadImport", reflect.TypeOf((*MockIContainerService)(nil).ReadImport), ctx, file, format, ownerId)
+}
+
+// RunAction mocks base method.
+func (m *MockIContainerService) RunAction(ctx context.Context, containerId string, action dto.ContainerAction, query dto.ActionQuery) (entities.ContainerStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunAction", ctx, containerId, action, query)
+	ret0, _ := ret[0].(entities.ContainerStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunAction indicates an expected call of RunAction.
+func (mr *MockIContainerServiceMockRecorder) RunAction(ctx, containerId, action, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunAction", reflect.TypeOf((*MockIContainerService)(nil).RunAction), ctx, containerId, action, query)
+}
+
+// Snapshot mocks base method.
+func (m *MockIContainerService) Snapshot(ctx context.Context, containerId string, query dto.SnapshotQuery) (*entities.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot", ctx, containerId, query)
+	ret0, _ := ret[0].(*entities.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockIContainerServiceMockRecorder) Snapshot(ctx, containerId, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockIContainerService)(nil).Snapshot), ctx, containerId, query)
+}
+
+// Snapshots mocks base method.
+func (m *MockIContainerService) Snapshots(ctx context.Context, containerId string) ([]*entities.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshots", ctx, containerId)
+	ret0, _ := ret[0].([]*entities.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Snapshots indicates an expected call of Snapshots.
+func (mr *MockIContainerServiceMockRecorder) Snapshots(ctx, containerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshots", reflect.TypeOf((*MockIContainerService)(nil).Snapshots), ctx, containerId)
+}
+
+// SyncStatus mocks base method.
+func (m *MockIContainerService) SyncStatus(ctx context.Context, containerId string, status entities.ContainerStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncStatus", ctx, containerId, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncStatus indicates an expected call of SyncStatus.
+func (mr *MockIContainerServiceMockRecorder) SyncStatus(ctx, containerId, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncStatus", reflect.TypeOf((*MockIContainerService)(nil).SyncStatus), ctx, containerId, status)
+}
+
+// Top mocks base method.
+func (m *MockIContainerService) Top(ctx context.Context, containerId string) (*dto.TopResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Top", ctx, containerId)
+	ret0, _ := ret[0].(*dto.TopResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Top indicates an expected call of Top.
+func (mr *MockIContainerServiceMockRecorder) Top(ctx, containerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Top", reflect.TypeOf((*MockIContainerService)(nil).Top), ctx, containerId)
+}
+
+// Transfer mocks base method.
+func (m *MockIContainerService) Transfer(ctx context.Context, containerId, ownerId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfer", ctx, containerId, ownerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *MockIContainerServiceMockRecorder) Transfer(ctx, containerId, ownerId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockIContainerService)(nil).Import), ctx, file)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockIContainerService)(nil).Transfer), ctx, containerId, ownerId)
 }
 
 // Update mocks base method.
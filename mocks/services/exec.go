@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/exec.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-sms/dto"
+)
+
+// MockIExecService is a mock of IExecService interface.
+type MockIExecService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIExecServiceMockRecorder
+}
+
+// MockIExecServiceMockRecorder is the mock recorder for MockIExecService.
+type MockIExecServiceMockRecorder struct {
+	mock *MockIExecService
+}
+
+// NewMockIExecService creates a new mock instance.
+func NewMockIExecService(ctrl *gomock.Controller) *MockIExecService {
+	mock := &MockIExecService{ctrl: ctrl}
+	mock.recorder = &MockIExecServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIExecService) EXPECT() *MockIExecServiceMockRecorder {
+	return m.recorder
+}
+
+// Finish mocks base method.
+func (m *MockIExecService) Finish(ctx context.Context, session *dto.ExecSession) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, session)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockIExecServiceMockRecorder) Finish(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockIExecService)(nil).Finish), ctx, session)
+}
+
+// Resize mocks base method.
+func (m *MockIExecService) Resize(ctx context.Context, session *dto.ExecSession, rows, cols uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resize", ctx, session, rows, cols)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resize indicates an expected call of Resize.
+func (mr *MockIExecServiceMockRecorder) Resize(ctx, session, rows, cols interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resize", reflect.TypeOf((*MockIExecService)(nil).Resize), ctx, session, rows, cols)
+}
+
+// Start mocks base method.
+func (m *MockIExecService) Start(ctx context.Context, userId, containerId string, cmd []string, tty bool) (*dto.ExecSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, userId, containerId, cmd, tty)
+	ret0, _ := ret[0].(*dto.ExecSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockIExecServiceMockRecorder) Start(ctx, userId, containerId, cmd, tty interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockIExecService)(nil).Start), ctx, userId, containerId, cmd, tty)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/expiry.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIExpiryService is a mock of IExpiryService interface.
+type MockIExpiryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIExpiryServiceMockRecorder
+}
+
+// MockIExpiryServiceMockRecorder is the mock recorder for MockIExpiryService.
+type MockIExpiryServiceMockRecorder struct {
+	mock *MockIExpiryService
+}
+
+// NewMockIExpiryService creates a new mock instance.
+func NewMockIExpiryService(ctrl *gomock.Controller) *MockIExpiryService {
+	mock := &MockIExpiryService{ctrl: ctrl}
+	mock.recorder = &MockIExpiryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIExpiryService) EXPECT() *MockIExpiryServiceMockRecorder {
+	return m.recorder
+}
+
+// Extend mocks base method.
+func (m *MockIExpiryService) Extend(ctx context.Context, containerId string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Extend", ctx, containerId, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Extend indicates an expected call of Extend.
+func (mr *MockIExpiryServiceMockRecorder) Extend(ctx, containerId, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Extend", reflect.TypeOf((*MockIExpiryService)(nil).Extend), ctx, containerId, expiresAt)
+}
+
+// Reap mocks base method.
+func (m *MockIExpiryService) Reap(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reap", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reap indicates an expected call of Reap.
+func (mr *MockIExpiryServiceMockRecorder) Reap(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reap", reflect.TypeOf((*MockIExpiryService)(nil).Reap), ctx)
+}
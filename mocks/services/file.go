@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/file.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-sms/dto"
+)
+
+// MockIFileService is a mock of IFileService interface.
+type MockIFileService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIFileServiceMockRecorder
+}
+
+// MockIFileServiceMockRecorder is the mock recorder for MockIFileService.
+type MockIFileServiceMockRecorder struct {
+	mock *MockIFileService
+}
+
+// NewMockIFileService creates a new mock instance.
+func NewMockIFileService(ctrl *gomock.Controller) *MockIFileService {
+	mock := &MockIFileService{ctrl: ctrl}
+	mock.recorder = &MockIFileServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIFileService) EXPECT() *MockIFileServiceMockRecorder {
+	return m.recorder
+}
+
+// Download mocks base method.
+func (m *MockIFileService) Download(ctx context.Context, userId, containerId string, query dto.FileQuery) (*dto.FileDownload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Download", ctx, userId, containerId, query)
+	ret0, _ := ret[0].(*dto.FileDownload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Download indicates an expected call of Download.
+func (mr *MockIFileServiceMockRecorder) Download(ctx, userId, containerId, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockIFileService)(nil).Download), ctx, userId, containerId, query)
+}
+
+// Finish mocks base method.
+func (m *MockIFileService) Finish(ctx context.Context, download *dto.FileDownload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, download)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockIFileServiceMockRecorder) Finish(ctx, download interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockIFileService)(nil).Finish), ctx, download)
+}
+
+// Upload mocks base method.
+func (m *MockIFileService) Upload(ctx context.Context, userId, containerId string, query dto.FileQuery, content io.Reader, size int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, userId, containerId, query, content, size)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockIFileServiceMockRecorder) Upload(ctx, userId, containerId, query, content, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockIFileService)(nil).Upload), ctx, userId, containerId, query, content, size)
+}
@@ -11,6 +11,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	dto "github.com/vnFuhung2903/vcs-sms/dto"
+	entities "github.com/vnFuhung2903/vcs-sms/entities"
 )
 
 // MockIHealthcheckService is a mock of IHealthcheckService interface.
@@ -51,6 +52,34 @@ func (mr *MockIHealthcheckServiceMockRecorder) GetEsStatus(ctx, ids, limit, star
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEsStatus", reflect.TypeOf((*MockIHealthcheckService)(nil).GetEsStatus), ctx, ids, limit, startTime, endTime, order)
 }
 
+// RecordAction mocks base method.
+func (m *MockIHealthcheckService) RecordAction(ctx context.Context, containerId string, status entities.ContainerStatus, action dto.ContainerAction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAction", ctx, containerId, status, action)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAction indicates an expected call of RecordAction.
+func (mr *MockIHealthcheckServiceMockRecorder) RecordAction(ctx, containerId, status, action interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAction", reflect.TypeOf((*MockIHealthcheckService)(nil).RecordAction), ctx, containerId, status, action)
+}
+
+// RecordTransition mocks base method.
+func (m *MockIHealthcheckService) RecordTransition(ctx context.Context, containerId string, status entities.ContainerStatus, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordTransition", ctx, containerId, status, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordTransition indicates an expected call of RecordTransition.
+func (mr *MockIHealthcheckServiceMockRecorder) RecordTransition(ctx, containerId, status, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordTransition", reflect.TypeOf((*MockIHealthcheckService)(nil).RecordTransition), ctx, containerId, status, at)
+}
+
 // UpdateStatus mocks base method.
 func (m *MockIHealthcheckService) UpdateStatus(ctx context.Context, statusList []dto.EsStatusUpdate, interval time.Duration) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/image.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-sms/dto"
+)
+
+// MockIImageService is a mock of IImageService interface.
+type MockIImageService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIImageServiceMockRecorder
+}
+
+// MockIImageServiceMockRecorder is the mock recorder for MockIImageService.
+type MockIImageServiceMockRecorder struct {
+	mock *MockIImageService
+}
+
+// NewMockIImageService creates a new mock instance.
+func NewMockIImageService(ctrl *gomock.Controller) *MockIImageService {
+	mock := &MockIImageService{ctrl: ctrl}
+	mock.recorder = &MockIImageServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIImageService) EXPECT() *MockIImageServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckPolicy mocks base method.
+func (m *MockIImageService) CheckPolicy(imageName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPolicy", imageName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckPolicy indicates an expected call of CheckPolicy.
+func (mr *MockIImageServiceMockRecorder) CheckPolicy(imageName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPolicy", reflect.TypeOf((*MockIImageService)(nil).CheckPolicy), imageName)
+}
+
+// Inspect mocks base method.
+func (m *MockIImageService) Inspect(ctx context.Context, imageName string) (*dto.ImageInspect, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Inspect", ctx, imageName)
+	ret0, _ := ret[0].(*dto.ImageInspect)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Inspect indicates an expected call of Inspect.
+func (mr *MockIImageServiceMockRecorder) Inspect(ctx, imageName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inspect", reflect.TypeOf((*MockIImageService)(nil).Inspect), ctx, imageName)
+}
+
+// List mocks base method.
+func (m *MockIImageService) List(ctx context.Context) ([]dto.ImageSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]dto.ImageSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIImageServiceMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIImageService)(nil).List), ctx)
+}
+
+// Prune mocks base method.
+func (m *MockIImageService) Prune(ctx context.Context, all bool) (*dto.ImagePruneResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prune", ctx, all)
+	ret0, _ := ret[0].(*dto.ImagePruneResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Prune indicates an expected call of Prune.
+func (mr *MockIImageServiceMockRecorder) Prune(ctx, all interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prune", reflect.TypeOf((*MockIImageService)(nil).Prune), ctx, all)
+}
+
+// Pull mocks base method.
+func (m *MockIImageService) Pull(ctx context.Context, imageName string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pull", ctx, imageName)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pull indicates an expected call of Pull.
+func (mr *MockIImageServiceMockRecorder) Pull(ctx, imageName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pull", reflect.TypeOf((*MockIImageService)(nil).Pull), ctx, imageName)
+}
+
+// Remove mocks base method.
+func (m *MockIImageService) Remove(ctx context.Context, imageName string, force bool) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, imageName, force)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockIImageServiceMockRecorder) Remove(ctx, imageName, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockIImageService)(nil).Remove), ctx, imageName, force)
+}
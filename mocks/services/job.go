@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/job.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/vnFuhung2903/vcs-sms/entities"
+)
+
+// MockIJobService is a mock of IJobService interface.
+type MockIJobService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIJobServiceMockRecorder
+}
+
+// MockIJobServiceMockRecorder is the mock recorder for MockIJobService.
+type MockIJobServiceMockRecorder struct {
+	mock *MockIJobService
+}
+
+// NewMockIJobService creates a new mock instance.
+func NewMockIJobService(ctrl *gomock.Controller) *MockIJobService {
+	mock := &MockIJobService{ctrl: ctrl}
+	mock.recorder = &MockIJobServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIJobService) EXPECT() *MockIJobServiceMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockIJobService) Cancel(ctx context.Context, jobId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, jobId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockIJobServiceMockRecorder) Cancel(ctx, jobId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockIJobService)(nil).Cancel), ctx, jobId)
+}
+
+// FindById mocks base method.
+func (m *MockIJobService) FindById(ctx context.Context, jobId string) (*entities.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, jobId)
+	ret0, _ := ret[0].(*entities.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockIJobServiceMockRecorder) FindById(ctx, jobId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockIJobService)(nil).FindById), ctx, jobId)
+}
+
+// Recover mocks base method.
+func (m *MockIJobService) Recover(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recover", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Recover indicates an expected call of Recover.
+func (mr *MockIJobServiceMockRecorder) Recover(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recover", reflect.TypeOf((*MockIJobService)(nil).Recover), ctx)
+}
+
+// RunNext mocks base method.
+func (m *MockIJobService) RunNext(ctx context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunNext", ctx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunNext indicates an expected call of RunNext.
+func (mr *MockIJobServiceMockRecorder) RunNext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunNext", reflect.TypeOf((*MockIJobService)(nil).RunNext), ctx)
+}
+
+// Submit mocks base method.
+func (m *MockIJobService) Submit(ctx context.Context, jobType entities.JobType, ownerId string, items []entities.JobItem) (*entities.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Submit", ctx, jobType, ownerId, items)
+	ret0, _ := ret[0].(*entities.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Submit indicates an expected call of Submit.
+func (mr *MockIJobServiceMockRecorder) Submit(ctx, jobType, ownerId, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockIJobService)(nil).Submit), ctx, jobType, ownerId, items)
+}
+
+// View mocks base method.
+func (m *MockIJobService) View(ctx context.Context, ownerId string) ([]*entities.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "View", ctx, ownerId)
+	ret0, _ := ret[0].([]*entities.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// View indicates an expected call of View.
+func (mr *MockIJobServiceMockRecorder) View(ctx, ownerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "View", reflect.TypeOf((*MockIJobService)(nil).View), ctx, ownerId)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/metrics.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-sms/dto"
+)
+
+// MockIMetricsService is a mock of IMetricsService interface.
+type MockIMetricsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMetricsServiceMockRecorder
+}
+
+// MockIMetricsServiceMockRecorder is the mock recorder for MockIMetricsService.
+type MockIMetricsServiceMockRecorder struct {
+	mock *MockIMetricsService
+}
+
+// NewMockIMetricsService creates a new mock instance.
+func NewMockIMetricsService(ctrl *gomock.Controller) *MockIMetricsService {
+	mock := &MockIMetricsService{ctrl: ctrl}
+	mock.recorder = &MockIMetricsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMetricsService) EXPECT() *MockIMetricsServiceMockRecorder {
+	return m.recorder
+}
+
+// GetMetrics mocks base method.
+func (m *MockIMetricsService) GetMetrics(ctx context.Context, containerId string, from, to time.Time, step time.Duration) ([]dto.MetricsPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetrics", ctx, containerId, from, to, step)
+	ret0, _ := ret[0].([]dto.MetricsPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetrics indicates an expected call of GetMetrics.
+func (mr *MockIMetricsServiceMockRecorder) GetMetrics(ctx, containerId, from, to, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetrics", reflect.TypeOf((*MockIMetricsService)(nil).GetMetrics), ctx, containerId, from, to, step)
+}
+
+// IndexMetrics mocks base method.
+func (m *MockIMetricsService) IndexMetrics(ctx context.Context, metricsList []dto.EsMetricsUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexMetrics", ctx, metricsList)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IndexMetrics indicates an expected call of IndexMetrics.
+func (mr *MockIMetricsServiceMockRecorder) IndexMetrics(ctx, metricsList interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexMetrics", reflect.TypeOf((*MockIMetricsService)(nil).IndexMetrics), ctx, metricsList)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/migration.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIMigrationService is a mock of IMigrationService interface.
+type MockIMigrationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMigrationServiceMockRecorder
+}
+
+// MockIMigrationServiceMockRecorder is the mock recorder for MockIMigrationService.
+type MockIMigrationServiceMockRecorder struct {
+	mock *MockIMigrationService
+}
+
+// NewMockIMigrationService creates a new mock instance.
+func NewMockIMigrationService(ctrl *gomock.Controller) *MockIMigrationService {
+	mock := &MockIMigrationService{ctrl: ctrl}
+	mock.recorder = &MockIMigrationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMigrationService) EXPECT() *MockIMigrationServiceMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockIMigrationService) Run(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockIMigrationServiceMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockIMigrationService)(nil).Run), ctx)
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/quota.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/vnFuhung2903/vcs-sms/entities"
+	gorm "gorm.io/gorm"
+)
+
+// MockIQuotaService is a mock of IQuotaService interface.
+type MockIQuotaService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIQuotaServiceMockRecorder
+}
+
+// MockIQuotaServiceMockRecorder is the mock recorder for MockIQuotaService.
+type MockIQuotaServiceMockRecorder struct {
+	mock *MockIQuotaService
+}
+
+// NewMockIQuotaService creates a new mock instance.
+func NewMockIQuotaService(ctrl *gomock.Controller) *MockIQuotaService {
+	mock := &MockIQuotaService{ctrl: ctrl}
+	mock.recorder = &MockIQuotaServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIQuotaService) EXPECT() *MockIQuotaServiceMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockIQuotaService) Check(ctx context.Context, userId string, containers, memory int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, userId, containers, memory)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockIQuotaServiceMockRecorder) Check(ctx, userId, containers, memory interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockIQuotaService)(nil).Check), ctx, userId, containers, memory)
+}
+
+// Delete mocks base method.
+func (m *MockIQuotaService) Delete(ctx context.Context, subjectType entities.QuotaSubjectType, subject string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, subjectType, subject)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIQuotaServiceMockRecorder) Delete(ctx, subjectType, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIQuotaService)(nil).Delete), ctx, subjectType, subject)
+}
+
+// Reserve mocks base method.
+func (m *MockIQuotaService) Reserve(ctx context.Context, userId string, containers, memory int64, record func(*gorm.DB) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, userId, containers, memory, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockIQuotaServiceMockRecorder) Reserve(ctx, userId, containers, memory, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockIQuotaService)(nil).Reserve), ctx, userId, containers, memory, record)
+}
+
+// Set mocks base method.
+func (m *MockIQuotaService) Set(ctx context.Context, quota *entities.Quota) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, quota)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockIQuotaServiceMockRecorder) Set(ctx, quota interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockIQuotaService)(nil).Set), ctx, quota)
+}
+
+// View mocks base method.
+func (m *MockIQuotaService) View(ctx context.Context) ([]*entities.Quota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "View", ctx)
+	ret0, _ := ret[0].([]*entities.Quota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// View indicates an expected call of View.
+func (mr *MockIQuotaServiceMockRecorder) View(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "View", reflect.TypeOf((*MockIQuotaService)(nil).View), ctx)
+}
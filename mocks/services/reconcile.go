@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/reconcile.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-sms/dto"
+)
+
+// MockIReconcileService is a mock of IReconcileService interface.
+type MockIReconcileService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIReconcileServiceMockRecorder
+}
+
+// MockIReconcileServiceMockRecorder is the mock recorder for MockIReconcileService.
+type MockIReconcileServiceMockRecorder struct {
+	mock *MockIReconcileService
+}
+
+// NewMockIReconcileService creates a new mock instance.
+func NewMockIReconcileService(ctrl *gomock.Controller) *MockIReconcileService {
+	mock := &MockIReconcileService{ctrl: ctrl}
+	mock.recorder = &MockIReconcileServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIReconcileService) EXPECT() *MockIReconcileServiceMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockIReconcileService) Reconcile(ctx context.Context, dryRun bool) (*dto.ReconcileReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, dryRun)
+	ret0, _ := ret[0].(*dto.ReconcileReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockIReconcileServiceMockRecorder) Reconcile(ctx, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockIReconcileService)(nil).Reconcile), ctx, dryRun)
+}
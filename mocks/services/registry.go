@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/registry.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-sms/dto"
+)
+
+// MockIRegistryService is a mock of IRegistryService interface.
+type MockIRegistryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRegistryServiceMockRecorder
+}
+
+// MockIRegistryServiceMockRecorder is the mock recorder for MockIRegistryService.
+type MockIRegistryServiceMockRecorder struct {
+	mock *MockIRegistryService
+}
+
+// NewMockIRegistryService creates a new mock instance.
+func NewMockIRegistryService(ctrl *gomock.Controller) *MockIRegistryService {
+	mock := &MockIRegistryService{ctrl: ctrl}
+	mock.recorder = &MockIRegistryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRegistryService) EXPECT() *MockIRegistryServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockIRegistryService) Delete(ctx context.Context, registryName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, registryName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIRegistryServiceMockRecorder) Delete(ctx, registryName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIRegistryService)(nil).Delete), ctx, registryName)
+}
+
+// RegistryAuth mocks base method.
+func (m *MockIRegistryService) RegistryAuth(ctx context.Context, registryName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegistryAuth", ctx, registryName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegistryAuth indicates an expected call of RegistryAuth.
+func (mr *MockIRegistryServiceMockRecorder) RegistryAuth(ctx, registryName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegistryAuth", reflect.TypeOf((*MockIRegistryService)(nil).RegistryAuth), ctx, registryName)
+}
+
+// Set mocks base method.
+func (m *MockIRegistryService) Set(ctx context.Context, req dto.RegistryRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockIRegistryServiceMockRecorder) Set(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockIRegistryService)(nil).Set), ctx, req)
+}
+
+// View mocks base method.
+func (m *MockIRegistryService) View(ctx context.Context) ([]dto.RegistryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "View", ctx)
+	ret0, _ := ret[0].([]dto.RegistryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// View indicates an expected call of View.
+func (mr *MockIRegistryServiceMockRecorder) View(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "View", reflect.TypeOf((*MockIRegistryService)(nil).View), ctx)
+}
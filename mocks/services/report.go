@@ -11,6 +11,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	dto "github.com/vnFuhung2903/vcs-sms/dto"
+	entities "github.com/vnFuhung2903/vcs-sms/entities"
 )
 
 // MockIReportService is a mock of IReportService interface.
@@ -65,3 +66,17 @@ func (mr *MockIReportServiceMockRecorder) SendEmail(ctx, to, totalCount, onCount
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmail", reflect.TypeOf((*MockIReportService)(nil).SendEmail), ctx, to, totalCount, onCount, offCount, totalUptime, startTime, endTime)
 }
+
+// SendExpiryNotice mocks base method.
+func (m *MockIReportService) SendExpiryNotice(ctx context.Context, to string, containers []*entities.Container) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendExpiryNotice", ctx, to, containers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendExpiryNotice indicates an expected call of SendExpiryNotice.
+func (mr *MockIReportServiceMockRecorder) SendExpiryNotice(ctx, to, containers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendExpiryNotice", reflect.TypeOf((*MockIReportService)(nil).SendExpiryNotice), ctx, to, containers)
+}
@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/schedule.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/vnFuhung2903/vcs-sms/dto"
+	entities "github.com/vnFuhung2903/vcs-sms/entities"
+)
+
+// MockIScheduleService is a mock of IScheduleService interface.
+type MockIScheduleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIScheduleServiceMockRecorder
+}
+
+// MockIScheduleServiceMockRecorder is the mock recorder for MockIScheduleService.
+type MockIScheduleServiceMockRecorder struct {
+	mock *MockIScheduleService
+}
+
+// NewMockIScheduleService creates a new mock instance.
+func NewMockIScheduleService(ctrl *gomock.Controller) *MockIScheduleService {
+	mock := &MockIScheduleService{ctrl: ctrl}
+	mock.recorder = &MockIScheduleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIScheduleService) EXPECT() *MockIScheduleServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIScheduleService) Create(ctx context.Context, containerId string, req dto.ScheduleRequest) (*entities.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, containerId, req)
+	ret0, _ := ret[0].(*entities.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIScheduleServiceMockRecorder) Create(ctx, containerId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIScheduleService)(nil).Create), ctx, containerId, req)
+}
+
+// Delete mocks base method.
+func (m *MockIScheduleService) Delete(ctx context.Context, containerId, scheduleId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, containerId, scheduleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIScheduleServiceMockRecorder) Delete(ctx, containerId, scheduleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIScheduleService)(nil).Delete), ctx, containerId, scheduleId)
+}
+
+// FindById mocks base method.
+func (m *MockIScheduleService) FindById(ctx context.Context, containerId, scheduleId string) (*entities.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, containerId, scheduleId)
+	ret0, _ := ret[0].(*entities.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockIScheduleServiceMockRecorder) FindById(ctx, containerId, scheduleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockIScheduleService)(nil).FindById), ctx, containerId, scheduleId)
+}
+
+// RunDue mocks base method.
+func (m *MockIScheduleService) RunDue(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunDue", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunDue indicates an expected call of RunDue.
+func (mr *MockIScheduleServiceMockRecorder) RunDue(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunDue", reflect.TypeOf((*MockIScheduleService)(nil).RunDue), ctx)
+}
+
+// Runs mocks base method.
+func (m *MockIScheduleService) Runs(ctx context.Context, containerId, scheduleId string) ([]*entities.ScheduleRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Runs", ctx, containerId, scheduleId)
+	ret0, _ := ret[0].([]*entities.ScheduleRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Runs indicates an expected call of Runs.
+func (mr *MockIScheduleServiceMockRecorder) Runs(ctx, containerId, scheduleId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Runs", reflect.TypeOf((*MockIScheduleService)(nil).Runs), ctx, containerId, scheduleId)
+}
+
+// Update mocks base method.
+func (m *MockIScheduleService) Update(ctx context.Context, containerId, scheduleId string, req dto.ScheduleRequest) (*entities.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, containerId, scheduleId, req)
+	ret0, _ := ret[0].(*entities.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIScheduleServiceMockRecorder) Update(ctx, containerId, scheduleId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIScheduleService)(nil).Update), ctx, containerId, scheduleId, req)
+}
+
+// View mocks base method.
+func (m *MockIScheduleService) View(ctx context.Context, containerId string) ([]*entities.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "View", ctx, containerId)
+	ret0, _ := ret[0].([]*entities.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// View indicates an expected call of View.
+func (mr *MockIScheduleServiceMockRecorder) View(ctx, containerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "View", reflect.TypeOf((*MockIScheduleService)(nil).View), ctx, containerId)
+}
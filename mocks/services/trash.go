@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecases/services/trash.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/vnFuhung2903/vcs-sms/entities"
+)
+
+// MockITrashService is a mock of ITrashService interface.
+type MockITrashService struct {
+	ctrl     *gomock.Controller
+	recorder *MockITrashServiceMockRecorder
+}
+
+// MockITrashServiceMockRecorder is the mock recorder for MockITrashService.
+type MockITrashServiceMockRecorder struct {
+	mock *MockITrashService
+}
+
+// NewMockITrashService creates a new mock instance.
+func NewMockITrashService(ctrl *gomock.Controller) *MockITrashService {
+	mock := &MockITrashService{ctrl: ctrl}
+	mock.recorder = &MockITrashServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITrashService) EXPECT() *MockITrashServiceMockRecorder {
+	return m.recorder
+}
+
+// FindById mocks base method.
+func (m *MockITrashService) FindById(ctx context.Context, containerId string) (*entities.Container, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, containerId)
+	ret0, _ := ret[0].(*entities.Container)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockITrashServiceMockRecorder) FindById(ctx, containerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockITrashService)(nil).FindById), ctx, containerId)
+}
+
+// Purge mocks base method.
+func (m *MockITrashService) Purge(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockITrashServiceMockRecorder) Purge(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockITrashService)(nil).Purge), ctx)
+}
+
+// Restore mocks base method.
+func (m *MockITrashService) Restore(ctx context.Context, containerId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, containerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockITrashServiceMockRecorder) Restore(ctx, containerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockITrashService)(nil).Restore), ctx, containerId)
+}
+
+// View mocks base method.
+func (m *MockITrashService) View(ctx context.Context, ownerId string) ([]*entities.Container, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "View", ctx, ownerId)
+	ret0, _ := ret[0].([]*entities.Container)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// View indicates an expected call of View.
+func (mr *MockITrashServiceMockRecorder) View(ctx, ownerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "View", reflect.TypeOf((*MockITrashService)(nil).View), ctx, ownerId)
+}
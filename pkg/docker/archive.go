@@ -0,0 +1,95 @@
+package docker
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/containerd/errdefs"
+)
+
+// CleanPath validates a path inside a container, which must be absolute, and returns it in its shortest form.
+func CleanPath(p string) (string, error) {
+	if p == "" || !strings.HasPrefix(p, "/") {
+		return "", fmt.Errorf("%w: path %q is not absolute", errdefs.ErrInvalidArgument, p)
+	}
+	if strings.ContainsRune(p, 0) {
+		return "", fmt.Errorf("%w: path %q contains a NUL byte", errdefs.ErrInvalidArgument, p)
+	}
+	return path.Clean(p), nil
+}
+
+// LimitedReader reads at most limit bytes, failing with a resource exhausted error once its reader has more.
+type LimitedReader struct {
+	r     io.Reader
+	limit int64
+	read  int64
+}
+
+func (l *LimitedReader) Read(p []byte) (int, error) {
+	if l.read > l.limit {
+		return 0, fmt.Errorf("%w: more than %d bytes", errdefs.ErrResourceExhausted, l.limit)
+	}
+	// One byte past the limit is read to tell a reader of exactly limit bytes from a larger one.
+	if remaining := l.limit - l.read + 1; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		return n - 1, fmt.Errorf("%w: more than %d bytes", errdefs.ErrResourceExhausted, l.limit)
+	}
+	return n, err
+}
+
+// Exceeded tells whether the reader went over its limit, for when the error got lost on the way to the caller.
+func (l *LimitedReader) Exceeded() bool {
+	return l.read > l.limit
+}
+
+func LimitReader(r io.Reader, limit int64) *LimitedReader {
+	return &LimitedReader{r: r, limit: limit}
+}
+
+// FileArchive streams a tar archive holding a single regular file, read from the size bytes of content. The archive
+// is written as it is read, so the reader must be closed when it is not read to the end for the writing to stop.
+func FileArchive(name string, content io.Reader, size int64) *io.PipeReader {
+	pr, pw := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(pw)
+		err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     0644,
+			Size:     size,
+			ModTime:  time.Now(),
+		})
+		if err == nil {
+			// Content shorter than its size must not end the pipe as if the archive was complete.
+			if _, err = io.CopyN(tw, content, size); err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+		}
+		if err == nil {
+			err = tw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+// ExtractFile reads the archive up to its first entry, which must be a regular file, and returns the content of it.
+func ExtractFile(archive io.Reader) (*tar.Header, io.Reader, error) {
+	tr := tar.NewReader(archive)
+	header, err := tr.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+	if header.Typeflag != tar.TypeReg {
+		return nil, nil, fmt.Errorf("%w: %s is not a regular file", errdefs.ErrInvalidArgument, header.Name)
+	}
+	return header, tr, nil
+}
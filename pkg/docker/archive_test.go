@@ -0,0 +1,70 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/containerd/errdefs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCleanPath(t *testing.T) {
+	p, err := CleanPath("/etc/./app/../hosts/")
+	assert.NoError(t, err)
+	assert.Equal(t, "/etc/hosts", p)
+
+	p, err = CleanPath("/../..")
+	assert.NoError(t, err)
+	assert.Equal(t, "/", p)
+
+	for _, invalid := range []string{"", "etc/hosts", "../etc", "/etc/\x00hosts"} {
+		_, err = CleanPath(invalid)
+		assert.True(t, errdefs.IsInvalidArgument(err), invalid)
+	}
+}
+
+func TestLimitReader(t *testing.T) {
+	limited := LimitReader(strings.NewReader("hello"), 5)
+	data, err := io.ReadAll(limited)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+	assert.False(t, limited.Exceeded())
+
+	limited = LimitReader(strings.NewReader("hello world"), 5)
+	data, err = io.ReadAll(limited)
+	assert.True(t, errdefs.IsResourceExhausted(err))
+	assert.Equal(t, "hello", string(data))
+	assert.True(t, limited.Exceeded())
+
+	_, err = limited.Read(make([]byte, 1))
+	assert.True(t, errdefs.IsResourceExhausted(err))
+}
+
+func TestFileArchive(t *testing.T) {
+	header, content, err := ExtractFile(FileArchive("app.yml", strings.NewReader("a: 1"), 4))
+	assert.NoError(t, err)
+	assert.Equal(t, "app.yml", header.Name)
+	assert.Equal(t, int64(4), header.Size)
+
+	data, err := io.ReadAll(content)
+	assert.NoError(t, err)
+	assert.Equal(t, "a: 1", string(data))
+}
+
+func TestFileArchiveShortContent(t *testing.T) {
+	_, err := io.ReadAll(FileArchive("app.yml", strings.NewReader("a"), 4))
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestExtractFileNotRegular(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: "app/", Mode: 0755}))
+	assert.NoError(t, tw.Close())
+
+	_, _, err := ExtractFile(&buf)
+	assert.True(t, errdefs.IsInvalidArgument(err))
+}
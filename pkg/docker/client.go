@@ -2,46 +2,114 @@ package docker
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
+	"strconv"
 
+	"github.com/containerd/errdefs"
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
 	"github.com/vnFuhung2903/vcs-sms/entities"
 )
 
+// ManagedLabel marks the containers created through the API, so they can be told apart on a shared daemon.
+const ManagedLabel = "vcs-sms.managed"
+
 type IDockerClient interface {
-	Create(ctx context.Context, name string, imageName string) (*container.CreateResponse, error)
+	Create(ctx context.Context, name string, imageName string, spec entities.ContainerSpec) (*container.CreateResponse, error)
 	Start(ctx context.Context, containerID string) error
+	List(ctx context.Context) ([]container.Summary, error)
 	GetStatus(ctx context.Context, containerID string) entities.ContainerStatus
 	GetIpv4(ctx context.Context, containerID string) string
+	Inspect(ctx context.Context, containerID string) (container.InspectResponse, error)
+	Top(ctx context.Context, containerID string) (container.TopResponse, error)
 	Stop(ctx context.Context, containerID string) error
+	Restart(ctx context.Context, containerID string, timeout *int) error
+	Pause(ctx context.Context, containerID string) error
+	Unpause(ctx context.Context, containerID string) error
+	Kill(ctx context.Context, containerID string, signal string) error
+	Rename(ctx context.Context, containerID string, name string) error
 	Delete(ctx context.Context, containerID string) error
+	Commit(ctx context.Context, containerID string, imageName string, comment string) (string, error)
+	Logs(ctx context.Context, containerID string, options container.LogsOptions) (io.ReadCloser, error)
+	CopyFrom(ctx context.Context, containerID string, srcPath string) (io.ReadCloser, container.PathStat, error)
+	CopyTo(ctx context.Context, containerID string, dstPath string, archive io.Reader) error
+	ExecCreate(ctx context.Context, containerID string, options container.ExecOptions) (string, error)
+	ExecAttach(ctx context.Context, execID string, options container.ExecAttachOptions) (types.HijackedResponse, error)
+	ExecResize(ctx context.Context, execID string, height uint, width uint) error
+	ExecInspect(ctx context.Context, execID string) (container.ExecInspect, error)
+	Stats(ctx context.Context, containerID string) (*container.StatsResponse, error)
+	Events(ctx context.Context) (<-chan events.Message, <-chan error)
+	ListImages(ctx context.Context) ([]image.Summary, error)
+	InspectImage(ctx context.Context, imageName string) (image.InspectResponse, error)
+	Pull(ctx context.Context, imageName string) (io.ReadCloser, error)
+	RemoveImage(ctx context.Context, imageName string, force bool) ([]image.DeleteResponse, error)
+	PruneImages(ctx context.Context, all bool) (image.PruneReport, error)
+}
+
+// ICredentialStore resolves the encoded auth docker sends to a registry, empty when the registry needs none.
+type ICredentialStore interface {
+	RegistryAuth(ctx context.Context, registry string) (string, error)
 }
 
 type DockerClient struct {
-	client *client.Client
+	client          *client.Client
+	credentialStore ICredentialStore
 }
 
-func NewDockerClient() (IDockerClient, error) {
+func NewDockerClient(credentialStore ICredentialStore) (IDockerClient, error) {
 	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
 	if err != nil {
 		return nil, err
 	}
 	return &DockerClient{
-		client: cli,
+		client:          cli,
+		credentialStore: credentialStore,
 	}, nil
 }
 
-func (c *DockerClient) Create(ctx context.Context, name string, imageName string) (*container.CreateResponse, error) {
+func (c *DockerClient) Create(ctx context.Context, name string, imageName string, spec entities.ContainerSpec) (*container.CreateResponse, error) {
+	exposedPorts, portBindings, err := toPortMap(spec.Ports)
+	if err != nil {
+		return nil, err
+	}
+
+	// Images only known to the daemon, such as snapshots, cannot be pulled but are fine to run.
 	if err := c.PullImage(ctx, imageName); err != nil {
-		return nil, fmt.Errorf("failed to pull image: %w", err)
+		if _, inspectErr := c.client.ImageInspect(ctx, imageName); inspectErr != nil {
+			return nil, fmt.Errorf("failed to pull image: %w", err)
+		}
 	}
 
-	con, err := c.client.ContainerCreate(ctx, &container.Config{
-		Image: imageName,
-	}, nil, nil, nil, name)
+	config := &container.Config{
+		Image:        imageName,
+		Cmd:          spec.Command,
+		Entrypoint:   spec.Entrypoint,
+		Env:          spec.Env,
+		Labels:       toLabels(spec.Labels),
+		ExposedPorts: exposedPorts,
+	}
+	hostConfig := &container.HostConfig{
+		PortBindings: portBindings,
+		Mounts:       toMounts(spec.Volumes),
+		RestartPolicy: container.RestartPolicy{
+			Name:              container.RestartPolicyMode(spec.RestartPolicy.Name),
+			MaximumRetryCount: spec.RestartPolicy.MaximumRetryCount,
+		},
+		Resources: toResources(spec.Resources),
+	}
+
+	con, err := c.client.ContainerCreate(ctx, config, hostConfig, nil, nil, name)
 	return &con, err
 }
 
@@ -49,19 +117,19 @@ func (c *DockerClient) Start(ctx context.Context, containerId string) error {
 	return c.client.ContainerStart(ctx, containerId, container.StartOptions{})
 }
 
+func (c *DockerClient) List(ctx context.Context) ([]container.Summary, error) {
+	return c.client.ContainerList(ctx, container.ListOptions{All: true})
+}
+
 func (c *DockerClient) GetStatus(ctx context.Context, containerId string) entities.ContainerStatus {
 	inspect, err := c.client.ContainerInspect(ctx, containerId)
-	if err != nil {
-		return entities.ContainerOff
+	if errdefs.IsNotFound(err) {
+		return entities.ContainerMissing
 	}
-
-	var status entities.ContainerStatus
-	if inspect.State.Running {
-		status = entities.ContainerOn
-	} else {
-		status = entities.ContainerOff
+	if err != nil {
+		return entities.ContainerUnknown
 	}
-	return status
+	return toStatus(inspect.State)
 }
 
 func (c *DockerClient) GetIpv4(ctx context.Context, containerId string) string {
@@ -78,18 +146,190 @@ func (c *DockerClient) GetIpv4(ctx context.Context, containerId string) string {
 	return ""
 }
 
+func (c *DockerClient) Inspect(ctx context.Context, containerId string) (container.InspectResponse, error) {
+	return c.client.ContainerInspect(ctx, containerId)
+}
+
+// Top lists the processes running in the container, as ps shows them by default.
+func (c *DockerClient) Top(ctx context.Context, containerId string) (container.TopResponse, error) {
+	return c.client.ContainerTop(ctx, containerId, nil)
+}
+
 func (c *DockerClient) Stop(ctx context.Context, containerId string) error {
 	return c.client.ContainerStop(ctx, containerId, container.StopOptions{})
 }
 
+func (c *DockerClient) Restart(ctx context.Context, containerId string, timeout *int) error {
+	return c.client.ContainerRestart(ctx, containerId, container.StopOptions{
+		Timeout: timeout,
+	})
+}
+
+func (c *DockerClient) Pause(ctx context.Context, containerId string) error {
+	return c.client.ContainerPause(ctx, containerId)
+}
+
+func (c *DockerClient) Unpause(ctx context.Context, containerId string) error {
+	return c.client.ContainerUnpause(ctx, containerId)
+}
+
+func (c *DockerClient) Kill(ctx context.Context, containerId string, signal string) error {
+	return c.client.ContainerKill(ctx, containerId, signal)
+}
+
+func (c *DockerClient) Rename(ctx context.Context, containerId string, name string) error {
+	return c.client.ContainerRename(ctx, containerId, name)
+}
+
 func (c *DockerClient) Delete(ctx context.Context, containerId string) error {
 	return c.client.ContainerRemove(ctx, containerId, container.RemoveOptions{
 		Force: true,
 	})
 }
 
+// Commit saves the filesystem and config of the container as a new image tagged imageName, pausing it meanwhile,
+// and returns the ID of the image.
+func (c *DockerClient) Commit(ctx context.Context, containerId string, imageName string, comment string) (string, error) {
+	res, err := c.client.ContainerCommit(ctx, containerId, container.CommitOptions{
+		Reference: imageName,
+		Comment:   comment,
+		Pause:     true,
+	})
+	return res.ID, err
+}
+
+func (c *DockerClient) Logs(ctx context.Context, containerId string, options container.LogsOptions) (io.ReadCloser, error) {
+	inspect, err := c.client.ContainerInspect(ctx, containerId)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := c.client.ContainerLogs(ctx, containerId, options)
+	if err != nil {
+		return nil, err
+	}
+	if inspect.Config != nil && inspect.Config.Tty {
+		return logs, nil
+	}
+	return demuxLogs(logs), nil
+}
+
+// CopyFrom streams a tar archive of the file or directory at srcPath in the container, along with what it is.
+func (c *DockerClient) CopyFrom(ctx context.Context, containerId string, srcPath string) (io.ReadCloser, container.PathStat, error) {
+	srcPath, err := CleanPath(srcPath)
+	if err != nil {
+		return nil, container.PathStat{}, err
+	}
+	return c.client.CopyFromContainer(ctx, containerId, srcPath)
+}
+
+// CopyTo extracts a tar archive into the directory at dstPath in the container, never replacing a directory with a file.
+func (c *DockerClient) CopyTo(ctx context.Context, containerId string, dstPath string, archive io.Reader) error {
+	dstPath, err := CleanPath(dstPath)
+	if err != nil {
+		return err
+	}
+	return c.client.CopyToContainer(ctx, containerId, dstPath, archive, container.CopyToContainerOptions{})
+}
+
+func (c *DockerClient) ExecCreate(ctx context.Context, containerId string, options container.ExecOptions) (string, error) {
+	resp, err := c.client.ContainerExecCreate(ctx, containerId, options)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+func (c *DockerClient) ExecAttach(ctx context.Context, execId string, options container.ExecAttachOptions) (types.HijackedResponse, error) {
+	return c.client.ContainerExecAttach(ctx, execId, options)
+}
+
+func (c *DockerClient) ExecResize(ctx context.Context, execId string, height uint, width uint) error {
+	return c.client.ContainerExecResize(ctx, execId, container.ResizeOptions{
+		Height: height,
+		Width:  width,
+	})
+}
+
+func (c *DockerClient) ExecInspect(ctx context.Context, execId string) (container.ExecInspect, error) {
+	return c.client.ContainerExecInspect(ctx, execId)
+}
+
+func (c *DockerClient) Stats(ctx context.Context, containerId string) (*container.StatsResponse, error) {
+	resp, err := c.client.ContainerStats(ctx, containerId, false)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var stats container.StatsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// Events subscribes to the container events that may change a container status.
+func (c *DockerClient) Events(ctx context.Context) (<-chan events.Message, <-chan error) {
+	return c.client.Events(ctx, events.ListOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("type", string(events.ContainerEventType)),
+			filters.Arg("event", string(events.ActionStart)),
+			filters.Arg("event", string(events.ActionDie)),
+			filters.Arg("event", string(events.ActionStop)),
+			filters.Arg("event", string(events.ActionOOM)),
+			filters.Arg("event", string(events.ActionHealthStatus)),
+			filters.Arg("event", string(events.ActionPause)),
+			filters.Arg("event", string(events.ActionUnPause)),
+			filters.Arg("event", string(events.ActionDestroy)),
+		),
+	})
+}
+
+func (c *DockerClient) ListImages(ctx context.Context) ([]image.Summary, error) {
+	return c.client.ImageList(ctx, image.ListOptions{})
+}
+
+func (c *DockerClient) InspectImage(ctx context.Context, imageName string) (image.InspectResponse, error) {
+	return c.client.ImageInspect(ctx, imageName)
+}
+
+// Pull starts pulling an image and returns the JSON progress stream, the pull is over once it is drained.
+// Pull authenticates against the registry hosting the image when the credential store knows it.
+func (c *DockerClient) Pull(ctx context.Context, imageName string) (io.ReadCloser, error) {
+	auth, err := c.registryAuth(ctx, imageName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve registry credentials: %w", err)
+	}
+	return c.client.ImagePull(ctx, imageName, image.PullOptions{RegistryAuth: auth})
+}
+
+func (c *DockerClient) RemoveImage(ctx context.Context, imageName string, force bool) ([]image.DeleteResponse, error) {
+	return c.client.ImageRemove(ctx, imageName, image.RemoveOptions{
+		Force:         force,
+		PruneChildren: true,
+	})
+}
+
+// PruneImages removes dangling images, or every image not used by a container when all is set.
+func (c *DockerClient) PruneImages(ctx context.Context, all bool) (image.PruneReport, error) {
+	return c.client.ImagesPrune(ctx, filters.NewArgs(filters.Arg("dangling", strconv.FormatBool(!all))))
+}
+
+func (c *DockerClient) registryAuth(ctx context.Context, imageName string) (string, error) {
+	if c.credentialStore == nil {
+		return "", nil
+	}
+	named, err := reference.ParseNormalizedNamed(imageName)
+	if err != nil {
+		// Leave the malformed reference for the daemon to reject.
+		return "", nil
+	}
+	return c.credentialStore.RegistryAuth(ctx, reference.Domain(named))
+}
+
 func (c *DockerClient) PullImage(ctx context.Context, refStr string) error {
-	resp, err := c.client.ImagePull(ctx, refStr, image.PullOptions{})
+	resp, err := c.Pull(ctx, refStr)
 	if err != nil {
 		return fmt.Errorf("failed to pull image: %w", err)
 	}
@@ -97,3 +337,112 @@ func (c *DockerClient) PullImage(ctx context.Context, refStr string) error {
 	_, err = io.Copy(io.Discard, resp)
 	return err
 }
+
+type demuxReader struct {
+	*io.PipeReader
+	logs io.ReadCloser
+}
+
+func (r *demuxReader) Close() error {
+	r.PipeReader.Close()
+	return r.logs.Close()
+}
+
+// demuxLogs merges the multiplexed stdout/stderr frames of a non-TTY container into a plain stream.
+func demuxLogs(logs io.ReadCloser) io.ReadCloser {
+	reader, writer := io.Pipe()
+	go func() {
+		_, err := stdcopy.StdCopy(writer, writer, logs)
+		writer.CloseWithError(err)
+	}()
+	return &demuxReader{reader, logs}
+}
+
+// toStatus maps the inspected state to a status, letting the healthcheck result refine a running container.
+func toStatus(state *container.State) entities.ContainerStatus {
+	switch {
+	case state == nil:
+		return entities.ContainerUnknown
+	case state.Paused:
+		return entities.ContainerPaused
+	case state.Restarting:
+		return entities.ContainerRestarting
+	case state.Running:
+		if state.Health == nil {
+			return entities.ContainerOn
+		}
+		switch state.Health.Status {
+		case container.Starting:
+			return entities.ContainerStarting
+		case container.Healthy:
+			return entities.ContainerHealthy
+		case container.Unhealthy:
+			return entities.ContainerUnhealthy
+		}
+		return entities.ContainerOn
+	case state.Dead || state.OOMKilled || state.ExitCode != 0:
+		return entities.ContainerExited
+	default:
+		return entities.ContainerOff
+	}
+}
+
+func toLabels(labels map[string]string) map[string]string {
+	res := maps.Clone(labels)
+	if res == nil {
+		res = map[string]string{}
+	}
+	res[ManagedLabel] = "true"
+	return res
+}
+
+func toPortMap(ports []entities.PortBinding) (nat.PortSet, nat.PortMap, error) {
+	exposedPorts := nat.PortSet{}
+	portBindings := nat.PortMap{}
+	for _, p := range ports {
+		protocol := p.Protocol
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		port, err := nat.NewPort(protocol, strconv.Itoa(p.ContainerPort))
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid port %d/%s: %w", p.ContainerPort, protocol, err)
+		}
+		exposedPorts[port] = struct{}{}
+		if p.HostPort == 0 {
+			continue
+		}
+		portBindings[port] = append(portBindings[port], nat.PortBinding{
+			HostIP:   p.HostIp,
+			HostPort: strconv.Itoa(p.HostPort),
+		})
+	}
+	return exposedPorts, portBindings, nil
+}
+
+func toMounts(volumes []entities.VolumeMount) []mount.Mount {
+	mounts := make([]mount.Mount, 0, len(volumes))
+	for _, v := range volumes {
+		mounts = append(mounts, mount.Mount{
+			Type:     mount.Type(v.Type),
+			Source:   v.Source,
+			Target:   v.Target,
+			ReadOnly: v.ReadOnly,
+		})
+	}
+	return mounts
+}
+
+func toResources(resources entities.Resources) container.Resources {
+	res := container.Resources{
+		CPUShares: resources.CpuShares,
+		CPUPeriod: resources.CpuPeriod,
+		CPUQuota:  resources.CpuQuota,
+		Memory:    resources.Memory,
+	}
+	if resources.PidsLimit > 0 {
+		pidsLimit := resources.PidsLimit
+		res.PidsLimit = &pidsLimit
+	}
+	return res
+}
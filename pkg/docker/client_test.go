@@ -1,10 +1,14 @@
 package docker
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"strings"
 	"testing"
 
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/stretchr/testify/suite"
 	"github.com/vnFuhung2903/vcs-sms/entities"
 )
@@ -18,7 +22,7 @@ type DockerClientSuite struct {
 func (suite *DockerClientSuite) SetupTest() {
 	suite.ctx = context.Background()
 
-	client, err := NewDockerClient()
+	client, err := NewDockerClient(nil)
 	suite.client = client
 	suite.NoError(err)
 }
@@ -28,7 +32,7 @@ func TestDockerClientSuite(t *testing.T) {
 }
 
 func (suite *DockerClientSuite) TestContainerOnLifeCycle() {
-	con, err := suite.client.Create(suite.ctx, "test-container", "nginx:stable-alpine-perl")
+	con, err := suite.client.Create(suite.ctx, "test-container", "nginx:stable-alpine-perl", entities.ContainerSpec{})
 	suite.NoError(err)
 
 	err = suite.client.Start(suite.ctx, con.ID)
@@ -39,6 +43,13 @@ func (suite *DockerClientSuite) TestContainerOnLifeCycle() {
 	ipv4 := suite.client.GetIpv4(suite.ctx, con.ID)
 	suite.NotEqual("", ipv4)
 
+	inspect, err := suite.client.Inspect(suite.ctx, con.ID)
+	suite.NoError(err)
+	suite.True(inspect.State.Running)
+	top, err := suite.client.Top(suite.ctx, con.ID)
+	suite.NoError(err)
+	suite.NotEmpty(top.Processes)
+
 	err = suite.client.Stop(suite.ctx, con.ID)
 	suite.NoError(err)
 
@@ -47,7 +58,7 @@ func (suite *DockerClientSuite) TestContainerOnLifeCycle() {
 }
 
 func (suite *DockerClientSuite) TestContainerOffLifeCycle() {
-	con, err := suite.client.Create(suite.ctx, "test-container", "nginx:stable-alpine-perl")
+	con, err := suite.client.Create(suite.ctx, "test-container", "nginx:stable-alpine-perl", entities.ContainerSpec{})
 	suite.NoError(err)
 
 	status := suite.client.GetStatus(suite.ctx, con.ID)
@@ -55,6 +66,12 @@ func (suite *DockerClientSuite) TestContainerOffLifeCycle() {
 	ipv4 := suite.client.GetIpv4(suite.ctx, con.ID)
 	suite.Equal("", ipv4)
 
+	err = suite.client.Rename(suite.ctx, con.ID, "test-container-renamed")
+	suite.NoError(err)
+	inspect, err := suite.client.Inspect(suite.ctx, con.ID)
+	suite.NoError(err)
+	suite.Equal("/test-container-renamed", inspect.Name)
+
 	err = suite.client.Delete(suite.ctx, con.ID)
 	suite.NoError(err)
 }
@@ -66,14 +83,54 @@ func (suite *DockerClientSuite) TestPullImageInvalidImage() {
 	suite.Contains(strings.ToLower(err.Error()), "failed to pull image")
 }
 
+func (suite *DockerClientSuite) TestImageLifeCycle() {
+	progress, err := suite.client.Pull(suite.ctx, "busybox:stable")
+	suite.NoError(err)
+	_, err = io.Copy(io.Discard, progress)
+	suite.NoError(err)
+	progress.Close()
+
+	images, err := suite.client.ListImages(suite.ctx)
+	suite.NoError(err)
+	suite.NotEmpty(images)
+
+	inspect, err := suite.client.InspectImage(suite.ctx, "busybox:stable")
+	suite.NoError(err)
+	suite.Contains(inspect.RepoTags, "busybox:stable")
+
+	_, err = suite.client.RemoveImage(suite.ctx, "busybox:stable", false)
+	suite.NoError(err)
+
+	_, err = suite.client.PruneImages(suite.ctx, false)
+	suite.NoError(err)
+}
+
+func (suite *DockerClientSuite) TestInspectImageNonExistent() {
+	_, err := suite.client.InspectImage(suite.ctx, "invalid/non-existent-image:invalid-tag")
+	suite.Error(err)
+}
+
 func (suite *DockerClientSuite) TestCreateContainerInvalidImage() {
-	_, err := suite.client.Create(suite.ctx, "test-container", "invalid/non-existent-image")
+	_, err := suite.client.Create(suite.ctx, "test-container", "invalid/non-existent-image", entities.ContainerSpec{})
 	suite.Error(err)
 }
 
 func (suite *DockerClientSuite) TestGetStatusNonExistentContainer() {
 	status := suite.client.GetStatus(suite.ctx, "non-existent-container-id")
-	suite.Equal(entities.ContainerOff, status)
+	suite.Equal(entities.ContainerMissing, status)
+}
+
+func (suite *DockerClientSuite) TestToStatus() {
+	suite.Equal(entities.ContainerUnknown, toStatus(nil))
+	suite.Equal(entities.ContainerOn, toStatus(&container.State{Running: true}))
+	suite.Equal(entities.ContainerStarting, toStatus(&container.State{Running: true, Health: &container.Health{Status: container.Starting}}))
+	suite.Equal(entities.ContainerHealthy, toStatus(&container.State{Running: true, Health: &container.Health{Status: container.Healthy}}))
+	suite.Equal(entities.ContainerUnhealthy, toStatus(&container.State{Running: true, Health: &container.Health{Status: container.Unhealthy}}))
+	suite.Equal(entities.ContainerPaused, toStatus(&container.State{Running: true, Paused: true}))
+	suite.Equal(entities.ContainerRestarting, toStatus(&container.State{Running: true, Restarting: true}))
+	suite.Equal(entities.ContainerExited, toStatus(&container.State{ExitCode: 137}))
+	suite.Equal(entities.ContainerExited, toStatus(&container.State{Dead: true}))
+	suite.Equal(entities.ContainerOff, toStatus(&container.State{}))
 }
 
 func (suite *DockerClientSuite) TestGetIpv4NonExistentContainer() {
@@ -81,6 +138,14 @@ func (suite *DockerClientSuite) TestGetIpv4NonExistentContainer() {
 	suite.Equal("", ipv4)
 }
 
+func (suite *DockerClientSuite) TestInspectNonExistentContainer() {
+	_, err := suite.client.Inspect(suite.ctx, "non-existent-container-id")
+	suite.Error(err)
+
+	_, err = suite.client.Top(suite.ctx, "non-existent-container-id")
+	suite.Error(err)
+}
+
 func (suite *DockerClientSuite) TestStartNonExistentContainer() {
 	err := suite.client.Start(suite.ctx, "non-existent-container-id")
 	suite.Error(err)
@@ -91,7 +156,129 @@ func (suite *DockerClientSuite) TestStopNonExistentContainer() {
 	suite.Error(err)
 }
 
+func (suite *DockerClientSuite) TestActionsNonExistentContainer() {
+	suite.Error(suite.client.Restart(suite.ctx, "nonexistent-container", nil))
+	suite.Error(suite.client.Pause(suite.ctx, "nonexistent-container"))
+	suite.Error(suite.client.Unpause(suite.ctx, "nonexistent-container"))
+	suite.Error(suite.client.Kill(suite.ctx, "nonexistent-container", "SIGKILL"))
+}
+
 func (suite *DockerClientSuite) TestDeleteNonExistentContainer() {
 	err := suite.client.Delete(suite.ctx, "non-existent-container-id")
 	suite.T().Logf("Delete non-existent container result: %v", err)
 }
+
+func (suite *DockerClientSuite) TestCommitNonExistentContainer() {
+	_, err := suite.client.Commit(suite.ctx, "nonexistent-container", "vcs-sms-snapshots/nonexistent:test", "")
+	suite.Error(err)
+}
+
+func (suite *DockerClientSuite) TestList() {
+	con, err := suite.client.Create(suite.ctx, "test-container", "nginx:stable-alpine-perl", entities.ContainerSpec{})
+	suite.NoError(err)
+
+	containers, err := suite.client.List(suite.ctx)
+	suite.NoError(err)
+	labels := map[string]map[string]string{}
+	for _, c := range containers {
+		labels[c.ID] = c.Labels
+	}
+	suite.Equal("true", labels[con.ID][ManagedLabel])
+
+	err = suite.client.Delete(suite.ctx, con.ID)
+	suite.NoError(err)
+}
+
+func (suite *DockerClientSuite) TestToLabels() {
+	labels := map[string]string{"app": "web"}
+	suite.Equal(map[string]string{"app": "web", ManagedLabel: "true"}, toLabels(labels))
+	suite.Equal(map[string]string{"app": "web"}, labels)
+	suite.Equal(map[string]string{ManagedLabel: "true"}, toLabels(nil))
+}
+
+type credentialStoreStub map[string]string
+
+func (s credentialStoreStub) RegistryAuth(ctx context.Context, registry string) (string, error) {
+	return s[registry], nil
+}
+
+func (suite *DockerClientSuite) TestRegistryAuth() {
+	client := &DockerClient{credentialStore: credentialStoreStub{"ghcr.io": "ghcr-auth", "docker.io": "hub-auth"}}
+
+	auth, err := client.registryAuth(suite.ctx, "ghcr.io/org/app:1.0")
+	suite.NoError(err)
+	suite.Equal("ghcr-auth", auth)
+
+	auth, err = client.registryAuth(suite.ctx, "nginx:alpine")
+	suite.NoError(err)
+	suite.Equal("hub-auth", auth)
+
+	auth, err = client.registryAuth(suite.ctx, "registry.example.com:5000/app")
+	suite.NoError(err)
+	suite.Empty(auth)
+
+	auth, err = client.registryAuth(suite.ctx, "INVALID")
+	suite.NoError(err)
+	suite.Empty(auth)
+
+	auth, err = (&DockerClient{}).registryAuth(suite.ctx, "ghcr.io/org/app")
+	suite.NoError(err)
+	suite.Empty(auth)
+}
+
+func (suite *DockerClientSuite) TestToPortMap() {
+	exposed, bindings, err := toPortMap([]entities.PortBinding{
+		{ContainerPort: 80, HostPort: 8080},
+		{ContainerPort: 53, Protocol: "udp"},
+	})
+	suite.NoError(err)
+	suite.Len(exposed, 2)
+	suite.Len(bindings, 1)
+	suite.Equal("8080", bindings["80/tcp"][0].HostPort)
+}
+
+func (suite *DockerClientSuite) TestToMounts() {
+	mounts := toMounts([]entities.VolumeMount{{Type: "bind", Source: "/data", Target: "/data", ReadOnly: true}})
+	suite.Len(mounts, 1)
+	suite.Equal("/data", mounts[0].Target)
+	suite.True(mounts[0].ReadOnly)
+}
+
+func (suite *DockerClientSuite) TestToResources() {
+	res := toResources(entities.Resources{CpuShares: 512, Memory: 256 << 20, PidsLimit: 100})
+	suite.Equal(int64(512), res.CPUShares)
+	suite.Equal(int64(256<<20), res.Memory)
+	suite.Equal(int64(100), *res.PidsLimit)
+
+	res = toResources(entities.Resources{})
+	suite.Nil(res.PidsLimit)
+}
+
+func (suite *DockerClientSuite) TestLogsNonExistentContainer() {
+	_, err := suite.client.Logs(suite.ctx, "nonexistent-container", container.LogsOptions{ShowStdout: true})
+	suite.Error(err)
+}
+
+func (suite *DockerClientSuite) TestStatsNonExistentContainer() {
+	_, err := suite.client.Stats(suite.ctx, "nonexistent-container")
+	suite.Error(err)
+}
+
+func (suite *DockerClientSuite) TestEventsCanceled() {
+	ctx, cancel := context.WithCancel(suite.ctx)
+	cancel()
+	_, errs := suite.client.Events(ctx)
+	suite.Error(<-errs)
+}
+
+func (suite *DockerClientSuite) TestToDemuxLogs() {
+	var buf bytes.Buffer
+	stdcopy.NewStdWriter(&buf, stdcopy.Stdout).Write([]byte("out line\n"))
+	stdcopy.NewStdWriter(&buf, stdcopy.Stderr).Write([]byte("err line\n"))
+
+	logs := demuxLogs(io.NopCloser(&buf))
+	data, err := io.ReadAll(logs)
+	suite.NoError(err)
+	suite.Equal("out line\nerr line\n", string(data))
+	suite.NoError(logs.Close())
+}
@@ -2,6 +2,9 @@ package env
 
 import (
 	"errors"
+	"path/filepath"
+	"slices"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -10,15 +13,40 @@ type AuthEnv struct {
 	JWTSecret string `mapstructure:"JWT_SECRET_KEY"`
 }
 
+type ContainerEnv struct {
+	// BindSources are the host directories bind mounts may be taken from, no bind mount being allowed when empty.
+	BindSources []string `mapstructure:"CONTAINER_BIND_SOURCES"`
+}
+
 type ElasticsearchEnv struct {
 	ElasticsearchAddress string `mapstructure:"ELASTICSEARCH_ADDRESS"`
 }
 
+type ExpiryEnv struct {
+	Notice time.Duration `mapstructure:"EXPIRY_NOTICE"`
+}
+
+type FilesEnv struct {
+	MaxDownloadSize int64 `mapstructure:"FILES_MAX_DOWNLOAD_SIZE"`
+	MaxUploadSize   int64 `mapstructure:"FILES_MAX_UPLOAD_SIZE"`
+}
+
 type GomailEnv struct {
 	MailUsername string `mapstructure:"MAIL_USERNAME"`
 	MailPassword string `mapstructure:"MAIL_PASSWORD"`
 }
 
+type ImageEnv struct {
+	AllowedRegistries []string `mapstructure:"IMAGE_ALLOWED_REGISTRIES"`
+	DeniedTags        []string `mapstructure:"IMAGE_DENIED_TAGS"`
+	RequireDigest     bool     `mapstructure:"IMAGE_REQUIRE_DIGEST"`
+}
+
+type MigrationEnv struct {
+	// DefaultOwner is the username given the containers recorded before containers had owners, left unowned when empty.
+	DefaultOwner string `mapstructure:"MIGRATION_DEFAULT_OWNER"`
+}
+
 type PostgresEnv struct {
 	PostgresHost     string `mapstructure:"POSTGRES_HOST"`
 	PostgresUser     string `mapstructure:"POSTGRES_USER"`
@@ -27,6 +55,23 @@ type PostgresEnv struct {
 	PostgresPort     string `mapstructure:"POSTGRES_PORT"`
 }
 
+type ReconcileEnv struct {
+	ManagedOnly     bool          `mapstructure:"RECONCILE_MANAGED_ONLY"`
+	UntrackedPolicy string        `mapstructure:"RECONCILE_UNTRACKED_POLICY"`
+	MissingPolicy   string        `mapstructure:"RECONCILE_MISSING_POLICY"`
+	GracePeriod     time.Duration `mapstructure:"RECONCILE_GRACE_PERIOD"`
+	// AdoptOwner is the username given the containers adopted from the daemon, required by the adopt policy.
+	AdoptOwner string `mapstructure:"RECONCILE_ADOPT_OWNER"`
+}
+
+type RegistryEnv struct {
+	SecretKey string `mapstructure:"REGISTRY_SECRET_KEY"`
+}
+
+type TrashEnv struct {
+	Retention time.Duration `mapstructure:"TRASH_RETENTION"`
+}
+
 type RedisEnv struct {
 	RedisAddress  string `mapstructure:"REDIS_ADDRESS"`
 	RedisPassword string `mapstructure:"REDIS_PASSWORD"`
@@ -43,10 +88,18 @@ type LoggerEnv struct {
 
 type Env struct {
 	AuthEnv          AuthEnv
+	ContainerEnv     ContainerEnv
 	GomailEnv        GomailEnv
 	ElasticsearchEnv ElasticsearchEnv
+	ExpiryEnv        ExpiryEnv
+	FilesEnv         FilesEnv
+	ImageEnv         ImageEnv
+	MigrationEnv     MigrationEnv
 	PostgresEnv      PostgresEnv
+	ReconcileEnv     ReconcileEnv
+	RegistryEnv      RegistryEnv
 	RedisEnv         RedisEnv
+	TrashEnv         TrashEnv
 	LoggerEnv        LoggerEnv
 }
 
@@ -56,15 +109,30 @@ func LoadEnv(path string) (*Env, error) {
 	v.SetConfigName(".env")
 	v.SetConfigType("env")
 
+	v.SetDefault("CONTAINER_BIND_SOURCES", []string{})
 	v.SetDefault("ELASTICSEARCH_ADDRESS", "http://localhost:9200")
+	v.SetDefault("EXPIRY_NOTICE", "1h")
+	v.SetDefault("FILES_MAX_DOWNLOAD_SIZE", 100<<20)
+	v.SetDefault("FILES_MAX_UPLOAD_SIZE", 10<<20)
+	v.SetDefault("IMAGE_ALLOWED_REGISTRIES", []string{})
+	v.SetDefault("IMAGE_DENIED_TAGS", []string{})
+	v.SetDefault("IMAGE_REQUIRE_DIGEST", false)
+	v.SetDefault("MIGRATION_DEFAULT_OWNER", "")
 	v.SetDefault("POSTGRES_HOST", "localhost")
 	v.SetDefault("POSTGRES_USER", "postgres")
 	v.SetDefault("POSTGRES_PASSWORD", "postgres")
 	v.SetDefault("POSTGRES_NAME", "postgres")
 	v.SetDefault("POSTGRES_PORT", "5432")
+	v.SetDefault("RECONCILE_MANAGED_ONLY", true)
+	v.SetDefault("RECONCILE_UNTRACKED_POLICY", "ignore")
+	v.SetDefault("RECONCILE_MISSING_POLICY", "orphan")
+	v.SetDefault("RECONCILE_GRACE_PERIOD", "5m")
+	v.SetDefault("RECONCILE_ADOPT_OWNER", "")
+	v.SetDefault("REGISTRY_SECRET_KEY", "")
 	v.SetDefault("REDIS_ADDRESS", "localhost:6379")
 	v.SetDefault("REDIS_PASSWORD", "")
 	v.SetDefault("REDIS_DB", 0)
+	v.SetDefault("TRASH_RETENTION", "168h")
 	v.SetDefault("ZAP_LEVEL", "info")
 	v.SetDefault("ZAP_FILEPATH", "./logs/app.log")
 	v.SetDefault("ZAP_MAXSIZE", 100)
@@ -78,41 +146,90 @@ func LoadEnv(path string) (*Env, error) {
 	}
 
 	var authEnv AuthEnv
+	var containerEnv ContainerEnv
 	var elasticsearchEnv ElasticsearchEnv
+	var expiryEnv ExpiryEnv
+	var filesEnv FilesEnv
 	var gomailEnv GomailEnv
+	var imageEnv ImageEnv
 	var loggerEnv LoggerEnv
+	var migrationEnv MigrationEnv
 	var postgresEnv PostgresEnv
+	var reconcileEnv ReconcileEnv
+	var registryEnv RegistryEnv
 	var redisEnv RedisEnv
+	var trashEnv TrashEnv
 
 	if err := v.Unmarshal(&authEnv); err != nil || authEnv.JWTSecret == "" {
 		err = errors.New("auth environment variables are empty")
 		return nil, err
 	}
+	if err := v.Unmarshal(&containerEnv); err != nil || slices.ContainsFunc(containerEnv.BindSources, func(source string) bool { return !filepath.IsAbs(source) }) {
+		err = errors.New("container environment variables are invalid")
+		return nil, err
+	}
 	if err := v.Unmarshal(&elasticsearchEnv); err != nil || elasticsearchEnv.ElasticsearchAddress == "" {
 		err = errors.New("elasticsearch environment variables are empty")
 		return nil, err
 	}
+	if err := v.Unmarshal(&expiryEnv); err != nil || expiryEnv.Notice <= 0 {
+		err = errors.New("expiry environment variables are invalid")
+		return nil, err
+	}
+	if err := v.Unmarshal(&filesEnv); err != nil || filesEnv.MaxDownloadSize <= 0 || filesEnv.MaxUploadSize <= 0 {
+		err = errors.New("files environment variables are invalid")
+		return nil, err
+	}
 	if err := v.Unmarshal(&gomailEnv); err != nil || gomailEnv.MailUsername == "" {
 		err = errors.New("gomail environment variables are empty")
 		return nil, err
 	}
+	if err := v.Unmarshal(&imageEnv); err != nil {
+		return nil, err
+	}
 	if err := v.Unmarshal(&loggerEnv); err != nil {
 		return nil, err
 	}
+	if err := v.Unmarshal(&migrationEnv); err != nil {
+		return nil, err
+	}
 	if err := v.Unmarshal(&postgresEnv); err != nil || postgresEnv.PostgresUser == "" || postgresEnv.PostgresName == "" || postgresEnv.PostgresHost == "" || postgresEnv.PostgresPort == "" {
 		err = errors.New("posgres environment variables are empty")
 		return nil, err
 	}
+	if err := v.Unmarshal(&reconcileEnv); err != nil ||
+		!slices.Contains([]string{"ignore", "adopt", "delete"}, reconcileEnv.UntrackedPolicy) ||
+		!slices.Contains([]string{"ignore", "orphan", "delete"}, reconcileEnv.MissingPolicy) ||
+		reconcileEnv.GracePeriod < 0 ||
+		(reconcileEnv.UntrackedPolicy == "adopt" && reconcileEnv.AdoptOwner == "") {
+		err = errors.New("reconcile environment variables are invalid")
+		return nil, err
+	}
+	if err := v.Unmarshal(&registryEnv); err != nil {
+		return nil, err
+	}
 	if err := v.Unmarshal(&redisEnv); err != nil || redisEnv.RedisAddress == "" {
 		err = errors.New("redis environment variables are empty")
 		return nil, err
 	}
+	if err := v.Unmarshal(&trashEnv); err != nil || trashEnv.Retention <= 0 {
+		err = errors.New("trash environment variables are invalid")
+		return nil, err
+	}
 	return &Env{
 		AuthEnv:          authEnv,
+		ContainerEnv:     containerEnv,
 		ElasticsearchEnv: elasticsearchEnv,
+		ExpiryEnv:        expiryEnv,
+		FilesEnv:         filesEnv,
 		GomailEnv:        gomailEnv,
+		ImageEnv:         imageEnv,
+		MigrationEnv:     migrationEnv,
 		PostgresEnv:      postgresEnv,
+		ReconcileEnv:     reconcileEnv,
+		RegistryEnv:      registryEnv,
 		RedisEnv:         redisEnv,
+		TrashEnv:         trashEnv,
 		LoggerEnv:        loggerEnv,
 	}, nil
 }
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -30,11 +31,25 @@ func TestViperSuite(t *testing.T) {
 func (suite *ViperSuite) SetupTest() {
 	envVars := []string{
 		"JWT_SECRET_KEY",
+		"CONTAINER_BIND_SOURCES",
+		"EXPIRY_NOTICE",
+		"FILES_MAX_DOWNLOAD_SIZE",
+		"FILES_MAX_UPLOAD_SIZE",
 		"MAIL_USERNAME",
 		"MAIL_PASSWORD",
+		"IMAGE_ALLOWED_REGISTRIES",
+		"IMAGE_DENIED_TAGS",
+		"IMAGE_REQUIRE_DIGEST",
+		"MIGRATION_DEFAULT_OWNER",
 		"POSTGRES_USER",
 		"POSTGRES_PASSWORD",
 		"POSTGRES_NAME",
+		"RECONCILE_MANAGED_ONLY",
+		"RECONCILE_UNTRACKED_POLICY",
+		"RECONCILE_MISSING_POLICY",
+		"RECONCILE_GRACE_PERIOD",
+		"RECONCILE_ADOPT_OWNER",
+		"TRASH_RETENTION",
 		"ZAP_LEVEL",
 		"ZAP_FILEPATH",
 		"ZAP_MAXSIZE",
@@ -55,18 +70,33 @@ func (suite *ViperSuite) createEnvFile(content string) string {
 }
 
 func (suite *ViperSuite) TestLoadEnv() {
-	envContent := `ELASTICSEARCH_ADDRESS=elasticsearch_address
+	envContent := `CONTAINER_BIND_SOURCES=/srv/data,/var/lib/app
+ELASTICSEARCH_ADDRESS=elasticsearch_address
 JWT_SECRET_KEY=test_jwt_secret
 MAIL_USERNAME=test@example.com
 MAIL_PASSWORD=test_password
+IMAGE_ALLOWED_REGISTRIES=docker.io,ghcr.io
+IMAGE_DENIED_TAGS=latest
+IMAGE_REQUIRE_DIGEST=true
+MIGRATION_DEFAULT_OWNER=admin
 POSTGRES_HOST=postgres_host
 POSTGRES_USER=test_user
 POSTGRES_PASSWORD=test_db_password
 POSTGRES_NAME=test_db
 POSTGRES_PORT=5432
+RECONCILE_MANAGED_ONLY=false
+RECONCILE_UNTRACKED_POLICY=adopt
+RECONCILE_MISSING_POLICY=delete
+RECONCILE_GRACE_PERIOD=1m
+RECONCILE_ADOPT_OWNER=operator
+REGISTRY_SECRET_KEY=registry_secret
 REDIS_ADDRESS=redis_address
 REDIS_PASSWORD=redis_password
 REDIS_DB=0
+TRASH_RETENTION=72h
+EXPIRY_NOTICE=30m
+FILES_MAX_DOWNLOAD_SIZE=2048
+FILES_MAX_UPLOAD_SIZE=1024
 ZAP_LEVEL=info
 ZAP_FILEPATH=/tmp/app.log
 ZAP_MAXSIZE=100
@@ -78,6 +108,8 @@ ZAP_MAXBACKUPS=5`
 	suite.NoError(err)
 	suite.NotNil(env)
 
+	suite.Equal([]string{"/srv/data", "/var/lib/app"}, env.ContainerEnv.BindSources)
+
 	suite.Equal("elasticsearch_address", env.ElasticsearchEnv.ElasticsearchAddress)
 
 	suite.Equal("test_jwt_secret", env.AuthEnv.JWTSecret)
@@ -85,16 +117,37 @@ ZAP_MAXBACKUPS=5`
 	suite.Equal("test@example.com", env.GomailEnv.MailUsername)
 	suite.Equal("test_password", env.GomailEnv.MailPassword)
 
+	suite.Equal([]string{"docker.io", "ghcr.io"}, env.ImageEnv.AllowedRegistries)
+	suite.Equal([]string{"latest"}, env.ImageEnv.DeniedTags)
+	suite.True(env.ImageEnv.RequireDigest)
+
+	suite.Equal("admin", env.MigrationEnv.DefaultOwner)
+
 	suite.Equal("postgres_host", env.PostgresEnv.PostgresHost)
 	suite.Equal("test_user", env.PostgresEnv.PostgresUser)
 	suite.Equal("test_db_password", env.PostgresEnv.PostgresPassword)
 	suite.Equal("test_db", env.PostgresEnv.PostgresName)
 	suite.Equal("5432", env.PostgresEnv.PostgresPort)
 
+	suite.False(env.ReconcileEnv.ManagedOnly)
+	suite.Equal("adopt", env.ReconcileEnv.UntrackedPolicy)
+	suite.Equal("delete", env.ReconcileEnv.MissingPolicy)
+	suite.Equal(time.Minute, env.ReconcileEnv.GracePeriod)
+	suite.Equal("operator", env.ReconcileEnv.AdoptOwner)
+
+	suite.Equal("registry_secret", env.RegistryEnv.SecretKey)
+
 	suite.Equal("redis_address", env.RedisEnv.RedisAddress)
 	suite.Equal("redis_password", env.RedisEnv.RedisPassword)
 	suite.Equal(0, env.RedisEnv.RedisDb)
 
+	suite.Equal(72*time.Hour, env.TrashEnv.Retention)
+
+	suite.Equal(30*time.Minute, env.ExpiryEnv.Notice)
+
+	suite.Equal(int64(2048), env.FilesEnv.MaxDownloadSize)
+	suite.Equal(int64(1024), env.FilesEnv.MaxUploadSize)
+
 	suite.Equal("info", env.LoggerEnv.Level)
 	suite.Equal("/tmp/app.log", env.LoggerEnv.FilePath)
 	suite.Equal(100, env.LoggerEnv.MaxSize)
@@ -123,6 +176,29 @@ MAIL_PASSWORD=test_password`
 	suite.Equal(30, env.LoggerEnv.MaxBackups)
 
 	suite.Equal("partial_user", env.PostgresEnv.PostgresUser)
+
+	suite.Empty(env.ContainerEnv.BindSources)
+
+	suite.Empty(env.ImageEnv.AllowedRegistries)
+	suite.Empty(env.ImageEnv.DeniedTags)
+	suite.False(env.ImageEnv.RequireDigest)
+
+	suite.Empty(env.MigrationEnv.DefaultOwner)
+
+	suite.True(env.ReconcileEnv.ManagedOnly)
+	suite.Equal("ignore", env.ReconcileEnv.UntrackedPolicy)
+	suite.Equal("orphan", env.ReconcileEnv.MissingPolicy)
+	suite.Equal(5*time.Minute, env.ReconcileEnv.GracePeriod)
+	suite.Empty(env.ReconcileEnv.AdoptOwner)
+
+	suite.Empty(env.RegistryEnv.SecretKey)
+
+	suite.Equal(7*24*time.Hour, env.TrashEnv.Retention)
+
+	suite.Equal(time.Hour, env.ExpiryEnv.Notice)
+
+	suite.Equal(int64(100<<20), env.FilesEnv.MaxDownloadSize)
+	suite.Equal(int64(10<<20), env.FilesEnv.MaxUploadSize)
 }
 
 func (suite *ViperSuite) TestLoadEnvConfigFileNotFound() {
@@ -220,3 +296,81 @@ MAIL_PASSWORD=test_password`
 	suite.Error(err)
 	suite.Nil(env)
 }
+
+func (suite *ViperSuite) TestLoadEnvInvalidReconcileValues() {
+	envContent := `JWT_SECRET_KEY=test_jwt_secret
+RECONCILE_UNTRACKED_POLICY=orphan
+MAIL_USERNAME=test@example.com
+MAIL_PASSWORD=test_password`
+
+	suite.createEnvFile(envContent)
+	env, err := LoadEnv(suite.tempDir)
+
+	suite.Error(err)
+	suite.Nil(env)
+}
+
+func (suite *ViperSuite) TestLoadEnvAdoptWithoutOwner() {
+	envContent := `JWT_SECRET_KEY=test_jwt_secret
+RECONCILE_UNTRACKED_POLICY=adopt
+MAIL_USERNAME=test@example.com
+MAIL_PASSWORD=test_password`
+
+	suite.createEnvFile(envContent)
+	env, err := LoadEnv(suite.tempDir)
+
+	suite.Error(err)
+	suite.Nil(env)
+}
+
+func (suite *ViperSuite) TestLoadEnvInvalidTrashValues() {
+	envContent := `JWT_SECRET_KEY=test_jwt_secret
+TRASH_RETENTION=0s
+MAIL_USERNAME=test@example.com
+MAIL_PASSWORD=test_password`
+
+	suite.createEnvFile(envContent)
+	env, err := LoadEnv(suite.tempDir)
+
+	suite.Error(err)
+	suite.Nil(env)
+}
+
+func (suite *ViperSuite) TestLoadEnvInvalidExpiryValues() {
+	envContent := `JWT_SECRET_KEY=test_jwt_secret
+EXPIRY_NOTICE=-1h
+MAIL_USERNAME=test@example.com
+MAIL_PASSWORD=test_password`
+
+	suite.createEnvFile(envContent)
+	env, err := LoadEnv(suite.tempDir)
+
+	suite.Error(err)
+	suite.Nil(env)
+}
+
+func (suite *ViperSuite) TestLoadEnvInvalidFilesValues() {
+	envContent := `JWT_SECRET_KEY=test_jwt_secret
+FILES_MAX_UPLOAD_SIZE=0
+MAIL_USERNAME=test@example.com
+MAIL_PASSWORD=test_password`
+
+	suite.createEnvFile(envContent)
+	env, err := LoadEnv(suite.tempDir)
+
+	suite.Error(err)
+	suite.Nil(env)
+}
+
+func (suite *ViperSuite) TestLoadEnvInvalidContainerValues() {
+	envContent := `JWT_SECRET_KEY=test_jwt_secret
+CONTAINER_BIND_SOURCES=srv/data
+MAIL_USERNAME=test@example.com
+MAIL_PASSWORD=test_password`
+
+	suite.createEnvFile(envContent)
+	env, err := LoadEnv(suite.tempDir)
+
+	suite.Error(err)
+	suite.Nil(env)
+}
@@ -2,12 +2,12 @@ package middlewares
 
 import (
 	"net/http"
-	"slices"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/vnFuhung2903/vcs-sms/pkg/env"
+	"github.com/vnFuhung2903/vcs-sms/utils"
 )
 
 type IJWTMiddleware interface {
@@ -64,12 +64,13 @@ func (m *jwtMiddleware) RequireScope(requiredScope string) gin.HandlerFunc {
 			}
 		}
 
-		if found := (slices.Contains(tokens, requiredScope) || requiredScope == ""); !found {
+		if found := utils.HasScope(tokens, requiredScope); !found {
 			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient scope"})
 			c.Abort()
 			return
 		}
 
+		c.Set("scopes", tokens)
 		if sub, ok := claims["sub"].(string); ok {
 			c.Set("userId", sub)
 		} else {
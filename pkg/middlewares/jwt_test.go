@@ -64,6 +64,7 @@ func (s *JWTMiddlewareSuite) TestRequireScope() {
 		userId, exists := c.Get("userId")
 		s.True(exists)
 		s.Equal("123", userId)
+		s.Equal([]string{"read", "write"}, c.GetStringSlice("scopes"))
 		c.JSON(http.StatusOK, gin.H{"message": "success"})
 	})
 
@@ -249,6 +250,38 @@ func (s *JWTMiddlewareSuite) TestRequireScopeInsufficientScope() {
 	s.Equal("Insufficient scope", response["error"])
 }
 
+func (s *JWTMiddlewareSuite) TestRequireScopeContainerAdmin() {
+	claims := jwt.MapClaims{
+		"sub":   "123",
+		"name":  "testuser",
+		"scope": []interface{}{"container:admin"},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"iat":   time.Now().Unix(),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString([]byte(s.testSecret))
+	s.Require().NoError(err)
+
+	s.router.DELETE("/test", s.jwtMiddleware.RequireScope("container:delete"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+	s.router.POST("/test", s.jwtMiddleware.RequireScope("container:create"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	req, _ := http.NewRequest("DELETE", "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+tokenString)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	req, _ = http.NewRequest("POST", "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+tokenString)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusForbidden, w.Code)
+}
+
 func (s *JWTMiddlewareSuite) TestRequireScopeNoScope() {
 	claims := jwt.MapClaims{
 		"sub":   "123",
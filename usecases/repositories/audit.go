@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"gorm.io/gorm"
+)
+
+type IAuditRepository interface {
+	Create(auditLog *entities.AuditLog) error
+	Finish(id uint, endedAt time.Time, exitCode *int) error
+	FinishTransfer(id uint, endedAt time.Time, bytes int64, errMessage string) error
+	BeginTransaction(ctx context.Context) (*gorm.DB, error)
+	WithTransaction(tx *gorm.DB) IAuditRepository
+}
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) IAuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) Create(auditLog *entities.AuditLog) error {
+	res := r.db.Create(auditLog)
+	return res.Error
+}
+
+func (r *auditRepository) Finish(id uint, endedAt time.Time, exitCode *int) error {
+	res := r.db.Model(&entities.AuditLog{}).Where("id = ?", id).Updates(map[string]any{
+		"ended_at":  endedAt,
+		"exit_code": exitCode,
+	})
+	return res.Error
+}
+
+func (r *auditRepository) FinishTransfer(id uint, endedAt time.Time, bytes int64, errMessage string) error {
+	res := r.db.Model(&entities.AuditLog{}).Where("id = ?", id).Updates(map[string]any{
+		"ended_at": endedAt,
+		"bytes":    bytes,
+		"error":    errMessage,
+	})
+	return res.Error
+}
+
+func (r *auditRepository) BeginTransaction(ctx context.Context) (*gorm.DB, error) {
+	tx := r.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	return tx, nil
+}
+
+func (r *auditRepository) WithTransaction(tx *gorm.DB) IAuditRepository {
+	return &auditRepository{db: tx}
+}
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+)
+
+type AuditRepoSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo IAuditRepository
+}
+
+func (suite *AuditRepoSuite) SetupTest() {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	assert.NoError(suite.T(), err)
+	err = gormDB.AutoMigrate(&entities.AuditLog{})
+	assert.NoError(suite.T(), err)
+	suite.db = gormDB
+	suite.repo = NewAuditRepository(gormDB)
+}
+
+func (suite *AuditRepoSuite) TearDownTest() {
+	sqlDB, err := suite.db.DB()
+	assert.NoError(suite.T(), err)
+	sqlDB.Close()
+}
+
+func TestAuditRepoSuite(t *testing.T) {
+	suite.Run(t, new(AuditRepoSuite))
+}
+
+func (suite *AuditRepoSuite) TestCreateAndFinish() {
+	auditLog := &entities.AuditLog{Action: entities.AuditExec, UserId: "user-id", ContainerId: "cid-1", Detail: "/bin/sh", StartedAt: time.Now()}
+	err := suite.repo.Create(auditLog)
+	assert.NoError(suite.T(), err)
+	assert.NotZero(suite.T(), auditLog.ID)
+
+	exitCode := 130
+	err = suite.repo.Finish(auditLog.ID, time.Now(), &exitCode)
+	assert.NoError(suite.T(), err)
+
+	var found entities.AuditLog
+	assert.NoError(suite.T(), suite.db.First(&found, auditLog.ID).Error)
+	assert.NotNil(suite.T(), found.EndedAt)
+	assert.Equal(suite.T(), 130, *found.ExitCode)
+}
+
+func (suite *AuditRepoSuite) TestFinishWithoutExitCode() {
+	auditLog := &entities.AuditLog{Action: entities.AuditExec, UserId: "user-id", ContainerId: "cid-2", StartedAt: time.Now()}
+	assert.NoError(suite.T(), suite.repo.Create(auditLog))
+
+	err := suite.repo.Finish(auditLog.ID, time.Now(), nil)
+	assert.NoError(suite.T(), err)
+
+	var found entities.AuditLog
+	assert.NoError(suite.T(), suite.db.First(&found, auditLog.ID).Error)
+	assert.NotNil(suite.T(), found.EndedAt)
+	assert.Nil(suite.T(), found.ExitCode)
+}
+
+func (suite *AuditRepoSuite) TestFinishTransfer() {
+	auditLog := &entities.AuditLog{Action: entities.AuditFileUpload, UserId: "user-id", ContainerId: "cid-4", StartedAt: time.Now()}
+	assert.NoError(suite.T(), suite.repo.Create(auditLog))
+
+	err := suite.repo.FinishTransfer(auditLog.ID, time.Now(), 2048, "container not found")
+	assert.NoError(suite.T(), err)
+
+	var found entities.AuditLog
+	assert.NoError(suite.T(), suite.db.First(&found, auditLog.ID).Error)
+	assert.NotNil(suite.T(), found.EndedAt)
+	assert.Equal(suite.T(), int64(2048), *found.Bytes)
+	assert.Equal(suite.T(), "container not found", found.Error)
+	assert.Nil(suite.T(), found.ExitCode)
+}
+
+func (suite *AuditRepoSuite) TestBeginAndWithTransaction() {
+	tx, err := suite.repo.BeginTransaction(suite.T().Context())
+	assert.NoError(suite.T(), err)
+	txRepo := suite.repo.WithTransaction(tx)
+	err = txRepo.Create(&entities.AuditLog{Action: entities.AuditExec, UserId: "user-id", ContainerId: "cid-3", StartedAt: time.Now()})
+	assert.NoError(suite.T(), err)
+	tx.Rollback()
+
+	var count int64
+	suite.db.Model(&entities.AuditLog{}).Count(&count)
+	assert.Equal(suite.T(), int64(0), count)
+}
+
+func (suite *AuditRepoSuite) TestBeginTransactionError() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+	_, err := suite.repo.BeginTransaction(suite.T().Context())
+	assert.Error(suite.T(), err)
+}
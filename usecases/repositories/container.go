@@ -3,6 +3,9 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/vnFuhung2903/vcs-sms/dto"
 	"github.com/vnFuhung2903/vcs-sms/entities"
@@ -13,10 +16,26 @@ type IContainerRepository interface {
 	FindById(containerId string) (*entities.Container, error)
 	FindByName(containerName string) (*entities.Container, error)
 	View(filter dto.ContainerFilter, from int, limit int, sort dto.ContainerSort) ([]*entities.Container, int64, error)
-	Create(containerId string, containerName string, status entities.ContainerStatus, ipv4 string) (*entities.Container, error)
+	Seek(filter dto.ContainerFilter, cursor *dto.ContainerCursor, limit int, sort dto.ContainerSort) ([]*entities.Container, error)
+	Count(filter dto.ContainerFilter) (int64, error)
+	Stream(filter dto.ContainerFilter, from int, limit int, sort dto.ContainerSort, fn func(*entities.Container) error) error
+	Create(container *entities.Container) error
 	CreateInBatches(containers []*entities.Container) error
 	Update(containerId string, status entities.ContainerStatus, ipv4 string) error
+	Activate(pendingId string, containerId string, status entities.ContainerStatus, ipv4 string) (bool, error)
+	UpdateOwner(containerId string, ownerId string) error
+	AssignUnowned(ownerId string) (int64, error)
+	UpdateName(containerId string, containerName string) error
+	UpdateMetadata(containerId string, description string, labels map[string]string) error
+	UpdateExpiry(containerId string, expiresAt *time.Time) error
+	ViewExpiring(before time.Time) ([]*entities.Container, error)
+	MarkExpiryNotified(containerIds []string, notifiedAt time.Time) error
 	Delete(containerId string) error
+	ViewDeleted(ownerId string, deletedBefore time.Time) ([]*entities.Container, error)
+	FindDeletedById(containerId string) (*entities.Container, error)
+	Restore(containerId string) error
+	Purge(containerId string) error
+	Usage(ownerId string) (int64, int64, error)
 	BeginTransaction(ctx context.Context) (*gorm.DB, error)
 	WithTransaction(tx *gorm.DB) IContainerRepository
 }
@@ -38,9 +57,10 @@ func (r *containerRepository) FindById(containerId string) (*entities.Container,
 	return &container, nil
 }
 
+// FindByName looks in the trash too, as a trashed container keeps its name until it is purged.
 func (r *containerRepository) FindByName(containerName string) (*entities.Container, error) {
 	var container entities.Container
-	res := r.db.First(&container, entities.Container{ContainerName: containerName})
+	res := r.db.Unscoped().First(&container, entities.Container{ContainerName: containerName})
 	if res.Error != nil {
 		return nil, res.Error
 	}
@@ -48,20 +68,7 @@ func (r *containerRepository) FindByName(containerName string) (*entities.Contai
 }
 
 func (r *containerRepository) View(filter dto.ContainerFilter, from int, limit int, sort dto.ContainerSort) ([]*entities.Container, int64, error) {
-	query := r.db.Model(entities.Container{})
-
-	if filter.ContainerId != "" {
-		query = query.Where("container_id = ?", filter.ContainerId)
-	}
-	if filter.Status != "" {
-		query = query.Where("status = ?", filter.Status)
-	}
-	if filter.ContainerName != "" {
-		query = query.Where("container_name LIKE ?", "%"+filter.ContainerName+"%")
-	}
-	if filter.Ipv4 != "" {
-		query = query.Where("ipv4 = ?", filter.Ipv4)
-	}
+	query := r.filter(filter)
 
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
@@ -77,18 +84,155 @@ func (r *containerRepository) View(filter dto.ContainerFilter, from int, limit i
 	return containers, total, nil
 }
 
-func (r *containerRepository) Create(containerId string, containerName string, status entities.ContainerStatus, ipv4 string) (*entities.Container, error) {
-	newContainer := &entities.Container{
-		ContainerId:   containerId,
-		Status:        status,
-		ContainerName: containerName,
-		Ipv4:          ipv4,
+// Seek returns up to limit containers following the cursor in the sort order, or preceding it for a before cursor,
+// the first ones without cursor. Ties on the sort field are broken by container_id so that no row is skipped nor repeated.
+func (r *containerRepository) Seek(filter dto.ContainerFilter, cursor *dto.ContainerCursor, limit int, sort dto.ContainerSort) ([]*entities.Container, error) {
+	order := sort.Order
+	if cursor != nil && cursor.Before {
+		order = reverseOrder(order)
 	}
-	res := r.db.Create(newContainer)
-	if res.Error != nil {
-		return nil, res.Error
+	operator := ">"
+	if order == dto.Dsc {
+		operator = "<"
+	}
+
+	query := r.filter(filter)
+	if cursor != nil {
+		var value any = cursor.Value
+		if sort.Field == "created_at" || sort.Field == "updated_at" {
+			at, err := cursor.TimeValue()
+			if err != nil {
+				return nil, err
+			}
+			value = at
+		}
+		if sort.Field == "container_id" {
+			query = query.Where(fmt.Sprintf("container_id %s ?", operator), cursor.ContainerId)
+		} else {
+			query = query.Where(fmt.Sprintf("(%s, container_id) %s (?, ?)", sort.Field, operator), value, cursor.ContainerId)
+		}
 	}
-	return newContainer, nil
+	if sort.Field == "container_id" {
+		query = query.Order(fmt.Sprintf("container_id %s", order))
+	} else {
+		query = query.Order(fmt.Sprintf("%s %s, container_id %s", sort.Field, order, order))
+	}
+
+	var containers []*entities.Container
+	if err := query.Limit(limit).Find(&containers).Error; err != nil {
+		return nil, err
+	}
+	if order != sort.Order {
+		slices.Reverse(containers)
+	}
+	return containers, nil
+}
+
+func (r *containerRepository) Count(filter dto.ContainerFilter) (int64, error) {
+	var total int64
+	res := r.filter(filter).Count(&total)
+	return total, res.Error
+}
+
+func reverseOrder(order dto.SortOrder) dto.SortOrder {
+	if order == dto.Dsc {
+		return dto.Asc
+	}
+	return dto.Dsc
+}
+
+// Stream calls fn on each matching container as it is read from the database cursor, stopping at the first error.
+func (r *containerRepository) Stream(filter dto.ContainerFilter, from int, limit int, sort dto.ContainerSort, fn func(*entities.Container) error) error {
+	rows, err := r.filter(filter).
+		Order(fmt.Sprintf("%s %s", sort.Field, sort.Order)).
+		Limit(limit).
+		Offset(from - 1).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var container entities.Container
+		if err := r.db.ScanRows(rows, &container); err != nil {
+			return err
+		}
+		if err := fn(&container); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// filter turns the filter into parameterized clauses, label keys and user text never being spliced into the SQL.
+func (r *containerRepository) filter(filter dto.ContainerFilter) *gorm.DB {
+	query := r.db.Model(entities.Container{})
+
+	if filter.ContainerId != "" {
+		query = query.Where("container_id = ?", filter.ContainerId)
+	}
+	if len(filter.Status) > 0 {
+		query = query.Where("status IN ?", filter.Status)
+	}
+	if filter.ContainerName != "" {
+		query = query.Where(`container_name LIKE ? ESCAPE '\'`, "%"+escapeLike(filter.ContainerName)+"%")
+	}
+	if filter.ImageName != "" {
+		query = query.Where(`image_name LIKE ? ESCAPE '\'`, "%"+escapeLike(filter.ImageName)+"%")
+	}
+	if strings.Contains(filter.Ipv4, "/") {
+		query = query.Where("CAST(NULLIF(ipv4, '') AS inet) <<= CAST(? AS cidr)", filter.Ipv4)
+	} else if filter.Ipv4 != "" {
+		query = query.Where("ipv4 = ?", filter.Ipv4)
+	}
+	if filter.OwnerId != "" {
+		query = query.Where("owner_id = ?", filter.OwnerId)
+	}
+	if !filter.CreatedAfter.IsZero() {
+		query = query.Where("created_at >= ?", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query = query.Where("created_at <= ?", filter.CreatedBefore)
+	}
+	if !filter.UpdatedAfter.IsZero() {
+		query = query.Where("updated_at >= ?", filter.UpdatedAfter)
+	}
+	if !filter.UpdatedBefore.IsZero() {
+		query = query.Where("updated_at <= ?", filter.UpdatedBefore)
+	}
+	for _, label := range filter.Labels {
+		// The database labels of the container override its docker labels, as in Container.AllLabels.
+		value := gorm.Expr("COALESCE(labels ->> CAST(? AS TEXT), spec -> 'labels' ->> CAST(? AS TEXT))", label.Key, label.Key)
+		switch label.Operator {
+		case dto.LabelEquals:
+			query = query.Where("? = ?", value, label.Value)
+		case dto.LabelNotEquals:
+			query = query.Where("(? IS NULL OR ? <> ?)", value, value, label.Value)
+		case dto.LabelExists:
+			query = query.Where("? IS NOT NULL", value)
+		case dto.LabelNotExists:
+			query = query.Where("? IS NULL", value)
+		}
+	}
+	if filter.Q != "" {
+		pattern := "%" + escapeLike(strings.ToLower(filter.Q)) + "%"
+		query = query.Where(
+			`(LOWER(container_id) LIKE ? ESCAPE '\' OR LOWER(container_name) LIKE ? ESCAPE '\' OR LOWER(image_name) LIKE ? ESCAPE '\' OR ipv4 LIKE ? ESCAPE '\')`,
+			pattern, pattern, pattern, pattern,
+		)
+	}
+	return query
+}
+
+// escapeLike makes the LIKE wildcards of a user value match literally.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+func (r *containerRepository) Create(container *entities.Container) error {
+	res := r.db.Create(container)
+	return res.Error
 }
 
 func (r *containerRepository) CreateInBatches(containers []*entities.Container) error {
@@ -105,13 +249,136 @@ func (r *containerRepository) Update(containerId string, status entities.Contain
 	return res.Error
 }
 
+// Activate gives the row reserved under pendingId the id of the docker container created for it, with its status and
+// IPv4. It reports false when the row is gone, removed by the reconciler while docker was slow to create the container.
+func (r *containerRepository) Activate(pendingId string, containerId string, status entities.ContainerStatus, ipv4 string) (bool, error) {
+	updateData := map[string]interface{}{
+		"container_id": containerId,
+		"status":       status,
+		"ipv4":         ipv4,
+	}
+	res := r.db.Model(&entities.Container{}).Where("container_id = ?", pendingId).Updates(updateData)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *containerRepository) UpdateOwner(containerId string, ownerId string) error {
+	res := r.db.Model(&entities.Container{}).Where("container_id = ?", containerId).Update("owner_id", ownerId)
+	return res.Error
+}
+
+// AssignUnowned gives the owner every container without one, trashed ones included, and returns how many it took.
+func (r *containerRepository) AssignUnowned(ownerId string) (int64, error) {
+	res := r.db.Unscoped().Model(&entities.Container{}).Where("owner_id = ''").Update("owner_id", ownerId)
+	return res.RowsAffected, res.Error
+}
+
+func (r *containerRepository) UpdateName(containerId string, containerName string) error {
+	res := r.db.Model(&entities.Container{}).Where("container_id = ?", containerId).Update("container_name", containerName)
+	return res.Error
+}
+
+// UpdateMetadata replaces the description and the database labels of the container, nil labels clearing them.
+func (r *containerRepository) UpdateMetadata(containerId string, description string, labels map[string]string) error {
+	res := r.db.Model(&entities.Container{}).
+		Where("container_id = ?", containerId).
+		Select("description", "labels").
+		Updates(&entities.Container{Description: description, Labels: labels})
+	return res.Error
+}
+
+// UpdateExpiry moves the expiry of the container, its owner being notified again before the new one.
+func (r *containerRepository) UpdateExpiry(containerId string, expiresAt *time.Time) error {
+	res := r.db.Model(&entities.Container{}).
+		Where("container_id = ?", containerId).
+		Updates(map[string]any{"expires_at": expiresAt, "expiry_notified_at": nil})
+	return res.Error
+}
+
+// ViewExpiring lists the ephemeral containers expiring by before, the earliest first.
+func (r *containerRepository) ViewExpiring(before time.Time) ([]*entities.Container, error) {
+	var containers []*entities.Container
+	res := r.db.Where("expires_at IS NOT NULL AND expires_at <= ?", before).Order("expires_at").Find(&containers)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return containers, nil
+}
+
+func (r *containerRepository) MarkExpiryNotified(containerIds []string, notifiedAt time.Time) error {
+	res := r.db.Model(&entities.Container{}).Where("container_id IN ?", containerIds).Update("expiry_notified_at", notifiedAt)
+	return res.Error
+}
+
 func (r *containerRepository) Delete(containerId string) error {
 	res := r.db.Where("container_id = ?", containerId).Delete(&entities.Container{})
 	return res.Error
 }
 
+// ViewDeleted lists the containers in the trash, latest deleted first, of one owner unless ownerId is empty and
+// deleted before deletedBefore unless it is zero.
+func (r *containerRepository) ViewDeleted(ownerId string, deletedBefore time.Time) ([]*entities.Container, error) {
+	query := r.db.Unscoped().Where("deleted_at IS NOT NULL")
+	if ownerId != "" {
+		query = query.Where("owner_id = ?", ownerId)
+	}
+	if !deletedBefore.IsZero() {
+		query = query.Where("deleted_at < ?", deletedBefore)
+	}
+
+	var containers []*entities.Container
+	if err := query.Order("deleted_at desc").Find(&containers).Error; err != nil {
+		return nil, err
+	}
+	return containers, nil
+}
+
+func (r *containerRepository) FindDeletedById(containerId string) (*entities.Container, error) {
+	var container entities.Container
+	res := r.db.Unscoped().Where("container_id = ? AND deleted_at IS NOT NULL", containerId).First(&container)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &container, nil
+}
+
+// Restore takes the container out of the trash, dropping its expiry so that the reaper leaves it alone.
+func (r *containerRepository) Restore(containerId string) error {
+	res := r.db.Unscoped().Model(&entities.Container{}).
+		Where("container_id = ?", containerId).
+		Updates(map[string]any{"deleted_at": nil, "expires_at": nil, "expiry_notified_at": nil})
+	return res.Error
+}
+
+// Purge removes the container for good, along with its schedules and their runs.
+func (r *containerRepository) Purge(containerId string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entities.ScheduleRun{}, "container_id = ?", containerId).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&entities.Schedule{}, "container_id = ?", containerId).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("container_id = ?", containerId).Delete(&entities.Container{}).Error
+	})
+}
+
+func (r *containerRepository) Usage(ownerId string) (int64, int64, error) {
+	var usage struct {
+		Containers int64
+		Memory     int64
+	}
+	res := r.db.Model(&entities.Container{}).
+		Select("COUNT(*) AS containers, COALESCE(SUM(CAST(spec -> 'resources' ->> 'memory' AS BIGINT)), 0) AS memory").
+		Where("owner_id = ?", ownerId).
+		Scan(&usage)
+	if res.Error != nil {
+		return 0, 0, res.Error
+	}
+	return usage.Containers, usage.Memory, nil
+}
+
 func (r *containerRepository) BeginTransaction(ctx context.Context) (*gorm.DB, error) {
-	tx := r.db.Begin()
+	tx := r.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
@@ -1,7 +1,9 @@
 package repositories
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -23,7 +25,7 @@ func (suite *ContainerRepoSuite) SetupTest() {
 		Logger: logger.Default.LogMode(logger.Silent),
 	})
 	assert.NoError(suite.T(), err)
-	err = gormDB.AutoMigrate(&entities.Container{})
+	err = gormDB.AutoMigrate(&entities.Container{}, &entities.Schedule{}, &entities.ScheduleRun{})
 	assert.NoError(suite.T(), err)
 	suite.db = gormDB
 	suite.repo = NewContainerRepository(gormDB)
@@ -40,16 +42,16 @@ func TestContainerRepoSuite(t *testing.T) {
 }
 
 func (suite *ContainerRepoSuite) TestCreateDuplicateContainerId() {
-	_, err := suite.repo.Create("dup-id", "Name1", entities.ContainerOn, "10.0.1.1")
+	err := suite.repo.Create(&entities.Container{ContainerId: "dup-id", ContainerName: "Name1", Status: entities.ContainerOn, Ipv4: "10.0.1.1"})
 	assert.NoError(suite.T(), err)
-	_, err = suite.repo.Create("dup-id", "Name2", entities.ContainerOff, "10.0.1.2")
+	err = suite.repo.Create(&entities.Container{ContainerId: "dup-id", ContainerName: "Name2", Status: entities.ContainerOff, Ipv4: "10.0.1.2"})
 	assert.Error(suite.T(), err)
 }
 
 func (suite *ContainerRepoSuite) TestCreateDuplicateContainerName() {
-	_, err := suite.repo.Create("id1", "dup-name", entities.ContainerOn, "10.0.2.1")
+	err := suite.repo.Create(&entities.Container{ContainerId: "id1", ContainerName: "dup-name", Status: entities.ContainerOn, Ipv4: "10.0.2.1"})
 	assert.NoError(suite.T(), err)
-	_, err = suite.repo.Create("id2", "dup-name", entities.ContainerOff, "10.0.2.2")
+	err = suite.repo.Create(&entities.Container{ContainerId: "id2", ContainerName: "dup-name", Status: entities.ContainerOff, Ipv4: "10.0.2.2"})
 	assert.Error(suite.T(), err)
 }
 
@@ -66,12 +68,28 @@ func (suite *ContainerRepoSuite) TestCreateInBatches() {
 }
 
 func (suite *ContainerRepoSuite) TestCreateAndFindById() {
-	c, err := suite.repo.Create("cid-1", "Alpha", entities.ContainerOn, "10.0.0.1")
+	err := suite.repo.Create(&entities.Container{
+		ContainerId:   "cid-1",
+		ContainerName: "Alpha",
+		Status:        entities.ContainerOn,
+		Ipv4:          "10.0.0.1",
+		ImageName:     "nginx",
+		Spec: entities.ContainerSpec{
+			Env:           []string{"KEY=value"},
+			Labels:        map[string]string{"team": "infra"},
+			Ports:         []entities.PortBinding{{ContainerPort: 80, HostPort: 8080}},
+			RestartPolicy: entities.RestartPolicy{Name: "always"},
+		},
+	})
 	assert.NoError(suite.T(), err)
-	assert.NotNil(suite.T(), c)
 	found, err := suite.repo.FindById("cid-1")
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), "cid-1", found.ContainerId)
+	assert.Equal(suite.T(), "nginx", found.ImageName)
+	assert.Equal(suite.T(), []string{"KEY=value"}, found.Spec.Env)
+	assert.Equal(suite.T(), "infra", found.Spec.Labels["team"])
+	assert.Equal(suite.T(), 8080, found.Spec.Ports[0].HostPort)
+	assert.Equal(suite.T(), "always", found.Spec.RestartPolicy.Name)
 }
 
 func (suite *ContainerRepoSuite) TestFindByIdNotFound() {
@@ -80,21 +98,31 @@ func (suite *ContainerRepoSuite) TestFindByIdNotFound() {
 }
 
 func (suite *ContainerRepoSuite) TestFindByName() {
-	_, err := suite.repo.Create("cid-2", "Beta", entities.ContainerOff, "10.0.0.2")
+	err := suite.repo.Create(&entities.Container{ContainerId: "cid-2", ContainerName: "Beta", Status: entities.ContainerOff, Ipv4: "10.0.0.2"})
 	assert.NoError(suite.T(), err)
 	found, err := suite.repo.FindByName("Beta")
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), "Beta", found.ContainerName)
 }
 
+func (suite *ContainerRepoSuite) TestFindByNameInTrash() {
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-2", ContainerName: "Beta", Status: entities.ContainerOff, Ipv4: "10.0.0.2"})
+	assert.NoError(suite.T(), suite.repo.Delete("cid-2"))
+
+	found, err := suite.repo.FindByName("Beta")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "cid-2", found.ContainerId)
+	assert.True(suite.T(), found.DeletedAt.Valid)
+}
+
 func (suite *ContainerRepoSuite) TestFindByNameNotFound() {
 	_, err := suite.repo.FindByName("not-exist-name")
 	assert.Error(suite.T(), err)
 }
 
 func (suite *ContainerRepoSuite) TestViewWithFilters() {
-	_, _ = suite.repo.Create("cid-3", "Gamma", entities.ContainerOn, "10.0.0.3")
-	_, _ = suite.repo.Create("cid-4", "Delta", entities.ContainerOff, "10.0.0.4")
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-3", ContainerName: "Gamma", Status: entities.ContainerOn, Ipv4: "10.0.0.3"})
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-4", ContainerName: "Delta", Status: entities.ContainerOff, Ipv4: "10.0.0.4", OwnerId: "owner-4"})
 
 	// ContainerId filter
 	filter := dto.ContainerFilter{ContainerId: "cid-3"}
@@ -105,7 +133,7 @@ func (suite *ContainerRepoSuite) TestViewWithFilters() {
 	assert.Equal(suite.T(), "cid-3", result[0].ContainerId)
 
 	// Status filter
-	filter = dto.ContainerFilter{Status: entities.ContainerOff}
+	filter = dto.ContainerFilter{Status: []entities.ContainerStatus{entities.ContainerOff}}
 	result, total, err = suite.repo.View(filter, 1, 10, sort)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), int64(1), total)
@@ -125,6 +153,13 @@ func (suite *ContainerRepoSuite) TestViewWithFilters() {
 	assert.Equal(suite.T(), int64(1), total)
 	assert.Equal(suite.T(), "cid-4", result[0].ContainerId)
 
+	// OwnerId filter
+	filter = dto.ContainerFilter{OwnerId: "owner-4"}
+	result, total, err = suite.repo.View(filter, 1, 10, sort)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), total)
+	assert.Equal(suite.T(), "cid-4", result[0].ContainerId)
+
 	// Multiple filters
 	filter = dto.ContainerFilter{ContainerId: "cid-3", Ipv4: "10.0.0.4"}
 	_, total, err = suite.repo.View(filter, 1, 10, sort)
@@ -132,9 +167,78 @@ func (suite *ContainerRepoSuite) TestViewWithFilters() {
 	assert.Equal(suite.T(), int64(0), total)
 }
 
+func (suite *ContainerRepoSuite) TestViewWithQueryLanguage() {
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-1", ContainerName: "web_1", Status: entities.ContainerOn, Ipv4: "10.0.0.1", ImageName: "nginx:1.27",
+		Spec: entities.ContainerSpec{Labels: map[string]string{"env": "prod", "team": "web"}}})
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-2", ContainerName: "webX1", Status: entities.ContainerExited, Ipv4: "10.0.0.2", ImageName: "nginx:1.25",
+		Spec: entities.ContainerSpec{Labels: map[string]string{"env": "prod", "team": "infra"}}})
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-3", ContainerName: "db", Status: entities.ContainerOff, Ipv4: "", ImageName: "postgres:16"})
+	suite.db.Model(&entities.Container{}).Where("container_id = ?", "cid-1").UpdateColumn("created_at", time.Unix(1000, 0))
+	suite.db.Model(&entities.Container{}).Where("container_id = ?", "cid-2").UpdateColumn("created_at", time.Unix(2000, 0))
+	suite.db.Model(&entities.Container{}).Where("container_id = ?", "cid-3").UpdateColumn("created_at", time.Unix(3000, 0))
+
+	sort := dto.ContainerSort{Field: "container_id", Order: "asc"}
+	ids := func(filter dto.ContainerFilter) []string {
+		result, total, err := suite.repo.View(filter, 1, -1, sort)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(len(result)), total)
+		var ids []string
+		for _, container := range result {
+			ids = append(ids, container.ContainerId)
+		}
+		return ids
+	}
+
+	assert.Equal(suite.T(), []string{"cid-2", "cid-3"}, ids(dto.ContainerFilter{Status: []entities.ContainerStatus{entities.ContainerExited, entities.ContainerOff}}))
+	assert.Equal(suite.T(), []string{"cid-1", "cid-2"}, ids(dto.ContainerFilter{ImageName: "nginx"}))
+	assert.Equal(suite.T(), []string{"cid-1"}, ids(dto.ContainerFilter{ContainerName: "web_"}))
+	assert.Equal(suite.T(), []string{"cid-2"}, ids(dto.ContainerFilter{CreatedAfter: time.Unix(1500, 0), CreatedBefore: time.Unix(2000, 0)}))
+	assert.Equal(suite.T(), []string{"cid-1", "cid-2"}, ids(dto.ContainerFilter{Labels: []dto.LabelRequirement{{Key: "env", Operator: dto.LabelEquals, Value: "prod"}}}))
+	assert.Equal(suite.T(), []string{"cid-1", "cid-3"}, ids(dto.ContainerFilter{Labels: []dto.LabelRequirement{{Key: "team", Operator: dto.LabelNotEquals, Value: "infra"}}}))
+	assert.Equal(suite.T(), []string{"cid-1", "cid-2"}, ids(dto.ContainerFilter{Labels: []dto.LabelRequirement{{Key: "team", Operator: dto.LabelExists}}}))
+	assert.Equal(suite.T(), []string{"cid-3"}, ids(dto.ContainerFilter{Labels: []dto.LabelRequirement{{Key: "team", Operator: dto.LabelNotExists}}}))
+	assert.Equal(suite.T(), []string{"cid-3"}, ids(dto.ContainerFilter{Q: "POSTGRES"}))
+	assert.Equal(suite.T(), []string{"cid-2"}, ids(dto.ContainerFilter{Q: "10.0.0.2"}))
+	assert.Empty(suite.T(), ids(dto.ContainerFilter{Q: "%"}))
+}
+
+func (suite *ContainerRepoSuite) TestViewWithDatabaseLabels() {
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-1", ContainerName: "web", Spec: entities.ContainerSpec{Labels: map[string]string{"team": "web"}},
+		Labels: map[string]string{"team": "infra", "env": "prod"}})
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-2", ContainerName: "api", Spec: entities.ContainerSpec{Labels: map[string]string{"team": "web"}}})
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-3", ContainerName: "db"})
+
+	sort := dto.ContainerSort{Field: "container_id", Order: "asc"}
+	ids := func(labels ...dto.LabelRequirement) []string {
+		result, _, err := suite.repo.View(dto.ContainerFilter{Labels: labels}, 1, -1, sort)
+		assert.NoError(suite.T(), err)
+		var ids []string
+		for _, container := range result {
+			ids = append(ids, container.ContainerId)
+		}
+		return ids
+	}
+
+	assert.Equal(suite.T(), []string{"cid-2"}, ids(dto.LabelRequirement{Key: "team", Operator: dto.LabelEquals, Value: "web"}))
+	assert.Equal(suite.T(), []string{"cid-1"}, ids(dto.LabelRequirement{Key: "team", Operator: dto.LabelEquals, Value: "infra"}))
+	assert.Equal(suite.T(), []string{"cid-1", "cid-3"}, ids(dto.LabelRequirement{Key: "team", Operator: dto.LabelNotEquals, Value: "web"}))
+	assert.Equal(suite.T(), []string{"cid-1"}, ids(dto.LabelRequirement{Key: "env", Operator: dto.LabelExists}))
+	assert.Equal(suite.T(), []string{"cid-2", "cid-3"}, ids(dto.LabelRequirement{Key: "env", Operator: dto.LabelNotExists}))
+}
+
+func (suite *ContainerRepoSuite) TestFilterCidr() {
+	repo := &containerRepository{db: suite.db}
+	sql := suite.db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		repo.db = tx
+		var containers []*entities.Container
+		return repo.filter(dto.ContainerFilter{Ipv4: "10.0.0.0/24"}).Find(&containers)
+	})
+	assert.Contains(suite.T(), sql, "CAST(NULLIF(ipv4, '') AS inet) <<= CAST(\"10.0.0.0/24\" AS cidr)")
+}
+
 func (suite *ContainerRepoSuite) TestViewDefaultNoLimit() {
-	_, _ = suite.repo.Create("cid-5", "Epsilon", entities.ContainerOn, "10.0.0.5")
-	_, _ = suite.repo.Create("cid-6", "Stigma", entities.ContainerOff, "10.0.0.6")
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-5", ContainerName: "Epsilon", Status: entities.ContainerOn, Ipv4: "10.0.0.5"})
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-6", ContainerName: "Stigma", Status: entities.ContainerOff, Ipv4: "10.0.0.6"})
 
 	filter := dto.ContainerFilter{}
 	sort := dto.ContainerSort{Field: "container_id", Order: "asc"}
@@ -168,8 +272,104 @@ func (suite *ContainerRepoSuite) TestViewWhileDbClose() {
 	assert.Contains(suite.T(), err.Error(), "database is closed")
 }
 
+func (suite *ContainerRepoSuite) TestSeek() {
+	created := time.Unix(1700000000, 0)
+	for i, id := range []string{"c1", "c2", "c3", "c4", "c5"} {
+		err := suite.repo.Create(&entities.Container{
+			ContainerId:   id,
+			ContainerName: "name-" + id,
+			Status:        entities.ContainerOn,
+			CreatedAt:     created.Add(time.Duration(i/2) * time.Hour),
+		})
+		assert.NoError(suite.T(), err)
+	}
+	sort := dto.ContainerSort{Field: "created_at", Order: dto.Dsc}
+	ids := func(containers []*entities.Container) []string {
+		result := make([]string, 0, len(containers))
+		for _, container := range containers {
+			result = append(result, container.ContainerId)
+		}
+		return result
+	}
+
+	first, err := suite.repo.Seek(dto.ContainerFilter{}, nil, 2, sort)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"c5", "c4"}, ids(first))
+
+	after := dto.NewContainerCursor(first[1], sort, false)
+	second, err := suite.repo.Seek(dto.ContainerFilter{}, &after, 2, sort)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"c3", "c2"}, ids(second))
+
+	before := dto.NewContainerCursor(second[0], sort, true)
+	previous, err := suite.repo.Seek(dto.ContainerFilter{}, &before, 2, sort)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"c5", "c4"}, ids(previous))
+
+	byId := dto.ContainerSort{Field: "container_id", Order: dto.Asc}
+	afterId := dto.NewContainerCursor(&entities.Container{ContainerId: "c3"}, byId, false)
+	rest, err := suite.repo.Seek(dto.ContainerFilter{}, &afterId, 10, byId)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"c4", "c5"}, ids(rest))
+}
+
+func (suite *ContainerRepoSuite) TestCount() {
+	err := suite.repo.Create(&entities.Container{ContainerId: "c1", ContainerName: "Alpha", Status: entities.ContainerOn})
+	assert.NoError(suite.T(), err)
+	err = suite.repo.Create(&entities.Container{ContainerId: "c2", ContainerName: "Beta", Status: entities.ContainerOff})
+	assert.NoError(suite.T(), err)
+
+	total, err := suite.repo.Count(dto.ContainerFilter{Status: []entities.ContainerStatus{entities.ContainerOn}})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), total)
+}
+
+func (suite *ContainerRepoSuite) TestStream() {
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-1", ContainerName: "Alpha", Status: entities.ContainerOn, Ipv4: "10.0.0.1", OwnerId: "user-1",
+		Spec: entities.ContainerSpec{Env: []string{"A=1"}}})
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-2", ContainerName: "Beta", Status: entities.ContainerOff, Ipv4: "10.0.0.2", OwnerId: "user-1"})
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-3", ContainerName: "Gamma", Status: entities.ContainerOn, Ipv4: "10.0.0.3", OwnerId: "user-2"})
+
+	var names []string
+	err := suite.repo.Stream(dto.ContainerFilter{OwnerId: "user-1"}, 1, -1, dto.ContainerSort{Field: "container_name", Order: "desc"}, func(container *entities.Container) error {
+		names = append(names, container.ContainerName)
+		if container.ContainerId == "cid-1" {
+			assert.Equal(suite.T(), []string{"A=1"}, container.Spec.Env)
+		}
+		return nil
+	})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"Beta", "Alpha"}, names)
+
+	names = nil
+	err = suite.repo.Stream(dto.ContainerFilter{}, 2, 1, dto.ContainerSort{Field: "container_id", Order: "asc"}, func(container *entities.Container) error {
+		names = append(names, container.ContainerName)
+		return nil
+	})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"Beta"}, names)
+}
+
+func (suite *ContainerRepoSuite) TestStreamStopsOnError() {
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-1", ContainerName: "Alpha", Status: entities.ContainerOn, Ipv4: "10.0.0.1"})
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-2", ContainerName: "Beta", Status: entities.ContainerOff, Ipv4: "10.0.0.2"})
+
+	calls := 0
+	err := suite.repo.Stream(dto.ContainerFilter{}, 1, -1, dto.ContainerSort{Field: "container_id", Order: "asc"}, func(container *entities.Container) error {
+		calls++
+		return assert.AnError
+	})
+	assert.ErrorIs(suite.T(), err, assert.AnError)
+	assert.Equal(suite.T(), 1, calls)
+
+	err = suite.repo.Stream(dto.ContainerFilter{}, 1, -1, dto.ContainerSort{Field: "not_a_field", Order: "asc"}, func(container *entities.Container) error {
+		return nil
+	})
+	assert.Error(suite.T(), err)
+}
+
 func (suite *ContainerRepoSuite) TestUpdate() {
-	_, _ = suite.repo.Create("cid-7", "Zeta", entities.ContainerOn, "10.0.0.7")
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-7", ContainerName: "Zeta", Status: entities.ContainerOn, Ipv4: "10.0.0.7"})
 	err := suite.repo.Update("cid-7", entities.ContainerOff, "")
 	assert.NoError(suite.T(), err)
 	found, _ := suite.repo.FindById("cid-7")
@@ -178,6 +378,53 @@ func (suite *ContainerRepoSuite) TestUpdate() {
 	assert.Equal(suite.T(), "Zeta", found.ContainerName)
 }
 
+func (suite *ContainerRepoSuite) TestActivate() {
+	_ = suite.repo.Create(&entities.Container{ContainerId: "pending-1", ContainerName: "Zeta", Status: entities.ContainerCreating})
+
+	activated, err := suite.repo.Activate("pending-1", "cid-7", entities.ContainerOn, "10.0.0.7")
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), activated)
+	found, err := suite.repo.FindByName("Zeta")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "cid-7", found.ContainerId)
+	assert.Equal(suite.T(), entities.ContainerOn, found.Status)
+	assert.Equal(suite.T(), "10.0.0.7", found.Ipv4)
+
+	activated, err = suite.repo.Activate("pending-1", "cid-8", entities.ContainerOn, "")
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), activated)
+}
+
+func (suite *ContainerRepoSuite) TestUpdateName() {
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-7", ContainerName: "Zeta", Status: entities.ContainerOn})
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-8", ContainerName: "Eta", Status: entities.ContainerOn})
+
+	err := suite.repo.UpdateName("cid-7", "Theta")
+	assert.NoError(suite.T(), err)
+	found, _ := suite.repo.FindByName("Theta")
+	assert.Equal(suite.T(), "cid-7", found.ContainerId)
+
+	err = suite.repo.UpdateName("cid-7", "Eta")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ContainerRepoSuite) TestUpdateMetadata() {
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-7", ContainerName: "Zeta", Status: entities.ContainerOn, Description: "old"})
+
+	err := suite.repo.UpdateMetadata("cid-7", "web frontend", map[string]string{"team": "web"})
+	assert.NoError(suite.T(), err)
+	found, _ := suite.repo.FindById("cid-7")
+	assert.Equal(suite.T(), "web frontend", found.Description)
+	assert.Equal(suite.T(), map[string]string{"team": "web"}, found.Labels)
+	assert.Equal(suite.T(), entities.ContainerOn, found.Status)
+
+	err = suite.repo.UpdateMetadata("cid-7", "", nil)
+	assert.NoError(suite.T(), err)
+	found, _ = suite.repo.FindById("cid-7")
+	assert.Empty(suite.T(), found.Description)
+	assert.Empty(suite.T(), found.Labels)
+}
+
 func (suite *ContainerRepoSuite) TestUpdateAndDeleteNonExistent() {
 	err := suite.repo.Update("not-exist", entities.ContainerOff, "")
 	assert.NoError(suite.T(), err)
@@ -186,24 +433,159 @@ func (suite *ContainerRepoSuite) TestUpdateAndDeleteNonExistent() {
 }
 
 func (suite *ContainerRepoSuite) TestDelete() {
-	_, _ = suite.repo.Create("cid-9", "Theta", entities.ContainerOn, "10.0.0.9")
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-9", ContainerName: "Theta", Status: entities.ContainerOn, Ipv4: "10.0.0.9"})
 	err := suite.repo.Delete("cid-9")
 	assert.NoError(suite.T(), err)
 	_, err = suite.repo.FindById("cid-9")
 	assert.Error(suite.T(), err)
 }
 
+func (suite *ContainerRepoSuite) TestTrashAndRestore() {
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-10", ContainerName: "Iota", OwnerId: "user-id"})
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-11", ContainerName: "Kappa", OwnerId: "other-id"})
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-12", ContainerName: "Lambda", OwnerId: "user-id"})
+	assert.NoError(suite.T(), suite.repo.Delete("cid-10"))
+	assert.NoError(suite.T(), suite.repo.Delete("cid-11"))
+
+	deleted, err := suite.repo.ViewDeleted("", time.Time{})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), deleted, 2)
+	assert.True(suite.T(), deleted[0].DeletedAt.Valid)
+
+	deleted, err = suite.repo.ViewDeleted("user-id", time.Time{})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), deleted, 1)
+	assert.Equal(suite.T(), "cid-10", deleted[0].ContainerId)
+
+	deleted, err = suite.repo.ViewDeleted("", time.Now().Add(-time.Hour))
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), deleted)
+
+	_, err = suite.repo.FindDeletedById("cid-12")
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+	found, err := suite.repo.FindDeletedById("cid-10")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Iota", found.ContainerName)
+
+	assert.NoError(suite.T(), suite.repo.Restore("cid-10"))
+	found, err = suite.repo.FindById("cid-10")
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), found.DeletedAt.Valid)
+}
+
+func (suite *ContainerRepoSuite) TestExpiry() {
+	now := time.Now()
+	soon, later := now.Add(time.Minute), now.Add(time.Hour)
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-14", ContainerName: "Nu", ExpiresAt: &later})
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-15", ContainerName: "Xi", ExpiresAt: &soon})
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-16", ContainerName: "Omicron"})
+
+	expiring, err := suite.repo.ViewExpiring(now.Add(2 * time.Hour))
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), expiring, 2)
+	assert.Equal(suite.T(), "cid-15", expiring[0].ContainerId)
+
+	expiring, err = suite.repo.ViewExpiring(now.Add(30 * time.Minute))
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), expiring, 1)
+
+	assert.NoError(suite.T(), suite.repo.MarkExpiryNotified([]string{"cid-15"}, now))
+	found, err := suite.repo.FindById("cid-15")
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), found.ExpiryNotifiedAt)
+
+	assert.NoError(suite.T(), suite.repo.UpdateExpiry("cid-15", &later))
+	found, err = suite.repo.FindById("cid-15")
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), found.ExpiryNotifiedAt)
+	assert.WithinDuration(suite.T(), later, *found.ExpiresAt, time.Second)
+
+	assert.NoError(suite.T(), suite.repo.Delete("cid-14"))
+	assert.NoError(suite.T(), suite.repo.Restore("cid-14"))
+	found, err = suite.repo.FindById("cid-14")
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), found.ExpiresAt)
+}
+
+func (suite *ContainerRepoSuite) TestPurge() {
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-13", ContainerName: "Mu"})
+	assert.NoError(suite.T(), suite.db.Create(&entities.Schedule{ID: "schedule-id", ContainerId: "cid-13", OwnerId: "user-id", Action: entities.ScheduleStop, Cron: "0 20 * * *", NextRunAt: time.Now()}).Error)
+	assert.NoError(suite.T(), suite.db.Create(&entities.ScheduleRun{ID: "run-id", ScheduleId: "schedule-id", ContainerId: "cid-13", Action: entities.ScheduleStop, Status: entities.ScheduleRunFailed, RanAt: time.Now()}).Error)
+	assert.NoError(suite.T(), suite.repo.Delete("cid-13"))
+	assert.NoError(suite.T(), suite.repo.Purge("cid-13"))
+
+	var count int64
+	suite.db.Unscoped().Model(&entities.Container{}).Where("container_id = ?", "cid-13").Count(&count)
+	assert.Zero(suite.T(), count)
+	suite.db.Model(&entities.Schedule{}).Where("container_id = ?", "cid-13").Count(&count)
+	assert.Zero(suite.T(), count)
+	suite.db.Model(&entities.ScheduleRun{}).Where("container_id = ?", "cid-13").Count(&count)
+	assert.Zero(suite.T(), count)
+}
+
+func (suite *ContainerRepoSuite) TestUpdateOwner() {
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-14", ContainerName: "Nu", OwnerId: "user-id"})
+	err := suite.repo.UpdateOwner("cid-14", "other-id")
+	assert.NoError(suite.T(), err)
+	found, _ := suite.repo.FindById("cid-14")
+	assert.Equal(suite.T(), "other-id", found.OwnerId)
+	assert.Equal(suite.T(), "Nu", found.ContainerName)
+}
+
+func (suite *ContainerRepoSuite) TestAssignUnowned() {
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-15", ContainerName: "Xi"})
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-16", ContainerName: "Omicron"})
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-17", ContainerName: "Pi", OwnerId: "other-id"})
+	_ = suite.repo.Delete("cid-16")
+
+	count, err := suite.repo.AssignUnowned("user-id")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), count)
+
+	found, _ := suite.repo.FindById("cid-15")
+	assert.Equal(suite.T(), "user-id", found.OwnerId)
+	found, _ = suite.repo.FindById("cid-17")
+	assert.Equal(suite.T(), "other-id", found.OwnerId)
+
+	var trashed entities.Container
+	suite.db.Unscoped().Where("container_id = ?", "cid-16").First(&trashed)
+	assert.Equal(suite.T(), "user-id", trashed.OwnerId)
+}
+
+func (suite *ContainerRepoSuite) TestUsage() {
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-11", ContainerName: "Kappa", OwnerId: "user-id", Spec: entities.ContainerSpec{Resources: entities.Resources{Memory: 256 << 20}}})
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-12", ContainerName: "Lambda", OwnerId: "user-id"})
+	_ = suite.repo.Create(&entities.Container{ContainerId: "cid-13", ContainerName: "Mu", OwnerId: "other-id", Spec: entities.ContainerSpec{Resources: entities.Resources{Memory: 128 << 20}}})
+
+	containers, memory, err := suite.repo.Usage("user-id")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), containers)
+	assert.Equal(suite.T(), int64(256<<20), memory)
+
+	containers, memory, err = suite.repo.Usage("nobody")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(0), containers)
+	assert.Equal(suite.T(), int64(0), memory)
+}
+
 func (suite *ContainerRepoSuite) TestBeginAndWithTransaction() {
 	tx, err := suite.repo.BeginTransaction(suite.T().Context())
 	assert.NoError(suite.T(), err)
 	txRepo := suite.repo.WithTransaction(tx)
-	_, err = txRepo.Create("cid-10", "Iota", entities.ContainerOn, "10.0.0.10")
+	err = txRepo.Create(&entities.Container{ContainerId: "cid-10", ContainerName: "Iota", Status: entities.ContainerOn, Ipv4: "10.0.0.10"})
 	assert.NoError(suite.T(), err)
 	tx.Rollback()
 	_, err = suite.repo.FindById("cid-10")
 	assert.Error(suite.T(), err)
 }
 
+func (suite *ContainerRepoSuite) TestBeginTransactionCanceled() {
+	ctx, cancel := context.WithCancel(suite.T().Context())
+	cancel()
+	_, err := suite.repo.BeginTransaction(ctx)
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+}
+
 func (suite *ContainerRepoSuite) TestBeginTransactionError() {
 	sqlDB, _ := suite.db.DB()
 	sqlDB.Close()
@@ -0,0 +1,188 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"gorm.io/gorm"
+)
+
+type IJobRepository interface {
+	Create(jobType entities.JobType, ownerId string, items []entities.JobItem) (*entities.Job, error)
+	FindById(jobId string) (*entities.Job, error)
+	View(ownerId string, status entities.JobStatus) ([]*entities.Job, error)
+	Claim(instanceId string) (*entities.Job, error)
+	Renew(jobId string, instanceId string, claimedAt time.Time) (bool, error)
+	ViewExpired(claimedBefore time.Time) ([]*entities.Job, error)
+	Requeue(job *entities.Job, claimedBefore time.Time) (bool, error)
+	Update(job *entities.Job) error
+	RequestCancel(jobId string) (bool, error)
+	CancelPending(jobId string, finishedAt time.Time) (bool, error)
+}
+
+type jobRepository struct {
+	db *gorm.DB
+}
+
+func NewJobRepository(db *gorm.DB) IJobRepository {
+	return &jobRepository{db: db}
+}
+
+func (r *jobRepository) Create(jobType entities.JobType, ownerId string, items []entities.JobItem) (*entities.Job, error) {
+	job := &entities.Job{
+		ID:      uuid.New().String(),
+		Type:    jobType,
+		Status:  entities.JobPending,
+		OwnerId: ownerId,
+		Items:   items,
+	}
+	res := r.db.Create(job)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return job, nil
+}
+
+func (r *jobRepository) FindById(jobId string) (*entities.Job, error) {
+	var job entities.Job
+	res := r.db.First(&job, entities.Job{ID: jobId})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &job, nil
+}
+
+// View lists the jobs newest first, an empty owner or status matches every job.
+func (r *jobRepository) View(ownerId string, status entities.JobStatus) ([]*entities.Job, error) {
+	query := r.db.Order("created_at desc")
+	if ownerId != "" {
+		query = query.Where("owner_id = ?", ownerId)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var jobs []*entities.Job
+	res := query.Find(&jobs)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return jobs, nil
+}
+
+// Claim moves the oldest pending job to running under the lease of the instance and returns it, or
+// gorm.ErrRecordNotFound when the queue is empty. The status guard on the update lets concurrent workers race for the
+// same job safely.
+func (r *jobRepository) Claim(instanceId string) (*entities.Job, error) {
+	for {
+		var job entities.Job
+		res := r.db.Where("status = ?", entities.JobPending).Order("created_at").First(&job)
+		if res.Error != nil {
+			return nil, res.Error
+		}
+
+		startedAt := time.Now()
+		res = r.db.Model(&entities.Job{}).
+			Where("id = ? AND status = ?", job.ID, entities.JobPending).
+			Updates(map[string]any{"status": entities.JobRunning, "started_at": startedAt, "claimed_by": instanceId, "claimed_at": startedAt})
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		if res.RowsAffected == 1 {
+			job.Status = entities.JobRunning
+			job.StartedAt = &startedAt
+			job.ClaimedBy = instanceId
+			job.ClaimedAt = &startedAt
+			return &job, nil
+		}
+	}
+}
+
+// Renew extends the lease of the instance on a job and reports whether it still held it.
+func (r *jobRepository) Renew(jobId string, instanceId string, claimedAt time.Time) (bool, error) {
+	res := r.db.Model(&entities.Job{}).
+		Where("id = ? AND claimed_by = ?", jobId, instanceId).
+		Update("claimed_at", claimedAt)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+// ViewExpired lists the running jobs whose lease was last renewed before the given time, oldest first.
+func (r *jobRepository) ViewExpired(claimedBefore time.Time) ([]*entities.Job, error) {
+	var jobs []*entities.Job
+	res := r.db.Where("status = ? AND (claimed_at IS NULL OR claimed_at < ?)", entities.JobRunning, claimedBefore).
+		Order("created_at").
+		Find(&jobs)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return jobs, nil
+}
+
+// Requeue puts a running job back in the queue and reports whether it did. The guard on the lease leaves alone a job
+// whose worker renewed it in the meantime.
+func (r *jobRepository) Requeue(job *entities.Job, claimedBefore time.Time) (bool, error) {
+	job.Status = entities.JobPending
+	job.ClaimedBy = ""
+	job.ClaimedAt = nil
+	res := r.db.Model(job).
+		Where("status = ? AND (claimed_at IS NULL OR claimed_at < ?)", entities.JobRunning, claimedBefore).
+		Select("status", "items", "claimed_by", "claimed_at", "updated_at").
+		Updates(job)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+// Update saves the progress of a job, leaving the cancel flag to RequestCancel. Nothing is saved once the job was
+// requeued away from the instance that claimed it.
+func (r *jobRepository) Update(job *entities.Job) error {
+	res := r.db.Model(job).
+		Where("claimed_by = ?", job.ClaimedBy).
+		Select("status", "items", "started_at", "finished_at", "updated_at").
+		Updates(job)
+	return res.Error
+}
+
+// RequestCancel flags an unfinished job for cancellation and reports whether there was one.
+func (r *jobRepository) RequestCancel(jobId string) (bool, error) {
+	res := r.db.Model(&entities.Job{}).
+		Where("id = ? AND status IN ?", jobId, []entities.JobStatus{entities.JobPending, entities.JobRunning}).
+		Update("cancel_requested", true)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+// CancelPending cancels a job no worker has claimed yet and reports whether it did.
+func (r *jobRepository) CancelPending(jobId string, finishedAt time.Time) (bool, error) {
+	job, err := r.FindById(jobId)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && job.Status != entities.JobPending) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for i := range job.Items {
+		if !job.Items[i].Status.IsFinished() {
+			job.Items[i].Status = entities.JobCanceled
+		}
+	}
+	job.Status = entities.JobCanceled
+	job.FinishedAt = &finishedAt
+	res := r.db.Model(job).
+		Where("status = ?", entities.JobPending).
+		Select("status", "items", "finished_at", "updated_at").
+		Updates(job)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
@@ -0,0 +1,239 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+)
+
+type JobRepoSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo IJobRepository
+}
+
+func (suite *JobRepoSuite) SetupTest() {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	assert.NoError(suite.T(), err)
+	err = gormDB.AutoMigrate(&entities.Job{})
+	assert.NoError(suite.T(), err)
+	suite.db = gormDB
+	suite.repo = NewJobRepository(gormDB)
+}
+
+func (suite *JobRepoSuite) TearDownTest() {
+	sqlDB, err := suite.db.DB()
+	assert.NoError(suite.T(), err)
+	sqlDB.Close()
+}
+
+func TestJobRepoSuite(t *testing.T) {
+	suite.Run(t, new(JobRepoSuite))
+}
+
+func (suite *JobRepoSuite) createJob(ownerId string) *entities.Job {
+	job, err := suite.repo.Create(entities.JobImport, ownerId, []entities.JobItem{
+		{ContainerName: "web", ImageName: "nginx", Status: entities.JobPending},
+		{ContainerName: "", ImageName: "nginx", Status: entities.JobFailed, Error: "container name is required"},
+	})
+	assert.NoError(suite.T(), err)
+	return job
+}
+
+func (suite *JobRepoSuite) TestCreateAndFind() {
+	job := suite.createJob("user-id")
+	assert.NotEmpty(suite.T(), job.ID)
+	assert.Equal(suite.T(), entities.JobPending, job.Status)
+
+	found, err := suite.repo.FindById(job.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entities.JobImport, found.Type)
+	assert.Equal(suite.T(), "user-id", found.OwnerId)
+	assert.Equal(suite.T(), job.Items, found.Items)
+}
+
+func (suite *JobRepoSuite) TestFindNotFound() {
+	_, err := suite.repo.FindById("not-exist")
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *JobRepoSuite) TestView() {
+	suite.createJob("user-id")
+	suite.createJob("other-id")
+
+	jobs, err := suite.repo.View("", "")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), jobs, 2)
+
+	jobs, err = suite.repo.View("user-id", entities.JobPending)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), jobs, 1)
+
+	jobs, err = suite.repo.View("", entities.JobRunning)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), jobs)
+}
+
+func (suite *JobRepoSuite) TestClaim() {
+	first := suite.createJob("user-id")
+	suite.db.Model(first).Update("created_at", time.Now().Add(-time.Minute))
+	second := suite.createJob("user-id")
+
+	claimed, err := suite.repo.Claim("instance-id")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), first.ID, claimed.ID)
+	assert.Equal(suite.T(), entities.JobRunning, claimed.Status)
+	assert.NotNil(suite.T(), claimed.StartedAt)
+	assert.Equal(suite.T(), "instance-id", claimed.ClaimedBy)
+	assert.NotNil(suite.T(), claimed.ClaimedAt)
+
+	claimed, err = suite.repo.Claim("instance-id")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), second.ID, claimed.ID)
+
+	_, err = suite.repo.Claim("instance-id")
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *JobRepoSuite) TestRenew() {
+	job := suite.createJob("user-id")
+	_, err := suite.repo.Claim("instance-id")
+	assert.NoError(suite.T(), err)
+
+	claimedAt := time.Now().Add(time.Minute)
+	ok, err := suite.repo.Renew(job.ID, "instance-id", claimedAt)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), ok)
+	found, _ := suite.repo.FindById(job.ID)
+	assert.WithinDuration(suite.T(), claimedAt, *found.ClaimedAt, time.Millisecond)
+
+	ok, err = suite.repo.Renew(job.ID, "other-instance", time.Now())
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), ok)
+}
+
+func (suite *JobRepoSuite) TestViewExpiredAndRequeue() {
+	expired := suite.createJob("user-id")
+	suite.db.Model(expired).Update("created_at", time.Now().Add(-time.Minute))
+	renewed := suite.createJob("user-id")
+	suite.db.Model(renewed).Update("created_at", time.Now().Add(-time.Second))
+	live := suite.createJob("user-id")
+	for _, instanceId := range []string{"old-instance", "slow-instance", "live-instance"} {
+		_, err := suite.repo.Claim(instanceId)
+		assert.NoError(suite.T(), err)
+	}
+	suite.db.Model(&entities.Job{}).Where("id IN ?", []string{expired.ID, renewed.ID}).Update("claimed_at", time.Now().Add(-time.Hour))
+
+	claimedBefore := time.Now().Add(-time.Minute)
+	jobs, err := suite.repo.ViewExpired(claimedBefore)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), jobs, 2)
+	assert.Equal(suite.T(), expired.ID, jobs[0].ID)
+	assert.Equal(suite.T(), renewed.ID, jobs[1].ID)
+
+	// A lease renewed after the job was listed keeps it from being requeued.
+	_, err = suite.repo.Renew(renewed.ID, "slow-instance", time.Now())
+	assert.NoError(suite.T(), err)
+	ok, err := suite.repo.Requeue(jobs[1], claimedBefore)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), ok)
+
+	jobs[0].Items[0].Status = entities.JobFailed
+	ok, err = suite.repo.Requeue(jobs[0], claimedBefore)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), ok)
+
+	found, _ := suite.repo.FindById(expired.ID)
+	assert.Equal(suite.T(), entities.JobPending, found.Status)
+	assert.Empty(suite.T(), found.ClaimedBy)
+	assert.Nil(suite.T(), found.ClaimedAt)
+	assert.Equal(suite.T(), entities.JobFailed, found.Items[0].Status)
+
+	found, _ = suite.repo.FindById(live.ID)
+	assert.Equal(suite.T(), entities.JobRunning, found.Status)
+}
+
+func (suite *JobRepoSuite) TestUpdateAfterRequeue() {
+	job := suite.createJob("user-id")
+	claimed, err := suite.repo.Claim("instance-id")
+	assert.NoError(suite.T(), err)
+	_, err = suite.repo.Requeue(&entities.Job{ID: job.ID, Items: job.Items}, time.Now().Add(time.Minute))
+	assert.NoError(suite.T(), err)
+
+	claimed.Status = entities.JobSucceeded
+	assert.NoError(suite.T(), suite.repo.Update(claimed))
+
+	found, _ := suite.repo.FindById(job.ID)
+	assert.Equal(suite.T(), entities.JobPending, found.Status)
+}
+
+func (suite *JobRepoSuite) TestUpdateKeepsCancelFlag() {
+	job := suite.createJob("user-id")
+	ok, err := suite.repo.RequestCancel(job.ID)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), ok)
+
+	job.Status = entities.JobRunning
+	job.Items[0].Status = entities.JobSucceeded
+	job.Items[0].ContainerId = "container-id"
+	err = suite.repo.Update(job)
+	assert.NoError(suite.T(), err)
+
+	found, err := suite.repo.FindById(job.ID)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), found.CancelRequested)
+	assert.Equal(suite.T(), entities.JobRunning, found.Status)
+	assert.Equal(suite.T(), "container-id", found.Items[0].ContainerId)
+}
+
+func (suite *JobRepoSuite) TestRequestCancelFinishedJob() {
+	job := suite.createJob("user-id")
+	job.Status = entities.JobSucceeded
+	assert.NoError(suite.T(), suite.repo.Update(job))
+
+	ok, err := suite.repo.RequestCancel(job.ID)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), ok)
+}
+
+func (suite *JobRepoSuite) TestCancelPending() {
+	job := suite.createJob("user-id")
+
+	ok, err := suite.repo.CancelPending(job.ID, time.Now())
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), ok)
+
+	found, err := suite.repo.FindById(job.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entities.JobCanceled, found.Status)
+	assert.NotNil(suite.T(), found.FinishedAt)
+	assert.Equal(suite.T(), entities.JobCanceled, found.Items[0].Status)
+	assert.Equal(suite.T(), entities.JobFailed, found.Items[1].Status)
+
+	ok, err = suite.repo.CancelPending(job.ID, time.Now())
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), ok)
+}
+
+func (suite *JobRepoSuite) TestCancelPendingClaimedJob() {
+	job := suite.createJob("user-id")
+	_, err := suite.repo.Claim("instance-id")
+	assert.NoError(suite.T(), err)
+
+	ok, err := suite.repo.CancelPending(job.ID, time.Now())
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), ok)
+
+	ok, err = suite.repo.CancelPending("not-exist", time.Now())
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), ok)
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IQuotaRepository interface {
+	Find(subjectType entities.QuotaSubjectType, subject string) (*entities.Quota, error)
+	View() ([]*entities.Quota, error)
+	Upsert(quota *entities.Quota) error
+	Delete(subjectType entities.QuotaSubjectType, subject string) error
+	BeginTransaction(ctx context.Context) (*gorm.DB, error)
+	WithTransaction(tx *gorm.DB) IQuotaRepository
+}
+
+type quotaRepository struct {
+	db *gorm.DB
+}
+
+func NewQuotaRepository(db *gorm.DB) IQuotaRepository {
+	return &quotaRepository{db: db}
+}
+
+func (r *quotaRepository) Find(subjectType entities.QuotaSubjectType, subject string) (*entities.Quota, error) {
+	var quota entities.Quota
+	res := r.db.First(&quota, entities.Quota{SubjectType: subjectType, Subject: subject})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &quota, nil
+}
+
+func (r *quotaRepository) View() ([]*entities.Quota, error) {
+	var quotas []*entities.Quota
+	res := r.db.Order("subject_type, subject").Find(&quotas)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return quotas, nil
+}
+
+func (r *quotaRepository) Upsert(quota *entities.Quota) error {
+	res := r.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(quota)
+	return res.Error
+}
+
+func (r *quotaRepository) Delete(subjectType entities.QuotaSubjectType, subject string) error {
+	res := r.db.Where("subject_type = ? AND subject = ?", subjectType, subject).Delete(&entities.Quota{})
+	return res.Error
+}
+
+func (r *quotaRepository) BeginTransaction(ctx context.Context) (*gorm.DB, error) {
+	tx := r.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	return tx, nil
+}
+
+func (r *quotaRepository) WithTransaction(tx *gorm.DB) IQuotaRepository {
+	return &quotaRepository{db: tx}
+}
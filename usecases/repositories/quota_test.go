@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+)
+
+type QuotaRepoSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo IQuotaRepository
+}
+
+func (suite *QuotaRepoSuite) SetupTest() {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	assert.NoError(suite.T(), err)
+	err = gormDB.AutoMigrate(&entities.Quota{})
+	assert.NoError(suite.T(), err)
+	suite.db = gormDB
+	suite.repo = NewQuotaRepository(gormDB)
+}
+
+func (suite *QuotaRepoSuite) TearDownTest() {
+	sqlDB, err := suite.db.DB()
+	assert.NoError(suite.T(), err)
+	sqlDB.Close()
+}
+
+func TestQuotaRepoSuite(t *testing.T) {
+	suite.Run(t, new(QuotaRepoSuite))
+}
+
+func (suite *QuotaRepoSuite) TestUpsertAndFind() {
+	err := suite.repo.Upsert(&entities.Quota{SubjectType: entities.QuotaRole, Subject: "developer", MaxContainers: 5})
+	assert.NoError(suite.T(), err)
+	err = suite.repo.Upsert(&entities.Quota{SubjectType: entities.QuotaRole, Subject: "developer", MaxContainers: 10, MaxMemory: 1 << 30})
+	assert.NoError(suite.T(), err)
+
+	found, err := suite.repo.Find(entities.QuotaRole, "developer")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(10), found.MaxContainers)
+	assert.Equal(suite.T(), int64(1<<30), found.MaxMemory)
+}
+
+func (suite *QuotaRepoSuite) TestFindNotFound() {
+	_, err := suite.repo.Find(entities.QuotaUser, "not-exist")
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *QuotaRepoSuite) TestView() {
+	_ = suite.repo.Upsert(&entities.Quota{SubjectType: entities.QuotaUser, Subject: "user-id", MaxContainers: 1})
+	_ = suite.repo.Upsert(&entities.Quota{SubjectType: entities.QuotaRole, Subject: "developer", MaxContainers: 5})
+
+	quotas, err := suite.repo.View()
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), quotas, 2)
+	assert.Equal(suite.T(), entities.QuotaRole, quotas[0].SubjectType)
+}
+
+func (suite *QuotaRepoSuite) TestDelete() {
+	_ = suite.repo.Upsert(&entities.Quota{SubjectType: entities.QuotaUser, Subject: "user-id", MaxContainers: 1})
+	err := suite.repo.Delete(entities.QuotaUser, "user-id")
+	assert.NoError(suite.T(), err)
+	_, err = suite.repo.Find(entities.QuotaUser, "user-id")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *QuotaRepoSuite) TestBeginAndWithTransaction() {
+	tx, err := suite.repo.BeginTransaction(suite.T().Context())
+	assert.NoError(suite.T(), err)
+	txRepo := suite.repo.WithTransaction(tx)
+	err = txRepo.Upsert(&entities.Quota{SubjectType: entities.QuotaUser, Subject: "user-id", MaxContainers: 1})
+	assert.NoError(suite.T(), err)
+	tx.Rollback()
+	_, err = suite.repo.Find(entities.QuotaUser, "user-id")
+	assert.Error(suite.T(), err)
+}
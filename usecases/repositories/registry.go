@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IRegistryRepository interface {
+	Find(registry string) (*entities.RegistryCredential, error)
+	View() ([]*entities.RegistryCredential, error)
+	Upsert(credential *entities.RegistryCredential) error
+	Delete(registry string) error
+}
+
+type registryRepository struct {
+	db *gorm.DB
+}
+
+func NewRegistryRepository(db *gorm.DB) IRegistryRepository {
+	return &registryRepository{db: db}
+}
+
+func (r *registryRepository) Find(registry string) (*entities.RegistryCredential, error) {
+	var credential entities.RegistryCredential
+	res := r.db.First(&credential, entities.RegistryCredential{Registry: registry})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &credential, nil
+}
+
+func (r *registryRepository) View() ([]*entities.RegistryCredential, error) {
+	var credentials []*entities.RegistryCredential
+	res := r.db.Order("registry").Find(&credentials)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return credentials, nil
+}
+
+func (r *registryRepository) Upsert(credential *entities.RegistryCredential) error {
+	res := r.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(credential)
+	return res.Error
+}
+
+func (r *registryRepository) Delete(registry string) error {
+	res := r.db.Where("registry = ?", registry).Delete(&entities.RegistryCredential{})
+	return res.Error
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+)
+
+type RegistryRepoSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo IRegistryRepository
+}
+
+func (suite *RegistryRepoSuite) SetupTest() {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	assert.NoError(suite.T(), err)
+	err = gormDB.AutoMigrate(&entities.RegistryCredential{})
+	assert.NoError(suite.T(), err)
+	suite.db = gormDB
+	suite.repo = NewRegistryRepository(gormDB)
+}
+
+func (suite *RegistryRepoSuite) TearDownTest() {
+	sqlDB, err := suite.db.DB()
+	assert.NoError(suite.T(), err)
+	sqlDB.Close()
+}
+
+func TestRegistryRepoSuite(t *testing.T) {
+	suite.Run(t, new(RegistryRepoSuite))
+}
+
+func (suite *RegistryRepoSuite) TestUpsertAndFind() {
+	err := suite.repo.Upsert(&entities.RegistryCredential{Registry: "ghcr.io", Username: "user", Password: "password"})
+	assert.NoError(suite.T(), err)
+	err = suite.repo.Upsert(&entities.RegistryCredential{Registry: "ghcr.io", Token: "token"})
+	assert.NoError(suite.T(), err)
+
+	found, err := suite.repo.Find("ghcr.io")
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), found.Username)
+	assert.Empty(suite.T(), found.Password)
+	assert.Equal(suite.T(), "token", found.Token)
+}
+
+func (suite *RegistryRepoSuite) TestFindNotFound() {
+	_, err := suite.repo.Find("not-exist")
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *RegistryRepoSuite) TestView() {
+	_ = suite.repo.Upsert(&entities.RegistryCredential{Registry: "registry.example.com", Token: "token"})
+	_ = suite.repo.Upsert(&entities.RegistryCredential{Registry: "ghcr.io", Token: "token"})
+
+	credentials, err := suite.repo.View()
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), credentials, 2)
+	assert.Equal(suite.T(), "ghcr.io", credentials[0].Registry)
+}
+
+func (suite *RegistryRepoSuite) TestDelete() {
+	_ = suite.repo.Upsert(&entities.RegistryCredential{Registry: "ghcr.io", Token: "token"})
+	err := suite.repo.Delete("ghcr.io")
+	assert.NoError(suite.T(), err)
+	_, err = suite.repo.Find("ghcr.io")
+	assert.Error(suite.T(), err)
+}
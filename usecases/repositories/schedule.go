@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"gorm.io/gorm"
+)
+
+type IScheduleRepository interface {
+	Create(schedule *entities.Schedule) error
+	FindById(scheduleId string) (*entities.Schedule, error)
+	View(containerId string) ([]*entities.Schedule, error)
+	ViewDue(now time.Time) ([]*entities.Schedule, error)
+	Update(schedule *entities.Schedule) error
+	Delete(scheduleId string) error
+	Claim(schedule *entities.Schedule, nextRunAt time.Time, ranAt time.Time) (bool, error)
+	CreateRun(run *entities.ScheduleRun) error
+	ViewRuns(scheduleId string, limit int) ([]*entities.ScheduleRun, error)
+}
+
+type scheduleRepository struct {
+	db *gorm.DB
+}
+
+func NewScheduleRepository(db *gorm.DB) IScheduleRepository {
+	return &scheduleRepository{db: db}
+}
+
+func (r *scheduleRepository) Create(schedule *entities.Schedule) error {
+	schedule.ID = uuid.New().String()
+	res := r.db.Create(schedule)
+	return res.Error
+}
+
+func (r *scheduleRepository) FindById(scheduleId string) (*entities.Schedule, error) {
+	var schedule entities.Schedule
+	res := r.db.First(&schedule, entities.Schedule{ID: scheduleId})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &schedule, nil
+}
+
+// View lists the schedules of a container, oldest first.
+func (r *scheduleRepository) View(containerId string) ([]*entities.Schedule, error) {
+	var schedules []*entities.Schedule
+	res := r.db.Where("container_id = ?", containerId).Order("created_at").Find(&schedules)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return schedules, nil
+}
+
+// ViewDue lists the enabled schedules whose next run is not after now, the most overdue first. The schedules of a
+// container in the trash are left out until it is restored.
+func (r *scheduleRepository) ViewDue(now time.Time) ([]*entities.Schedule, error) {
+	var schedules []*entities.Schedule
+	res := r.db.Where("enabled = ? AND next_run_at <= ?", true, now).
+		Where("container_id IN (?)", r.db.Model(&entities.Container{}).Select("container_id")).
+		Order("next_run_at").
+		Find(&schedules)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return schedules, nil
+}
+
+// Update saves the definition of a schedule, leaving its last run to Claim.
+func (r *scheduleRepository) Update(schedule *entities.Schedule) error {
+	res := r.db.Model(schedule).Select("action", "cron", "timezone", "enabled", "next_run_at", "updated_at").Updates(schedule)
+	return res.Error
+}
+
+// Delete removes a schedule along with its runs.
+func (r *scheduleRepository) Delete(scheduleId string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entities.ScheduleRun{}, "schedule_id = ?", scheduleId).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entities.Schedule{}, "id = ?", scheduleId).Error
+	})
+}
+
+// Claim moves a due schedule to its next run and reports whether it did. The guard on the run it was due for lets
+// concurrent schedulers race for the same run safely.
+func (r *scheduleRepository) Claim(schedule *entities.Schedule, nextRunAt time.Time, ranAt time.Time) (bool, error) {
+	res := r.db.Model(&entities.Schedule{}).
+		Where("id = ? AND next_run_at = ?", schedule.ID, schedule.NextRunAt).
+		Updates(map[string]any{"next_run_at": nextRunAt, "last_run_at": ranAt})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (r *scheduleRepository) CreateRun(run *entities.ScheduleRun) error {
+	run.ID = uuid.New().String()
+	res := r.db.Create(run)
+	return res.Error
+}
+
+// ViewRuns lists the latest runs of a schedule, newest first.
+func (r *scheduleRepository) ViewRuns(scheduleId string, limit int) ([]*entities.ScheduleRun, error) {
+	var runs []*entities.ScheduleRun
+	res := r.db.Where("schedule_id = ?", scheduleId).Order("ran_at desc").Limit(limit).Find(&runs)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return runs, nil
+}
@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+)
+
+type ScheduleRepoSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo IScheduleRepository
+}
+
+func (suite *ScheduleRepoSuite) SetupTest() {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	assert.NoError(suite.T(), err)
+	err = gormDB.AutoMigrate(&entities.Container{}, &entities.Schedule{}, &entities.ScheduleRun{})
+	assert.NoError(suite.T(), err)
+	suite.db = gormDB
+	suite.repo = NewScheduleRepository(gormDB)
+}
+
+func (suite *ScheduleRepoSuite) TearDownTest() {
+	sqlDB, err := suite.db.DB()
+	assert.NoError(suite.T(), err)
+	sqlDB.Close()
+}
+
+func TestScheduleRepoSuite(t *testing.T) {
+	suite.Run(t, new(ScheduleRepoSuite))
+}
+
+func (suite *ScheduleRepoSuite) newSchedule(containerId string, nextRunAt time.Time, enabled bool) *entities.Schedule {
+	schedule := &entities.Schedule{
+		ContainerId: containerId,
+		OwnerId:     "user-id",
+		Action:      entities.ScheduleStop,
+		Cron:        "0 20 * * 1-5",
+		Timezone:    "Asia/Ho_Chi_Minh",
+		Enabled:     enabled,
+		NextRunAt:   nextRunAt,
+	}
+	err := suite.repo.Create(schedule)
+	assert.NoError(suite.T(), err)
+	return schedule
+}
+
+func (suite *ScheduleRepoSuite) TestCreateAndFindById() {
+	schedule := suite.newSchedule("cid-1", time.Now(), true)
+	assert.NotEmpty(suite.T(), schedule.ID)
+
+	found, err := suite.repo.FindById(schedule.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "0 20 * * 1-5", found.Cron)
+	assert.Equal(suite.T(), "Asia/Ho_Chi_Minh", found.Timezone)
+	assert.Nil(suite.T(), found.LastRunAt)
+
+	_, err = suite.repo.FindById("missing")
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *ScheduleRepoSuite) TestView() {
+	suite.newSchedule("cid-1", time.Now(), true)
+	suite.newSchedule("cid-1", time.Now(), false)
+	suite.newSchedule("cid-2", time.Now(), true)
+
+	schedules, err := suite.repo.View("cid-1")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), schedules, 2)
+}
+
+func (suite *ScheduleRepoSuite) TestViewDue() {
+	for _, containerId := range []string{"cid-1", "cid-2", "cid-3", "cid-4", "cid-5"} {
+		assert.NoError(suite.T(), suite.db.Create(&entities.Container{ContainerId: containerId, ContainerName: containerId}).Error)
+	}
+	assert.NoError(suite.T(), suite.db.Delete(&entities.Container{}, "container_id = ?", "cid-5").Error)
+
+	now := time.Now()
+	late := suite.newSchedule("cid-1", now.Add(-2*time.Minute), true)
+	due := suite.newSchedule("cid-2", now.Add(-time.Minute), true)
+	suite.newSchedule("cid-3", now.Add(time.Minute), true)
+	suite.newSchedule("cid-4", now.Add(-time.Minute), false)
+	suite.newSchedule("cid-5", now.Add(-time.Minute), true)
+	suite.newSchedule("purged", now.Add(-time.Minute), true)
+
+	schedules, err := suite.repo.ViewDue(now)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), schedules, 2)
+	assert.Equal(suite.T(), late.ID, schedules[0].ID)
+	assert.Equal(suite.T(), due.ID, schedules[1].ID)
+}
+
+func (suite *ScheduleRepoSuite) TestUpdate() {
+	schedule := suite.newSchedule("cid-1", time.Now(), true)
+	schedule.Action = entities.ScheduleStart
+	schedule.Cron = "0 8 * * 1-5"
+	schedule.Enabled = false
+	err := suite.repo.Update(schedule)
+	assert.NoError(suite.T(), err)
+
+	found, err := suite.repo.FindById(schedule.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entities.ScheduleStart, found.Action)
+	assert.Equal(suite.T(), "0 8 * * 1-5", found.Cron)
+	assert.False(suite.T(), found.Enabled)
+}
+
+func (suite *ScheduleRepoSuite) TestClaim() {
+	now := time.Now()
+	schedule := suite.newSchedule("cid-1", now.Add(-time.Minute), true)
+	next := now.Add(time.Hour)
+
+	claimed, err := suite.repo.Claim(schedule, next, now)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), claimed)
+
+	claimed, err = suite.repo.Claim(schedule, next, now)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), claimed)
+
+	found, err := suite.repo.FindById(schedule.ID)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), next.Equal(found.NextRunAt))
+	assert.True(suite.T(), now.Equal(*found.LastRunAt))
+}
+
+func (suite *ScheduleRepoSuite) TestRunsAndDelete() {
+	schedule := suite.newSchedule("cid-1", time.Now(), true)
+	ranAt := time.Unix(1700000000, 0)
+	err := suite.repo.CreateRun(&entities.ScheduleRun{ScheduleId: schedule.ID, ContainerId: "cid-1", Action: entities.ScheduleStop, Status: entities.ScheduleRunFailed, Error: "daemon error", RanAt: ranAt})
+	assert.NoError(suite.T(), err)
+	err = suite.repo.CreateRun(&entities.ScheduleRun{ScheduleId: schedule.ID, ContainerId: "cid-1", Action: entities.ScheduleStop, Status: entities.ScheduleRunSucceeded, RanAt: ranAt.Add(time.Hour)})
+	assert.NoError(suite.T(), err)
+
+	runs, err := suite.repo.ViewRuns(schedule.ID, 1)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), runs, 1)
+	assert.Equal(suite.T(), entities.ScheduleRunSucceeded, runs[0].Status)
+
+	err = suite.repo.Delete(schedule.ID)
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.repo.FindById(schedule.ID)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+	runs, err = suite.repo.ViewRuns(schedule.ID, 10)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), runs)
+}
+
+func (suite *ScheduleRepoSuite) TestViewDueWhileDbClose() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+
+	_, err := suite.repo.ViewDue(time.Now())
+	assert.Error(suite.T(), err)
+}
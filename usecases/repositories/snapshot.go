@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"github.com/google/uuid"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"gorm.io/gorm"
+)
+
+type ISnapshotRepository interface {
+	Create(snapshot *entities.Snapshot) error
+	FindById(snapshotId string) (*entities.Snapshot, error)
+	View(containerId string) ([]*entities.Snapshot, error)
+}
+
+type snapshotRepository struct {
+	db *gorm.DB
+}
+
+func NewSnapshotRepository(db *gorm.DB) ISnapshotRepository {
+	return &snapshotRepository{db: db}
+}
+
+func (r *snapshotRepository) Create(snapshot *entities.Snapshot) error {
+	snapshot.ID = uuid.New().String()
+	res := r.db.Create(snapshot)
+	return res.Error
+}
+
+func (r *snapshotRepository) FindById(snapshotId string) (*entities.Snapshot, error) {
+	var snapshot entities.Snapshot
+	res := r.db.First(&snapshot, entities.Snapshot{ID: snapshotId})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &snapshot, nil
+}
+
+// View lists the snapshots of a container, newest first.
+func (r *snapshotRepository) View(containerId string) ([]*entities.Snapshot, error) {
+	var snapshots []*entities.Snapshot
+	res := r.db.Where("container_id = ?", containerId).Order("created_at desc").Find(&snapshots)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return snapshots, nil
+}
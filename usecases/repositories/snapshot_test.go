@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+)
+
+type SnapshotRepoSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo ISnapshotRepository
+}
+
+func (suite *SnapshotRepoSuite) SetupTest() {
+	gormDB, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	assert.NoError(suite.T(), err)
+	err = gormDB.AutoMigrate(&entities.Snapshot{})
+	assert.NoError(suite.T(), err)
+	suite.db = gormDB
+	suite.repo = NewSnapshotRepository(gormDB)
+}
+
+func (suite *SnapshotRepoSuite) TearDownTest() {
+	sqlDB, err := suite.db.DB()
+	assert.NoError(suite.T(), err)
+	sqlDB.Close()
+}
+
+func TestSnapshotRepoSuite(t *testing.T) {
+	suite.Run(t, new(SnapshotRepoSuite))
+}
+
+func (suite *SnapshotRepoSuite) TestCreateAndFindById() {
+	snapshot := &entities.Snapshot{
+		ContainerId: "cid-1",
+		ImageId:     "sha256:abc",
+		ImageName:   "vcs-sms-snapshots/cid-1:v1",
+		OwnerId:     "user-id",
+		Spec:        entities.ContainerSpec{Env: []string{"KEY=value"}},
+	}
+	err := suite.repo.Create(snapshot)
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), snapshot.ID)
+
+	found, err := suite.repo.FindById(snapshot.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "vcs-sms-snapshots/cid-1:v1", found.ImageName)
+	assert.Equal(suite.T(), []string{"KEY=value"}, found.Spec.Env)
+
+	_, err = suite.repo.FindById("missing")
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *SnapshotRepoSuite) TestView() {
+	created := time.Unix(1700000000, 0)
+	_ = suite.repo.Create(&entities.Snapshot{ContainerId: "cid-1", ImageId: "sha256:1", ImageName: "old", CreatedAt: created})
+	_ = suite.repo.Create(&entities.Snapshot{ContainerId: "cid-1", ImageId: "sha256:2", ImageName: "new", CreatedAt: created.Add(time.Hour)})
+	_ = suite.repo.Create(&entities.Snapshot{ContainerId: "cid-2", ImageId: "sha256:3", ImageName: "other", CreatedAt: created})
+
+	snapshots, err := suite.repo.View("cid-1")
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), snapshots, 2)
+	assert.Equal(suite.T(), "new", snapshots[0].ImageName)
+	assert.Equal(suite.T(), "old", snapshots[1].ImageName)
+}
+
+func (suite *SnapshotRepoSuite) TestViewWhileDbClose() {
+	sqlDB, _ := suite.db.DB()
+	sqlDB.Close()
+
+	_, err := suite.repo.View("cid-1")
+	assert.Error(suite.T(), err)
+}
@@ -5,18 +5,23 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/utils"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type IUserRepository interface {
 	FindById(userId string) (*entities.User, error)
+	FindByIdForUpdate(userId string) (*entities.User, error)
 	FindByName(username string) (*entities.User, error)
 	FindByEmail(email string) (*entities.User, error)
 	Create(username, hash, email string, role entities.UserRole, scopes int64) (*entities.User, error)
 	UpdatePassword(user *entities.User, hash string) error
 	UpdateRole(user *entities.User, role entities.UserRole) error
 	UpdateScope(user *entities.User, scopes int64) error
+	ViewOutdatedScopes(version int) ([]*entities.User, error)
+	UpgradeScopes(user *entities.User, scopes int64, version int) error
 	Delete(userId string) error
 	BeginTransaction(ctx context.Context) (*gorm.DB, error)
 	WithTransaction(tx *gorm.DB) IUserRepository
@@ -39,6 +44,16 @@ func (r *userRepository) FindById(userId string) (*entities.User, error) {
 	return &user, nil
 }
 
+// FindByIdForUpdate locks the user's row until the transaction of the repository ends.
+func (r *userRepository) FindByIdForUpdate(userId string) (*entities.User, error) {
+	var user entities.User
+	res := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, entities.User{ID: userId})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &user, nil
+}
+
 func (r *userRepository) FindByName(username string) (*entities.User, error) {
 	var user entities.User
 	res := r.db.First(&user, entities.User{Username: username})
@@ -65,6 +80,8 @@ func (r *userRepository) Create(username, hash, email string, role entities.User
 		Email:    email,
 		Role:     role,
 		Scopes:   scopes,
+		// The scopes of a new user are granted by the current scope list.
+		ScopeVersion: utils.NumberOfScopes(),
 	}
 	res := r.db.Create(newUser)
 	if res.Error != nil {
@@ -88,13 +105,28 @@ func (r *userRepository) UpdateScope(user *entities.User, scopes int64) error {
 	return res.Error
 }
 
+// ViewOutdatedScopes lists the users whose scopes were granted before the scope list reached version scopes.
+func (r *userRepository) ViewOutdatedScopes(version int) ([]*entities.User, error) {
+	var users []*entities.User
+	res := r.db.Where("scope_version < ?", version).Order("id").Find(&users)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return users, nil
+}
+
+func (r *userRepository) UpgradeScopes(user *entities.User, scopes int64, version int) error {
+	res := r.db.Model(user).Updates(map[string]any{"scopes": scopes, "scope_version": version})
+	return res.Error
+}
+
 func (r *userRepository) Delete(userId string) error {
 	res := r.db.Where("id = ?", userId).Delete(&entities.User{})
 	return res.Error
 }
 
 func (r *userRepository) BeginTransaction(ctx context.Context) (*gorm.DB, error) {
-	tx := r.db.Begin()
+	tx := r.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
@@ -63,6 +63,22 @@ func (suite *UserRepoSuite) TestFindByIdNotFound() {
 	assert.Error(suite.T(), err)
 }
 
+func (suite *UserRepoSuite) TestFindByIdForUpdate() {
+	user, err := suite.repo.Create("lee", "hash", "lee@example.com", entities.Developer, 1)
+	assert.NoError(suite.T(), err)
+
+	tx, err := suite.repo.BeginTransaction(context.Background())
+	assert.NoError(suite.T(), err)
+	defer tx.Rollback()
+
+	found, err := suite.repo.WithTransaction(tx).FindByIdForUpdate(user.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "lee", found.Username)
+
+	_, err = suite.repo.WithTransaction(tx).FindByIdForUpdate("non-existent-id")
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
 func (suite *UserRepoSuite) TestFindByName() {
 	_, err := suite.repo.Create("bob", "pass", "bob@example.com", entities.Developer, 1)
 	assert.NoError(suite.T(), err)
@@ -118,6 +134,28 @@ func (suite *UserRepoSuite) TestUpdateScope() {
 	assert.Equal(suite.T(), int64(5), updated.Scopes)
 }
 
+func (suite *UserRepoSuite) TestViewOutdatedAndUpgradeScopes() {
+	current, _ := suite.repo.Create("judy", "hash", "judy@example.com", entities.Developer, 1)
+	outdated, _ := suite.repo.Create("kim", "hash", "kim@example.com", entities.Developer, 1)
+	suite.db.Model(outdated).Update("scope_version", 7)
+
+	users, err := suite.repo.ViewOutdatedScopes(current.ScopeVersion)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), users, 1)
+	assert.Equal(suite.T(), outdated.ID, users[0].ID)
+
+	err = suite.repo.UpgradeScopes(users[0], 3, current.ScopeVersion)
+	assert.NoError(suite.T(), err)
+
+	updated, _ := suite.repo.FindById(outdated.ID)
+	assert.Equal(suite.T(), int64(3), updated.Scopes)
+	assert.Equal(suite.T(), current.ScopeVersion, updated.ScopeVersion)
+
+	users, err = suite.repo.ViewOutdatedScopes(current.ScopeVersion)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), users)
+}
+
 func (suite *UserRepoSuite) TestUpdateNilUser() {
 	err := suite.repo.UpdateRole(nil, entities.Manager)
 	assert.Error(suite.T(), err)
@@ -151,6 +189,14 @@ func (suite *UserRepoSuite) TestBeginTransactionError() {
 	assert.Error(suite.T(), err)
 }
 
+func (suite *UserRepoSuite) TestBeginTransactionCanceled() {
+	ctx, cancel := context.WithCancel(suite.T().Context())
+	cancel()
+
+	_, err := suite.repo.BeginTransaction(ctx)
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+}
+
 func (suite *UserRepoSuite) TestBeginAndWithTransaction_Rollback() {
 	tx, err := suite.repo.BeginTransaction(suite.T().Context())
 	assert.NoError(suite.T(), err)
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
+	"github.com/vnFuhung2903/vcs-sms/usecases/repositories"
+	"go.uber.org/zap"
+)
+
+type IAuditService interface {
+	Start(ctx context.Context, action entities.AuditAction, userId string, containerId string, detail string) (*entities.AuditLog, error)
+	Finish(ctx context.Context, auditLog *entities.AuditLog, exitCode *int) error
+	FinishTransfer(ctx context.Context, auditLog *entities.AuditLog, bytes int64, transferErr error) error
+}
+
+type AuditService struct {
+	auditRepo repositories.IAuditRepository
+	logger    logger.ILogger
+}
+
+func NewAuditService(auditRepo repositories.IAuditRepository, logger logger.ILogger) IAuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+		logger:    logger,
+	}
+}
+
+func (s *AuditService) Start(ctx context.Context, action entities.AuditAction, userId string, containerId string, detail string) (*entities.AuditLog, error) {
+	auditLog := &entities.AuditLog{
+		Action:      action,
+		UserId:      userId,
+		ContainerId: containerId,
+		Detail:      detail,
+		StartedAt:   time.Now(),
+	}
+	if err := s.auditRepo.Create(auditLog); err != nil {
+		s.logger.Error("failed to create audit log", zap.Error(err))
+		return nil, err
+	}
+	return auditLog, nil
+}
+
+func (s *AuditService) Finish(ctx context.Context, auditLog *entities.AuditLog, exitCode *int) error {
+	endedAt := time.Now()
+	if err := s.auditRepo.Finish(auditLog.ID, endedAt, exitCode); err != nil {
+		s.logger.Error("failed to finish audit log", zap.Error(err))
+		return err
+	}
+	auditLog.EndedAt = &endedAt
+	auditLog.ExitCode = exitCode
+	return nil
+}
+
+// FinishTransfer records the end of a file transfer with the bytes it moved, and the error that failed it if any.
+func (s *AuditService) FinishTransfer(ctx context.Context, auditLog *entities.AuditLog, bytes int64, transferErr error) error {
+	endedAt := time.Now()
+	errMessage := ""
+	if transferErr != nil {
+		errMessage = transferErr.Error()
+	}
+	if err := s.auditRepo.FinishTransfer(auditLog.ID, endedAt, bytes, errMessage); err != nil {
+		s.logger.Error("failed to finish audit log", zap.Error(err))
+		return err
+	}
+	auditLog.EndedAt = &endedAt
+	auditLog.Bytes = &bytes
+	auditLog.Error = errMessage
+	return nil
+}
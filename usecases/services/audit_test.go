@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
+	"github.com/vnFuhung2903/vcs-sms/mocks/repositories"
+)
+
+type AuditServiceSuite struct {
+	suite.Suite
+	ctrl          *gomock.Controller
+	auditService  IAuditService
+	mockAuditRepo *repositories.MockIAuditRepository
+	logger        *logger.MockILogger
+	ctx           context.Context
+}
+
+func (s *AuditServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockAuditRepo = repositories.NewMockIAuditRepository(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.auditService = NewAuditService(s.mockAuditRepo, s.logger)
+	s.ctx = context.Background()
+}
+
+func (s *AuditServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestAuditServiceSuite(t *testing.T) {
+	suite.Run(t, new(AuditServiceSuite))
+}
+
+func (s *AuditServiceSuite) TestStart() {
+	s.mockAuditRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(auditLog *entities.AuditLog) error {
+		auditLog.ID = 1
+		return nil
+	})
+
+	auditLog, err := s.auditService.Start(s.ctx, entities.AuditExec, "user-id", "container-id", "/bin/sh")
+	s.NoError(err)
+	s.Equal(uint(1), auditLog.ID)
+	s.Equal(entities.AuditExec, auditLog.Action)
+	s.Equal("user-id", auditLog.UserId)
+	s.Equal("container-id", auditLog.ContainerId)
+	s.Equal("/bin/sh", auditLog.Detail)
+	s.False(auditLog.StartedAt.IsZero())
+}
+
+func (s *AuditServiceSuite) TestStartRepoError() {
+	s.mockAuditRepo.EXPECT().Create(gomock.Any()).Return(errors.New("db error"))
+	s.logger.EXPECT().Error("failed to create audit log", gomock.Any()).Times(1)
+
+	_, err := s.auditService.Start(s.ctx, entities.AuditExec, "user-id", "container-id", "/bin/sh")
+	s.ErrorContains(err, "db error")
+}
+
+func (s *AuditServiceSuite) TestFinish() {
+	exitCode := 0
+	auditLog := &entities.AuditLog{ID: 1, StartedAt: time.Now()}
+	s.mockAuditRepo.EXPECT().Finish(uint(1), gomock.Any(), &exitCode).Return(nil)
+
+	err := s.auditService.Finish(s.ctx, auditLog, &exitCode)
+	s.NoError(err)
+	s.NotNil(auditLog.EndedAt)
+	s.Equal(0, *auditLog.ExitCode)
+}
+
+func (s *AuditServiceSuite) TestFinishTransfer() {
+	auditLog := &entities.AuditLog{ID: 1, StartedAt: time.Now()}
+	s.mockAuditRepo.EXPECT().FinishTransfer(uint(1), gomock.Any(), int64(42), "connection reset").Return(nil)
+
+	err := s.auditService.FinishTransfer(s.ctx, auditLog, 42, errors.New("connection reset"))
+	s.NoError(err)
+	s.NotNil(auditLog.EndedAt)
+	s.Equal(int64(42), *auditLog.Bytes)
+	s.Equal("connection reset", auditLog.Error)
+}
+
+func (s *AuditServiceSuite) TestFinishTransferRepoError() {
+	auditLog := &entities.AuditLog{ID: 1, StartedAt: time.Now()}
+	s.mockAuditRepo.EXPECT().FinishTransfer(uint(1), gomock.Any(), int64(0), "").Return(errors.New("db error"))
+	s.logger.EXPECT().Error("failed to finish audit log", gomock.Any()).Times(1)
+
+	err := s.auditService.FinishTransfer(s.ctx, auditLog, 0, nil)
+	s.ErrorContains(err, "db error")
+	s.Nil(auditLog.EndedAt)
+}
+
+func (s *AuditServiceSuite) TestFinishRepoError() {
+	auditLog := &entities.AuditLog{ID: 1, StartedAt: time.Now()}
+	s.mockAuditRepo.EXPECT().Finish(uint(1), gomock.Any(), nil).Return(errors.New("db error"))
+	s.logger.EXPECT().Error("failed to finish audit log", gomock.Any()).Times(1)
+
+	err := s.auditService.Finish(s.ctx, auditLog, nil)
+	s.ErrorContains(err, "db error")
+	s.Nil(auditLog.EndedAt)
+}
@@ -1,51 +1,133 @@
 package services
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"mime/multipart"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/containerd/errdefs"
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/container"
+	"github.com/google/uuid"
 	"github.com/vnFuhung2903/vcs-sms/dto"
 	"github.com/vnFuhung2903/vcs-sms/entities"
 	"github.com/vnFuhung2903/vcs-sms/pkg/docker"
+	"github.com/vnFuhung2903/vcs-sms/pkg/env"
 	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
 	"github.com/vnFuhung2903/vcs-sms/usecases/repositories"
-	"github.com/xuri/excelize/v2"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultPageLimit is the size of a page when the client asks for none.
+	defaultPageLimit = 50
+	// snapshotRepository is the local repository snapshot images are committed to, tagged per snapshot.
+	snapshotRepository = "vcs-sms-snapshots"
+	// pendingIdPrefix marks the id of a container reserved in the database but not created by docker yet.
+	pendingIdPrefix = "pending-"
 )
 
 type IContainerService interface {
-	Create(ctx context.Context, containerName string, imageName string) (*entities.Container, error)
+	Create(ctx context.Context, containerName string, imageName string, spec entities.ContainerSpec, ownerId string, expiresAt *time.Time) (*entities.Container, error)
+	FindById(ctx context.Context, containerId string) (*entities.Container, error)
 	View(ctx context.Context, containerFilter dto.ContainerFilter, from int, to int, sort dto.ContainerSort) ([]*entities.Container, int64, error)
+	Page(ctx context.Context, containerFilter dto.ContainerFilter, page dto.PageQuery, sort dto.ContainerSort) (*dto.PageResponse, error)
 	Update(ctx context.Context, containerId string, updateData dto.ContainerUpdate) error
-	Import(ctx context.Context, file multipart.File) (*dto.ImportResponse, error)
-	Export(ctx context.Context, filter dto.ContainerFilter, from int, to int, sort dto.ContainerSort) ([]byte, error)
+	SyncStatus(ctx context.Context, containerId string, status entities.ContainerStatus) error
+	RunAction(ctx context.Context, containerId string, action dto.ContainerAction, query dto.ActionQuery) (entities.ContainerStatus, error)
+	Transfer(ctx context.Context, containerId string, ownerId string) error
+	Snapshot(ctx context.Context, containerId string, query dto.SnapshotQuery) (*entities.Snapshot, error)
+	Snapshots(ctx context.Context, containerId string) ([]*entities.Snapshot, error)
+	Clone(ctx context.Context, containerId string, req dto.CloneRequest, ownerId string) (*entities.Container, error)
+	ReadImport(ctx context.Context, file multipart.File, format dto.FileFormat, ownerId string) ([]dto.ImportRow, error)
+	Import(ctx context.Context, file multipart.File, format dto.FileFormat, ownerId string, dryRun bool) (*dto.ImportResponse, error)
+	Export(ctx context.Context, filter dto.ContainerFilter, from int, to int, sort dto.ContainerSort, format dto.FileFormat, w io.Writer) error
 	Delete(ctx context.Context, containerId string) error
+	Logs(ctx context.Context, containerId string, query dto.LogsQuery) (io.ReadCloser, error)
+	Inspect(ctx context.Context, containerId string) (*dto.InspectResponse, error)
+	Top(ctx context.Context, containerId string) (*dto.TopResponse, error)
 }
 
 type ContainerService struct {
-	containerRepo repositories.IContainerRepository
-	dockerClient  docker.IDockerClient
-	logger        logger.ILogger
+	containerRepo      repositories.IContainerRepository
+	snapshotRepo       repositories.ISnapshotRepository
+	dockerClient       docker.IDockerClient
+	quotaService       IQuotaService
+	imageService       IImageService
+	healthcheckService IHealthcheckService
+	bindSources        []string
+	logger             logger.ILogger
 }
 
-func NewContainerService(repo repositories.IContainerRepository, dockerClient docker.IDockerClient, logger logger.ILogger) IContainerService {
+func NewContainerService(repo repositories.IContainerRepository, snapshotRepo repositories.ISnapshotRepository, dockerClient docker.IDockerClient, quotaService IQuotaService, imageService IImageService, healthcheckService IHealthcheckService, logger logger.ILogger, env env.ContainerEnv) IContainerService {
+	bindSources := make([]string, 0, len(env.BindSources))
+	for _, source := range env.BindSources {
+		bindSources = append(bindSources, filepath.Clean(source))
+	}
 	return &ContainerService{
-		containerRepo: repo,
-		dockerClient:  dockerClient,
-		logger:        logger,
+		containerRepo:      repo,
+		snapshotRepo:       snapshotRepo,
+		dockerClient:       dockerClient,
+		quotaService:       quotaService,
+		imageService:       imageService,
+		healthcheckService: healthcheckService,
+		bindSources:        bindSources,
+		logger:             logger,
+	}
+}
+
+// Create runs a container for the owner, an ephemeral one when expiresAt is set.
+func (s *ContainerService) Create(ctx context.Context, containerName string, imageName string, spec entities.ContainerSpec, ownerId string, expiresAt *time.Time) (*entities.Container, error) {
+	if err := s.imageService.CheckPolicy(imageName); err != nil {
+		s.logger.Error("failed to check image policy", zap.Error(err))
+		return nil, err
 	}
+	return s.create(ctx, containerName, imageName, spec, ownerId, expiresAt)
 }
 
-func (s *ContainerService) Create(ctx context.Context, containerName string, imageName string) (*entities.Container, error) {
-	con, err := s.dockerClient.Create(ctx, containerName, imageName)
+// create reserves the container in the owner's quota with a pending row, so that concurrent creations for the same
+// owner cannot all pass the quota check, then runs it and gives the row its docker id. The docker work happens after
+// the reservation committed, a slow pull holding neither a connection nor the owner's lock. The pending row is
+// removed again if docker fails, and the docker container if the row cannot be activated.
+func (s *ContainerService) create(ctx context.Context, containerName string, imageName string, spec entities.ContainerSpec, ownerId string, expiresAt *time.Time) (*entities.Container, error) {
+	if err := s.checkMounts(spec.Volumes); err != nil {
+		s.logger.Error("failed to check mounts", zap.Error(err))
+		return nil, err
+	}
+
+	container := &entities.Container{
+		ContainerId:   pendingIdPrefix + uuid.New().String(),
+		ContainerName: containerName,
+		Status:        entities.ContainerCreating,
+		ImageName:     imageName,
+		OwnerId:       ownerId,
+		Spec:          spec,
+		ExpiresAt:     expiresAt,
+	}
+	pendingId := container.ContainerId
+	err := s.quotaService.Reserve(ctx, ownerId, 1, spec.Resources.Memory, func(tx *gorm.DB) error {
+		if err := s.containerRepo.WithTransaction(tx).Create(container); err != nil {
+			s.logger.Error("failed to reserve container", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	con, err := s.dockerClient.Create(ctx, containerName, imageName, spec)
 	if err != nil {
 		s.logger.Error("failed to create docker container", zap.Error(err))
+		s.release(pendingId)
 		return nil, err
 	}
 
@@ -53,24 +135,70 @@ func (s *ContainerService) Create(ctx context.Context, containerName string, ima
 		s.logger.Error("failed to start docker container", zap.Error(err))
 	}
 
-	status := s.dockerClient.GetStatus(ctx, con.ID)
-	ipv4 := s.dockerClient.GetIpv4(ctx, con.ID)
-
-	container, err := s.containerRepo.Create(con.ID, containerName, status, ipv4)
+	container.Status = s.dockerClient.GetStatus(ctx, con.ID)
+	container.Ipv4 = s.dockerClient.GetIpv4(ctx, con.ID)
+	activated, err := s.containerRepo.Activate(pendingId, con.ID, container.Status, container.Ipv4)
+	if err == nil && !activated {
+		err = fmt.Errorf("%w: %s was removed while it was being created", ErrContainerNotFound, pendingId)
+	}
 	if err != nil {
 		s.logger.Error("failed to create container", zap.Error(err))
-		if err := s.dockerClient.Stop(ctx, con.ID); err != nil {
-			s.logger.Error("failed to stop docker container", zap.Error(err))
-			return nil, err
+		s.release(pendingId)
+		s.discard(ctx, con.ID)
+		return nil, err
+	}
+	container.ContainerId = con.ID
+
+	s.logger.Info("container created successfully", zap.String("containerId", container.ContainerId))
+	return container, nil
+}
+
+// discard removes the docker container created for a row that could not be activated, the removal being forced when
+// it cannot be stopped. Its failures are only logged, the caller reporting why the container could not be created.
+func (s *ContainerService) discard(ctx context.Context, containerId string) {
+	if err := s.dockerClient.Stop(ctx, containerId); err != nil {
+		s.logger.Error("failed to stop docker container", zap.String("containerId", containerId), zap.Error(err))
+	}
+	if err := s.dockerClient.Delete(ctx, containerId); err != nil {
+		s.logger.Error("failed to delete docker container", zap.String("containerId", containerId), zap.Error(err))
+	}
+}
+
+// release removes the pending row of a container docker failed to create, giving its quota back. A row left behind
+// is reported missing by the reconciler once its grace period is over.
+func (s *ContainerService) release(pendingId string) {
+	if err := s.containerRepo.Purge(pendingId); err != nil {
+		s.logger.Error("failed to release container reservation", zap.String("containerId", pendingId), zap.Error(err))
+	}
+}
+
+// checkMounts rejects bind mounts of a host path outside the configured bind sources, which would otherwise let any
+// creator mount the docker socket or the host root into its container.
+func (s *ContainerService) checkMounts(volumes []entities.VolumeMount) error {
+	for _, volume := range volumes {
+		if volume.Type != "bind" {
+			continue
 		}
-		if err := s.dockerClient.Delete(ctx, con.ID); err != nil {
-			s.logger.Error("failed to delete docker container", zap.Error(err))
-			return nil, err
+		source := filepath.Clean(volume.Source)
+		allowed := slices.ContainsFunc(s.bindSources, func(dir string) bool {
+			return source == dir || strings.HasPrefix(source, strings.TrimSuffix(dir, "/")+"/")
+		})
+		if !allowed {
+			return fmt.Errorf("%w: bind source %q is outside the allowed host directories", ErrMountNotAllowed, volume.Source)
 		}
-		return nil, err
 	}
+	return nil
+}
 
-	s.logger.Info("container created successfully", zap.String("containerId", con.ID))
+func (s *ContainerService) FindById(ctx context.Context, containerId string) (*entities.Container, error) {
+	container, err := s.containerRepo.FindById(containerId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrContainerNotFound, containerId)
+	}
+	if err != nil {
+		s.logger.Error("failed to find container by id", zap.Error(err))
+		return nil, err
+	}
 	return container, nil
 }
 
@@ -92,176 +220,412 @@ func (s *ContainerService) View(ctx context.Context, filter dto.ContainerFilter,
 	return containers, total, nil
 }
 
+// Page lists the containers by keyset, a page holding the cursors to the pages around it when they have containers.
+func (s *ContainerService) Page(ctx context.Context, filter dto.ContainerFilter, page dto.PageQuery, sort dto.ContainerSort) (*dto.PageResponse, error) {
+	if !dto.SortField[sort.Field] {
+		return nil, fmt.Errorf("%w: invalid sort field %s", errdefs.ErrInvalidArgument, sort.Field)
+	}
+	if page.Limit < 1 {
+		page.Limit = defaultPageLimit
+	}
+
+	var cursor *dto.ContainerCursor
+	if page.Cursor != "" {
+		decoded, err := dto.DecodeContainerCursor(page.Cursor, sort)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errdefs.ErrInvalidArgument, err)
+		}
+		cursor = &decoded
+	}
+	backward := cursor != nil && cursor.Before
+
+	containers, err := s.containerRepo.Seek(filter, cursor, page.Limit+1, sort)
+	if err != nil {
+		s.logger.Error("failed to page containers", zap.Error(err))
+		return nil, err
+	}
+
+	more := len(containers) > page.Limit
+	if more && backward {
+		containers = containers[1:]
+	} else if more {
+		containers = containers[:page.Limit]
+	}
+
+	response := &dto.PageResponse{Data: containers}
+	if len(containers) > 0 {
+		if more || backward {
+			response.Next = dto.NewContainerCursor(containers[len(containers)-1], sort, false).Encode()
+		}
+		if (more && backward) || (cursor != nil && !backward) {
+			response.Prev = dto.NewContainerCursor(containers[0], sort, true).Encode()
+		}
+	}
+
+	if page.WithTotal {
+		total, err := s.containerRepo.Count(filter)
+		if err != nil {
+			s.logger.Error("failed to count containers", zap.Error(err))
+			return nil, err
+		}
+		response.Total = &total
+	}
+
+	s.logger.Info("containers paged successfully", zap.Int("count", len(containers)))
+	return response, nil
+}
+
+// Update applies the fields set in the update: the name and the metadata of the container first, then its status.
 func (s *ContainerService) Update(ctx context.Context, containerId string, updateData dto.ContainerUpdate) error {
-	if updateData.Status != entities.ContainerOn && updateData.Status != entities.ContainerOff {
+	if updateData.Status != "" && updateData.Status != entities.ContainerOn && updateData.Status != entities.ContainerOff {
 		return fmt.Errorf("invalid status: %s", updateData.Status)
 	}
 
+	if updateData.ContainerName != "" || updateData.Description != nil || updateData.Labels != nil {
+		if err := s.edit(ctx, containerId, updateData); err != nil {
+			return err
+		}
+	}
+
+	action := dto.ActionStart
 	if updateData.Status == entities.ContainerOn {
 		if err := s.dockerClient.Start(ctx, containerId); err != nil {
 			s.logger.Error("failed to start docker container", zap.Error(err))
 			return err
 		}
-	} else {
+	} else if updateData.Status == entities.ContainerOff {
+		action = dto.ActionStop
 		if err := s.dockerClient.Stop(ctx, containerId); err != nil {
 			s.logger.Error("failed to stop docker container", zap.Error(err))
 			return err
 		}
 	}
 
-	status := s.dockerClient.GetStatus(ctx, containerId)
-	ipv4 := s.dockerClient.GetIpv4(ctx, containerId)
+	if updateData.Status != "" {
+		status := s.dockerClient.GetStatus(ctx, containerId)
+		ipv4 := s.dockerClient.GetIpv4(ctx, containerId)
 
-	if err := s.containerRepo.Update(containerId, status, ipv4); err != nil {
-		s.logger.Error("failed to update container", zap.Error(err))
-		return err
+		// The container already changed state, so a failure to record it, logged by the healthcheck service, must not
+		// fail the update.
+		s.healthcheckService.RecordAction(ctx, containerId, status, action)
+
+		if err := s.containerRepo.Update(containerId, status, ipv4); err != nil {
+			s.logger.Error("failed to update container", zap.Error(err))
+			return err
+		}
 	}
 	s.logger.Info("container updated successfully", zap.String("containerId", containerId))
 	return nil
 }
 
-func (s *ContainerService) Delete(ctx context.Context, containerId string) error {
-	if err := s.dockerClient.Stop(ctx, containerId); err != nil && !errdefs.IsNotFound(err) {
-		s.logger.Error("failed to stop docker container", zap.Error(err))
+// edit renames the container and edits its metadata in one transaction. Docker renames the container last, so that
+// the database is left untouched when it fails, and back when the transaction cannot commit.
+func (s *ContainerService) edit(ctx context.Context, containerId string, updateData dto.ContainerUpdate) error {
+	container, err := s.FindById(ctx, containerId)
+	if err != nil {
 		return err
 	}
 
-	if err := s.dockerClient.Delete(ctx, containerId); err != nil && !errdefs.IsNotFound(err) {
-		s.logger.Error("failed to delete docker container", zap.Error(err))
+	name := updateData.ContainerName
+	rename := name != "" && name != container.ContainerName
+	if rename && !containerNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: container name must match %s", errdefs.ErrInvalidArgument, containerNamePattern.String())
+	}
+
+	tx, err := s.containerRepo.BeginTransaction(ctx)
+	if err != nil {
+		s.logger.Error("failed to begin transaction", zap.Error(err))
 		return err
 	}
+	defer tx.Rollback()
+	containerRepo := s.containerRepo.WithTransaction(tx)
 
-	if err := s.containerRepo.Delete(containerId); err != nil {
-		s.logger.Error("failed to delete container", zap.Error(err))
+	if rename {
+		_, err := containerRepo.FindByName(name)
+		if err == nil {
+			return fmt.Errorf("%w: %s", ErrContainerNameTaken, name)
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Error("failed to find container by name", zap.Error(err))
+			return err
+		}
+		if err := containerRepo.UpdateName(containerId, name); err != nil {
+			s.logger.Error("failed to rename container", zap.Error(err))
+			return err
+		}
+	}
+
+	if updateData.Description != nil || updateData.Labels != nil {
+		description := container.Description
+		if updateData.Description != nil {
+			description = *updateData.Description
+		}
+		labels := maps.Clone(container.Labels)
+		if labels == nil {
+			labels = make(map[string]string, len(updateData.Labels))
+		}
+		for key, value := range updateData.Labels {
+			if value == nil {
+				delete(labels, key)
+			} else {
+				labels[key] = *value
+			}
+		}
+		if len(labels) == 0 {
+			labels = nil
+		}
+		if err := containerRepo.UpdateMetadata(containerId, description, labels); err != nil {
+			s.logger.Error("failed to update container metadata", zap.Error(err))
+			return err
+		}
+	}
+
+	if rename {
+		err := s.dockerClient.Rename(ctx, containerId, name)
+		if errdefs.IsNotFound(err) {
+			return fmt.Errorf("%w: %s", ErrContainerNotFound, containerId)
+		}
+		if errdefs.IsConflict(err) {
+			return fmt.Errorf("%w: %s", ErrContainerNameTaken, name)
+		}
+		if err != nil {
+			s.logger.Error("failed to rename docker container", zap.Error(err))
+			return err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		s.logger.Error("failed to commit container update", zap.Error(err))
+		if rename {
+			if err := s.dockerClient.Rename(ctx, containerId, container.ContainerName); err != nil {
+				s.logger.Error("failed to restore docker container name", zap.Error(err))
+			}
+		}
 		return err
 	}
-	s.logger.Info("container deleted successfully", zap.String("containerId", containerId))
 	return nil
 }
 
-func (s *ContainerService) Import(ctx context.Context, file multipart.File) (*dto.ImportResponse, error) {
-	f, err := excelize.OpenReader(file)
+func (s *ContainerService) SyncStatus(ctx context.Context, containerId string, status entities.ContainerStatus) error {
+	ipv4 := s.dockerClient.GetIpv4(ctx, containerId)
+	if err := s.containerRepo.Update(containerId, status, ipv4); err != nil {
+		s.logger.Error("failed to sync container status", zap.Error(err))
+		return err
+	}
+	s.logger.Info("container status synced successfully", zap.String("containerId", containerId), zap.String("status", string(status)))
+	return nil
+}
+
+func (s *ContainerService) RunAction(ctx context.Context, containerId string, action dto.ContainerAction, query dto.ActionQuery) (entities.ContainerStatus, error) {
+	var err error
+	switch action {
+	case dto.ActionRestart:
+		err = s.dockerClient.Restart(ctx, containerId, query.Timeout)
+	case dto.ActionPause:
+		err = s.dockerClient.Pause(ctx, containerId)
+	case dto.ActionUnpause:
+		err = s.dockerClient.Unpause(ctx, containerId)
+	case dto.ActionKill:
+		err = s.dockerClient.Kill(ctx, containerId, query.Signal)
+	default:
+		return "", fmt.Errorf("invalid action: %s", action)
+	}
+	if errdefs.IsNotFound(err) {
+		return "", fmt.Errorf("%w: %s", ErrContainerNotFound, containerId)
+	}
+	if errdefs.IsConflict(err) {
+		return "", fmt.Errorf("%w: %v", ErrContainerConflict, err)
+	}
+	if err != nil {
+		s.logger.Error("failed to run docker container action", zap.String("action", string(action)), zap.Error(err))
+		return "", err
+	}
+
+	status := s.dockerClient.GetStatus(ctx, containerId)
+	if err := s.SyncStatus(ctx, containerId, status); err != nil {
+		return "", err
+	}
+	s.logger.Info("container action completed successfully", zap.String("containerId", containerId), zap.String("action", string(action)))
+	return status, nil
+}
+
+func (s *ContainerService) Transfer(ctx context.Context, containerId string, ownerId string) error {
+	container, err := s.FindById(ctx, containerId)
+	if err != nil {
+		return err
+	}
+
+	err = s.quotaService.Reserve(ctx, ownerId, 1, container.Spec.Resources.Memory, func(tx *gorm.DB) error {
+		if err := s.containerRepo.WithTransaction(tx).UpdateOwner(containerId, ownerId); err != nil {
+			s.logger.Error("failed to transfer container", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.logger.Info("container transferred successfully", zap.String("containerId", containerId), zap.String("ownerId", ownerId))
+	return nil
+}
+
+// Snapshot commits the container to an image of the snapshot repository and records it with the spec of the container.
+func (s *ContainerService) Snapshot(ctx context.Context, containerId string, query dto.SnapshotQuery) (*entities.Snapshot, error) {
+	container, err := s.FindById(ctx, containerId)
 	if err != nil {
-		s.logger.Error("failed to import containers", zap.Error(err))
 		return nil, err
 	}
-	defer f.Close()
 
-	sheetName := f.GetSheetName(0)
-	rows, err := f.GetRows(sheetName)
+	tag := query.Tag
+	if tag == "" {
+		tag = time.Now().UTC().Format("20060102150405")
+	}
+	imageName := fmt.Sprintf("%s/%s:%s", snapshotRepository, container.ContainerId[:min(len(container.ContainerId), 12)], tag)
+	if _, err := reference.ParseNormalizedNamed(imageName); err != nil {
+		return nil, fmt.Errorf("%w: %v", errdefs.ErrInvalidArgument, err)
+	}
+
+	imageId, err := s.dockerClient.Commit(ctx, containerId, imageName, query.Comment)
+	if errdefs.IsNotFound(err) {
+		return nil, fmt.Errorf("%w: %s", ErrContainerNotFound, containerId)
+	}
 	if err != nil {
-		s.logger.Error("failed to import containers", zap.String("sheetName", sheetName), zap.Error(err))
+		s.logger.Error("failed to commit docker container", zap.Error(err))
 		return nil, err
 	}
 
-	result := &dto.ImportResponse{}
-	containers := make([]*entities.Container, 0)
-	for i, row := range rows {
-		if i == 0 {
-			if len(row) < 2 {
-				err := errors.New("invalid header row")
-				s.logger.Error("failed to import containers", zap.Error(err))
-				return nil, err
-			}
-			containerName := strings.TrimSpace(row[0])
-			imageName := strings.TrimSpace(row[1])
-			if containerName != "Container Name" || imageName != "Image Name" {
-				err := errors.New("invalid header row")
-				s.logger.Error("failed to import containers", zap.Error(err))
-				return nil, err
-			}
-			continue
+	snapshot := &entities.Snapshot{
+		ContainerId: containerId,
+		ImageId:     imageId,
+		ImageName:   imageName,
+		OwnerId:     container.OwnerId,
+		Spec:        container.Spec,
+	}
+	if err := s.snapshotRepo.Create(snapshot); err != nil {
+		s.logger.Error("failed to create snapshot", zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info("container snapshot created successfully", zap.String("containerId", containerId), zap.String("image", imageName))
+	return snapshot, nil
+}
+
+func (s *ContainerService) Snapshots(ctx context.Context, containerId string) ([]*entities.Snapshot, error) {
+	snapshots, err := s.snapshotRepo.View(containerId)
+	if err != nil {
+		s.logger.Error("failed to view snapshots", zap.Error(err))
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// Clone creates a container for the owner from one of the snapshots of the container, or from its image and spec.
+// Host ports are left for docker to pick, the source container most likely holding the ones of the spec.
+func (s *ContainerService) Clone(ctx context.Context, containerId string, req dto.CloneRequest, ownerId string) (*entities.Container, error) {
+	source, err := s.FindById(ctx, containerId)
+	if err != nil {
+		return nil, err
+	}
+	imageName, spec := source.ImageName, source.Spec
+
+	if req.SnapshotId != "" {
+		snapshot, err := s.snapshotRepo.FindById(req.SnapshotId)
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && snapshot.ContainerId != containerId) {
+			return nil, fmt.Errorf("%w: %s", ErrSnapshotNotFound, req.SnapshotId)
 		}
-		if len(row) < 2 {
-			s.logger.Warn("skipping invalid row", zap.Int("row", i+1))
-			continue
+		if err != nil {
+			s.logger.Error("failed to find snapshot by id", zap.Error(err))
+			return nil, err
 		}
+		imageName, spec = snapshot.ImageName, snapshot.Spec
+	}
 
-		containerName := strings.TrimSpace(row[0])
-		imageName := strings.TrimSpace(row[1])
+	spec.Ports = slices.Clone(spec.Ports)
+	for i := range spec.Ports {
+		spec.Ports[i].HostPort = 0
+	}
 
-		if containerName == "" || imageName == "" {
-			result.FailedCount++
-			result.FailedContainers = append(result.FailedContainers, containerName)
-			continue
+	// Snapshots are local images derived from an allowed one, only the original image goes through the policy.
+	if req.SnapshotId == "" {
+		if err := s.imageService.CheckPolicy(imageName); err != nil {
+			s.logger.Error("failed to check image policy", zap.Error(err))
+			return nil, err
 		}
+	}
+	return s.create(ctx, req.ContainerName, imageName, spec, ownerId, nil)
+}
 
-		con, err := s.dockerClient.Create(ctx, containerName, imageName)
-		if err != nil {
-			result.FailedCount++
-			result.FailedContainers = append(result.FailedContainers, containerName)
-			continue
-		}
+// Delete moves the container to the trash: the docker container is only stopped, so that it can be restored until
+// the trash is purged.
+func (s *ContainerService) Delete(ctx context.Context, containerId string) error {
+	if err := s.dockerClient.Stop(ctx, containerId); err != nil && !errdefs.IsNotFound(err) {
+		s.logger.Error("failed to stop docker container", zap.Error(err))
+		return err
+	}
 
-		s.dockerClient.Start(ctx, con.ID)
-		status := s.dockerClient.GetStatus(ctx, con.ID)
-		ipv4 := s.dockerClient.GetIpv4(ctx, con.ID)
+	if err := s.containerRepo.Update(containerId, entities.ContainerOff, ""); err != nil {
+		s.logger.Error("failed to update container", zap.Error(err))
+		return err
+	}
 
-		containers = append(containers, &entities.Container{
-			ContainerId:   con.ID,
-			ContainerName: containerName,
-			Status:        status,
-			Ipv4:          ipv4,
-		})
+	if err := s.containerRepo.Delete(containerId); err != nil {
+		s.logger.Error("failed to delete container", zap.Error(err))
+		return err
 	}
+	s.logger.Info("container deleted successfully", zap.String("containerId", containerId))
+	return nil
+}
 
-	if err := s.containerRepo.CreateInBatches(containers); err != nil {
-		result.FailedCount += len(containers)
-		for _, container := range containers {
-			result.FailedContainers = append(result.FailedContainers, container.ContainerName)
-			if err := s.dockerClient.Stop(ctx, container.ContainerId); err != nil {
-				s.logger.Error("failed to stop docker container", zap.String("container_id", container.ContainerId), zap.Error(err))
-			} else if err := s.dockerClient.Delete(ctx, container.ContainerId); err != nil {
-				s.logger.Error("failed to delete docker container", zap.String("container_id", container.ContainerId), zap.Error(err))
-			}
-		}
-	} else {
-		result.SuccessCount += len(containers)
-		for _, container := range containers {
-			result.SuccessContainers = append(result.SuccessContainers, container.ContainerName)
-		}
-		s.logger.Info("containers imported successfully")
+func (s *ContainerService) Logs(ctx context.Context, containerId string, query dto.LogsQuery) (io.ReadCloser, error) {
+	logs, err := s.dockerClient.Logs(ctx, containerId, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     query.Follow,
+		Tail:       query.Tail,
+		Since:      query.Since,
+		Until:      query.Until,
+		Timestamps: query.Timestamps,
+	})
+	if errdefs.IsNotFound(err) {
+		return nil, fmt.Errorf("%w: %s", ErrContainerNotFound, containerId)
+	}
+	if err != nil {
+		s.logger.Error("failed to stream container logs", zap.Error(err))
+		return nil, err
 	}
-	return result, nil
+	return logs, nil
 }
 
-func (s *ContainerService) Export(ctx context.Context, filter dto.ContainerFilter, from int, to int, sort dto.ContainerSort) ([]byte, error) {
+// Export streams the matching containers to w as they are read from the database, so memory does not grow with the fleet.
+// Nothing is written to w when the export fails before its first record.
+func (s *ContainerService) Export(ctx context.Context, filter dto.ContainerFilter, from int, to int, sort dto.ContainerSort, format dto.FileFormat, w io.Writer) error {
 	if from < 1 {
 		err := errors.New("invalid range")
 		s.logger.Error("failed to export containers", zap.Error(err))
-		return nil, err
+		return err
 	}
 	limit := max(to-from+1, -1)
 
-	containers, _, err := s.containerRepo.View(filter, from, limit, sort)
+	writer, err := newRecordWriter(w, format)
 	if err != nil {
-		return nil, err
+		s.logger.Error("failed to export containers", zap.Error(err))
+		return err
 	}
-
-	f := excelize.NewFile()
-	sheetName := time.Now().Format(time.DateOnly)
-	f.SetSheetName("Sheet1", sheetName)
-
-	headers := []string{"Container ID", "Container Name", "Status", "IPv4", "Created At"}
-	for i, h := range headers {
-		cell := fmt.Sprintf("%s1", string(rune('A'+i)))
-		f.SetCellValue(sheetName, cell, h)
+	if closer, ok := writer.(io.Closer); ok {
+		defer closer.Close()
 	}
 
-	for idx, container := range containers {
-		row := idx + 2
-		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), container.ContainerId)
-		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), container.ContainerName)
-		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), container.Status)
-		f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), container.Ipv4)
-		f.SetCellValue(sheetName, fmt.Sprintf("E%d", row), container.CreatedAt.Format(time.RFC3339))
+	count := 0
+	err = s.containerRepo.Stream(filter, from, limit, sort, func(container *entities.Container) error {
+		count++
+		return writer.Write(toContainerRecord(container))
+	})
+	if err == nil {
+		err = writer.Flush()
 	}
-
-	var buf bytes.Buffer
-	if err := f.Write(&buf); err != nil {
+	if err != nil {
 		s.logger.Error("failed to export containers", zap.Error(err))
-		return nil, err
+		return err
 	}
-	s.logger.Info("containers exported successfully")
-	return buf.Bytes(), nil
+	s.logger.Info("containers exported successfully", zap.String("format", string(format)), zap.Int("count", count))
+	return nil
 }
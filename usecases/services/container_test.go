@@ -4,21 +4,31 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"mime/multipart"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/containerd/errdefs"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
 	"github.com/xuri/excelize/v2"
 	"go.uber.org/zap"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
 
 	"github.com/vnFuhung2903/vcs-sms/dto"
 	"github.com/vnFuhung2903/vcs-sms/entities"
 	"github.com/vnFuhung2903/vcs-sms/mocks/docker"
 	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
 	"github.com/vnFuhung2903/vcs-sms/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+	"github.com/vnFuhung2903/vcs-sms/pkg/env"
 )
 
 type ContainerServiceSuite struct {
@@ -26,7 +36,11 @@ type ContainerServiceSuite struct {
 	ctrl             *gomock.Controller
 	containerService IContainerService
 	mockRepo         *repositories.MockIContainerRepository
+	mockSnapshotRepo *repositories.MockISnapshotRepository
 	dockerClient     *docker.MockIDockerClient
+	quotaService     *services.MockIQuotaService
+	imageService     *services.MockIImageService
+	healthcheck      *services.MockIHealthcheckService
 	logger           *logger.MockILogger
 	ctx              context.Context
 }
@@ -34,9 +48,15 @@ type ContainerServiceSuite struct {
 func (s *ContainerServiceSuite) SetupTest() {
 	s.ctrl = gomock.NewController(s.T())
 	s.mockRepo = repositories.NewMockIContainerRepository(s.ctrl)
+	s.mockSnapshotRepo = repositories.NewMockISnapshotRepository(s.ctrl)
 	s.dockerClient = docker.NewMockIDockerClient(s.ctrl)
+	s.quotaService = services.NewMockIQuotaService(s.ctrl)
+	s.imageService = services.NewMockIImageService(s.ctrl)
+	s.healthcheck = services.NewMockIHealthcheckService(s.ctrl)
 	s.logger = logger.NewMockILogger(s.ctrl)
-	s.containerService = NewContainerService(s.mockRepo, s.dockerClient, s.logger)
+	s.containerService = NewContainerService(s.mockRepo, s.mockSnapshotRepo, s.dockerClient, s.quotaService, s.imageService, s.healthcheck, s.logger, env.ContainerEnv{BindSources: []string{"/data"}})
+
+	s.imageService.EXPECT().CheckPolicy(gomock.Any()).Return(nil).AnyTimes()
 	s.ctx = context.Background()
 }
 
@@ -51,50 +71,183 @@ func TestContainerServiceSuite(t *testing.T) {
 func (s *ContainerServiceSuite) TestCreate() {
 	containerResp := &container.CreateResponse{ID: "test-id"}
 
-	s.dockerClient.EXPECT().Create(s.ctx, "container", "testcontainers/ryuk:0.12.0").Return(containerResp, nil)
+	s.expectReserve("user-id", int64(0), nil)
+	s.dockerClient.EXPECT().Create(s.ctx, "container", "testcontainers/ryuk:0.12.0", entities.ContainerSpec{}).Return(containerResp, nil)
 	s.dockerClient.EXPECT().Start(s.ctx, "test-id").Return(nil)
 	s.dockerClient.EXPECT().GetStatus(s.ctx, "test-id").Return(entities.ContainerOn)
 	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("127.0.0.1")
-	s.mockRepo.EXPECT().Create("test-id", "container", entities.ContainerOn, "127.0.0.1").Return(&entities.Container{
+	s.expectPending(&entities.Container{
 		ContainerId:   "test-id",
 		ContainerName: "container",
 		Status:        entities.ContainerOn,
 		Ipv4:          "127.0.0.1",
-	}, nil)
+		ImageName:     "testcontainers/ryuk:0.12.0",
+		OwnerId:       "user-id",
+	})
 	s.logger.EXPECT().Info("container created successfully", zap.String("containerId", "test-id")).Times(1)
 
-	result, err := s.containerService.Create(s.ctx, "container", "testcontainers/ryuk:0.12.0")
+	result, err := s.containerService.Create(s.ctx, "container", "testcontainers/ryuk:0.12.0", entities.ContainerSpec{}, "user-id", nil)
 	s.NoError(err)
 	s.Equal("container", result.ContainerName)
 	s.Equal("test-id", result.ContainerId)
 }
 
+func (s *ContainerServiceSuite) TestCreateEphemeral() {
+	expiresAt := time.Now().Add(time.Hour)
+	containerResp := &container.CreateResponse{ID: "test-id"}
+
+	s.expectReserve("user-id", int64(0), nil)
+	s.dockerClient.EXPECT().Create(s.ctx, "container", "nginx", entities.ContainerSpec{}).Return(containerResp, nil)
+	s.dockerClient.EXPECT().Start(s.ctx, "test-id").Return(nil)
+	s.dockerClient.EXPECT().GetStatus(s.ctx, "test-id").Return(entities.ContainerOn)
+	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("127.0.0.1")
+	s.expectPending(&entities.Container{
+		ContainerId:   "test-id",
+		ContainerName: "container",
+		Status:        entities.ContainerOn,
+		Ipv4:          "127.0.0.1",
+		ImageName:     "nginx",
+		OwnerId:       "user-id",
+		ExpiresAt:     &expiresAt,
+	})
+	s.logger.EXPECT().Info("container created successfully", zap.String("containerId", "test-id")).Times(1)
+
+	result, err := s.containerService.Create(s.ctx, "container", "nginx", entities.ContainerSpec{}, "user-id", &expiresAt)
+	s.NoError(err)
+	s.Equal(&expiresAt, result.ExpiresAt)
+}
+
+func (s *ContainerServiceSuite) TestCreateWithSpec() {
+	containerResp := &container.CreateResponse{ID: "test-id"}
+	spec := entities.ContainerSpec{
+		Command: []string{"nginx", "-g", "daemon off;"},
+		Env:     []string{"KEY=value"},
+		Labels:  map[string]string{"team": "infra"},
+		Ports:   []entities.PortBinding{{ContainerPort: 80, HostPort: 8080}},
+		Volumes: []entities.VolumeMount{{Type: "bind", Source: "/data", Target: "/usr/share/nginx/html", ReadOnly: true}},
+		RestartPolicy: entities.RestartPolicy{
+			Name: "on-failure", MaximumRetryCount: 3,
+		},
+	}
+
+	s.expectReserve("user-id", int64(0), nil)
+	s.dockerClient.EXPECT().Create(s.ctx, "container", "nginx", spec).Return(containerResp, nil)
+	s.dockerClient.EXPECT().Start(s.ctx, "test-id").Return(nil)
+	s.dockerClient.EXPECT().GetStatus(s.ctx, "test-id").Return(entities.ContainerOn)
+	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("127.0.0.1")
+	s.expectPending(&entities.Container{
+		ContainerId:   "test-id",
+		ContainerName: "container",
+		Status:        entities.ContainerOn,
+		Ipv4:          "127.0.0.1",
+		ImageName:     "nginx",
+		OwnerId:       "user-id",
+		Spec:          spec,
+	})
+	s.logger.EXPECT().Info("container created successfully", zap.String("containerId", "test-id")).Times(1)
+
+	result, err := s.containerService.Create(s.ctx, "container", "nginx", spec, "user-id", nil)
+	s.NoError(err)
+	s.Equal("nginx", result.ImageName)
+	s.Equal(spec, result.Spec)
+}
+
+func (s *ContainerServiceSuite) TestCreateQuotaExceeded() {
+	spec := entities.ContainerSpec{Resources: entities.Resources{Memory: 512 << 20}}
+	s.expectReserve("user-id", int64(512<<20), ErrQuotaExceeded)
+
+	result, err := s.containerService.Create(s.ctx, "container", "nginx", spec, "user-id", nil)
+	s.ErrorIs(err, ErrQuotaExceeded)
+	s.Nil(result)
+}
+
+func (s *ContainerServiceSuite) TestCreateBindNotAllowed() {
+	for _, source := range []string{"/var/run/docker.sock", "/", "/data/../etc", "/database", "data"} {
+		spec := entities.ContainerSpec{
+			Volumes: []entities.VolumeMount{{Type: "bind", Source: source, Target: "/mnt"}},
+		}
+		s.logger.EXPECT().Error("failed to check mounts", gomock.Any()).Times(1)
+
+		result, err := s.containerService.Create(s.ctx, "container", "nginx", spec, "user-id", nil)
+		s.ErrorIs(err, ErrMountNotAllowed, source)
+		s.Nil(result)
+	}
+}
+
+func (s *ContainerServiceSuite) TestCreateImageNotAllowed() {
+	imageService := services.NewMockIImageService(s.ctrl)
+	containerService := NewContainerService(s.mockRepo, s.mockSnapshotRepo, s.dockerClient, s.quotaService, imageService, s.healthcheck, s.logger, env.ContainerEnv{})
+
+	imageService.EXPECT().CheckPolicy("nginx:latest").Return(fmt.Errorf("%w: tag latest is denied", ErrImageNotAllowed))
+	s.logger.EXPECT().Error("failed to check image policy", gomock.Any()).Times(1)
+
+	result, err := containerService.Create(s.ctx, "container", "nginx:latest", entities.ContainerSpec{}, "user-id", nil)
+	s.ErrorIs(err, ErrImageNotAllowed)
+	s.Nil(result)
+}
+
 func (s *ContainerServiceSuite) TestCreateDockerCreateError() {
-	s.dockerClient.EXPECT().Create(s.ctx, "container", "testcontainers/ryuk:0.12.0").Return(nil, errors.New("docker create error"))
+	s.expectReserve("user-id", int64(0), nil)
+	pendingId := s.expectReservation(&entities.Container{ContainerName: "container", ImageName: "testcontainers/ryuk:0.12.0", OwnerId: "user-id"}, nil)
+	s.expectRelease(pendingId)
+	s.dockerClient.EXPECT().Create(s.ctx, "container", "testcontainers/ryuk:0.12.0", entities.ContainerSpec{}).Return(nil, errors.New("docker create error"))
 	s.logger.EXPECT().Error("failed to create docker container", gomock.Any()).Times(1)
 
-	result, err := s.containerService.Create(s.ctx, "container", "testcontainers/ryuk:0.12.0")
+	result, err := s.containerService.Create(s.ctx, "container", "testcontainers/ryuk:0.12.0", entities.ContainerSpec{}, "user-id", nil)
 	s.ErrorContains(err, "docker create error")
 	s.Nil(result)
 }
 
+func (s *ContainerServiceSuite) TestCreateReservationError() {
+	s.expectReserve("user-id", int64(0), nil)
+	s.expectReservation(&entities.Container{ContainerName: "container", ImageName: "nginx", OwnerId: "user-id"}, errors.New("duplicate name"))
+	s.logger.EXPECT().Error("failed to reserve container", gomock.Any()).Times(1)
+
+	result, err := s.containerService.Create(s.ctx, "container", "nginx", entities.ContainerSpec{}, "user-id", nil)
+	s.ErrorContains(err, "duplicate name")
+	s.Nil(result)
+}
+
+func (s *ContainerServiceSuite) TestCreateRemovedWhileCreating() {
+	containerResp := &container.CreateResponse{ID: "test-id"}
+
+	s.expectReserve("user-id", int64(0), nil)
+	pendingId := s.expectReservation(&entities.Container{ContainerName: "container", ImageName: "nginx", OwnerId: "user-id"}, nil)
+	s.dockerClient.EXPECT().Create(s.ctx, "container", "nginx", entities.ContainerSpec{}).Return(containerResp, nil)
+	s.dockerClient.EXPECT().Start(s.ctx, "test-id").Return(nil)
+	s.dockerClient.EXPECT().GetStatus(s.ctx, "test-id").Return(entities.ContainerOn)
+	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("127.0.0.1")
+	s.mockRepo.EXPECT().Activate(gomock.Any(), "test-id", entities.ContainerOn, "127.0.0.1").Return(false, nil)
+	s.expectRelease(pendingId)
+	s.dockerClient.EXPECT().Stop(s.ctx, "test-id").Return(nil)
+	s.dockerClient.EXPECT().Delete(s.ctx, "test-id").Return(nil)
+	s.logger.EXPECT().Error("failed to create container", gomock.Any()).Times(1)
+
+	result, err := s.containerService.Create(s.ctx, "container", "nginx", entities.ContainerSpec{}, "user-id", nil)
+	s.ErrorIs(err, ErrContainerNotFound)
+	s.Nil(result)
+}
+
 func (s *ContainerServiceSuite) TestCreateDockerStartError() {
 	containerResp := &container.CreateResponse{ID: "test-id"}
 
-	s.dockerClient.EXPECT().Create(s.ctx, "container", "testcontainers/ryuk:0.12.0").Return(containerResp, nil)
+	s.expectReserve("user-id", int64(0), nil)
+	s.dockerClient.EXPECT().Create(s.ctx, "container", "testcontainers/ryuk:0.12.0", entities.ContainerSpec{}).Return(containerResp, nil)
 	s.dockerClient.EXPECT().Start(s.ctx, "test-id").Return(errors.New("docker start error"))
 	s.dockerClient.EXPECT().GetStatus(s.ctx, "test-id").Return(entities.ContainerOff)
 	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("")
-	s.mockRepo.EXPECT().Create("test-id", "container", entities.ContainerOff, "").Return(&entities.Container{
+	s.expectPending(&entities.Container{
 		ContainerId:   "test-id",
 		ContainerName: "container",
 		Status:        entities.ContainerOff,
 		Ipv4:          "",
-	}, nil)
+		ImageName:     "testcontainers/ryuk:0.12.0",
+		OwnerId:       "user-id",
+	})
 	s.logger.EXPECT().Error("failed to start docker container", zap.Error(errors.New("docker start error"))).Times(1)
 	s.logger.EXPECT().Info("container created successfully", zap.String("containerId", "test-id")).Times(1)
 
-	result, err := s.containerService.Create(s.ctx, "container", "testcontainers/ryuk:0.12.0")
+	result, err := s.containerService.Create(s.ctx, "container", "testcontainers/ryuk:0.12.0", entities.ContainerSpec{}, "user-id", nil)
 	s.NoError(err)
 	s.Equal("container", result.ContainerName)
 	s.Equal("test-id", result.ContainerId)
@@ -103,16 +256,19 @@ func (s *ContainerServiceSuite) TestCreateDockerStartError() {
 func (s *ContainerServiceSuite) TestCreateRepoError() {
 	containerResp := &container.CreateResponse{ID: "test-id"}
 
-	s.dockerClient.EXPECT().Create(s.ctx, "container", "testcontainers/ryuk:0.12.0").Return(containerResp, nil)
+	s.expectReserve("user-id", int64(0), nil)
+	s.dockerClient.EXPECT().Create(s.ctx, "container", "testcontainers/ryuk:0.12.0", entities.ContainerSpec{}).Return(containerResp, nil)
 	s.dockerClient.EXPECT().Start(s.ctx, "test-id").Return(nil)
 	s.dockerClient.EXPECT().GetStatus(s.ctx, "test-id").Return(entities.ContainerOn)
 	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("127.0.0.1")
-	s.mockRepo.EXPECT().Create("test-id", "container", entities.ContainerOn, "127.0.0.1").Return(nil, errors.New("db error"))
+	pendingId := s.expectReservation(&entities.Container{ContainerName: "container", ImageName: "testcontainers/ryuk:0.12.0", OwnerId: "user-id"}, nil)
+	s.mockRepo.EXPECT().Activate(gomock.Any(), "test-id", entities.ContainerOn, "127.0.0.1").Return(false, errors.New("db error"))
+	s.expectRelease(pendingId)
 	s.dockerClient.EXPECT().Stop(s.ctx, "test-id").Return(nil)
 	s.dockerClient.EXPECT().Delete(s.ctx, "test-id").Return(nil)
 	s.logger.EXPECT().Error("failed to create container", gomock.Any()).Times(1)
 
-	result, err := s.containerService.Create(s.ctx, "container", "testcontainers/ryuk:0.12.0")
+	result, err := s.containerService.Create(s.ctx, "container", "testcontainers/ryuk:0.12.0", entities.ContainerSpec{}, "user-id", nil)
 	s.ErrorContains(err, "db error")
 	s.Nil(result)
 }
@@ -120,35 +276,42 @@ func (s *ContainerServiceSuite) TestCreateRepoError() {
 func (s *ContainerServiceSuite) TestCreateRepoAndDockerStopError() {
 	containerResp := &container.CreateResponse{ID: "test-id"}
 
-	s.dockerClient.EXPECT().Create(s.ctx, "container", "testcontainers/ryuk:0.12.0").Return(containerResp, nil)
+	s.expectReserve("user-id", int64(0), nil)
+	s.dockerClient.EXPECT().Create(s.ctx, "container", "testcontainers/ryuk:0.12.0", entities.ContainerSpec{}).Return(containerResp, nil)
 	s.dockerClient.EXPECT().Start(s.ctx, "test-id").Return(nil)
 	s.dockerClient.EXPECT().GetStatus(s.ctx, "test-id").Return(entities.ContainerOn)
 	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("127.0.0.1")
-	s.mockRepo.EXPECT().Create("test-id", "container", entities.ContainerOn, "127.0.0.1").Return(nil, errors.New("db error"))
+	pendingId := s.expectReservation(&entities.Container{ContainerName: "container", ImageName: "testcontainers/ryuk:0.12.0", OwnerId: "user-id"}, nil)
+	s.mockRepo.EXPECT().Activate(gomock.Any(), "test-id", entities.ContainerOn, "127.0.0.1").Return(false, errors.New("db error"))
+	s.expectRelease(pendingId)
 	s.dockerClient.EXPECT().Stop(s.ctx, "test-id").Return(errors.New("docker stop error"))
+	s.dockerClient.EXPECT().Delete(s.ctx, "test-id").Return(nil)
 	s.logger.EXPECT().Error("failed to create container", gomock.Any()).Times(1)
 	s.logger.EXPECT().Error("failed to stop docker container", gomock.Any()).Times(1)
 
-	result, err := s.containerService.Create(s.ctx, "container", "testcontainers/ryuk:0.12.0")
-	s.ErrorContains(err, "docker stop error")
+	result, err := s.containerService.Create(s.ctx, "container", "testcontainers/ryuk:0.12.0", entities.ContainerSpec{}, "user-id", nil)
+	s.ErrorContains(err, "db error")
 	s.Nil(result)
 }
 
 func (s *ContainerServiceSuite) TestCreateRepoAndDockerDeleteError() {
 	containerResp := &container.CreateResponse{ID: "test-id"}
 
-	s.dockerClient.EXPECT().Create(s.ctx, "container", "testcontainers/ryuk:0.12.0").Return(containerResp, nil)
+	s.expectReserve("user-id", int64(0), nil)
+	s.dockerClient.EXPECT().Create(s.ctx, "container", "testcontainers/ryuk:0.12.0", entities.ContainerSpec{}).Return(containerResp, nil)
 	s.dockerClient.EXPECT().Start(s.ctx, "test-id").Return(nil)
 	s.dockerClient.EXPECT().GetStatus(s.ctx, "test-id").Return(entities.ContainerOn)
 	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("127.0.0.1")
-	s.mockRepo.EXPECT().Create("test-id", "container", entities.ContainerOn, "127.0.0.1").Return(nil, errors.New("db error"))
+	pendingId := s.expectReservation(&entities.Container{ContainerName: "container", ImageName: "testcontainers/ryuk:0.12.0", OwnerId: "user-id"}, nil)
+	s.mockRepo.EXPECT().Activate(gomock.Any(), "test-id", entities.ContainerOn, "127.0.0.1").Return(false, errors.New("db error"))
+	s.expectRelease(pendingId)
 	s.dockerClient.EXPECT().Stop(s.ctx, "test-id").Return(nil)
 	s.dockerClient.EXPECT().Delete(s.ctx, "test-id").Return(errors.New("docker delete error"))
 	s.logger.EXPECT().Error("failed to create container", gomock.Any()).Times(1)
 	s.logger.EXPECT().Error("failed to delete docker container", gomock.Any()).Times(1)
 
-	result, err := s.containerService.Create(s.ctx, "container", "testcontainers/ryuk:0.12.0")
-	s.ErrorContains(err, "docker delete error")
+	result, err := s.containerService.Create(s.ctx, "container", "testcontainers/ryuk:0.12.0", entities.ContainerSpec{}, "user-id", nil)
+	s.ErrorContains(err, "db error")
 	s.Nil(result)
 }
 
@@ -180,12 +343,84 @@ func (s *ContainerServiceSuite) TestViewInvalidRange() {
 	s.ErrorContains(err, "invalid range")
 }
 
+func (s *ContainerServiceSuite) TestPage() {
+	sort := dto.ContainerSort{Field: "container_id", Order: dto.Asc}
+	containers := []*entities.Container{{ContainerId: "a"}, {ContainerId: "b"}, {ContainerId: "c"}}
+
+	s.mockRepo.EXPECT().Seek(dto.ContainerFilter{}, nil, 3, sort).Return(containers, nil)
+	s.logger.EXPECT().Info("containers paged successfully", gomock.Any()).Times(1)
+
+	page, err := s.containerService.Page(s.ctx, dto.ContainerFilter{}, dto.PageQuery{Limit: 2}, sort)
+	s.NoError(err)
+	s.Equal(containers[:2], page.Data)
+	s.Empty(page.Prev)
+	s.Nil(page.Total)
+
+	next, err := dto.DecodeContainerCursor(page.Next, sort)
+	s.NoError(err)
+	s.Equal(dto.ContainerCursor{Field: "container_id", Order: dto.Asc, Value: "b", ContainerId: "b"}, next)
+}
+
+func (s *ContainerServiceSuite) TestPageAfterCursor() {
+	sort := dto.ContainerSort{Field: "container_name", Order: dto.Dsc}
+	cursor := dto.ContainerCursor{Field: "container_name", Order: dto.Dsc, Value: "delta", ContainerId: "d"}
+	containers := []*entities.Container{{ContainerId: "c", ContainerName: "charlie"}}
+
+	s.mockRepo.EXPECT().Seek(dto.ContainerFilter{}, &cursor, 51, sort).Return(containers, nil)
+	s.mockRepo.EXPECT().Count(dto.ContainerFilter{}).Return(int64(4), nil)
+	s.logger.EXPECT().Info("containers paged successfully", gomock.Any()).Times(1)
+
+	page, err := s.containerService.Page(s.ctx, dto.ContainerFilter{}, dto.PageQuery{Cursor: cursor.Encode(), WithTotal: true}, sort)
+	s.NoError(err)
+	s.Equal(containers, page.Data)
+	s.Empty(page.Next)
+	s.Equal(int64(4), *page.Total)
+
+	prev, err := dto.DecodeContainerCursor(page.Prev, sort)
+	s.NoError(err)
+	s.Equal(dto.ContainerCursor{Field: "container_name", Order: dto.Dsc, Value: "charlie", ContainerId: "c", Before: true}, prev)
+}
+
+func (s *ContainerServiceSuite) TestPageBeforeCursor() {
+	sort := dto.ContainerSort{Field: "container_id", Order: dto.Asc}
+	cursor := dto.ContainerCursor{Field: "container_id", Order: dto.Asc, Value: "d", ContainerId: "d", Before: true}
+	containers := []*entities.Container{{ContainerId: "a"}, {ContainerId: "b"}, {ContainerId: "c"}}
+
+	s.mockRepo.EXPECT().Seek(dto.ContainerFilter{}, &cursor, 3, sort).Return(containers, nil)
+	s.logger.EXPECT().Info("containers paged successfully", gomock.Any()).Times(1)
+
+	page, err := s.containerService.Page(s.ctx, dto.ContainerFilter{}, dto.PageQuery{Cursor: cursor.Encode(), Limit: 2}, sort)
+	s.NoError(err)
+	s.Equal(containers[1:], page.Data)
+	s.NotEmpty(page.Prev)
+	s.NotEmpty(page.Next)
+}
+
+func (s *ContainerServiceSuite) TestPageInvalidCursor() {
+	sort := dto.ContainerSort{Field: "container_id", Order: dto.Asc}
+	otherSort := dto.ContainerCursor{Field: "created_at", Order: dto.Asc, Value: "2024-01-01T00:00:00Z", ContainerId: "a"}
+
+	for _, cursor := range []string{"not a cursor", otherSort.Encode()} {
+		_, err := s.containerService.Page(s.ctx, dto.ContainerFilter{}, dto.PageQuery{Cursor: cursor}, sort)
+		s.True(errdefs.IsInvalidArgument(err))
+	}
+}
+
+func (s *ContainerServiceSuite) TestPageError() {
+	s.mockRepo.EXPECT().Seek(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to page containers", gomock.Any()).Times(1)
+
+	_, err := s.containerService.Page(s.ctx, dto.ContainerFilter{}, dto.PageQuery{}, dto.ContainerSort{Field: "status", Order: dto.Asc})
+	s.ErrorContains(err, "db error")
+}
+
 func (s *ContainerServiceSuite) TestUpdateOn() {
 	updateData := dto.ContainerUpdate{Status: "ON"}
 
 	s.dockerClient.EXPECT().Start(s.ctx, "test-id").Return(nil)
 	s.dockerClient.EXPECT().GetStatus(s.ctx, "test-id").Return(entities.ContainerOn)
 	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("127.0.0.1")
+	s.healthcheck.EXPECT().RecordAction(s.ctx, "test-id", entities.ContainerOn, dto.ActionStart).Return(nil)
 	s.mockRepo.EXPECT().Update("test-id", entities.ContainerOn, "127.0.0.1").Return(nil)
 	s.logger.EXPECT().Info("container updated successfully", gomock.Any()).Times(1)
 
@@ -199,6 +434,21 @@ func (s *ContainerServiceSuite) TestUpdateOff() {
 	s.dockerClient.EXPECT().Stop(s.ctx, "test-id").Return(nil)
 	s.dockerClient.EXPECT().GetStatus(s.ctx, "test-id").Return(entities.ContainerOff)
 	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("")
+	s.healthcheck.EXPECT().RecordAction(s.ctx, "test-id", entities.ContainerOff, dto.ActionStop).Return(nil)
+	s.mockRepo.EXPECT().Update("test-id", entities.ContainerOff, "").Return(nil)
+	s.logger.EXPECT().Info("container updated successfully", gomock.Any()).Times(1)
+
+	err := s.containerService.Update(s.ctx, "test-id", updateData)
+	s.NoError(err)
+}
+
+func (s *ContainerServiceSuite) TestUpdateRecordActionError() {
+	updateData := dto.ContainerUpdate{Status: "OFF"}
+
+	s.dockerClient.EXPECT().Stop(s.ctx, "test-id").Return(nil)
+	s.dockerClient.EXPECT().GetStatus(s.ctx, "test-id").Return(entities.ContainerOff)
+	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("")
+	s.healthcheck.EXPECT().RecordAction(s.ctx, "test-id", entities.ContainerOff, dto.ActionStop).Return(errors.New("es error"))
 	s.mockRepo.EXPECT().Update("test-id", entities.ContainerOff, "").Return(nil)
 	s.logger.EXPECT().Info("container updated successfully", gomock.Any()).Times(1)
 
@@ -239,6 +489,7 @@ func (s *ContainerServiceSuite) TestUpdateRepoError() {
 	s.dockerClient.EXPECT().Stop(s.ctx, "test-id").Return(nil)
 	s.dockerClient.EXPECT().GetStatus(s.ctx, "test-id").Return(entities.ContainerOff)
 	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("")
+	s.healthcheck.EXPECT().RecordAction(s.ctx, "test-id", entities.ContainerOff, dto.ActionStop).Return(nil)
 	s.mockRepo.EXPECT().Update("test-id", entities.ContainerOff, "").Return(errors.New("update failed"))
 	s.logger.EXPECT().Error("failed to update container", gomock.Any()).Times(1)
 
@@ -246,233 +497,731 @@ func (s *ContainerServiceSuite) TestUpdateRepoError() {
 	s.ErrorContains(err, "update failed")
 }
 
-func (s *ContainerServiceSuite) TestDelete() {
-	s.dockerClient.EXPECT().Stop(s.ctx, "test-id").Return(nil)
-	s.dockerClient.EXPECT().Delete(s.ctx, "test-id").Return(nil)
-	s.mockRepo.EXPECT().Delete("test-id").Return(nil)
-	s.logger.EXPECT().Info("container deleted successfully", zap.String("containerId", "test-id")).Times(1)
-
-	err := s.containerService.Delete(s.ctx, "test-id")
-	s.NoError(err)
+// expectReserve stands in for the quota reservation of one container, running its record in a transaction of an
+// in-memory database unless the check fails with checkErr.
+func (s *ContainerServiceSuite) expectReserve(ownerId string, memory int64, checkErr error) {
+	s.quotaService.EXPECT().
+		Reserve(s.ctx, ownerId, int64(1), memory, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ int64, _ int64, record func(tx *gorm.DB) error) error {
+			if checkErr != nil {
+				return checkErr
+			}
+			db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+			s.Require().NoError(err)
+			return record(db.Begin())
+		})
+	if checkErr == nil {
+		s.mockRepo.EXPECT().WithTransaction(gomock.Any()).Return(s.mockRepo).MaxTimes(1)
+	}
 }
 
-func (s *ContainerServiceSuite) TestDeleteDockerStopError() {
-	s.dockerClient.EXPECT().Stop(s.ctx, "test-id").Return(errors.New("stop failed"))
-	s.logger.EXPECT().Error("failed to stop docker container", gomock.Any()).Times(1)
-
-	err := s.containerService.Delete(s.ctx, "test-id")
-	s.ErrorContains(err, "stop failed")
+// expectPending expects the pending row reserving the container, then its activation with the docker id, status and
+// IPv4 of the container.
+func (s *ContainerServiceSuite) expectPending(expected *entities.Container) {
+	pendingId := s.expectReservation(expected, nil)
+	s.mockRepo.EXPECT().
+		Activate(gomock.Any(), expected.ContainerId, expected.Status, expected.Ipv4).
+		DoAndReturn(func(id string, _ string, _ entities.ContainerStatus, _ string) (bool, error) {
+			s.Equal(*pendingId, id)
+			return true, nil
+		})
 }
 
-func (s *ContainerServiceSuite) TestDeleteDockerDeleteError() {
-	s.dockerClient.EXPECT().Stop(s.ctx, "test-id").Return(nil)
-	s.dockerClient.EXPECT().Delete(s.ctx, "test-id").Return(errors.New("delete failed"))
-	s.logger.EXPECT().Error("failed to delete docker container", gomock.Any()).Times(1)
-
-	err := s.containerService.Delete(s.ctx, "test-id")
-	s.ErrorContains(err, "delete failed")
+// expectReservation expects the pending row reserving the container, holding all of it but the docker id, status and
+// IPv4, and returns the id the row was given.
+func (s *ContainerServiceSuite) expectReservation(expected *entities.Container, err error) *string {
+	pendingId := new(string)
+	s.mockRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(container *entities.Container) error {
+		s.True(strings.HasPrefix(container.ContainerId, pendingIdPrefix))
+		pending := *expected
+		pending.ContainerId, pending.Status, pending.Ipv4 = container.ContainerId, entities.ContainerCreating, ""
+		s.Equal(&pending, container)
+		*pendingId = container.ContainerId
+		return err
+	})
+	return pendingId
 }
 
-func (s *ContainerServiceSuite) TestDeleteRepoError() {
-	s.dockerClient.EXPECT().Stop(s.ctx, "test-id").Return(nil)
-	s.dockerClient.EXPECT().Delete(s.ctx, "test-id").Return(nil)
-	s.mockRepo.EXPECT().Delete("test-id").Return(errors.New("delete failed"))
-	s.logger.EXPECT().Error("failed to delete container", gomock.Any()).Times(1)
-
-	err := s.containerService.Delete(s.ctx, "test-id")
-	s.ErrorContains(err, "delete failed")
+// expectRelease expects the pending row to be removed again.
+func (s *ContainerServiceSuite) expectRelease(pendingId *string) {
+	s.mockRepo.EXPECT().Purge(gomock.Any()).DoAndReturn(func(id string) error {
+		s.Equal(*pendingId, id)
+		return nil
+	})
 }
 
-func (s *ContainerServiceSuite) TestImport() {
-	f := excelize.NewFile()
-	sheet := f.GetSheetName(0)
-	f.SetCellValue(sheet, "A1", "Container Name")
-	f.SetCellValue(sheet, "B1", "Image Name")
-
-	f.SetCellValue(sheet, "A2", "test-name")
-	f.SetCellValue(sheet, "B2", "nginx")
-
-	var buf bytes.Buffer
-	err := f.Write(&buf)
+// expectTransaction hands out a transaction of an in-memory database, the mocked repository standing in for its own
+// transactional copy.
+func (s *ContainerServiceSuite) expectTransaction() *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	s.Require().NoError(err)
+	tx := db.Begin()
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(tx, nil)
+	s.mockRepo.EXPECT().WithTransaction(tx).Return(s.mockRepo)
+	return tx
+}
 
-	reader := bytes.NewReader(buf.Bytes())
-	file := struct {
-		io.Reader
-		io.ReaderAt
-		io.Seeker
-		io.Closer
-	}{
-		Reader:   reader,
-		ReaderAt: reader,
-		Seeker:   reader,
-		Closer:   io.NopCloser(nil),
-	}
+func (s *ContainerServiceSuite) TestUpdateRename() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id", ContainerName: "web"}, nil)
+	s.expectTransaction()
+	s.mockRepo.EXPECT().FindByName("web-2").Return(nil, gorm.ErrRecordNotFound)
+	s.mockRepo.EXPECT().UpdateName("test-id", "web-2").Return(nil)
+	s.dockerClient.EXPECT().Rename(s.ctx, "test-id", "web-2").Return(nil)
+	s.logger.EXPECT().Info("container updated successfully", gomock.Any()).Times(1)
 
-	containerResp := &container.CreateResponse{ID: "test-id"}
-	containerEntity := &entities.Container{
+	err := s.containerService.Update(s.ctx, "test-id", dto.ContainerUpdate{ContainerName: "web-2"})
+	s.NoError(err)
+}
+
+func (s *ContainerServiceSuite) TestUpdateMetadata() {
+	description := "web frontend"
+	prod := "prod"
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{
 		ContainerId:   "test-id",
-		ContainerName: "test-name",
-		Status:        entities.ContainerOn,
-		Ipv4:          "127.0.0.1",
-	}
-	containers := []*entities.Container{containerEntity}
+		ContainerName: "web",
+		Description:   "old",
+		Labels:        map[string]string{"team": "web", "stale": "yes"},
+	}, nil)
+	s.expectTransaction()
+	s.mockRepo.EXPECT().UpdateMetadata("test-id", "web frontend", map[string]string{"team": "web", "env": "prod"}).Return(nil)
+	s.logger.EXPECT().Info("container updated successfully", gomock.Any()).Times(1)
+
+	err := s.containerService.Update(s.ctx, "test-id", dto.ContainerUpdate{
+		ContainerName: "web",
+		Description:   &description,
+		Labels:        map[string]*string{"env": &prod, "stale": nil},
+	})
+	s.NoError(err)
+}
 
-	s.dockerClient.EXPECT().Create(s.ctx, "test-name", "nginx").Return(containerResp, nil)
+func (s *ContainerServiceSuite) TestUpdateMetadataClearLabels() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id", Description: "old", Labels: map[string]string{"team": "web"}}, nil)
+	s.expectTransaction()
+	s.mockRepo.EXPECT().UpdateMetadata("test-id", "old", nil).Return(nil)
 	s.dockerClient.EXPECT().Start(s.ctx, "test-id").Return(nil)
 	s.dockerClient.EXPECT().GetStatus(s.ctx, "test-id").Return(entities.ContainerOn)
 	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("127.0.0.1")
-	s.mockRepo.EXPECT().CreateInBatches(containers).Return(nil)
-	s.logger.EXPECT().Info("containers imported successfully").Times(1)
+	s.healthcheck.EXPECT().RecordAction(s.ctx, "test-id", entities.ContainerOn, dto.ActionStart).Return(nil)
+	s.mockRepo.EXPECT().Update("test-id", entities.ContainerOn, "127.0.0.1").Return(nil)
+	s.logger.EXPECT().Info("container updated successfully", gomock.Any()).Times(1)
 
-	resp, err := s.containerService.Import(s.ctx, file)
+	err := s.containerService.Update(s.ctx, "test-id", dto.ContainerUpdate{Status: "ON", Labels: map[string]*string{"team": nil}})
 	s.NoError(err)
-	s.Equal(1, resp.SuccessCount)
-	s.Equal(0, resp.FailedCount)
-	s.Contains(resp.SuccessContainers, "test-name")
 }
 
-func (s *ContainerServiceSuite) TestImportInvalidExcelFile() {
-	data := []byte("this is not a real Excel file")
-	reader := bytes.NewReader(data)
+func (s *ContainerServiceSuite) TestUpdateRenameContainerNotFound() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(nil, gorm.ErrRecordNotFound)
 
-	fakeFile := struct {
-		io.Reader
-		io.ReaderAt
-		io.Seeker
-		io.Closer
-	}{
-		Reader:   reader,
-		ReaderAt: reader,
-		Seeker:   reader,
-		Closer:   io.NopCloser(nil),
-	}
+	err := s.containerService.Update(s.ctx, "test-id", dto.ContainerUpdate{ContainerName: "web-2"})
+	s.ErrorIs(err, ErrContainerNotFound)
+}
 
-	s.logger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+func (s *ContainerServiceSuite) TestUpdateRenameInvalidName() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id", ContainerName: "web"}, nil)
 
-	resp, err := s.containerService.Import(s.ctx, fakeFile)
-	s.Error(err)
-	s.Nil(resp)
+	err := s.containerService.Update(s.ctx, "test-id", dto.ContainerUpdate{ContainerName: "-web"})
+	s.True(errdefs.IsInvalidArgument(err))
 }
 
-func (s *ContainerServiceSuite) TestImportWithMissingHeaderRows() {
-	s.logger.EXPECT().Error("failed to import containers", gomock.Any()).Times(1)
+func (s *ContainerServiceSuite) TestUpdateRenameNameTaken() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id", ContainerName: "web"}, nil)
+	s.expectTransaction()
+	s.mockRepo.EXPECT().FindByName("db").Return(&entities.Container{ContainerId: "other-id", ContainerName: "db"}, nil)
 
-	f := excelize.NewFile()
-	sheetName := "Sheet1"
-	f.SetSheetName("Sheet1", sheetName)
-	f.SetCellValue(sheetName, "A1", "Container Id")
+	err := s.containerService.Update(s.ctx, "test-id", dto.ContainerUpdate{ContainerName: "db"})
+	s.ErrorIs(err, ErrContainerNameTaken)
+}
 
-	var buf bytes.Buffer
-	err := f.Write(&buf)
-	s.Require().NoError(err)
+func (s *ContainerServiceSuite) TestUpdateRenameDockerConflict() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id", ContainerName: "web"}, nil)
+	s.expectTransaction()
+	s.mockRepo.EXPECT().FindByName("web-2").Return(nil, gorm.ErrRecordNotFound)
+	s.mockRepo.EXPECT().UpdateName("test-id", "web-2").Return(nil)
+	s.dockerClient.EXPECT().Rename(s.ctx, "test-id", "web-2").Return(errdefs.ErrConflict)
 
-	reader := bytes.NewReader(buf.Bytes())
+	err := s.containerService.Update(s.ctx, "test-id", dto.ContainerUpdate{ContainerName: "web-2"})
+	s.ErrorIs(err, ErrContainerNameTaken)
+}
 
-	fakeFile := struct {
-		io.Reader
-		io.ReaderAt
-		io.Seeker
-		io.Closer
-	}{
-		Reader:   reader,
-		ReaderAt: reader,
-		Seeker:   reader,
-		Closer:   io.NopCloser(nil),
-	}
+func (s *ContainerServiceSuite) TestUpdateRenameDockerError() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id", ContainerName: "web"}, nil)
+	s.expectTransaction()
+	s.mockRepo.EXPECT().FindByName("web-2").Return(nil, gorm.ErrRecordNotFound)
+	s.mockRepo.EXPECT().UpdateName("test-id", "web-2").Return(nil)
+	s.dockerClient.EXPECT().Rename(s.ctx, "test-id", "web-2").Return(errors.New("docker error"))
+	s.logger.EXPECT().Error("failed to rename docker container", gomock.Any()).Times(1)
 
-	resp, err := s.containerService.Import(s.ctx, fakeFile)
+	err := s.containerService.Update(s.ctx, "test-id", dto.ContainerUpdate{ContainerName: "web-2"})
+	s.ErrorContains(err, "docker error")
+}
+
+func (s *ContainerServiceSuite) TestUpdateRenameCommitError() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id", ContainerName: "web"}, nil)
+	tx := s.expectTransaction()
+	tx.Commit()
+	s.mockRepo.EXPECT().FindByName("web-2").Return(nil, gorm.ErrRecordNotFound)
+	s.mockRepo.EXPECT().UpdateName("test-id", "web-2").Return(nil)
+	gomock.InOrder(
+		s.dockerClient.EXPECT().Rename(s.ctx, "test-id", "web-2").Return(nil),
+		s.dockerClient.EXPECT().Rename(s.ctx, "test-id", "web").Return(nil),
+	)
+	s.logger.EXPECT().Error("failed to commit container update", gomock.Any()).Times(1)
+
+	err := s.containerService.Update(s.ctx, "test-id", dto.ContainerUpdate{ContainerName: "web-2"})
 	s.Error(err)
-	s.Nil(resp)
 }
 
-func (s *ContainerServiceSuite) TestImportWithInvalidHeaderRows() {
-	s.logger.EXPECT().Error("failed to import containers", gomock.Any()).Times(1)
+func (s *ContainerServiceSuite) TestUpdateMetadataRepoError() {
+	description := "web frontend"
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id"}, nil)
+	s.expectTransaction()
+	s.mockRepo.EXPECT().UpdateMetadata("test-id", "web frontend", nil).Return(errors.New("db error"))
+	s.logger.EXPECT().Error("failed to update container metadata", gomock.Any()).Times(1)
 
-	f := excelize.NewFile()
-	sheetName := "Sheet1"
-	f.SetSheetName("Sheet1", sheetName)
-	f.SetCellValue(sheetName, "A1", "Container Id")
-	f.SetCellValue(sheetName, "B1", "Image Name")
+	err := s.containerService.Update(s.ctx, "test-id", dto.ContainerUpdate{Description: &description})
+	s.ErrorContains(err, "db error")
+}
 
-	f.SetCellValue(sheetName, "A2", "test-id")
+func (s *ContainerServiceSuite) TestUpdateBeginTransactionError() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id", ContainerName: "web"}, nil)
+	s.mockRepo.EXPECT().BeginTransaction(s.ctx).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to begin transaction", gomock.Any()).Times(1)
 
-	var buf bytes.Buffer
-	err := f.Write(&buf)
-	s.Require().NoError(err)
+	err := s.containerService.Update(s.ctx, "test-id", dto.ContainerUpdate{ContainerName: "web-2"})
+	s.ErrorContains(err, "db error")
+}
 
-	reader := bytes.NewReader(buf.Bytes())
+func (s *ContainerServiceSuite) TestSyncStatus() {
+	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("127.0.0.1")
+	s.mockRepo.EXPECT().Update("test-id", entities.ContainerUnhealthy, "127.0.0.1").Return(nil)
+	s.logger.EXPECT().Info("container status synced successfully", gomock.Any(), gomock.Any()).Times(1)
 
-	fakeFile := struct {
-		io.Reader
-		io.ReaderAt
-		io.Seeker
-		io.Closer
-	}{
-		Reader:   reader,
-		ReaderAt: reader,
-		Seeker:   reader,
-		Closer:   io.NopCloser(nil),
-	}
+	err := s.containerService.SyncStatus(s.ctx, "test-id", entities.ContainerUnhealthy)
+	s.NoError(err)
+}
 
-	resp, err := s.containerService.Import(s.ctx, fakeFile)
-	s.Error(err)
-	s.Nil(resp)
+func (s *ContainerServiceSuite) TestSyncStatusRepoError() {
+	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("")
+	s.mockRepo.EXPECT().Update("test-id", entities.ContainerExited, "").Return(errors.New("update failed"))
+	s.logger.EXPECT().Error("failed to sync container status", gomock.Any()).Times(1)
+
+	err := s.containerService.SyncStatus(s.ctx, "test-id", entities.ContainerExited)
+	s.ErrorContains(err, "update failed")
 }
 
-func (s *ContainerServiceSuite) TestImportWithInvalidRows() {
-	s.logger.EXPECT().Warn("skipping invalid row", gomock.Any()).Times(1)
-	s.logger.EXPECT().Info("containers imported successfully").Times(1)
+func (s *ContainerServiceSuite) TestRunActionRestart() {
+	timeout := 10
+	s.dockerClient.EXPECT().Restart(s.ctx, "test-id", &timeout).Return(nil)
+	s.dockerClient.EXPECT().GetStatus(s.ctx, "test-id").Return(entities.ContainerHealthy)
+	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("127.0.0.1")
+	s.mockRepo.EXPECT().Update("test-id", entities.ContainerHealthy, "127.0.0.1").Return(nil)
+	s.logger.EXPECT().Info("container status synced successfully", gomock.Any(), gomock.Any()).Times(1)
+	s.logger.EXPECT().Info("container action completed successfully", gomock.Any(), gomock.Any()).Times(1)
 
-	f := excelize.NewFile()
-	sheetName := "Sheet1"
-	f.SetSheetName("Sheet1", sheetName)
-	f.SetCellValue(sheetName, "A1", "Container Name")
-	f.SetCellValue(sheetName, "B1", "Image Name")
+	status, err := s.containerService.RunAction(s.ctx, "test-id", dto.ActionRestart, dto.ActionQuery{Timeout: &timeout})
+	s.NoError(err)
+	s.Equal(entities.ContainerHealthy, status)
+}
 
-	f.SetCellValue(sheetName, "A2", "test-name")
+func (s *ContainerServiceSuite) TestRunActionPauseUnpauseKill() {
+	s.dockerClient.EXPECT().Pause(s.ctx, "test-id").Return(nil)
+	s.dockerClient.EXPECT().Unpause(s.ctx, "test-id").Return(nil)
+	s.dockerClient.EXPECT().Kill(s.ctx, "test-id", "SIGTERM").Return(nil)
+	s.dockerClient.EXPECT().GetStatus(s.ctx, "test-id").Return(entities.ContainerPaused).Times(3)
+	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("").Times(3)
+	s.mockRepo.EXPECT().Update("test-id", entities.ContainerPaused, "").Return(nil).Times(3)
+	s.logger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any()).Times(6)
 
-	var buf bytes.Buffer
-	err := f.Write(&buf)
-	s.Require().NoError(err)
+	_, err := s.containerService.RunAction(s.ctx, "test-id", dto.ActionPause, dto.ActionQuery{})
+	s.NoError(err)
+	_, err = s.containerService.RunAction(s.ctx, "test-id", dto.ActionUnpause, dto.ActionQuery{})
+	s.NoError(err)
+	_, err = s.containerService.RunAction(s.ctx, "test-id", dto.ActionKill, dto.ActionQuery{Signal: "SIGTERM"})
+	s.NoError(err)
+}
 
-	reader := bytes.NewReader(buf.Bytes())
+func (s *ContainerServiceSuite) TestRunActionInvalid() {
+	_, err := s.containerService.RunAction(s.ctx, "test-id", dto.ContainerAction("explode"), dto.ActionQuery{})
+	s.ErrorContains(err, "invalid action")
+}
 
-	fakeFile := struct {
-		io.Reader
-		io.ReaderAt
-		io.Seeker
-		io.Closer
-	}{
-		Reader:   reader,
-		ReaderAt: reader,
-		Seeker:   reader,
-		Closer:   io.NopCloser(nil),
+func (s *ContainerServiceSuite) TestRunActionNotFound() {
+	s.dockerClient.EXPECT().Pause(s.ctx, "test-id").Return(errdefs.ErrNotFound)
+
+	_, err := s.containerService.RunAction(s.ctx, "test-id", dto.ActionPause, dto.ActionQuery{})
+	s.ErrorIs(err, ErrContainerNotFound)
+}
+
+func (s *ContainerServiceSuite) TestRunActionConflict() {
+	s.dockerClient.EXPECT().Unpause(s.ctx, "test-id").Return(errdefs.ErrConflict)
+
+	_, err := s.containerService.RunAction(s.ctx, "test-id", dto.ActionUnpause, dto.ActionQuery{})
+	s.ErrorIs(err, ErrContainerConflict)
+}
+
+func (s *ContainerServiceSuite) TestRunActionDockerError() {
+	s.dockerClient.EXPECT().Kill(s.ctx, "test-id", "").Return(errors.New("docker error"))
+	s.logger.EXPECT().Error("failed to run docker container action", gomock.Any(), gomock.Any()).Times(1)
+
+	_, err := s.containerService.RunAction(s.ctx, "test-id", dto.ActionKill, dto.ActionQuery{})
+	s.ErrorContains(err, "docker error")
+}
+
+func (s *ContainerServiceSuite) TestRunActionSyncError() {
+	s.dockerClient.EXPECT().Pause(s.ctx, "test-id").Return(nil)
+	s.dockerClient.EXPECT().GetStatus(s.ctx, "test-id").Return(entities.ContainerPaused)
+	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("")
+	s.mockRepo.EXPECT().Update("test-id", entities.ContainerPaused, "").Return(errors.New("update failed"))
+	s.logger.EXPECT().Error("failed to sync container status", gomock.Any()).Times(1)
+
+	_, err := s.containerService.RunAction(s.ctx, "test-id", dto.ActionPause, dto.ActionQuery{})
+	s.ErrorContains(err, "update failed")
+}
+
+func (s *ContainerServiceSuite) TestFindById() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id", OwnerId: "user-id"}, nil)
+
+	container, err := s.containerService.FindById(s.ctx, "test-id")
+	s.NoError(err)
+	s.Equal("user-id", container.OwnerId)
+}
+
+func (s *ContainerServiceSuite) TestFindByIdNotFound() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := s.containerService.FindById(s.ctx, "test-id")
+	s.ErrorIs(err, ErrContainerNotFound)
+}
+
+func (s *ContainerServiceSuite) TestFindByIdRepoError() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find container by id", gomock.Any()).Times(1)
+
+	_, err := s.containerService.FindById(s.ctx, "test-id")
+	s.ErrorContains(err, "db error")
+}
+
+func (s *ContainerServiceSuite) TestTransfer() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{
+		ContainerId: "test-id",
+		OwnerId:     "user-id",
+		Spec:        entities.ContainerSpec{Resources: entities.Resources{Memory: 64 << 20}},
+	}, nil)
+	s.expectReserve("other-id", int64(64<<20), nil)
+	s.mockRepo.EXPECT().UpdateOwner("test-id", "other-id").Return(nil)
+	s.logger.EXPECT().Info("container transferred successfully", gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.containerService.Transfer(s.ctx, "test-id", "other-id")
+	s.NoError(err)
+}
+
+func (s *ContainerServiceSuite) TestTransferNotFound() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(nil, gorm.ErrRecordNotFound)
+
+	err := s.containerService.Transfer(s.ctx, "test-id", "other-id")
+	s.ErrorIs(err, ErrContainerNotFound)
+}
+
+func (s *ContainerServiceSuite) TestTransferQuotaExceeded() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id", OwnerId: "user-id"}, nil)
+	s.expectReserve("other-id", int64(0), ErrQuotaExceeded)
+
+	err := s.containerService.Transfer(s.ctx, "test-id", "other-id")
+	s.ErrorIs(err, ErrQuotaExceeded)
+}
+
+func (s *ContainerServiceSuite) TestTransferRepoError() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id", OwnerId: "user-id"}, nil)
+	s.expectReserve("other-id", int64(0), nil)
+	s.mockRepo.EXPECT().UpdateOwner("test-id", "other-id").Return(errors.New("db error"))
+	s.logger.EXPECT().Error("failed to transfer container", gomock.Any()).Times(1)
+
+	err := s.containerService.Transfer(s.ctx, "test-id", "other-id")
+	s.ErrorContains(err, "db error")
+}
+
+func (s *ContainerServiceSuite) TestSnapshot() {
+	spec := entities.ContainerSpec{Env: []string{"KEY=value"}}
+	s.mockRepo.EXPECT().FindById("0123456789abcdef").Return(&entities.Container{ContainerId: "0123456789abcdef", OwnerId: "user-id", Spec: spec}, nil)
+	s.dockerClient.EXPECT().Commit(s.ctx, "0123456789abcdef", "vcs-sms-snapshots/0123456789ab:before-upgrade", "debug").Return("sha256:image", nil)
+	s.mockSnapshotRepo.EXPECT().Create(&entities.Snapshot{
+		ContainerId: "0123456789abcdef",
+		ImageId:     "sha256:image",
+		ImageName:   "vcs-sms-snapshots/0123456789ab:before-upgrade",
+		OwnerId:     "user-id",
+		Spec:        spec,
+	}).Return(nil)
+	s.logger.EXPECT().Info("container snapshot created successfully", gomock.Any(), gomock.Any()).Times(1)
+
+	snapshot, err := s.containerService.Snapshot(s.ctx, "0123456789abcdef", dto.SnapshotQuery{Tag: "before-upgrade", Comment: "debug"})
+	s.NoError(err)
+	s.Equal("sha256:image", snapshot.ImageId)
+}
+
+func (s *ContainerServiceSuite) TestSnapshotDefaultTag() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id"}, nil)
+	s.dockerClient.EXPECT().Commit(s.ctx, "test-id", gomock.Any(), "").DoAndReturn(func(ctx context.Context, containerId string, imageName string, comment string) (string, error) {
+		s.Regexp(`^vcs-sms-snapshots/test-id:\d{14}$`, imageName)
+		return "sha256:image", nil
+	})
+	s.mockSnapshotRepo.EXPECT().Create(gomock.Any()).Return(nil)
+	s.logger.EXPECT().Info("container snapshot created successfully", gomock.Any(), gomock.Any()).Times(1)
+
+	_, err := s.containerService.Snapshot(s.ctx, "test-id", dto.SnapshotQuery{})
+	s.NoError(err)
+}
+
+func (s *ContainerServiceSuite) TestSnapshotInvalidTag() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id"}, nil)
+
+	_, err := s.containerService.Snapshot(s.ctx, "test-id", dto.SnapshotQuery{Tag: "not a tag"})
+	s.True(errdefs.IsInvalidArgument(err))
+}
+
+func (s *ContainerServiceSuite) TestSnapshotDockerError() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id"}, nil)
+	s.dockerClient.EXPECT().Commit(s.ctx, "test-id", gomock.Any(), "").Return("", errors.New("commit failed"))
+	s.logger.EXPECT().Error("failed to commit docker container", gomock.Any()).Times(1)
+
+	_, err := s.containerService.Snapshot(s.ctx, "test-id", dto.SnapshotQuery{})
+	s.ErrorContains(err, "commit failed")
+}
+
+func (s *ContainerServiceSuite) TestSnapshotDockerNotFound() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id"}, nil)
+	s.dockerClient.EXPECT().Commit(s.ctx, "test-id", gomock.Any(), "").Return("", errdefs.ErrNotFound)
+
+	_, err := s.containerService.Snapshot(s.ctx, "test-id", dto.SnapshotQuery{})
+	s.ErrorIs(err, ErrContainerNotFound)
+}
+
+func (s *ContainerServiceSuite) TestSnapshotRepoError() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id"}, nil)
+	s.dockerClient.EXPECT().Commit(s.ctx, "test-id", gomock.Any(), "").Return("sha256:image", nil)
+	s.mockSnapshotRepo.EXPECT().Create(gomock.Any()).Return(errors.New("db error"))
+	s.logger.EXPECT().Error("failed to create snapshot", gomock.Any()).Times(1)
+
+	_, err := s.containerService.Snapshot(s.ctx, "test-id", dto.SnapshotQuery{})
+	s.ErrorContains(err, "db error")
+}
+
+func (s *ContainerServiceSuite) TestSnapshots() {
+	expected := []*entities.Snapshot{{ID: "snapshot-id"}}
+	s.mockSnapshotRepo.EXPECT().View("test-id").Return(expected, nil)
+
+	snapshots, err := s.containerService.Snapshots(s.ctx, "test-id")
+	s.NoError(err)
+	s.Equal(expected, snapshots)
+
+	s.mockSnapshotRepo.EXPECT().View("test-id").Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to view snapshots", gomock.Any()).Times(1)
+
+	_, err = s.containerService.Snapshots(s.ctx, "test-id")
+	s.ErrorContains(err, "db error")
+}
+
+func (s *ContainerServiceSuite) TestCloneFromSpec() {
+	source := &entities.Container{
+		ContainerId: "test-id",
+		ImageName:   "nginx:1.27",
+		OwnerId:     "other-id",
+		Spec: entities.ContainerSpec{
+			Ports:     []entities.PortBinding{{ContainerPort: 80, HostPort: 8080}},
+			Resources: entities.Resources{Memory: 64 << 20},
+		},
 	}
+	cloneSpec := entities.ContainerSpec{
+		Ports:     []entities.PortBinding{{ContainerPort: 80}},
+		Resources: entities.Resources{Memory: 64 << 20},
+	}
+	s.mockRepo.EXPECT().FindById("test-id").Return(source, nil)
+	s.expectReserve("user-id", int64(64<<20), nil)
+	s.dockerClient.EXPECT().Create(s.ctx, "clone", "nginx:1.27", cloneSpec).Return(&container.CreateResponse{ID: "clone-id"}, nil)
+	s.dockerClient.EXPECT().Start(s.ctx, "clone-id").Return(nil)
+	s.dockerClient.EXPECT().GetStatus(s.ctx, "clone-id").Return(entities.ContainerOn)
+	s.dockerClient.EXPECT().GetIpv4(s.ctx, "clone-id").Return("172.17.0.3")
+	s.expectPending(&entities.Container{
+		ContainerId:   "clone-id",
+		ContainerName: "clone",
+		Status:        entities.ContainerOn,
+		Ipv4:          "172.17.0.3",
+		ImageName:     "nginx:1.27",
+		OwnerId:       "user-id",
+		Spec:          cloneSpec,
+	})
+	s.logger.EXPECT().Info("container created successfully", gomock.Any()).Times(1)
+
+	clone, err := s.containerService.Clone(s.ctx, "test-id", dto.CloneRequest{ContainerName: "clone"}, "user-id")
+	s.NoError(err)
+	s.Equal("clone-id", clone.ContainerId)
+	s.Equal("user-id", clone.OwnerId)
+	s.Equal(8080, source.Spec.Ports[0].HostPort)
+}
 
-	s.mockRepo.EXPECT().CreateInBatches([]*entities.Container{}).Return(nil)
-	resp, err := s.containerService.Import(s.ctx, fakeFile)
+func (s *ContainerServiceSuite) TestCloneFromSnapshot() {
+	imageService := services.NewMockIImageService(s.ctrl)
+	containerService := NewContainerService(s.mockRepo, s.mockSnapshotRepo, s.dockerClient, s.quotaService, imageService, s.healthcheck, s.logger, env.ContainerEnv{})
+	spec := entities.ContainerSpec{Env: []string{"KEY=snapshot"}}
+
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id", ImageName: "nginx:1.27"}, nil)
+	s.mockSnapshotRepo.EXPECT().FindById("snapshot-id").Return(&entities.Snapshot{ID: "snapshot-id", ContainerId: "test-id", ImageName: "vcs-sms-snapshots/test-id:v1", Spec: spec}, nil)
+	s.expectReserve("user-id", int64(0), nil)
+	s.dockerClient.EXPECT().Create(s.ctx, "clone", "vcs-sms-snapshots/test-id:v1", spec).Return(&container.CreateResponse{ID: "clone-id"}, nil)
+	s.dockerClient.EXPECT().Start(s.ctx, "clone-id").Return(nil)
+	s.dockerClient.EXPECT().GetStatus(s.ctx, "clone-id").Return(entities.ContainerOn)
+	s.dockerClient.EXPECT().GetIpv4(s.ctx, "clone-id").Return("172.17.0.3")
+	s.expectPending(&entities.Container{
+		ContainerId:   "clone-id",
+		ContainerName: "clone",
+		Status:        entities.ContainerOn,
+		Ipv4:          "172.17.0.3",
+		ImageName:     "vcs-sms-snapshots/test-id:v1",
+		OwnerId:       "user-id",
+		Spec:          spec,
+	})
+	s.logger.EXPECT().Info("container created successfully", gomock.Any()).Times(1)
+
+	clone, err := containerService.Clone(s.ctx, "test-id", dto.CloneRequest{ContainerName: "clone", SnapshotId: "snapshot-id"}, "user-id")
 	s.NoError(err)
-	s.NotNil(resp)
-	s.Equal(0, resp.SuccessCount)
-	s.Equal(0, resp.FailedCount)
+	s.Equal("vcs-sms-snapshots/test-id:v1", clone.ImageName)
 }
 
-func (s *ContainerServiceSuite) TestImportDockerCreateError() {
+func (s *ContainerServiceSuite) TestCloneSnapshotNotFound() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id"}, nil).Times(2)
+	s.mockSnapshotRepo.EXPECT().FindById("missing").Return(nil, gorm.ErrRecordNotFound)
+	s.mockSnapshotRepo.EXPECT().FindById("foreign").Return(&entities.Snapshot{ID: "foreign", ContainerId: "other-id"}, nil)
+
+	_, err := s.containerService.Clone(s.ctx, "test-id", dto.CloneRequest{ContainerName: "clone", SnapshotId: "missing"}, "user-id")
+	s.ErrorIs(err, ErrSnapshotNotFound)
+	_, err = s.containerService.Clone(s.ctx, "test-id", dto.CloneRequest{ContainerName: "clone", SnapshotId: "foreign"}, "user-id")
+	s.ErrorIs(err, ErrSnapshotNotFound)
+}
+
+func (s *ContainerServiceSuite) TestCloneSnapshotError() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id"}, nil)
+	s.mockSnapshotRepo.EXPECT().FindById("snapshot-id").Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find snapshot by id", gomock.Any()).Times(1)
+
+	_, err := s.containerService.Clone(s.ctx, "test-id", dto.CloneRequest{ContainerName: "clone", SnapshotId: "snapshot-id"}, "user-id")
+	s.ErrorContains(err, "db error")
+}
+
+func (s *ContainerServiceSuite) TestCloneNotFound() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := s.containerService.Clone(s.ctx, "test-id", dto.CloneRequest{ContainerName: "clone"}, "user-id")
+	s.ErrorIs(err, ErrContainerNotFound)
+}
+
+func (s *ContainerServiceSuite) TestCloneQuotaExceeded() {
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id", ImageName: "nginx"}, nil)
+	s.expectReserve("user-id", int64(0), ErrQuotaExceeded)
+
+	_, err := s.containerService.Clone(s.ctx, "test-id", dto.CloneRequest{ContainerName: "clone"}, "user-id")
+	s.ErrorIs(err, ErrQuotaExceeded)
+}
+
+func (s *ContainerServiceSuite) TestCloneImageNotAllowed() {
+	imageService := services.NewMockIImageService(s.ctrl)
+	containerService := NewContainerService(s.mockRepo, s.mockSnapshotRepo, s.dockerClient, s.quotaService, imageService, s.healthcheck, s.logger, env.ContainerEnv{})
+
+	s.mockRepo.EXPECT().FindById("test-id").Return(&entities.Container{ContainerId: "test-id", ImageName: "nginx:latest"}, nil)
+	imageService.EXPECT().CheckPolicy("nginx:latest").Return(fmt.Errorf("%w: tag latest is denied", ErrImageNotAllowed))
+	s.logger.EXPECT().Error("failed to check image policy", gomock.Any()).Times(1)
+
+	_, err := containerService.Clone(s.ctx, "test-id", dto.CloneRequest{ContainerName: "clone"}, "user-id")
+	s.ErrorIs(err, ErrImageNotAllowed)
+}
+
+func (s *ContainerServiceSuite) TestDelete() {
+	s.dockerClient.EXPECT().Stop(s.ctx, "test-id").Return(nil)
+	s.mockRepo.EXPECT().Update("test-id", entities.ContainerOff, "").Return(nil)
+	s.mockRepo.EXPECT().Delete("test-id").Return(nil)
+	s.logger.EXPECT().Info("container deleted successfully", zap.String("containerId", "test-id")).Times(1)
+
+	err := s.containerService.Delete(s.ctx, "test-id")
+	s.NoError(err)
+}
+
+func (s *ContainerServiceSuite) TestDeleteDockerStopError() {
+	s.dockerClient.EXPECT().Stop(s.ctx, "test-id").Return(errors.New("stop failed"))
+	s.logger.EXPECT().Error("failed to stop docker container", gomock.Any()).Times(1)
+
+	err := s.containerService.Delete(s.ctx, "test-id")
+	s.ErrorContains(err, "stop failed")
+}
+
+func (s *ContainerServiceSuite) TestDeleteUpdateError() {
+	s.dockerClient.EXPECT().Stop(s.ctx, "test-id").Return(nil)
+	s.mockRepo.EXPECT().Update("test-id", entities.ContainerOff, "").Return(errors.New("update failed"))
+	s.logger.EXPECT().Error("failed to update container", gomock.Any()).Times(1)
+
+	err := s.containerService.Delete(s.ctx, "test-id")
+	s.ErrorContains(err, "update failed")
+}
+
+func (s *ContainerServiceSuite) TestDeleteRepoError() {
+	s.dockerClient.EXPECT().Stop(s.ctx, "test-id").Return(errdefs.ErrNotFound)
+	s.mockRepo.EXPECT().Update("test-id", entities.ContainerOff, "").Return(nil)
+	s.mockRepo.EXPECT().Delete("test-id").Return(errors.New("delete failed"))
+	s.logger.EXPECT().Error("failed to delete container", gomock.Any()).Times(1)
+
+	err := s.containerService.Delete(s.ctx, "test-id")
+	s.ErrorContains(err, "delete failed")
+}
+
+func (s *ContainerServiceSuite) TestLogs() {
+	s.dockerClient.EXPECT().Logs(s.ctx, "test-id", container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+		Tail:       "10",
+		Timestamps: true,
+	}).Return(io.NopCloser(bytes.NewBufferString("line\n")), nil)
+
+	logs, err := s.containerService.Logs(s.ctx, "test-id", dto.LogsQuery{Follow: true, Tail: "10", Timestamps: true})
+	s.NoError(err)
+	data, _ := io.ReadAll(logs)
+	s.Equal("line\n", string(data))
+}
+
+func (s *ContainerServiceSuite) TestLogsNotFound() {
+	s.dockerClient.EXPECT().Logs(s.ctx, "test-id", gomock.Any()).Return(nil, errdefs.ErrNotFound)
+
+	_, err := s.containerService.Logs(s.ctx, "test-id", dto.LogsQuery{})
+	s.ErrorIs(err, ErrContainerNotFound)
+}
+
+func (s *ContainerServiceSuite) TestLogsDockerError() {
+	s.dockerClient.EXPECT().Logs(s.ctx, "test-id", gomock.Any()).Return(nil, errors.New("docker error"))
+	s.logger.EXPECT().Error("failed to stream container logs", gomock.Any()).Times(1)
+
+	_, err := s.containerService.Logs(s.ctx, "test-id", dto.LogsQuery{})
+	s.ErrorContains(err, "docker error")
+}
+
+func (s *ContainerServiceSuite) TestInspect() {
+	s.dockerClient.EXPECT().Inspect(s.ctx, "test-id").Return(container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			ID:           "test-id",
+			Image:        "sha256:abc",
+			Path:         "nginx",
+			Args:         []string{"-g", "daemon off;"},
+			RestartCount: 2,
+			State: &container.State{
+				Status:     container.StateExited,
+				ExitCode:   137,
+				OOMKilled:  true,
+				StartedAt:  "2026-01-02T03:04:05.123456789Z",
+				FinishedAt: "0001-01-01T00:00:00Z",
+			},
+		},
+		Config: &container.Config{Image: "nginx:1.27"},
+		Mounts: []container.MountPoint{{Type: "volume", Name: "data", Source: "/var/lib/docker/volumes/data/_data", Destination: "/data", RW: false}},
+		NetworkSettings: &container.NetworkSettings{Networks: map[string]*network.EndpointSettings{
+			"web":    {IPAddress: "172.18.0.2", Gateway: "172.18.0.1", Aliases: []string{"app"}},
+			"bridge": {IPAddress: "172.17.0.2", Gateway: "172.17.0.1", MacAddress: "02:42:ac:11:00:02"},
+		}},
+	}, nil)
+	s.dockerClient.EXPECT().InspectImage(s.ctx, "sha256:abc").Return(image.InspectResponse{
+		RepoDigests: []string{"mirror.local/nginx@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "nginx@sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"},
+	}, nil)
+
+	inspect, err := s.containerService.Inspect(s.ctx, "test-id")
+	s.NoError(err)
+	startedAt := time.Date(2026, 1, 2, 3, 4, 5, 123456789, time.UTC)
+	s.Equal(&dto.InspectResponse{
+		ContainerId: "test-id",
+		Image:       "nginx:1.27",
+		ImageId:     "sha256:abc",
+		ImageDigest: "nginx@sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+		Command:     []string{"nginx", "-g", "daemon off;"},
+		Mounts:      []dto.InspectMount{{Type: "volume", Name: "data", Source: "/var/lib/docker/volumes/data/_data", Destination: "/data", ReadOnly: true}},
+		Networks: []dto.InspectNetwork{
+			{Name: "bridge", IPAddress: "172.17.0.2", Gateway: "172.17.0.1", MacAddress: "02:42:ac:11:00:02"},
+			{Name: "web", IPAddress: "172.18.0.2", Gateway: "172.18.0.1", Aliases: []string{"app"}},
+		},
+		State:        "exited",
+		RestartCount: 2,
+		ExitCode:     137,
+		OOMKilled:    true,
+		StartedAt:    &startedAt,
+	}, inspect)
+}
+
+func (s *ContainerServiceSuite) TestInspectImageError() {
+	s.dockerClient.EXPECT().Inspect(s.ctx, "test-id").Return(container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{ID: "test-id", Image: "sha256:abc"},
+	}, nil)
+	s.dockerClient.EXPECT().InspectImage(s.ctx, "sha256:abc").Return(image.InspectResponse{}, errdefs.ErrNotFound)
+	s.logger.EXPECT().Warn("failed to inspect container image", gomock.Any(), gomock.Any()).Times(1)
+
+	inspect, err := s.containerService.Inspect(s.ctx, "test-id")
+	s.NoError(err)
+	s.Empty(inspect.ImageDigest)
+	s.Empty(inspect.Command)
+}
+
+func (s *ContainerServiceSuite) TestInspectNotFound() {
+	s.dockerClient.EXPECT().Inspect(s.ctx, "test-id").Return(container.InspectResponse{}, errdefs.ErrNotFound)
+
+	_, err := s.containerService.Inspect(s.ctx, "test-id")
+	s.ErrorIs(err, ErrContainerNotFound)
+}
+
+func (s *ContainerServiceSuite) TestInspectDockerError() {
+	s.dockerClient.EXPECT().Inspect(s.ctx, "test-id").Return(container.InspectResponse{}, errors.New("docker error"))
+	s.logger.EXPECT().Error("failed to inspect docker container", gomock.Any()).Times(1)
+
+	_, err := s.containerService.Inspect(s.ctx, "test-id")
+	s.ErrorContains(err, "docker error")
+}
+
+func (s *ContainerServiceSuite) TestTop() {
+	s.dockerClient.EXPECT().Top(s.ctx, "test-id").Return(container.TopResponse{
+		Titles:    []string{"UID", "PID", "CMD"},
+		Processes: [][]string{{"root", "1", "nginx: master process"}},
+	}, nil)
+
+	top, err := s.containerService.Top(s.ctx, "test-id")
+	s.NoError(err)
+	s.Equal([]string{"UID", "PID", "CMD"}, top.Titles)
+	s.Equal([][]string{{"root", "1", "nginx: master process"}}, top.Processes)
+}
+
+func (s *ContainerServiceSuite) TestTopErrors() {
+	s.dockerClient.EXPECT().Top(s.ctx, "missing-id").Return(container.TopResponse{}, errdefs.ErrNotFound)
+	_, err := s.containerService.Top(s.ctx, "missing-id")
+	s.ErrorIs(err, ErrContainerNotFound)
+
+	s.dockerClient.EXPECT().Top(s.ctx, "stopped-id").Return(container.TopResponse{}, errdefs.ErrConflict)
+	_, err = s.containerService.Top(s.ctx, "stopped-id")
+	s.ErrorIs(err, ErrContainerNotRunning)
+
+	s.dockerClient.EXPECT().Top(s.ctx, "test-id").Return(container.TopResponse{}, errors.New("docker error"))
+	s.logger.EXPECT().Error("failed to list container processes", gomock.Any()).Times(1)
+	_, err = s.containerService.Top(s.ctx, "test-id")
+	s.ErrorContains(err, "docker error")
+}
+
+// importFile builds an in-memory spreadsheet from the given rows, the first one being the header.
+func importFile(s *ContainerServiceSuite, rows ...[]string) multipart.File {
 	f := excelize.NewFile()
 	sheet := f.GetSheetName(0)
-	f.SetCellValue(sheet, "A1", "Container Name")
-	f.SetCellValue(sheet, "B1", "Image Name")
-
-	f.SetCellValue(sheet, "A2", "test-name")
-	f.SetCellValue(sheet, "B2", "nginx")
+	for i, row := range rows {
+		for j, value := range row {
+			cell, err := excelize.CoordinatesToCellName(j+1, i+1)
+			s.Require().NoError(err)
+			s.Require().NoError(f.SetCellValue(sheet, cell, value))
+		}
+	}
 
 	var buf bytes.Buffer
 	err := f.Write(&buf)
 	s.Require().NoError(err)
 
 	reader := bytes.NewReader(buf.Bytes())
-	file := struct {
+	return struct {
 		io.Reader
 		io.ReaderAt
 		io.Seeker
@@ -483,33 +1232,230 @@ func (s *ContainerServiceSuite) TestImportDockerCreateError() {
 		Seeker:   reader,
 		Closer:   io.NopCloser(nil),
 	}
+}
 
-	s.dockerClient.EXPECT().Create(s.ctx, "test-name", "nginx").Return(nil, errors.New("create error"))
-	s.mockRepo.EXPECT().CreateInBatches([]*entities.Container{}).Return(nil)
-	s.logger.EXPECT().Info("containers imported successfully").Times(1)
+func (s *ContainerServiceSuite) TestImport() {
+	file := importFile(s,
+		[]string{"Container Name", "Image Name", "Ports", "Env", "Labels"},
+		[]string{"test-name", "nginx", "8080:80/tcp", "MODE=prod", "team=core"},
+	)
+
+	spec := entities.ContainerSpec{
+		Ports:  []entities.PortBinding{{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"}},
+		Env:    []string{"MODE=prod"},
+		Labels: map[string]string{"team": "core"},
+	}
+	containerResp := &container.CreateResponse{ID: "test-id"}
+
+	s.mockRepo.EXPECT().FindByName("test-name").Return(nil, gorm.ErrRecordNotFound)
+	s.quotaService.EXPECT().Check(s.ctx, "user-id", int64(1), int64(0)).Return(nil)
+	s.expectReserve("user-id", int64(0), nil)
+	s.dockerClient.EXPECT().Create(s.ctx, "test-name", "nginx", spec).Return(containerResp, nil)
+	s.dockerClient.EXPECT().Start(s.ctx, "test-id").Return(nil)
+	s.dockerClient.EXPECT().GetStatus(s.ctx, "test-id").Return(entities.ContainerOn)
+	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("127.0.0.1")
+	s.expectPending(&entities.Container{
+		ContainerId:   "test-id",
+		ContainerName: "test-name",
+		Status:        entities.ContainerOn,
+		Ipv4:          "127.0.0.1",
+		ImageName:     "nginx",
+		OwnerId:       "user-id",
+		Spec:          spec,
+	})
+	s.logger.EXPECT().Info("container created successfully", zap.String("containerId", "test-id")).Times(1)
+	s.logger.EXPECT().Info("containers imported successfully", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
 
-	resp, err := s.containerService.Import(s.ctx, file)
+	resp, err := s.containerService.Import(s.ctx, file, dto.FormatXLSX, "user-id", false)
+	s.NoError(err)
+	s.False(resp.DryRun)
+	s.Equal(1, resp.SuccessCount)
+	s.Equal(0, resp.FailedCount)
+	s.Equal([]string{"test-name"}, resp.SuccessContainers)
+	s.Empty(resp.Errors)
+}
+
+func (s *ContainerServiceSuite) TestImportDryRun() {
+	file := importFile(s,
+		[]string{"Container Name", "Image Name"},
+		[]string{"test-name", "nginx"},
+		[]string{"bad name", "nginx"},
+	)
+
+	s.mockRepo.EXPECT().FindByName("test-name").Return(nil, gorm.ErrRecordNotFound)
+	s.quotaService.EXPECT().Check(s.ctx, "user-id", int64(1), int64(0)).Return(nil)
+	s.logger.EXPECT().Info("containers imported successfully", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	resp, err := s.containerService.Import(s.ctx, file, dto.FormatXLSX, "user-id", true)
+	s.NoError(err)
+	s.True(resp.DryRun)
+	s.Equal(1, resp.SuccessCount)
+	s.Equal([]string{"test-name"}, resp.SuccessContainers)
+	s.Equal(1, resp.FailedCount)
+	s.Equal([]dto.ImportError{{
+		Row: 3, Column: "Container Name", Code: dto.ImportInvalidName,
+		Message: "container name must match ^[a-zA-Z0-9][a-zA-Z0-9_.-]+$",
+	}}, resp.Errors)
+}
+
+func (s *ContainerServiceSuite) TestImportSkipsExistingContainers() {
+	file := importFile(s,
+		[]string{"Container Name", "Image Name"},
+		[]string{"test-name", "nginx"},
+		[]string{"other-name", "nginx"},
+	)
+
+	s.mockRepo.EXPECT().FindByName("test-name").Return(&entities.Container{ContainerName: "test-name", ImageName: "nginx", OwnerId: "user-id"}, nil)
+	s.mockRepo.EXPECT().FindByName("other-name").Return(&entities.Container{ContainerName: "other-name", ImageName: "nginx", OwnerId: "another-user"}, nil)
+	s.logger.EXPECT().Info("containers imported successfully", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	resp, err := s.containerService.Import(s.ctx, file, dto.FormatXLSX, "user-id", false)
 	s.NoError(err)
 	s.Equal(0, resp.SuccessCount)
+	s.Equal(1, resp.SkippedCount)
+	s.Equal([]string{"test-name"}, resp.SkippedContainers)
 	s.Equal(1, resp.FailedCount)
-	s.Contains(resp.FailedContainers, "test-name")
+	s.Equal([]string{"other-name"}, resp.FailedContainers)
+	s.Equal(dto.ImportNameConflict, resp.Errors[0].Code)
 }
 
-func (s *ContainerServiceSuite) TestImportInvalidContainerField() {
-	f := excelize.NewFile()
-	sheet := f.GetSheetName(0)
-	f.SetCellValue(sheet, "A1", "Container Name")
-	f.SetCellValue(sheet, "B1", "Image Name")
+func (s *ContainerServiceSuite) TestImportTrashedNameConflict() {
+	file := importFile(s,
+		[]string{"Container Name", "Image Name"},
+		[]string{"test-name", "nginx"},
+	)
 
-	f.SetCellValue(sheet, "A2", "")
-	f.SetCellValue(sheet, "B2", "nginx")
+	trashed := &entities.Container{ContainerName: "test-name", ImageName: "nginx", OwnerId: "user-id", DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}
+	s.mockRepo.EXPECT().FindByName("test-name").Return(trashed, nil)
+	s.logger.EXPECT().Info("containers imported successfully", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
 
-	var buf bytes.Buffer
-	err := f.Write(&buf)
-	s.Require().NoError(err)
+	resp, err := s.containerService.Import(s.ctx, file, dto.FormatXLSX, "user-id", false)
+	s.NoError(err)
+	s.Equal(0, resp.SkippedCount)
+	s.Equal([]string{"test-name"}, resp.FailedContainers)
+	s.Equal(dto.ImportNameConflict, resp.Errors[0].Code)
+}
 
-	reader := bytes.NewReader(buf.Bytes())
-	file := struct {
+func (s *ContainerServiceSuite) TestReadImport() {
+	file := importFile(s,
+		[]string{"Container Name", "Image Name", "Notes", "Ports", "Env", "Labels"},
+		[]string{" web ", "nginx", "ignored", "80; 127.0.0.1:8443:443/udp", "A=1\nB=", "tier=front"},
+		[]string{"incomplete"},
+		[]string{"", "", "", "", "", ""},
+		[]string{"web", "nginx"},
+		[]string{"ports", "nginx", "", "70000"},
+		[]string{"env", "nginx", "", "", "NOVALUE"},
+		[]string{"labels", "nginx", "", "", "", "=x"},
+	)
+
+	s.mockRepo.EXPECT().FindByName("web").Return(nil, gorm.ErrRecordNotFound)
+	s.quotaService.EXPECT().Check(s.ctx, "user-id", int64(1), int64(0)).Return(nil)
+
+	rows, err := s.containerService.ReadImport(s.ctx, file, dto.FormatXLSX, "user-id")
+	s.NoError(err)
+	s.Equal([]dto.ImportRow{
+		{Row: 2, ContainerName: "web", ImageName: "nginx", Spec: entities.ContainerSpec{
+			Ports: []entities.PortBinding{
+				{ContainerPort: 80},
+				{HostIp: "127.0.0.1", HostPort: 8443, ContainerPort: 443, Protocol: "udp"},
+			},
+			Env:    []string{"A=1", "B="},
+			Labels: map[string]string{"tier": "front"},
+		}},
+		{Row: 3, ContainerName: "incomplete", Errors: []dto.ImportError{
+			{Row: 3, Column: "Image Name", Code: dto.ImportMissingValue, Message: "image name is required"},
+		}},
+		{Row: 5, ContainerName: "web", ImageName: "nginx", Errors: []dto.ImportError{
+			{Row: 5, Column: "Container Name", Code: dto.ImportDuplicateName, Message: "container name already used on row 2"},
+		}},
+		{Row: 6, ContainerName: "ports", ImageName: "nginx", Errors: []dto.ImportError{
+			{Row: 6, Column: "Ports", Code: dto.ImportInvalidPorts, Message: `invalid container port in "70000"`},
+		}},
+		{Row: 7, ContainerName: "env", ImageName: "nginx", Errors: []dto.ImportError{
+			{Row: 7, Column: "Env", Code: dto.ImportInvalidEnv, Message: `invalid variable "NOVALUE", expected KEY=value`},
+		}},
+		{Row: 8, ContainerName: "labels", ImageName: "nginx", Errors: []dto.ImportError{
+			{Row: 8, Column: "Labels", Code: dto.ImportInvalidLabels, Message: `invalid label "=x", expected key=value`},
+		}},
+	}, rows)
+}
+
+func (s *ContainerServiceSuite) TestReadImportExpiry() {
+	expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	file := importFile(s,
+		[]string{"Container Name", "Image Name", "Expires At", "TTL"},
+		[]string{"dated", "nginx", expiresAt.Format(time.RFC3339)},
+		[]string{"ttl", "nginx", "", "2h"},
+		[]string{"both", "nginx", expiresAt.Format(time.RFC3339), "2h"},
+		[]string{"past", "nginx", "2020-01-01T00:00:00Z"},
+		[]string{"bad-ttl", "nginx", "", "-1h"},
+	)
+
+	s.mockRepo.EXPECT().FindByName("dated").Return(nil, gorm.ErrRecordNotFound)
+	s.mockRepo.EXPECT().FindByName("ttl").Return(nil, gorm.ErrRecordNotFound)
+	s.quotaService.EXPECT().Check(s.ctx, "user-id", int64(1), int64(0)).Return(nil)
+	s.quotaService.EXPECT().Check(s.ctx, "user-id", int64(2), int64(0)).Return(nil)
+
+	rows, err := s.containerService.ReadImport(s.ctx, file, dto.FormatXLSX, "user-id")
+	s.NoError(err)
+	s.Len(rows, 5)
+	s.Empty(rows[0].Errors)
+	s.True(expiresAt.Equal(*rows[0].ExpiresAt))
+	s.Empty(rows[1].Errors)
+	s.WithinDuration(time.Now().Add(2*time.Hour), *rows[1].ExpiresAt, time.Minute)
+	s.Equal([]dto.ImportError{{Row: 4, Column: "Expires At", Code: dto.ImportInvalidExpiry, Message: "expires at and ttl cannot both be set"}}, rows[2].Errors)
+	s.Equal([]dto.ImportError{{Row: 5, Column: "Expires At", Code: dto.ImportInvalidExpiry, Message: "expires_at is not in the future"}}, rows[3].Errors)
+	s.Equal([]dto.ImportError{{Row: 6, Column: "TTL", Code: dto.ImportInvalidExpiry, Message: `invalid ttl "-1h", expected a positive duration such as 2h30m`}}, rows[4].Errors)
+}
+
+func (s *ContainerServiceSuite) TestReadImportMemory() {
+	file := importFile(s,
+		[]string{"Container Name", "Image Name", "Memory"},
+		[]string{"small", "nginx", "67108864"},
+		[]string{"large", "nginx", "134217728"},
+		[]string{"unlimited", "nginx"},
+		[]string{"tiny", "nginx", "1024"},
+		[]string{"unit", "nginx", "64m"},
+	)
+
+	s.mockRepo.EXPECT().FindByName("small").Return(nil, gorm.ErrRecordNotFound)
+	s.mockRepo.EXPECT().FindByName("large").Return(nil, gorm.ErrRecordNotFound)
+	s.mockRepo.EXPECT().FindByName("unlimited").Return(nil, gorm.ErrRecordNotFound)
+	s.quotaService.EXPECT().Check(s.ctx, "user-id", int64(1), int64(64<<20)).Return(nil)
+	s.quotaService.EXPECT().Check(s.ctx, "user-id", int64(2), int64(192<<20)).Return(nil)
+	s.quotaService.EXPECT().Check(s.ctx, "user-id", int64(3), int64(0)).Return(fmt.Errorf("%w: a memory limit is required", ErrQuotaExceeded))
+
+	rows, err := s.containerService.ReadImport(s.ctx, file, dto.FormatXLSX, "user-id")
+	s.NoError(err)
+	s.Len(rows, 5)
+	s.Empty(rows[0].Errors)
+	s.Equal(int64(64<<20), rows[0].Spec.Resources.Memory)
+	s.Empty(rows[1].Errors)
+	s.Equal(int64(128<<20), rows[1].Spec.Resources.Memory)
+	s.Equal([]dto.ImportError{{Row: 4, Code: dto.ImportQuotaExceeded, Message: "quota exceeded: a memory limit is required"}}, rows[2].Errors)
+	s.Equal([]dto.ImportError{{Row: 5, Column: "Memory", Code: dto.ImportInvalidMemory, Message: `invalid memory "1024", expected at least 6291456 bytes`}}, rows[3].Errors)
+	s.Equal(dto.ImportInvalidMemory, rows[4].Errors[0].Code)
+}
+
+func (s *ContainerServiceSuite) TestReadImportRepoError() {
+	file := importFile(s,
+		[]string{"Container Name", "Image Name"},
+		[]string{"test-name", "nginx"},
+	)
+
+	s.mockRepo.EXPECT().FindByName("test-name").Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to import containers", gomock.Any()).Times(1)
+
+	rows, err := s.containerService.ReadImport(s.ctx, file, dto.FormatXLSX, "user-id")
+	s.Error(err)
+	s.Nil(rows)
+}
+
+func (s *ContainerServiceSuite) TestImportInvalidExcelFile() {
+	data := []byte("this is not a real Excel file")
+	reader := bytes.NewReader(data)
+
+	fakeFile := struct {
 		io.Reader
 		io.ReaderAt
 		io.Seeker
@@ -520,115 +1466,179 @@ func (s *ContainerServiceSuite) TestImportInvalidContainerField() {
 		Seeker:   reader,
 		Closer:   io.NopCloser(nil),
 	}
-	s.mockRepo.EXPECT().CreateInBatches([]*entities.Container{}).Return(nil)
-	s.logger.EXPECT().Info("containers imported successfully").Times(1)
 
-	resp, err := s.containerService.Import(s.ctx, file)
+	s.logger.EXPECT().Error(gomock.Any(), gomock.Any()).Times(1)
+
+	resp, err := s.containerService.Import(s.ctx, fakeFile, dto.FormatXLSX, "user-id", false)
+	s.Error(err)
+	s.Nil(resp)
+}
+
+func (s *ContainerServiceSuite) TestImportWithMissingHeaderRows() {
+	s.logger.EXPECT().Error("failed to import containers", gomock.Any()).Times(1)
+
+	file := importFile(s, []string{"Container Id"})
+
+	resp, err := s.containerService.Import(s.ctx, file, dto.FormatXLSX, "user-id", false)
+	s.Error(err)
+	s.Nil(resp)
+}
+
+func (s *ContainerServiceSuite) TestImportWithInvalidHeaderRows() {
+	s.logger.EXPECT().Error("failed to import containers", gomock.Any()).Times(1)
+
+	file := importFile(s,
+		[]string{"Container Id", "Image Name"},
+		[]string{"test-id"},
+	)
+
+	resp, err := s.containerService.Import(s.ctx, file, dto.FormatXLSX, "user-id", false)
+	s.Error(err)
+	s.Nil(resp)
+}
+
+func (s *ContainerServiceSuite) TestImportDockerCreateError() {
+	file := importFile(s,
+		[]string{"Container Name", "Image Name"},
+		[]string{"test-name", "nginx"},
+	)
+
+	s.mockRepo.EXPECT().FindByName("test-name").Return(nil, gorm.ErrRecordNotFound)
+	s.quotaService.EXPECT().Check(s.ctx, "user-id", int64(1), int64(0)).Return(nil)
+	s.expectReserve("user-id", int64(0), nil)
+	pendingId := s.expectReservation(&entities.Container{ContainerName: "test-name", ImageName: "nginx", OwnerId: "user-id"}, nil)
+	s.expectRelease(pendingId)
+	s.dockerClient.EXPECT().Create(s.ctx, "test-name", "nginx", entities.ContainerSpec{}).Return(nil, errors.New("create error"))
+	s.logger.EXPECT().Error("failed to create docker container", gomock.Any()).Times(1)
+	s.logger.EXPECT().Info("containers imported successfully", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	resp, err := s.containerService.Import(s.ctx, file, dto.FormatXLSX, "user-id", false)
 	s.NoError(err)
 	s.Equal(0, resp.SuccessCount)
 	s.Equal(1, resp.FailedCount)
-	s.Contains(resp.FailedContainers, "")
+	s.Contains(resp.FailedContainers, "test-name")
+	s.Equal([]dto.ImportError{{Row: 2, Code: dto.ImportCreateFailed, Message: "create error"}}, resp.Errors)
 }
 
-func (s *ContainerServiceSuite) TestImportRepoAndDockerStopError() {
-	f := excelize.NewFile()
-	sheet := f.GetSheetName(0)
-	f.SetCellValue(sheet, "A1", "Container Name")
-	f.SetCellValue(sheet, "B1", "Image Name")
+func (s *ContainerServiceSuite) TestImportQuotaExceeded() {
+	file := importFile(s,
+		[]string{"Container Name", "Image Name"},
+		[]string{"first", "nginx"},
+		[]string{"second", "nginx"},
+	)
 
-	f.SetCellValue(sheet, "A2", "test-name")
-	f.SetCellValue(sheet, "B2", "nginx")
+	s.mockRepo.EXPECT().FindByName("first").Return(nil, gorm.ErrRecordNotFound)
+	s.mockRepo.EXPECT().FindByName("second").Return(nil, gorm.ErrRecordNotFound)
+	s.quotaService.EXPECT().Check(s.ctx, "user-id", int64(1), int64(0)).Return(nil)
+	s.quotaService.EXPECT().Check(s.ctx, "user-id", int64(2), int64(0)).Return(fmt.Errorf("%w: containers 2/1", ErrQuotaExceeded))
+	s.logger.EXPECT().Info("containers imported successfully", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
 
-	var buf bytes.Buffer
-	err := f.Write(&buf)
-	s.Require().NoError(err)
+	resp, err := s.containerService.Import(s.ctx, file, dto.FormatXLSX, "user-id", true)
+	s.NoError(err)
+	s.Equal([]string{"first"}, resp.SuccessContainers)
+	s.Equal([]string{"second"}, resp.FailedContainers)
+	s.Equal(dto.ImportQuotaExceeded, resp.Errors[0].Code)
+}
 
-	reader := bytes.NewReader(buf.Bytes())
-	file := struct {
-		io.Reader
-		io.ReaderAt
-		io.Seeker
-		io.Closer
-	}{
-		Reader:   reader,
-		ReaderAt: reader,
-		Seeker:   reader,
-		Closer:   io.NopCloser(nil),
-	}
+func (s *ContainerServiceSuite) TestImportImageNotAllowed() {
+	imageService := services.NewMockIImageService(s.ctrl)
+	containerService := NewContainerService(s.mockRepo, s.mockSnapshotRepo, s.dockerClient, s.quotaService, imageService, s.healthcheck, s.logger, env.ContainerEnv{})
+
+	file := importFile(s,
+		[]string{"Container Name", "Image Name"},
+		[]string{"test-name", "evil.io/nginx"},
+		[]string{"other-name", "NGINX"},
+	)
+
+	imageService.EXPECT().CheckPolicy("evil.io/nginx").Return(fmt.Errorf("%w: registry evil.io is not allowed", ErrImageNotAllowed))
+	imageService.EXPECT().CheckPolicy("NGINX").Return(fmt.Errorf("%w: invalid reference format", errdefs.ErrInvalidArgument))
+	s.logger.EXPECT().Info("containers imported successfully", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	resp, err := containerService.Import(s.ctx, file, dto.FormatXLSX, "user-id", false)
+	s.NoError(err)
+	s.Equal(0, resp.SuccessCount)
+	s.Equal(2, resp.FailedCount)
+	s.Equal(dto.ImportImageNotAllowed, resp.Errors[0].Code)
+	s.Equal(dto.ImportInvalidImage, resp.Errors[1].Code)
+}
+
+func (s *ContainerServiceSuite) TestImportInvalidContainerField() {
+	file := importFile(s,
+		[]string{"Container Name", "Image Name"},
+		[]string{"", "nginx"},
+	)
+
+	s.logger.EXPECT().Info("containers imported successfully", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	resp, err := s.containerService.Import(s.ctx, file, dto.FormatXLSX, "user-id", false)
+	s.NoError(err)
+	s.Equal(0, resp.SuccessCount)
+	s.Equal(1, resp.FailedCount)
+	s.Equal([]dto.ImportError{{Row: 2, Column: "Container Name", Code: dto.ImportMissingValue, Message: "container name is required"}}, resp.Errors)
+}
+
+func (s *ContainerServiceSuite) TestImportRepoAndDockerStopError() {
+	file := importFile(s,
+		[]string{"Container Name", "Image Name"},
+		[]string{"test-name", "nginx"},
+	)
 
 	containerResp := &container.CreateResponse{ID: "test-id"}
-	containerEntity := &entities.Container{
-		ContainerId:   "test-id",
-		ContainerName: "test-name",
-		Status:        entities.ContainerOn,
-		Ipv4:          "127.0.0.1",
-	}
-	containers := []*entities.Container{containerEntity}
 
-	s.dockerClient.EXPECT().Create(s.ctx, "test-name", "nginx").Return(containerResp, nil)
+	s.mockRepo.EXPECT().FindByName("test-name").Return(nil, gorm.ErrRecordNotFound)
+	s.quotaService.EXPECT().Check(s.ctx, "user-id", int64(1), int64(0)).Return(nil)
+	s.expectReserve("user-id", int64(0), nil)
+	s.dockerClient.EXPECT().Create(s.ctx, "test-name", "nginx", entities.ContainerSpec{}).Return(containerResp, nil)
 	s.dockerClient.EXPECT().Start(s.ctx, "test-id").Return(nil)
 	s.dockerClient.EXPECT().GetStatus(s.ctx, "test-id").Return(entities.ContainerOn)
 	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("127.0.0.1")
-	s.mockRepo.EXPECT().CreateInBatches(containers).Return(errors.New("db error"))
+	pendingId := s.expectReservation(&entities.Container{ContainerName: "test-name", ImageName: "nginx", OwnerId: "user-id"}, nil)
+	s.mockRepo.EXPECT().Activate(gomock.Any(), "test-id", entities.ContainerOn, "127.0.0.1").Return(false, errors.New("db error"))
+	s.expectRelease(pendingId)
+	s.logger.EXPECT().Error("failed to create container", gomock.Any()).Times(1)
 	s.dockerClient.EXPECT().Stop(s.ctx, "test-id").Return(errors.New("docker stop error"))
-	s.logger.EXPECT().Error("failed to stop docker container", zap.String("container_id", containerEntity.ContainerId), gomock.Any()).Times(1)
+	s.dockerClient.EXPECT().Delete(s.ctx, "test-id").Return(nil)
+	s.logger.EXPECT().Error("failed to stop docker container", gomock.Any()).Times(1)
+	s.logger.EXPECT().Info("containers imported successfully", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
 
-	resp, err := s.containerService.Import(s.ctx, file)
+	resp, err := s.containerService.Import(s.ctx, file, dto.FormatXLSX, "user-id", false)
 	s.NoError(err)
 	s.Equal(0, resp.SuccessCount)
 	s.Equal(1, resp.FailedCount)
 	s.Contains(resp.FailedContainers, "test-name")
+	s.Equal("db error", resp.Errors[0].Message)
 }
 
 func (s *ContainerServiceSuite) TestImportRepoAndDockerDeleteError() {
-	f := excelize.NewFile()
-	sheet := f.GetSheetName(0)
-	f.SetCellValue(sheet, "A1", "Container Name")
-	f.SetCellValue(sheet, "B1", "Image Name")
-
-	f.SetCellValue(sheet, "A2", "test-name")
-	f.SetCellValue(sheet, "B2", "nginx")
-
-	var buf bytes.Buffer
-	err := f.Write(&buf)
-	s.Require().NoError(err)
-
-	reader := bytes.NewReader(buf.Bytes())
-	file := struct {
-		io.Reader
-		io.ReaderAt
-		io.Seeker
-		io.Closer
-	}{
-		Reader:   reader,
-		ReaderAt: reader,
-		Seeker:   reader,
-		Closer:   io.NopCloser(nil),
-	}
+	file := importFile(s,
+		[]string{"Container Name", "Image Name"},
+		[]string{"test-name", "nginx"},
+	)
 
 	containerResp := &container.CreateResponse{ID: "test-id"}
-	containerEntity := &entities.Container{
-		ContainerId:   "test-id",
-		ContainerName: "test-name",
-		Status:        entities.ContainerOn,
-		Ipv4:          "127.0.0.1",
-	}
-	containers := []*entities.Container{containerEntity}
 
-	s.dockerClient.EXPECT().Create(s.ctx, "test-name", "nginx").Return(containerResp, nil)
+	s.mockRepo.EXPECT().FindByName("test-name").Return(nil, gorm.ErrRecordNotFound)
+	s.quotaService.EXPECT().Check(s.ctx, "user-id", int64(1), int64(0)).Return(nil)
+	s.expectReserve("user-id", int64(0), nil)
+	s.dockerClient.EXPECT().Create(s.ctx, "test-name", "nginx", entities.ContainerSpec{}).Return(containerResp, nil)
 	s.dockerClient.EXPECT().Start(s.ctx, "test-id").Return(nil)
 	s.dockerClient.EXPECT().GetStatus(s.ctx, "test-id").Return(entities.ContainerOn)
 	s.dockerClient.EXPECT().GetIpv4(s.ctx, "test-id").Return("127.0.0.1")
-	s.mockRepo.EXPECT().CreateInBatches(containers).Return(errors.New("db error"))
+	pendingId := s.expectReservation(&entities.Container{ContainerName: "test-name", ImageName: "nginx", OwnerId: "user-id"}, nil)
+	s.mockRepo.EXPECT().Activate(gomock.Any(), "test-id", entities.ContainerOn, "127.0.0.1").Return(false, errors.New("db error"))
+	s.expectRelease(pendingId)
+	s.logger.EXPECT().Error("failed to create container", gomock.Any()).Times(1)
 	s.dockerClient.EXPECT().Stop(s.ctx, "test-id").Return(nil)
-	s.dockerClient.EXPECT().Delete(s.ctx, "test-id").Return(errors.New("docker stop error"))
-	s.logger.EXPECT().Error("failed to delete docker container", zap.String("container_id", containerEntity.ContainerId), gomock.Any()).Times(1)
+	s.dockerClient.EXPECT().Delete(s.ctx, "test-id").Return(errors.New("docker delete error"))
+	s.logger.EXPECT().Error("failed to delete docker container", gomock.Any()).Times(1)
+	s.logger.EXPECT().Info("containers imported successfully", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
 
-	resp, err := s.containerService.Import(s.ctx, file)
+	resp, err := s.containerService.Import(s.ctx, file, dto.FormatXLSX, "user-id", false)
 	s.NoError(err)
 	s.Equal(0, resp.SuccessCount)
 	s.Equal(1, resp.FailedCount)
 	s.Contains(resp.FailedContainers, "test-name")
+	s.Equal("db error", resp.Errors[0].Message)
 }
 
 func (s *ContainerServiceSuite) TestExport() {
@@ -645,17 +1655,50 @@ func (s *ContainerServiceSuite) TestExport() {
 		},
 	}
 
-	s.mockRepo.EXPECT().View(filter, from, to-from+1, sort).Return(containers, int64(len(containers)), nil)
-	s.logger.EXPECT().Info("containers exported successfully").Times(1)
+	s.mockRepo.EXPECT().Stream(filter, from, to-from+1, sort, gomock.Any()).DoAndReturn(
+		func(filter dto.ContainerFilter, from int, limit int, sort dto.ContainerSort, fn func(*entities.Container) error) error {
+			for _, container := range containers {
+				if err := fn(container); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	s.logger.EXPECT().Info("containers exported successfully", zap.String("format", "xlsx"), zap.Int("count", 1)).Times(1)
 
-	result, err := s.containerService.Export(s.ctx, filter, from, to, sort)
+	var buf bytes.Buffer
+	err := s.containerService.Export(s.ctx, filter, from, to, sort, dto.FormatXLSX, &buf)
 	s.NoError(err)
-	s.True(len(result) > 0)
+
+	f, err := excelize.OpenReader(&buf)
+	s.Require().NoError(err)
+	rows, err := f.GetRows(f.GetSheetName(0))
+	s.NoError(err)
+	s.Len(rows, 2)
+	s.Equal("test-id", rows[1][1])
+}
+
+func (s *ContainerServiceSuite) TestExportNDJSON() {
+	sort := dto.ContainerSort{Field: "container_id", Order: "asc"}
+
+	s.mockRepo.EXPECT().Stream(dto.ContainerFilter{}, 1, -1, sort, gomock.Any()).DoAndReturn(
+		func(filter dto.ContainerFilter, from int, limit int, sort dto.ContainerSort, fn func(*entities.Container) error) error {
+			s.NoError(fn(&entities.Container{ContainerId: "a", ContainerName: "web", ImageName: "nginx", CreatedAt: time.Unix(0, 0).UTC()}))
+			return fn(&entities.Container{ContainerId: "b", ContainerName: "db", ImageName: "postgres", CreatedAt: time.Unix(0, 0).UTC()})
+		})
+	s.logger.EXPECT().Info("containers exported successfully", zap.String("format", "ndjson"), zap.Int("count", 2)).Times(1)
+
+	var buf bytes.Buffer
+	err := s.containerService.Export(s.ctx, dto.ContainerFilter{}, 1, -1, sort, dto.FormatNDJSON, &buf)
+	s.NoError(err)
+	s.Equal(`{"container_id":"a","container_name":"web","image_name":"nginx","created_at":"1970-01-01T00:00:00Z"}
+{"container_id":"b","container_name":"db","image_name":"postgres","created_at":"1970-01-01T00:00:00Z"}
+`, buf.String())
 }
 
 func (s *ContainerServiceSuite) TestExportInvalidRange() {
 	s.logger.EXPECT().Error("failed to export containers", gomock.Any()).Times(1)
-	_, err := s.containerService.Export(s.ctx, dto.ContainerFilter{}, 0, 10, dto.ContainerSort{})
+	err := s.containerService.Export(s.ctx, dto.ContainerFilter{}, 0, 10, dto.ContainerSort{}, dto.FormatXLSX, io.Discard)
 	s.ErrorContains(err, "invalid range")
 }
 
@@ -664,8 +1707,11 @@ func (s *ContainerServiceSuite) TestExportError() {
 	sort := dto.ContainerSort{Field: "container_id", Order: "asc"}
 	from, to := 1, 5
 
-	s.mockRepo.EXPECT().View(filter, from, to-from+1, sort).Return(nil, int64(0), errors.New("fetch error"))
+	s.mockRepo.EXPECT().Stream(filter, from, to-from+1, sort, gomock.Any()).Return(errors.New("fetch error"))
+	s.logger.EXPECT().Error("failed to export containers", gomock.Any()).Times(1)
 
-	_, err := s.containerService.Export(s.ctx, filter, from, to, sort)
+	var buf bytes.Buffer
+	err := s.containerService.Export(s.ctx, filter, from, to, sort, dto.FormatXLSX, &buf)
 	s.ErrorContains(err, "fetch error")
+	s.Zero(buf.Len())
 }
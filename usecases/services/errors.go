@@ -0,0 +1,23 @@
+package services
+
+import "errors"
+
+var (
+	ErrQuotaExceeded       = errors.New("quota exceeded")
+	ErrContainerNotFound   = errors.New("container not found")
+	ErrContainerNotRunning = errors.New("container not running")
+	ErrContainerConflict   = errors.New("container state conflict")
+	ErrContainerNameTaken  = errors.New("container name already in use")
+	ErrSnapshotNotFound    = errors.New("snapshot not found")
+	ErrFileNotFound        = errors.New("file not found")
+	ErrFileTooLarge        = errors.New("file too large")
+	ErrScheduleNotFound    = errors.New("schedule not found")
+	ErrImageNotAllowed     = errors.New("image not allowed by registry policy")
+	ErrMountNotAllowed     = errors.New("mount not allowed")
+	ErrImageNotFound       = errors.New("image not found")
+	ErrImageInUse          = errors.New("image in use")
+	ErrRegistryKeyMissing  = errors.New("registry secret key is not configured")
+	ErrJobNotFound         = errors.New("job not found")
+	ErrJobFinished         = errors.New("job already finished")
+	ErrUserNotFound        = errors.New("user not found")
+)
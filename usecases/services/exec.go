@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/pkg/docker"
+	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type IExecService interface {
+	Start(ctx context.Context, userId string, containerId string, cmd []string, tty bool) (*dto.ExecSession, error)
+	Resize(ctx context.Context, session *dto.ExecSession, rows uint, cols uint) error
+	Finish(ctx context.Context, session *dto.ExecSession) (*int, error)
+}
+
+type ExecService struct {
+	dockerClient docker.IDockerClient
+	auditService IAuditService
+	logger       logger.ILogger
+}
+
+func NewExecService(dockerClient docker.IDockerClient, auditService IAuditService, logger logger.ILogger) IExecService {
+	return &ExecService{
+		dockerClient: dockerClient,
+		auditService: auditService,
+		logger:       logger,
+	}
+}
+
+func (s *ExecService) Start(ctx context.Context, userId string, containerId string, cmd []string, tty bool) (*dto.ExecSession, error) {
+	execId, err := s.dockerClient.ExecCreate(ctx, containerId, container.ExecOptions{
+		Tty:          tty,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          cmd,
+	})
+	if errdefs.IsNotFound(err) {
+		return nil, fmt.Errorf("%w: %s", ErrContainerNotFound, containerId)
+	}
+	if errdefs.IsConflict(err) {
+		return nil, fmt.Errorf("%w: %s", ErrContainerNotRunning, containerId)
+	}
+	if err != nil {
+		s.logger.Error("failed to create exec", zap.Error(err))
+		return nil, err
+	}
+
+	auditLog, err := s.auditService.Start(ctx, entities.AuditExec, userId, containerId, strings.Join(cmd, " "))
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := s.dockerClient.ExecAttach(ctx, execId, container.ExecAttachOptions{Tty: tty})
+	if err != nil {
+		s.logger.Error("failed to attach exec", zap.Error(err))
+		s.auditService.Finish(ctx, auditLog, nil)
+		return nil, err
+	}
+
+	s.logger.Info("exec session started", zap.String("containerId", containerId), zap.String("execId", execId), zap.String("userId", userId))
+	return &dto.ExecSession{
+		ExecId:   execId,
+		Tty:      tty,
+		Stream:   stream,
+		AuditLog: auditLog,
+	}, nil
+}
+
+func (s *ExecService) Resize(ctx context.Context, session *dto.ExecSession, rows uint, cols uint) error {
+	if err := s.dockerClient.ExecResize(ctx, session.ExecId, rows, cols); err != nil {
+		s.logger.Error("failed to resize exec", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Finish closes the session and records its exit code, which stays nil if the process outlived the session.
+func (s *ExecService) Finish(ctx context.Context, session *dto.ExecSession) (*int, error) {
+	session.Stream.Close()
+
+	var exitCode *int
+	inspect, err := s.dockerClient.ExecInspect(ctx, session.ExecId)
+	if err != nil {
+		s.logger.Error("failed to inspect exec", zap.Error(err))
+	} else if !inspect.Running {
+		exitCode = &inspect.ExitCode
+	}
+
+	if err := s.auditService.Finish(ctx, session.AuditLog, exitCode); err != nil {
+		return exitCode, err
+	}
+	s.logger.Info("exec session finished", zap.String("execId", session.ExecId))
+	return exitCode, nil
+}
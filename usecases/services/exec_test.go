@@ -0,0 +1,164 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/docker"
+	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+)
+
+type ExecServiceSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	execService  IExecService
+	dockerClient *docker.MockIDockerClient
+	auditService *services.MockIAuditService
+	logger       *logger.MockILogger
+	ctx          context.Context
+}
+
+func (s *ExecServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.dockerClient = docker.NewMockIDockerClient(s.ctrl)
+	s.auditService = services.NewMockIAuditService(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.execService = NewExecService(s.dockerClient, s.auditService, s.logger)
+	s.ctx = context.Background()
+}
+
+func (s *ExecServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestExecServiceSuite(t *testing.T) {
+	suite.Run(t, new(ExecServiceSuite))
+}
+
+func (s *ExecServiceSuite) newStream() types.HijackedResponse {
+	conn, _ := net.Pipe()
+	return types.HijackedResponse{Conn: conn, Reader: bufio.NewReader(conn)}
+}
+
+func (s *ExecServiceSuite) TestStart() {
+	auditLog := &entities.AuditLog{ID: 1}
+	stream := s.newStream()
+	s.dockerClient.EXPECT().ExecCreate(s.ctx, "container-id", container.ExecOptions{
+		Tty:          true,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          []string{"ls", "-la"},
+	}).Return("exec-id", nil)
+	s.auditService.EXPECT().Start(s.ctx, entities.AuditExec, "user-id", "container-id", "ls -la").Return(auditLog, nil)
+	s.dockerClient.EXPECT().ExecAttach(s.ctx, "exec-id", container.ExecAttachOptions{Tty: true}).Return(stream, nil)
+	s.logger.EXPECT().Info("exec session started", gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	session, err := s.execService.Start(s.ctx, "user-id", "container-id", []string{"ls", "-la"}, true)
+	s.NoError(err)
+	s.Equal("exec-id", session.ExecId)
+	s.True(session.Tty)
+	s.Equal(auditLog, session.AuditLog)
+}
+
+func (s *ExecServiceSuite) TestStartContainerNotFound() {
+	s.dockerClient.EXPECT().ExecCreate(s.ctx, "container-id", gomock.Any()).Return("", errdefs.ErrNotFound)
+
+	_, err := s.execService.Start(s.ctx, "user-id", "container-id", []string{"/bin/sh"}, true)
+	s.ErrorIs(err, ErrContainerNotFound)
+}
+
+func (s *ExecServiceSuite) TestStartContainerNotRunning() {
+	s.dockerClient.EXPECT().ExecCreate(s.ctx, "container-id", gomock.Any()).Return("", errdefs.ErrConflict)
+
+	_, err := s.execService.Start(s.ctx, "user-id", "container-id", []string{"/bin/sh"}, true)
+	s.ErrorIs(err, ErrContainerNotRunning)
+}
+
+func (s *ExecServiceSuite) TestStartExecCreateError() {
+	s.dockerClient.EXPECT().ExecCreate(s.ctx, "container-id", gomock.Any()).Return("", errors.New("docker error"))
+	s.logger.EXPECT().Error("failed to create exec", gomock.Any()).Times(1)
+
+	_, err := s.execService.Start(s.ctx, "user-id", "container-id", []string{"/bin/sh"}, true)
+	s.ErrorContains(err, "docker error")
+}
+
+func (s *ExecServiceSuite) TestStartAuditError() {
+	s.dockerClient.EXPECT().ExecCreate(s.ctx, "container-id", gomock.Any()).Return("exec-id", nil)
+	s.auditService.EXPECT().Start(s.ctx, entities.AuditExec, "user-id", "container-id", "/bin/sh").Return(nil, errors.New("db error"))
+
+	_, err := s.execService.Start(s.ctx, "user-id", "container-id", []string{"/bin/sh"}, true)
+	s.ErrorContains(err, "db error")
+}
+
+func (s *ExecServiceSuite) TestStartAttachError() {
+	auditLog := &entities.AuditLog{ID: 1}
+	s.dockerClient.EXPECT().ExecCreate(s.ctx, "container-id", gomock.Any()).Return("exec-id", nil)
+	s.auditService.EXPECT().Start(s.ctx, entities.AuditExec, "user-id", "container-id", "/bin/sh").Return(auditLog, nil)
+	s.dockerClient.EXPECT().ExecAttach(s.ctx, "exec-id", gomock.Any()).Return(types.HijackedResponse{}, errors.New("attach error"))
+	s.logger.EXPECT().Error("failed to attach exec", gomock.Any()).Times(1)
+	s.auditService.EXPECT().Finish(s.ctx, auditLog, nil).Return(nil)
+
+	_, err := s.execService.Start(s.ctx, "user-id", "container-id", []string{"/bin/sh"}, true)
+	s.ErrorContains(err, "attach error")
+}
+
+func (s *ExecServiceSuite) TestResize() {
+	s.dockerClient.EXPECT().ExecResize(s.ctx, "exec-id", uint(40), uint(120)).Return(nil)
+
+	err := s.execService.Resize(s.ctx, &dto.ExecSession{ExecId: "exec-id"}, 40, 120)
+	s.NoError(err)
+}
+
+func (s *ExecServiceSuite) TestResizeError() {
+	s.dockerClient.EXPECT().ExecResize(s.ctx, "exec-id", uint(40), uint(120)).Return(errors.New("docker error"))
+	s.logger.EXPECT().Error("failed to resize exec", gomock.Any()).Times(1)
+
+	err := s.execService.Resize(s.ctx, &dto.ExecSession{ExecId: "exec-id"}, 40, 120)
+	s.ErrorContains(err, "docker error")
+}
+
+func (s *ExecServiceSuite) TestFinish() {
+	auditLog := &entities.AuditLog{ID: 1}
+	s.dockerClient.EXPECT().ExecInspect(s.ctx, "exec-id").Return(container.ExecInspect{ExitCode: 2}, nil)
+	s.auditService.EXPECT().Finish(s.ctx, auditLog, gomock.Any()).Return(nil)
+	s.logger.EXPECT().Info("exec session finished", gomock.Any()).Times(1)
+
+	exitCode, err := s.execService.Finish(s.ctx, &dto.ExecSession{ExecId: "exec-id", Stream: s.newStream(), AuditLog: auditLog})
+	s.NoError(err)
+	s.Equal(2, *exitCode)
+}
+
+func (s *ExecServiceSuite) TestFinishStillRunning() {
+	auditLog := &entities.AuditLog{ID: 1}
+	s.dockerClient.EXPECT().ExecInspect(s.ctx, "exec-id").Return(container.ExecInspect{Running: true}, nil)
+	s.auditService.EXPECT().Finish(s.ctx, auditLog, nil).Return(nil)
+	s.logger.EXPECT().Info("exec session finished", gomock.Any()).Times(1)
+
+	exitCode, err := s.execService.Finish(s.ctx, &dto.ExecSession{ExecId: "exec-id", Stream: s.newStream(), AuditLog: auditLog})
+	s.NoError(err)
+	s.Nil(exitCode)
+}
+
+func (s *ExecServiceSuite) TestFinishInspectError() {
+	auditLog := &entities.AuditLog{ID: 1}
+	s.dockerClient.EXPECT().ExecInspect(s.ctx, "exec-id").Return(container.ExecInspect{}, errors.New("docker error"))
+	s.logger.EXPECT().Error("failed to inspect exec", gomock.Any()).Times(1)
+	s.auditService.EXPECT().Finish(s.ctx, auditLog, nil).Return(errors.New("db error"))
+
+	exitCode, err := s.execService.Finish(s.ctx, &dto.ExecSession{ExecId: "exec-id", Stream: s.newStream(), AuditLog: auditLog})
+	s.ErrorContains(err, "db error")
+	s.Nil(exitCode)
+}
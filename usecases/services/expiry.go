@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/pkg/env"
+	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
+	"github.com/vnFuhung2903/vcs-sms/usecases/repositories"
+	"go.uber.org/zap"
+)
+
+type IExpiryService interface {
+	Extend(ctx context.Context, containerId string, expiresAt time.Time) error
+	Reap(ctx context.Context) (int, error)
+}
+
+type ExpiryService struct {
+	containerRepo    repositories.IContainerRepository
+	userRepo         repositories.IUserRepository
+	containerService IContainerService
+	reportService    IReportService
+	notice           time.Duration
+	logger           logger.ILogger
+}
+
+func NewExpiryService(containerRepo repositories.IContainerRepository, userRepo repositories.IUserRepository, containerService IContainerService, reportService IReportService, logger logger.ILogger, env env.ExpiryEnv) IExpiryService {
+	return &ExpiryService{
+		containerRepo:    containerRepo,
+		userRepo:         userRepo,
+		containerService: containerService,
+		reportService:    reportService,
+		notice:           env.Notice,
+		logger:           logger,
+	}
+}
+
+// Extend moves the expiry of the container to expiresAt, its owner being notified again before then.
+func (s *ExpiryService) Extend(ctx context.Context, containerId string, expiresAt time.Time) error {
+	if _, err := s.containerService.FindById(ctx, containerId); err != nil {
+		return err
+	}
+
+	if err := s.containerRepo.UpdateExpiry(containerId, &expiresAt); err != nil {
+		s.logger.Error("failed to update container expiry", zap.Error(err))
+		return err
+	}
+	s.logger.Info("container expiry extended successfully", zap.String("containerId", containerId), zap.Time("expiresAt", expiresAt))
+	return nil
+}
+
+// Reap notifies the owners of the containers expiring within the notice period, then stops and deletes the expired
+// ones, returning how many were deleted. Owners are notified once per expiry, containers that expire before a notice
+// went out being included in it as well.
+func (s *ExpiryService) Reap(ctx context.Context) (int, error) {
+	now := time.Now()
+	containers, err := s.containerRepo.ViewExpiring(now.Add(s.notice))
+	if err != nil {
+		s.logger.Error("failed to view expiring containers", zap.Error(err))
+		return 0, err
+	}
+
+	pending := make(map[string][]*entities.Container)
+	for _, container := range containers {
+		if container.ExpiryNotifiedAt == nil && container.OwnerId != "" {
+			pending[container.OwnerId] = append(pending[container.OwnerId], container)
+		}
+	}
+	for ownerId, owned := range pending {
+		s.notify(ctx, ownerId, owned, now)
+	}
+
+	reaped := 0
+	for _, container := range containers {
+		if container.ExpiresAt.After(now) {
+			continue
+		}
+		if err := s.containerService.Delete(ctx, container.ContainerId); err != nil {
+			s.logger.Error("failed to delete expired container", zap.String("containerId", container.ContainerId), zap.Error(err))
+			continue
+		}
+		reaped++
+	}
+	s.logger.Info("expired containers reaped successfully", zap.Int("count", reaped))
+	return reaped, nil
+}
+
+// notify sends the owner a notice of their expiring containers, which are not notified again unless it fails.
+func (s *ExpiryService) notify(ctx context.Context, ownerId string, containers []*entities.Container, now time.Time) {
+	owner, err := s.userRepo.FindById(ownerId)
+	if err != nil {
+		s.logger.Error("failed to find container owner", zap.String("ownerId", ownerId), zap.Error(err))
+		return
+	}
+	if err := s.reportService.SendExpiryNotice(ctx, owner.Email, containers); err != nil {
+		s.logger.Error("failed to send expiry notice", zap.String("ownerId", ownerId), zap.Error(err))
+		return
+	}
+
+	containerIds := make([]string, 0, len(containers))
+	for _, container := range containers {
+		containerIds = append(containerIds, container.ContainerId)
+	}
+	if err := s.containerRepo.MarkExpiryNotified(containerIds, now); err != nil {
+		s.logger.Error("failed to mark expiry notified", zap.String("ownerId", ownerId), zap.Error(err))
+	}
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
+	"github.com/vnFuhung2903/vcs-sms/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+	"github.com/vnFuhung2903/vcs-sms/pkg/env"
+)
+
+type ExpiryServiceSuite struct {
+	suite.Suite
+	ctrl             *gomock.Controller
+	expiryService    IExpiryService
+	mockRepo         *repositories.MockIContainerRepository
+	mockUserRepo     *repositories.MockIUserRepository
+	containerService *services.MockIContainerService
+	reportService    *services.MockIReportService
+	logger           *logger.MockILogger
+	ctx              context.Context
+}
+
+func (s *ExpiryServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = repositories.NewMockIContainerRepository(s.ctrl)
+	s.mockUserRepo = repositories.NewMockIUserRepository(s.ctrl)
+	s.containerService = services.NewMockIContainerService(s.ctrl)
+	s.reportService = services.NewMockIReportService(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.expiryService = NewExpiryService(s.mockRepo, s.mockUserRepo, s.containerService, s.reportService, s.logger, env.ExpiryEnv{Notice: time.Hour})
+	s.ctx = context.Background()
+}
+
+func (s *ExpiryServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestExpiryServiceSuite(t *testing.T) {
+	suite.Run(t, new(ExpiryServiceSuite))
+}
+
+func (s *ExpiryServiceSuite) TestExtend() {
+	expiresAt := time.Now().Add(24 * time.Hour)
+	s.containerService.EXPECT().FindById(s.ctx, "test-id").Return(&entities.Container{ContainerId: "test-id"}, nil)
+	s.mockRepo.EXPECT().UpdateExpiry("test-id", &expiresAt).Return(nil)
+	s.logger.EXPECT().Info("container expiry extended successfully", gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.expiryService.Extend(s.ctx, "test-id", expiresAt)
+	s.NoError(err)
+}
+
+func (s *ExpiryServiceSuite) TestExtendNotFound() {
+	s.containerService.EXPECT().FindById(s.ctx, "test-id").Return(nil, fmt.Errorf("%w: test-id", ErrContainerNotFound))
+
+	err := s.expiryService.Extend(s.ctx, "test-id", time.Now().Add(time.Hour))
+	s.ErrorIs(err, ErrContainerNotFound)
+}
+
+func (s *ExpiryServiceSuite) TestExtendRepoError() {
+	s.containerService.EXPECT().FindById(s.ctx, "test-id").Return(&entities.Container{ContainerId: "test-id"}, nil)
+	s.mockRepo.EXPECT().UpdateExpiry("test-id", gomock.Any()).Return(errors.New("db error"))
+	s.logger.EXPECT().Error("failed to update container expiry", gomock.Any()).Times(1)
+
+	err := s.expiryService.Extend(s.ctx, "test-id", time.Now().Add(time.Hour))
+	s.ErrorContains(err, "db error")
+}
+
+func (s *ExpiryServiceSuite) TestReap() {
+	past, soon := time.Now().Add(-time.Minute), time.Now().Add(30*time.Minute)
+	notified := time.Now().Add(-time.Hour)
+	expired := &entities.Container{ContainerId: "expired-id", OwnerId: "user-id", ExpiresAt: &past, ExpiryNotifiedAt: &notified}
+	expiring := &entities.Container{ContainerId: "expiring-id", OwnerId: "user-id", ExpiresAt: &soon}
+	unowned := &entities.Container{ContainerId: "unowned-id", ExpiresAt: &soon}
+	s.mockRepo.EXPECT().ViewExpiring(gomock.Any()).Return([]*entities.Container{expired, expiring, unowned}, nil)
+	s.mockUserRepo.EXPECT().FindById("user-id").Return(&entities.User{ID: "user-id", Email: "user@example.com"}, nil)
+	s.reportService.EXPECT().SendExpiryNotice(s.ctx, "user@example.com", []*entities.Container{expiring}).Return(nil)
+	s.mockRepo.EXPECT().MarkExpiryNotified([]string{"expiring-id"}, gomock.Any()).Return(nil)
+	s.containerService.EXPECT().Delete(s.ctx, "expired-id").Return(nil)
+	s.logger.EXPECT().Info("expired containers reaped successfully", gomock.Any()).Times(1)
+
+	reaped, err := s.expiryService.Reap(s.ctx)
+	s.NoError(err)
+	s.Equal(1, reaped)
+}
+
+func (s *ExpiryServiceSuite) TestReapNotifiesBeforeDeleting() {
+	past := time.Now().Add(-time.Minute)
+	expired := &entities.Container{ContainerId: "expired-id", OwnerId: "user-id", ExpiresAt: &past}
+	s.mockRepo.EXPECT().ViewExpiring(gomock.Any()).Return([]*entities.Container{expired}, nil)
+	gomock.InOrder(
+		s.mockUserRepo.EXPECT().FindById("user-id").Return(&entities.User{ID: "user-id", Email: "user@example.com"}, nil),
+		s.reportService.EXPECT().SendExpiryNotice(s.ctx, "user@example.com", []*entities.Container{expired}).Return(nil),
+		s.mockRepo.EXPECT().MarkExpiryNotified([]string{"expired-id"}, gomock.Any()).Return(nil),
+		s.containerService.EXPECT().Delete(s.ctx, "expired-id").Return(nil),
+	)
+	s.logger.EXPECT().Info("expired containers reaped successfully", gomock.Any()).Times(1)
+
+	reaped, err := s.expiryService.Reap(s.ctx)
+	s.NoError(err)
+	s.Equal(1, reaped)
+}
+
+func (s *ExpiryServiceSuite) TestReapNoticeError() {
+	soon := time.Now().Add(30 * time.Minute)
+	s.mockRepo.EXPECT().ViewExpiring(gomock.Any()).Return([]*entities.Container{{ContainerId: "test-id", OwnerId: "user-id", ExpiresAt: &soon}}, nil)
+	s.mockUserRepo.EXPECT().FindById("user-id").Return(&entities.User{ID: "user-id", Email: "user@example.com"}, nil)
+	s.reportService.EXPECT().SendExpiryNotice(s.ctx, "user@example.com", gomock.Any()).Return(errors.New("smtp error"))
+	s.logger.EXPECT().Error("failed to send expiry notice", gomock.Any(), gomock.Any()).Times(1)
+	s.logger.EXPECT().Info("expired containers reaped successfully", gomock.Any()).Times(1)
+
+	reaped, err := s.expiryService.Reap(s.ctx)
+	s.NoError(err)
+	s.Zero(reaped)
+}
+
+func (s *ExpiryServiceSuite) TestReapOwnerError() {
+	soon := time.Now().Add(30 * time.Minute)
+	s.mockRepo.EXPECT().ViewExpiring(gomock.Any()).Return([]*entities.Container{{ContainerId: "test-id", OwnerId: "user-id", ExpiresAt: &soon}}, nil)
+	s.mockUserRepo.EXPECT().FindById("user-id").Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find container owner", gomock.Any(), gomock.Any()).Times(1)
+	s.logger.EXPECT().Info("expired containers reaped successfully", gomock.Any()).Times(1)
+
+	reaped, err := s.expiryService.Reap(s.ctx)
+	s.NoError(err)
+	s.Zero(reaped)
+}
+
+func (s *ExpiryServiceSuite) TestReapDeleteError() {
+	past := time.Now().Add(-time.Minute)
+	notified := time.Now().Add(-time.Hour)
+	s.mockRepo.EXPECT().ViewExpiring(gomock.Any()).Return([]*entities.Container{
+		{ContainerId: "failing-id", ExpiresAt: &past, ExpiryNotifiedAt: &notified},
+		{ContainerId: "test-id", ExpiresAt: &past, ExpiryNotifiedAt: &notified},
+	}, nil)
+	s.containerService.EXPECT().Delete(s.ctx, "failing-id").Return(errors.New("docker error"))
+	s.containerService.EXPECT().Delete(s.ctx, "test-id").Return(nil)
+	s.logger.EXPECT().Error("failed to delete expired container", gomock.Any(), gomock.Any()).Times(1)
+	s.logger.EXPECT().Info("expired containers reaped successfully", gomock.Any()).Times(1)
+
+	reaped, err := s.expiryService.Reap(s.ctx)
+	s.NoError(err)
+	s.Equal(1, reaped)
+}
+
+func (s *ExpiryServiceSuite) TestReapViewError() {
+	s.mockRepo.EXPECT().ViewExpiring(gomock.Any()).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to view expiring containers", gomock.Any()).Times(1)
+
+	_, err := s.expiryService.Reap(s.ctx)
+	s.ErrorContains(err, "db error")
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/containerd/errdefs"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/pkg/docker"
+	"github.com/vnFuhung2903/vcs-sms/pkg/env"
+	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type IFileService interface {
+	Download(ctx context.Context, userId string, containerId string, query dto.FileQuery) (*dto.FileDownload, error)
+	Finish(ctx context.Context, download *dto.FileDownload) error
+	Upload(ctx context.Context, userId string, containerId string, query dto.FileQuery, content io.Reader, size int64) error
+}
+
+type FileService struct {
+	dockerClient    docker.IDockerClient
+	auditService    IAuditService
+	maxDownloadSize int64
+	maxUploadSize   int64
+	logger          logger.ILogger
+}
+
+func NewFileService(dockerClient docker.IDockerClient, auditService IAuditService, logger logger.ILogger, env env.FilesEnv) IFileService {
+	return &FileService{
+		dockerClient:    dockerClient,
+		auditService:    auditService,
+		maxDownloadSize: env.MaxDownloadSize,
+		maxUploadSize:   env.MaxUploadSize,
+		logger:          logger,
+	}
+}
+
+// transfer counts the bytes read through it and keeps the error that stopped the reading, if any.
+type transfer struct {
+	io.Reader
+	io.Closer
+	bytes int64
+	eof   bool
+	err   error
+}
+
+func (t *transfer) Read(p []byte) (int, error) {
+	n, err := t.Reader.Read(p)
+	t.bytes += int64(n)
+	if err == io.EOF {
+		t.eof = true
+	} else if err != nil {
+		t.err = err
+	}
+	return n, err
+}
+
+// result tells how the transfer ended, reading that stopped short of the end counting as failed.
+func (t *transfer) result() error {
+	if t.err == nil && !t.eof {
+		return fmt.Errorf("transfer interrupted after %d bytes", t.bytes)
+	}
+	return t.err
+}
+
+// Download opens the file at the path in the container, or a tar archive of it when asked for or when it is not a
+// regular file. Archives are cut once they go over the download size limit, files over it are refused up front.
+func (s *FileService) Download(ctx context.Context, userId string, containerId string, query dto.FileQuery) (*dto.FileDownload, error) {
+	archive, stat, err := s.dockerClient.CopyFrom(ctx, containerId, query.Path)
+	if err != nil {
+		return nil, s.copyError(err, query.Path, "failed to copy from container")
+	}
+
+	download := &dto.FileDownload{Name: stat.Name + ".tar", Size: -1, Archive: true}
+	if !query.Archive && stat.Mode.IsRegular() {
+		if stat.Size > s.maxDownloadSize {
+			archive.Close()
+			return nil, fmt.Errorf("%w: %s is %d bytes, at most %d allowed", ErrFileTooLarge, query.Path, stat.Size, s.maxDownloadSize)
+		}
+		_, content, err := docker.ExtractFile(archive)
+		if err != nil {
+			archive.Close()
+			s.logger.Error("failed to extract file", zap.Error(err))
+			return nil, err
+		}
+		download = &dto.FileDownload{Name: stat.Name, Size: stat.Size, Content: &transfer{Reader: content, Closer: archive}}
+	} else {
+		download.Content = &transfer{Reader: docker.LimitReader(archive, s.maxDownloadSize), Closer: archive}
+	}
+
+	auditLog, err := s.auditService.Start(ctx, entities.AuditFileDownload, userId, containerId, path.Clean(query.Path))
+	if err != nil {
+		archive.Close()
+		return nil, err
+	}
+	download.AuditLog = auditLog
+
+	s.logger.Info("file download started", zap.String("containerId", containerId), zap.String("path", query.Path), zap.String("userId", userId))
+	return download, nil
+}
+
+// Finish closes the download and records its end, with how much of it was sent and whether it went through.
+func (s *FileService) Finish(ctx context.Context, download *dto.FileDownload) error {
+	download.Content.Close()
+	var bytes int64
+	var err error
+	if content, ok := download.Content.(*transfer); ok {
+		bytes, err = content.bytes, content.result()
+	}
+	return s.auditService.FinishTransfer(ctx, download.AuditLog, bytes, err)
+}
+
+// Upload writes content as the file at the path in the container, or extracts it into the directory at the path when
+// it is a tar archive. A size below 0 means the size of the file is unknown until read.
+func (s *FileService) Upload(ctx context.Context, userId string, containerId string, query dto.FileQuery, content io.Reader, size int64) error {
+	dstPath, err := docker.CleanPath(query.Path)
+	if err != nil {
+		return err
+	}
+
+	received := &transfer{Reader: content}
+	limited := docker.LimitReader(received, s.maxUploadSize)
+	var archive io.Reader = limited
+	var file io.Reader
+	name, detail := "", dstPath
+	if !query.Archive {
+		if dstPath == "/" {
+			return fmt.Errorf("%w: path %q names no file", errdefs.ErrInvalidArgument, dstPath)
+		}
+		if size > s.maxUploadSize {
+			return fmt.Errorf("%w: %d bytes, at most %d allowed", ErrFileTooLarge, size, s.maxUploadSize)
+		}
+		file = received
+		if size < 0 {
+			data, err := io.ReadAll(limited)
+			if err != nil {
+				return s.copyError(err, dstPath, "failed to read uploaded file")
+			}
+			file, size = bytes.NewReader(data), int64(len(data))
+		}
+		name, detail = path.Base(dstPath), fmt.Sprintf("%s (%d bytes)", dstPath, size)
+		dstPath = path.Dir(dstPath)
+	}
+
+	auditLog, err := s.auditService.Start(ctx, entities.AuditFileUpload, userId, containerId, detail)
+	if err != nil {
+		return err
+	}
+	var pipe *io.PipeReader
+	if file != nil {
+		pipe = docker.FileArchive(name, file, size)
+		archive = pipe
+	}
+	err = s.dockerClient.CopyTo(ctx, containerId, dstPath, archive)
+	if pipe != nil {
+		// CopyTo may return before reading the whole archive, closing it stops the writer instead of leaving it blocked.
+		pipe.CloseWithError(io.ErrClosedPipe)
+	}
+	if limited.Exceeded() {
+		err = fmt.Errorf("%w: more than %d bytes", ErrFileTooLarge, s.maxUploadSize)
+	} else if err != nil {
+		err = s.copyError(err, query.Path, "failed to copy to container")
+	}
+	s.auditService.FinishTransfer(ctx, auditLog, received.bytes, err)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("file uploaded successfully", zap.String("containerId", containerId), zap.String("path", query.Path), zap.String("userId", userId))
+	return nil
+}
+
+// copyError tells the failures of a copy the caller can act on from the others, which are logged.
+func (s *FileService) copyError(err error, filePath string, message string) error {
+	if errdefs.IsNotFound(err) {
+		return fmt.Errorf("%w: %s: %v", ErrFileNotFound, filePath, err)
+	}
+	if errdefs.IsResourceExhausted(err) {
+		return fmt.Errorf("%w: %v", ErrFileTooLarge, err)
+	}
+	if errdefs.IsInvalidArgument(err) {
+		return err
+	}
+	s.logger.Error(message, zap.Error(err))
+	return err
+}
@@ -0,0 +1,335 @@
+package services
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/docker"
+	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+	"github.com/vnFuhung2903/vcs-sms/pkg/env"
+)
+
+type FileServiceSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	fileService  IFileService
+	dockerClient *docker.MockIDockerClient
+	auditService *services.MockIAuditService
+	logger       *logger.MockILogger
+	ctx          context.Context
+}
+
+func (s *FileServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.dockerClient = docker.NewMockIDockerClient(s.ctrl)
+	s.auditService = services.NewMockIAuditService(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.fileService = NewFileService(s.dockerClient, s.auditService, s.logger, env.FilesEnv{
+		MaxDownloadSize: 4096,
+		MaxUploadSize:   4096,
+	})
+	s.ctx = context.Background()
+}
+
+func (s *FileServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestFileServiceSuite(t *testing.T) {
+	suite.Run(t, new(FileServiceSuite))
+}
+
+// tarFile archives a single regular file the way docker does when copying one out of a container.
+func (s *FileServiceSuite) tarFile(name string, content string) io.ReadCloser {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	s.Require().NoError(tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0644, Size: int64(len(content))}))
+	_, err := tw.Write([]byte(content))
+	s.Require().NoError(err)
+	s.Require().NoError(tw.Close())
+	return io.NopCloser(&buf)
+}
+
+// untarFile reads back the single regular file of an uploaded archive.
+func (s *FileServiceSuite) untarFile(archive io.Reader) (string, string) {
+	tr := tar.NewReader(archive)
+	header, err := tr.Next()
+	s.Require().NoError(err)
+	content, err := io.ReadAll(tr)
+	s.Require().NoError(err)
+	_, err = tr.Next()
+	s.Equal(io.EOF, err)
+	return header.Name, string(content)
+}
+
+func (s *FileServiceSuite) TestDownloadFile() {
+	auditLog := &entities.AuditLog{ID: 1}
+	s.dockerClient.EXPECT().CopyFrom(s.ctx, "container-id", "/var/log/app.log").
+		Return(s.tarFile("app.log", "hello"), container.PathStat{Name: "app.log", Size: 5}, nil)
+	s.auditService.EXPECT().Start(s.ctx, entities.AuditFileDownload, "user-id", "container-id", "/var/log/app.log").Return(auditLog, nil)
+	s.logger.EXPECT().Info("file download started", gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	download, err := s.fileService.Download(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "/var/log/app.log"})
+	s.NoError(err)
+	s.Equal("app.log", download.Name)
+	s.Equal(int64(5), download.Size)
+	s.False(download.Archive)
+	s.Equal(auditLog, download.AuditLog)
+
+	content, err := io.ReadAll(download.Content)
+	s.NoError(err)
+	s.Equal("hello", string(content))
+
+	s.auditService.EXPECT().FinishTransfer(s.ctx, auditLog, int64(5), nil).Return(nil)
+	s.NoError(s.fileService.Finish(s.ctx, download))
+}
+
+func (s *FileServiceSuite) TestDownloadArchive() {
+	s.dockerClient.EXPECT().CopyFrom(s.ctx, "container-id", "/etc/app/").
+		Return(s.tarFile("app/config.yml", "a: 1"), container.PathStat{Name: "app", Mode: os.ModeDir | 0755}, nil)
+	s.auditService.EXPECT().Start(s.ctx, entities.AuditFileDownload, "user-id", "container-id", "/etc/app").Return(&entities.AuditLog{ID: 1}, nil)
+	s.logger.EXPECT().Info("file download started", gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	download, err := s.fileService.Download(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "/etc/app/"})
+	s.NoError(err)
+	s.Equal("app.tar", download.Name)
+	s.Equal(int64(-1), download.Size)
+	s.True(download.Archive)
+
+	name, content := s.untarFile(download.Content)
+	s.Equal("app/config.yml", name)
+	s.Equal("a: 1", content)
+}
+
+func (s *FileServiceSuite) TestDownloadArchiveOfFile() {
+	s.dockerClient.EXPECT().CopyFrom(s.ctx, "container-id", "/var/log/app.log").
+		Return(s.tarFile("app.log", "hello"), container.PathStat{Name: "app.log", Size: 5}, nil)
+	s.auditService.EXPECT().Start(s.ctx, entities.AuditFileDownload, "user-id", "container-id", "/var/log/app.log").Return(&entities.AuditLog{ID: 1}, nil)
+	s.logger.EXPECT().Info("file download started", gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	download, err := s.fileService.Download(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "/var/log/app.log", Archive: true})
+	s.NoError(err)
+	s.Equal("app.log.tar", download.Name)
+	s.True(download.Archive)
+}
+
+func (s *FileServiceSuite) TestDownloadArchiveOverLimit() {
+	s.dockerClient.EXPECT().CopyFrom(s.ctx, "container-id", "/etc").
+		Return(s.tarFile("etc/hosts", strings.Repeat("a", 4096)), container.PathStat{Name: "etc", Mode: os.ModeDir | 0755}, nil)
+	s.auditService.EXPECT().Start(s.ctx, entities.AuditFileDownload, "user-id", "container-id", "/etc").Return(&entities.AuditLog{ID: 1}, nil)
+	s.logger.EXPECT().Info("file download started", gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	download, err := s.fileService.Download(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "/etc"})
+	s.NoError(err)
+
+	_, err = io.ReadAll(download.Content)
+	s.True(errdefs.IsResourceExhausted(err))
+
+	s.auditService.EXPECT().FinishTransfer(s.ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *entities.AuditLog, bytes int64, err error) error {
+			s.Equal(int64(4096), bytes)
+			s.True(errdefs.IsResourceExhausted(err))
+			return nil
+		})
+	s.NoError(s.fileService.Finish(s.ctx, download))
+}
+
+func (s *FileServiceSuite) TestDownloadFileTooLarge() {
+	s.dockerClient.EXPECT().CopyFrom(s.ctx, "container-id", "/var/log/app.log").
+		Return(s.tarFile("app.log", "hello"), container.PathStat{Name: "app.log", Size: 1 << 20}, nil)
+
+	_, err := s.fileService.Download(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "/var/log/app.log"})
+	s.ErrorIs(err, ErrFileTooLarge)
+}
+
+func (s *FileServiceSuite) TestDownloadNotFound() {
+	s.dockerClient.EXPECT().CopyFrom(s.ctx, "container-id", "/missing").
+		Return(nil, container.PathStat{}, errdefs.ErrNotFound)
+
+	_, err := s.fileService.Download(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "/missing"})
+	s.ErrorIs(err, ErrFileNotFound)
+}
+
+func (s *FileServiceSuite) TestDownloadInvalidPath() {
+	s.dockerClient.EXPECT().CopyFrom(s.ctx, "container-id", "etc/hosts").
+		Return(nil, container.PathStat{}, errdefs.ErrInvalidArgument)
+
+	_, err := s.fileService.Download(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "etc/hosts"})
+	s.True(errdefs.IsInvalidArgument(err))
+}
+
+func (s *FileServiceSuite) TestDownloadCopyError() {
+	s.dockerClient.EXPECT().CopyFrom(s.ctx, "container-id", "/etc/hosts").
+		Return(nil, container.PathStat{}, errors.New("docker error"))
+	s.logger.EXPECT().Error("failed to copy from container", gomock.Any()).Times(1)
+
+	_, err := s.fileService.Download(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "/etc/hosts"})
+	s.ErrorContains(err, "docker error")
+}
+
+func (s *FileServiceSuite) TestDownloadAuditError() {
+	s.dockerClient.EXPECT().CopyFrom(s.ctx, "container-id", "/etc/hosts").
+		Return(s.tarFile("hosts", "hello"), container.PathStat{Name: "hosts", Size: 5}, nil)
+	s.auditService.EXPECT().Start(s.ctx, entities.AuditFileDownload, "user-id", "container-id", "/etc/hosts").Return(nil, errors.New("db error"))
+
+	_, err := s.fileService.Download(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "/etc/hosts"})
+	s.ErrorContains(err, "db error")
+}
+
+func (s *FileServiceSuite) TestFinishInterrupted() {
+	auditLog := &entities.AuditLog{ID: 1}
+	s.dockerClient.EXPECT().CopyFrom(s.ctx, "container-id", "/var/log/app.log").
+		Return(s.tarFile("app.log", "hello"), container.PathStat{Name: "app.log", Size: 5}, nil)
+	s.auditService.EXPECT().Start(s.ctx, entities.AuditFileDownload, "user-id", "container-id", "/var/log/app.log").Return(auditLog, nil)
+	s.logger.EXPECT().Info("file download started", gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	download, err := s.fileService.Download(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "/var/log/app.log"})
+	s.NoError(err)
+	_, err = download.Content.Read(make([]byte, 2))
+	s.NoError(err)
+
+	s.auditService.EXPECT().FinishTransfer(s.ctx, auditLog, int64(2), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *entities.AuditLog, _ int64, err error) error {
+			s.EqualError(err, "transfer interrupted after 2 bytes")
+			return nil
+		})
+	s.NoError(s.fileService.Finish(s.ctx, download))
+}
+
+func (s *FileServiceSuite) TestUploadFile() {
+	auditLog := &entities.AuditLog{ID: 1}
+	s.auditService.EXPECT().Start(s.ctx, entities.AuditFileUpload, "user-id", "container-id", "/etc/app.yml (4 bytes)").Return(auditLog, nil)
+	s.dockerClient.EXPECT().CopyTo(s.ctx, "container-id", "/etc", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ string, archive io.Reader) error {
+			name, content := s.untarFile(archive)
+			s.Equal("app.yml", name)
+			s.Equal("a: 1", content)
+			return nil
+		})
+	s.auditService.EXPECT().FinishTransfer(s.ctx, auditLog, int64(4), nil).Return(nil)
+	s.logger.EXPECT().Info("file uploaded successfully", gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.fileService.Upload(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "/etc/./app.yml"}, strings.NewReader("a: 1"), 4)
+	s.NoError(err)
+}
+
+func (s *FileServiceSuite) TestUploadFileUnknownSize() {
+	s.auditService.EXPECT().Start(s.ctx, entities.AuditFileUpload, "user-id", "container-id", "/etc/app.yml (4 bytes)").Return(&entities.AuditLog{ID: 1}, nil)
+	s.dockerClient.EXPECT().CopyTo(s.ctx, "container-id", "/etc", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ string, archive io.Reader) error {
+			_, content := s.untarFile(archive)
+			s.Equal("a: 1", content)
+			return nil
+		})
+	s.auditService.EXPECT().FinishTransfer(s.ctx, gomock.Any(), int64(4), nil).Return(nil)
+	s.logger.EXPECT().Info("file uploaded successfully", gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.fileService.Upload(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "/etc/app.yml"}, strings.NewReader("a: 1"), -1)
+	s.NoError(err)
+}
+
+func (s *FileServiceSuite) TestUploadFileTooLarge() {
+	err := s.fileService.Upload(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "/etc/app.yml"}, strings.NewReader(""), 1<<20)
+	s.ErrorIs(err, ErrFileTooLarge)
+}
+
+func (s *FileServiceSuite) TestUploadFileUnknownSizeTooLarge() {
+	err := s.fileService.Upload(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "/etc/app.yml"}, strings.NewReader(strings.Repeat("a", 4097)), -1)
+	s.ErrorIs(err, ErrFileTooLarge)
+}
+
+func (s *FileServiceSuite) TestUploadInvalidPath() {
+	err := s.fileService.Upload(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "etc/app.yml"}, strings.NewReader("a: 1"), 4)
+	s.True(errdefs.IsInvalidArgument(err))
+
+	err = s.fileService.Upload(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "/"}, strings.NewReader("a: 1"), 4)
+	s.True(errdefs.IsInvalidArgument(err))
+}
+
+func (s *FileServiceSuite) TestUploadArchive() {
+	archive := s.tarFile("app.yml", "a: 1")
+	s.auditService.EXPECT().Start(s.ctx, entities.AuditFileUpload, "user-id", "container-id", "/etc").Return(&entities.AuditLog{ID: 1}, nil)
+	s.dockerClient.EXPECT().CopyTo(s.ctx, "container-id", "/etc", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ string, archive io.Reader) error {
+			name, _ := s.untarFile(archive)
+			s.Equal("app.yml", name)
+			return nil
+		})
+	s.auditService.EXPECT().FinishTransfer(s.ctx, gomock.Any(), gomock.Any(), nil).Return(nil)
+	s.logger.EXPECT().Info("file uploaded successfully", gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.fileService.Upload(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "/etc/", Archive: true}, archive, -1)
+	s.NoError(err)
+}
+
+func (s *FileServiceSuite) TestUploadArchiveOverLimit() {
+	s.auditService.EXPECT().Start(s.ctx, entities.AuditFileUpload, "user-id", "container-id", "/etc").Return(&entities.AuditLog{ID: 1}, nil)
+	s.dockerClient.EXPECT().CopyTo(s.ctx, "container-id", "/etc", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ string, archive io.Reader) error {
+			_, err := io.ReadAll(archive)
+			return errors.New("request body: " + err.Error())
+		})
+	s.auditService.EXPECT().FinishTransfer(s.ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *entities.AuditLog, _ int64, err error) error {
+			s.ErrorIs(err, ErrFileTooLarge)
+			return nil
+		})
+
+	err := s.fileService.Upload(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "/etc", Archive: true}, s.tarFile("app.yml", strings.Repeat("a", 4096)), -1)
+	s.ErrorIs(err, ErrFileTooLarge)
+}
+
+func (s *FileServiceSuite) TestUploadDirectoryNotFound() {
+	s.auditService.EXPECT().Start(s.ctx, entities.AuditFileUpload, "user-id", "container-id", gomock.Any()).Return(&entities.AuditLog{ID: 1}, nil)
+	s.dockerClient.EXPECT().CopyTo(s.ctx, "container-id", "/missing", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ string, archive io.Reader) error {
+			io.Copy(io.Discard, archive)
+			return errdefs.ErrNotFound
+		})
+	s.auditService.EXPECT().FinishTransfer(s.ctx, gomock.Any(), int64(4), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *entities.AuditLog, _ int64, err error) error {
+			s.ErrorIs(err, ErrFileNotFound)
+			return nil
+		})
+
+	err := s.fileService.Upload(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "/missing/app.yml"}, strings.NewReader("a: 1"), 4)
+	s.ErrorIs(err, ErrFileNotFound)
+}
+
+func (s *FileServiceSuite) TestUploadCopyReturnsEarly() {
+	var archive io.Reader
+	s.auditService.EXPECT().Start(s.ctx, entities.AuditFileUpload, "user-id", "container-id", gomock.Any()).Return(&entities.AuditLog{ID: 1}, nil)
+	s.dockerClient.EXPECT().CopyTo(s.ctx, "container-id", "/missing", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ string, a io.Reader) error {
+			archive = a
+			return errdefs.ErrNotFound
+		})
+	s.auditService.EXPECT().FinishTransfer(s.ctx, gomock.Any(), int64(0), gomock.Any()).Return(nil)
+
+	err := s.fileService.Upload(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "/missing/app.yml"}, strings.NewReader("a: 1"), 4)
+	s.ErrorIs(err, ErrFileNotFound)
+
+	_, err = archive.Read(make([]byte, 1))
+	s.ErrorIs(err, io.ErrClosedPipe)
+}
+
+func (s *FileServiceSuite) TestUploadAuditError() {
+	s.auditService.EXPECT().Start(s.ctx, entities.AuditFileUpload, "user-id", "container-id", gomock.Any()).Return(nil, errors.New("db error"))
+
+	err := s.fileService.Upload(s.ctx, "user-id", "container-id", dto.FileQuery{Path: "/etc/app.yml"}, strings.NewReader("a: 1"), 4)
+	s.ErrorContains(err, "db error")
+}
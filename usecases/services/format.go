@@ -0,0 +1,422 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/xuri/excelize/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Spreadsheet and CSV columns of dto.ContainerRecord, JSON and YAML use its field names instead.
+const (
+	idColumn        = "Container ID"
+	nameColumn      = "Container Name"
+	imageColumn     = "Image Name"
+	statusColumn    = "Status"
+	ipv4Column      = "IPv4"
+	portsColumn     = "Ports"
+	envColumn       = "Env"
+	labelsColumn    = "Labels"
+	createdAtColumn = "Created At"
+	expiresAtColumn = "Expires At"
+	// ttlColumn is only read on import, exports giving the expiry as a time.
+	ttlColumn = "TTL"
+	// descriptionColumn is only written on export, imported containers starting without one.
+	descriptionColumn = "Description"
+	// memoryColumn is the memory limit in bytes, empty for none.
+	memoryColumn = "Memory"
+)
+
+var recordColumns = []string{idColumn, nameColumn, imageColumn, statusColumn, ipv4Column, portsColumn, envColumn, labelsColumn, createdAtColumn, expiresAtColumn, descriptionColumn, memoryColumn}
+
+// importRecord is one container read from an import file, its list fields split but not parsed yet.
+// Row is the spreadsheet or CSV row, or the position of the record in JSON and YAML files.
+type importRecord struct {
+	Row           int
+	ContainerName string
+	ImageName     string
+	Ports         []string
+	Env           []string
+	Labels        []string
+	ExpiresAt     string
+	TTL           string
+	Memory        string
+}
+
+// decodeImport reads the records of an import file, XLSX being the default format.
+func decodeImport(file io.Reader, format dto.FileFormat) ([]importRecord, error) {
+	switch format {
+	case dto.FormatCSV:
+		reader := csv.NewReader(file)
+		reader.FieldsPerRecord = -1
+		var rows [][]string
+		for {
+			cells, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			// The reader drops empty lines, pad them back so rows keep their line number.
+			line, _ := reader.FieldPos(0)
+			for len(rows) < line-1 {
+				rows = append(rows, nil)
+			}
+			rows = append(rows, cells)
+		}
+		return decodeTable(rows)
+	case dto.FormatJSON:
+		var records []dto.ContainerRecord
+		if err := json.NewDecoder(file).Decode(&records); err != nil {
+			return nil, err
+		}
+		return fromContainerRecords(records), nil
+	case dto.FormatNDJSON:
+		var records []dto.ContainerRecord
+		decoder := json.NewDecoder(file)
+		for {
+			var record dto.ContainerRecord
+			if err := decoder.Decode(&record); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+			records = append(records, record)
+		}
+		return fromContainerRecords(records), nil
+	case dto.FormatYAML:
+		var records []dto.ContainerRecord
+		if err := yaml.NewDecoder(file).Decode(&records); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		return fromContainerRecords(records), nil
+	default:
+		f, err := excelize.OpenReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		rows, err := f.GetRows(f.GetSheetName(0))
+		if err != nil {
+			return nil, err
+		}
+		return decodeTable(rows)
+	}
+}
+
+// decodeTable finds the columns by their header, ignoring unknown ones, and skips blank rows.
+func decodeTable(rows [][]string) ([]importRecord, error) {
+	columns := make(map[string]int)
+	if len(rows) > 0 {
+		for i, header := range rows[0] {
+			columns[strings.TrimSpace(header)] = i
+		}
+	}
+	if _, ok := columns[nameColumn]; !ok {
+		return nil, errors.New("invalid header row")
+	}
+	if _, ok := columns[imageColumn]; !ok {
+		return nil, errors.New("invalid header row")
+	}
+
+	records := make([]importRecord, 0, len(rows))
+	for i, cells := range rows[1:] {
+		cell := func(column string) string {
+			j, ok := columns[column]
+			if !ok || j >= len(cells) {
+				return ""
+			}
+			return strings.TrimSpace(cells[j])
+		}
+		if isBlankRow(cells) {
+			continue
+		}
+		records = append(records, importRecord{
+			Row:           i + 2,
+			ContainerName: cell(nameColumn),
+			ImageName:     cell(imageColumn),
+			Ports:         splitEntries(cell(portsColumn)),
+			Env:           splitEntries(cell(envColumn)),
+			Labels:        splitEntries(cell(labelsColumn)),
+			ExpiresAt:     cell(expiresAtColumn),
+			TTL:           cell(ttlColumn),
+			Memory:        cell(memoryColumn),
+		})
+	}
+	return records, nil
+}
+
+func fromContainerRecords(records []dto.ContainerRecord) []importRecord {
+	result := make([]importRecord, 0, len(records))
+	for i, record := range records {
+		var memory string
+		if record.Memory != 0 {
+			memory = strconv.FormatInt(record.Memory, 10)
+		}
+		result = append(result, importRecord{
+			Row:           i + 1,
+			ContainerName: strings.TrimSpace(record.ContainerName),
+			ImageName:     strings.TrimSpace(record.ImageName),
+			Ports:         record.Ports,
+			Env:           record.Env,
+			Labels:        labelEntries(record.Labels),
+			ExpiresAt:     strings.TrimSpace(record.ExpiresAt),
+			TTL:           strings.TrimSpace(record.TTL),
+			Memory:        memory,
+		})
+	}
+	return result
+}
+
+// recordWriter encodes exported records one at a time, Flush writing whatever the format still buffers.
+// Writers holding temporary resources also implement io.Closer.
+type recordWriter interface {
+	Write(record dto.ContainerRecord) error
+	Flush() error
+}
+
+// newRecordWriter returns the encoder of the given format, XLSX being the default.
+func newRecordWriter(w io.Writer, format dto.FileFormat) (recordWriter, error) {
+	switch format {
+	case dto.FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(recordColumns); err != nil {
+			return nil, err
+		}
+		return &csvRecordWriter{writer}, nil
+	case dto.FormatJSON:
+		return &jsonRecordWriter{w: w, encoder: json.NewEncoder(w)}, nil
+	case dto.FormatNDJSON:
+		return &ndjsonRecordWriter{json.NewEncoder(w)}, nil
+	case dto.FormatYAML:
+		return &yamlRecordWriter{w: w}, nil
+	default:
+		f := excelize.NewFile()
+		sheetName := time.Now().Format(time.DateOnly)
+		f.SetSheetName("Sheet1", sheetName)
+		stream, err := f.NewStreamWriter(sheetName)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		writer := &xlsxRecordWriter{w: w, file: f, stream: stream}
+		if err := writer.writeRow(recordColumns); err != nil {
+			f.Close()
+			return nil, err
+		}
+		return writer, nil
+	}
+}
+
+type csvRecordWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvRecordWriter) Write(record dto.ContainerRecord) error {
+	return w.writer.Write(tableRow(record))
+}
+
+func (w *csvRecordWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// jsonRecordWriter writes a JSON array without holding its elements.
+type jsonRecordWriter struct {
+	w       io.Writer
+	encoder *json.Encoder
+	count   int
+}
+
+func (w *jsonRecordWriter) Write(record dto.ContainerRecord) error {
+	separator := ","
+	if w.count == 0 {
+		separator = "["
+	}
+	w.count++
+	if _, err := io.WriteString(w.w, separator); err != nil {
+		return err
+	}
+	return w.encoder.Encode(record)
+}
+
+func (w *jsonRecordWriter) Flush() error {
+	closing := "]\n"
+	if w.count == 0 {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(w.w, closing)
+	return err
+}
+
+type ndjsonRecordWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonRecordWriter) Write(record dto.ContainerRecord) error {
+	return w.encoder.Encode(record)
+}
+
+func (w *ndjsonRecordWriter) Flush() error {
+	return nil
+}
+
+// yamlRecordWriter writes a YAML sequence, each record being marshalled as a sequence of one so the items add up.
+type yamlRecordWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (w *yamlRecordWriter) Write(record dto.ContainerRecord) error {
+	w.count++
+	data, err := yaml.Marshal([]dto.ContainerRecord{record})
+	if err != nil {
+		return err
+	}
+	_, err = w.w.Write(data)
+	return err
+}
+
+func (w *yamlRecordWriter) Flush() error {
+	if w.count > 0 {
+		return nil
+	}
+	_, err := io.WriteString(w.w, "[]\n")
+	return err
+}
+
+// xlsxRecordWriter streams the rows to a temporary file, the workbook is only written to w on Flush.
+type xlsxRecordWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func (w *xlsxRecordWriter) Write(record dto.ContainerRecord) error {
+	return w.writeRow(tableRow(record))
+}
+
+func (w *xlsxRecordWriter) writeRow(cells []string) error {
+	w.row++
+	values := make([]any, len(cells))
+	for i, cell := range cells {
+		values[i] = cell
+	}
+	return w.stream.SetRow(fmt.Sprintf("A%d", w.row), values)
+}
+
+func (w *xlsxRecordWriter) Flush() error {
+	if err := w.stream.Flush(); err != nil {
+		return err
+	}
+	return w.file.Write(w.w)
+}
+
+func (w *xlsxRecordWriter) Close() error {
+	return w.file.Close()
+}
+
+func toContainerRecord(container *entities.Container) dto.ContainerRecord {
+	ports := make([]string, 0, len(container.Spec.Ports))
+	for _, port := range container.Spec.Ports {
+		ports = append(ports, formatPort(port))
+	}
+	var expiresAt string
+	if container.ExpiresAt != nil {
+		expiresAt = container.ExpiresAt.Format(time.RFC3339)
+	}
+	return dto.ContainerRecord{
+		ContainerId:   container.ContainerId,
+		ContainerName: container.ContainerName,
+		ImageName:     container.ImageName,
+		Status:        container.Status,
+		Ipv4:          container.Ipv4,
+		Ports:         ports,
+		Env:           container.Spec.Env,
+		Labels:        container.AllLabels(),
+		CreatedAt:     container.CreatedAt.Format(time.RFC3339),
+		ExpiresAt:     expiresAt,
+		Description:   container.Description,
+		Memory:        container.Spec.Resources.Memory,
+	}
+}
+
+// tableRow lays the record out in recordColumns order, joining the list fields with ";".
+func tableRow(record dto.ContainerRecord) []string {
+	var memory string
+	if record.Memory != 0 {
+		memory = strconv.FormatInt(record.Memory, 10)
+	}
+	return []string{
+		record.ContainerId,
+		record.ContainerName,
+		record.ImageName,
+		string(record.Status),
+		record.Ipv4,
+		strings.Join(record.Ports, ";"),
+		strings.Join(record.Env, ";"),
+		strings.Join(labelEntries(record.Labels), ";"),
+		record.CreatedAt,
+		record.ExpiresAt,
+		record.Description,
+		memory,
+	}
+}
+
+// formatPort is the reverse of parsePorts.
+func formatPort(port entities.PortBinding) string {
+	result := strconv.Itoa(port.ContainerPort)
+	if port.HostPort != 0 {
+		result = strconv.Itoa(port.HostPort) + ":" + result
+		if port.HostIp != "" {
+			result = port.HostIp + ":" + result
+		}
+	}
+	if port.Protocol != "" {
+		result += "/" + port.Protocol
+	}
+	return result
+}
+
+// labelEntries returns the labels as "key=value" entries sorted by key.
+func labelEntries(labels map[string]string) []string {
+	entries := make([]string, 0, len(labels))
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		entries = append(entries, key+"="+labels[key])
+	}
+	return entries
+}
+
+func isBlankRow(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func splitEntries(value string) []string {
+	entries := strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == '\n' })
+	result := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
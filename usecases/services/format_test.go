@@ -0,0 +1,191 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/xuri/excelize/v2"
+)
+
+type FormatSuite struct {
+	suite.Suite
+}
+
+func TestFormatSuite(t *testing.T) {
+	suite.Run(t, new(FormatSuite))
+}
+
+// encode runs the records through the streaming writer of the format.
+func encode(w io.Writer, format dto.FileFormat, records []dto.ContainerRecord) error {
+	writer, err := newRecordWriter(w, format)
+	if err != nil {
+		return err
+	}
+	if closer, ok := writer.(io.Closer); ok {
+		defer closer.Close()
+	}
+	for _, record := range records {
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+func (s *FormatSuite) TestRoundTrip() {
+	record := toContainerRecord(&entities.Container{
+		ContainerId:   "abc",
+		ContainerName: "web",
+		ImageName:     "nginx",
+		Status:        entities.ContainerOn,
+		Ipv4:          "172.17.0.2",
+		CreatedAt:     time.Unix(0, 0).UTC(),
+		Spec: entities.ContainerSpec{
+			Ports: []entities.PortBinding{
+				{ContainerPort: 80},
+				{HostIp: "127.0.0.1", HostPort: 8443, ContainerPort: 443, Protocol: "udp"},
+			},
+			Env:       []string{"A=1", "B=2"},
+			Labels:    map[string]string{"tier": "front", "app": "web"},
+			Resources: entities.Resources{Memory: 64 << 20},
+		},
+	})
+	s.Equal([]string{"80", "127.0.0.1:8443:443/udp"}, record.Ports)
+	s.Equal("1970-01-01T00:00:00Z", record.CreatedAt)
+
+	for _, format := range dto.FileFormats {
+		var buf bytes.Buffer
+		s.Require().NoError(encode(&buf, format, []dto.ContainerRecord{record}), format)
+
+		records, err := decodeImport(&buf, format)
+		s.Require().NoError(err, format)
+		s.Require().Len(records, 1, format)
+		s.Equal("web", records[0].ContainerName, format)
+		s.Equal("nginx", records[0].ImageName, format)
+		s.Equal([]string{"80", "127.0.0.1:8443:443/udp"}, records[0].Ports, format)
+		s.Equal([]string{"A=1", "B=2"}, records[0].Env, format)
+		s.Equal([]string{"app=web", "tier=front"}, records[0].Labels, format)
+		s.Equal("67108864", records[0].Memory, format)
+	}
+}
+
+func (s *FormatSuite) TestContainerRecordMetadata() {
+	record := toContainerRecord(&entities.Container{
+		ContainerName: "web",
+		Spec:          entities.ContainerSpec{Labels: map[string]string{"team": "web", "app": "web"}},
+		Labels:        map[string]string{"team": "infra", "env": "prod"},
+		Description:   "web frontend",
+	})
+	s.Equal(map[string]string{"app": "web", "team": "infra", "env": "prod"}, record.Labels)
+	s.Equal("web frontend", record.Description)
+	s.Equal("web frontend", tableRow(record)[slices.Index(recordColumns, descriptionColumn)])
+}
+
+func (s *FormatSuite) TestEncodeCSV() {
+	var buf bytes.Buffer
+	err := encode(&buf, dto.FormatCSV, []dto.ContainerRecord{{ContainerName: "web", ImageName: "nginx", Env: []string{"A=1"}, Memory: 64 << 20}})
+	s.NoError(err)
+	s.Equal("Container ID,Container Name,Image Name,Status,IPv4,Ports,Env,Labels,Created At,Expires At,Description,Memory\n,web,nginx,,,,A=1,,,,,67108864\n", buf.String())
+}
+
+func (s *FormatSuite) TestEncodeEmpty() {
+	var buf bytes.Buffer
+	s.NoError(encode(&buf, dto.FormatJSON, []dto.ContainerRecord{}))
+	s.Equal("[]\n", buf.String())
+
+	buf.Reset()
+	s.NoError(encode(&buf, dto.FormatNDJSON, []dto.ContainerRecord{}))
+	s.Empty(buf.String())
+
+	buf.Reset()
+	s.NoError(encode(&buf, dto.FormatYAML, []dto.ContainerRecord{}))
+	s.Equal("[]\n", buf.String())
+}
+
+func (s *FormatSuite) TestEncodeJSONArray() {
+	var buf bytes.Buffer
+	err := encode(&buf, dto.FormatJSON, []dto.ContainerRecord{{ContainerName: "web"}, {ContainerName: "db"}})
+	s.NoError(err)
+
+	var records []dto.ContainerRecord
+	s.NoError(json.Unmarshal(buf.Bytes(), &records))
+	s.Equal([]dto.ContainerRecord{{ContainerName: "web"}, {ContainerName: "db"}}, records)
+}
+
+func (s *FormatSuite) TestEncodeXLSX() {
+	var buf bytes.Buffer
+	err := encode(&buf, dto.FormatXLSX, []dto.ContainerRecord{{ContainerName: "web", ImageName: "nginx"}})
+	s.Require().NoError(err)
+
+	f, err := excelize.OpenReader(&buf)
+	s.Require().NoError(err)
+	rows, err := f.GetRows(f.GetSheetName(0))
+	s.NoError(err)
+	s.Equal(recordColumns, rows[0])
+	s.Equal([]string{"", "web", "nginx"}, rows[1])
+}
+
+func (s *FormatSuite) TestDecodeCSV() {
+	records, err := decodeImport(strings.NewReader("Image Name,Container Name,Notes\nnginx,web,extra\n\ndb\n"), dto.FormatCSV)
+	s.NoError(err)
+	s.Equal([]importRecord{
+		{Row: 2, ContainerName: "web", ImageName: "nginx", Ports: []string{}, Env: []string{}, Labels: []string{}},
+		{Row: 4, ImageName: "db", Ports: []string{}, Env: []string{}, Labels: []string{}},
+	}, records)
+}
+
+func (s *FormatSuite) TestDecodeNDJSON() {
+	records, err := decodeImport(strings.NewReader(`{"container_name":"web","image_name":"nginx","env":["A=1"]}
+{"container_name":"db","image_name":"postgres","labels":{"tier":"back"}}
+`), dto.FormatNDJSON)
+	s.NoError(err)
+	s.Equal([]importRecord{
+		{Row: 1, ContainerName: "web", ImageName: "nginx", Env: []string{"A=1"}, Labels: []string{}},
+		{Row: 2, ContainerName: "db", ImageName: "postgres", Labels: []string{"tier=back"}},
+	}, records)
+}
+
+func (s *FormatSuite) TestDecodeYAML() {
+	records, err := decodeImport(strings.NewReader(`
+- container_name: web
+  image_name: nginx
+  ports: ["8080:80"]
+`), dto.FormatYAML)
+	s.NoError(err)
+	s.Equal([]importRecord{{Row: 1, ContainerName: "web", ImageName: "nginx", Ports: []string{"8080:80"}, Labels: []string{}}}, records)
+
+	records, err = decodeImport(strings.NewReader(""), dto.FormatYAML)
+	s.NoError(err)
+	s.Empty(records)
+}
+
+func (s *FormatSuite) TestDecodeErrors() {
+	_, err := decodeImport(strings.NewReader("Container Id,Image Name\n"), dto.FormatCSV)
+	s.ErrorContains(err, "invalid header row")
+
+	_, err = decodeImport(strings.NewReader(""), dto.FormatCSV)
+	s.ErrorContains(err, "invalid header row")
+
+	_, err = decodeImport(strings.NewReader(`{"container_name":"web"}`), dto.FormatJSON)
+	s.Error(err)
+
+	_, err = decodeImport(strings.NewReader("{not json}\n"), dto.FormatNDJSON)
+	s.Error(err)
+
+	_, err = decodeImport(strings.NewReader("container_name: web"), dto.FormatYAML)
+	s.Error(err)
+}
+
+func (s *FormatSuite) TestFormatPort() {
+	s.Equal("80", formatPort(entities.PortBinding{ContainerPort: 80}))
+	s.Equal("8080:80/tcp", formatPort(entities.PortBinding{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"}))
+	s.Equal("0.0.0.0:53:53/udp", formatPort(entities.PortBinding{HostIp: "0.0.0.0", HostPort: 53, ContainerPort: 53, Protocol: "udp"}))
+}
@@ -6,18 +6,27 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/elastic/go-elasticsearch/esapi"
 	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
 	"github.com/vnFuhung2903/vcs-sms/interfaces"
 	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
 	"go.uber.org/zap"
 )
 
+// statusWriteAttempts bounds how often a status write is retried after another writer created the document it
+// was about to create.
+const statusWriteAttempts = 3
+
 type IHealthcheckService interface {
 	UpdateStatus(ctx context.Context, statusList []dto.EsStatusUpdate, interval time.Duration) error
+	RecordAction(ctx context.Context, containerId string, status entities.ContainerStatus, action dto.ContainerAction) error
+	RecordTransition(ctx context.Context, containerId string, status entities.ContainerStatus, at time.Time) error
 	GetEsStatus(ctx context.Context, ids []string, limit int, startTime time.Time, endTime time.Time, order dto.SortOrder) (map[string][]dto.EsStatus, error)
 }
 
@@ -33,8 +42,37 @@ func NewHealthcheckService(esClient interfaces.IElasticsearchClient, logger logg
 	}
 }
 
+// UpdateStatus extends the latest status document of each container or starts a new one. Documents are created
+// rather than overwritten, so the containers whose new document was taken by another writer are read and written again.
 func (s *HealthcheckService) UpdateStatus(ctx context.Context, statusList []dto.EsStatusUpdate, interval time.Duration) error {
+	for attempt := 1; ; attempt++ {
+		conflicts, err := s.writeStatus(ctx, statusList, interval)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) == 0 {
+			break
+		}
+		if attempt == statusWriteAttempts {
+			err := fmt.Errorf("status documents of %d containers kept conflicting", len(conflicts))
+			s.logger.Error("failed to bulk elasticsearch status", zap.Error(err))
+			return err
+		}
+		var retried []dto.EsStatusUpdate
+		for _, status := range statusList {
+			if slices.Contains(conflicts, status.ContainerId) {
+				retried = append(retried, status)
+			}
+		}
+		statusList = retried
+	}
+	s.logger.Info("elasticsearch status indexed successfully")
+	return nil
+}
+
+func (s *HealthcheckService) writeStatus(ctx context.Context, statusList []dto.EsStatusUpdate, interval time.Duration) ([]string, error) {
 	var buf bytes.Buffer
+	var opContainerIds []string
 	indexName := "sms_container"
 
 	var ids []string
@@ -46,13 +84,14 @@ func (s *HealthcheckService) UpdateStatus(ctx context.Context, statusList []dto.
 	startTime := endTime.Add(-interval)
 	var zeroTime time.Time
 
-	existingDocs, err := s.GetEsStatus(ctx, ids, 1, startTime, endTime, dto.Dsc)
+	// The range upper bound is exclusive and truncated to seconds, so look one second ahead.
+	existingDocs, err := s.GetEsStatus(ctx, ids, 1, startTime, endTime.Add(time.Second), dto.Dsc)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	previousDocs, err := s.GetEsStatus(ctx, ids, 1, zeroTime, startTime, dto.Dsc)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for _, status := range statusList {
@@ -71,7 +110,7 @@ func (s *HealthcheckService) UpdateStatus(ctx context.Context, statusList []dto.
 				nextCounter = previous[0].Counter + 1
 			}
 			meta = map[string]map[string]string{
-				"index": {
+				"create": {
 					"_index": indexName,
 					"_id":    fmt.Sprintf("%s_%d", status.ContainerId, nextCounter),
 				},
@@ -82,9 +121,10 @@ func (s *HealthcheckService) UpdateStatus(ctx context.Context, statusList []dto.
 				LastUpdated: endTime,
 				Uptime:      int64(interval.Seconds()),
 				Counter:     nextCounter,
+				Action:      status.Action,
 			}
 
-		case old[0].Status == status.Status:
+		case old[0].Status == status.Status && status.Action == "":
 			meta = map[string]map[string]string{
 				"update": {
 					"_index": indexName,
@@ -101,7 +141,7 @@ func (s *HealthcheckService) UpdateStatus(ctx context.Context, statusList []dto.
 		default:
 			nextCounter := old[0].Counter + 1
 			meta = map[string]map[string]string{
-				"index": {
+				"create": {
 					"_index": indexName,
 					"_id":    fmt.Sprintf("%s_%d", status.ContainerId, nextCounter),
 				},
@@ -112,6 +152,7 @@ func (s *HealthcheckService) UpdateStatus(ctx context.Context, statusList []dto.
 				Uptime:      int64(endTime.Sub(old[0].LastUpdated).Seconds()),
 				LastUpdated: endTime,
 				Counter:     nextCounter,
+				Action:      status.Action,
 			}
 		}
 
@@ -131,19 +172,155 @@ func (s *HealthcheckService) UpdateStatus(ctx context.Context, statusList []dto.
 		buf.WriteByte('\n')
 		buf.Write(docLine)
 		buf.WriteByte('\n')
+		opContainerIds = append(opContainerIds, status.ContainerId)
+	}
+
+	return s.bulkStatus(ctx, buf.Bytes(), opContainerIds)
+}
+
+// RecordAction starts a new status document for an operator action, so it is not mistaken for a crash.
+// With a zero interval no document is extended; the next healthcheck tick extends this one instead.
+func (s *HealthcheckService) RecordAction(ctx context.Context, containerId string, status entities.ContainerStatus, action dto.ContainerAction) error {
+	return s.UpdateStatus(ctx, []dto.EsStatusUpdate{{
+		ContainerId: containerId,
+		Status:      status,
+		Action:      action,
+	}}, 0)
+}
+
+// RecordTransition closes the latest status document at the given time and opens a new one from there,
+// so a transition reported by the docker events stream keeps its exact timestamp.
+func (s *HealthcheckService) RecordTransition(ctx context.Context, containerId string, status entities.ContainerStatus, at time.Time) error {
+	for attempt := 1; ; attempt++ {
+		conflicts, err := s.writeTransition(ctx, containerId, status, at)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) == 0 {
+			break
+		}
+		if attempt == statusWriteAttempts {
+			err := fmt.Errorf("status document of %s kept conflicting", containerId)
+			s.logger.Error("failed to bulk elasticsearch status", zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *HealthcheckService) writeTransition(ctx context.Context, containerId string, status entities.ContainerStatus, at time.Time) ([]string, error) {
+	var buf bytes.Buffer
+	var opContainerIds []string
+	indexName := "sms_container"
+
+	// The range upper bound is exclusive and truncated to seconds, so look one second ahead.
+	latestDocs, err := s.GetEsStatus(ctx, []string{containerId}, 1, time.Time{}, time.Now().Add(time.Second), dto.Dsc)
+	if err != nil {
+		return nil, err
+	}
+
+	nextCounter := int64(0)
+	if latest := latestDocs[containerId]; len(latest) > 0 {
+		if at.Before(latest[0].LastUpdated) {
+			at = latest[0].LastUpdated
+		}
+		if latest[0].Status == status {
+			return nil, nil
+		}
+		nextCounter = latest[0].Counter + 1
+
+		meta := map[string]map[string]string{
+			"update": {
+				"_index": indexName,
+				"_id":    fmt.Sprintf("%s_%d", containerId, latest[0].Counter),
+			},
+		}
+		doc := map[string]interface{}{
+			"doc": map[string]interface{}{
+				"uptime":       latest[0].Uptime + int64(at.Sub(latest[0].LastUpdated).Seconds()),
+				"last_updated": at,
+			},
+		}
+		metaLine, _ := json.Marshal(meta)
+		docLine, _ := json.Marshal(doc)
+		buf.Write(metaLine)
+		buf.WriteByte('\n')
+		buf.Write(docLine)
+		buf.WriteByte('\n')
+		opContainerIds = append(opContainerIds, containerId)
+	}
+
+	meta := map[string]map[string]string{
+		"create": {
+			"_index": indexName,
+			"_id":    fmt.Sprintf("%s_%d", containerId, nextCounter),
+		},
 	}
+	doc := dto.EsStatus{
+		ContainerId: containerId,
+		Status:      status,
+		LastUpdated: at,
+		Counter:     nextCounter,
+	}
+	metaLine, _ := json.Marshal(meta)
+	docLine, _ := json.Marshal(doc)
+	buf.Write(metaLine)
+	buf.WriteByte('\n')
+	buf.Write(docLine)
+	buf.WriteByte('\n')
+	opContainerIds = append(opContainerIds, containerId)
+
+	conflicts, err := s.bulkStatus(ctx, buf.Bytes(), opContainerIds)
+	if err == nil && len(conflicts) == 0 {
+		s.logger.Info("elasticsearch status transition recorded", zap.String("containerId", containerId), zap.String("status", string(status)))
+	}
+	return conflicts, err
+}
 
+// bulkStatus sends the status operations, one per container of opContainerIds, and returns the containers whose
+// document another writer created first. The request waits for a refresh, so the next search sees what it wrote.
+func (s *HealthcheckService) bulkStatus(ctx context.Context, body []byte, opContainerIds []string) ([]string, error) {
 	req := esapi.BulkRequest{
-		Body: bytes.NewReader(buf.Bytes()),
+		Body:    bytes.NewReader(body),
+		Refresh: "wait_for",
 	}
 	res, err := s.esClient.Do(ctx, req)
 	if err != nil {
 		s.logger.Error("failed to bulk elasticsearch status", zap.Error(err))
-		return err
+		return nil, err
 	}
 	defer res.Body.Close()
-	s.logger.Info("elasticsearch status indexed successfully")
-	return nil
+	if res.IsError() {
+		err := fmt.Errorf("elasticsearch bulk failed: %s", res.Status())
+		s.logger.Error("failed to bulk elasticsearch status", zap.Error(err))
+		return nil, err
+	}
+
+	var parsed struct {
+		Items []map[string]struct {
+			Status int `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&parsed); err != nil {
+		s.logger.Error("failed to decode response body", zap.Error(err))
+		return nil, err
+	}
+
+	var conflicts []string
+	for i, item := range parsed.Items {
+		if i >= len(opContainerIds) {
+			break
+		}
+		for op, result := range item {
+			switch {
+			case op == "create" && result.Status == http.StatusConflict:
+				conflicts = append(conflicts, opContainerIds[i])
+			case result.Status >= http.StatusMultipleChoices:
+				s.logger.Error("failed to write elasticsearch status", zap.String("containerId", opContainerIds[i]), zap.Int("status", result.Status))
+			}
+		}
+	}
+	return conflicts, nil
 }
 
 func (s *HealthcheckService) GetEsStatus(ctx context.Context, ids []string, limit int, startTime time.Time, endTime time.Time, order dto.SortOrder) (map[string][]dto.EsStatus, error) {
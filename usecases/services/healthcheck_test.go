@@ -224,6 +224,55 @@ func (s *HealthcheckServiceSuite) TestUpdateStatusSameStatusUpdate() {
 	s.NoError(err)
 }
 
+func (s *HealthcheckServiceSuite) TestRecordActionNewDocument() {
+	lastUpdated := time.Now().Add(-1 * time.Minute)
+
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, req esapi.Request) (*esapi.Response, error) {
+		response := &esapi.Response{
+			StatusCode: 200,
+			Body: io.NopCloser(strings.NewReader(`{
+                "responses": [
+                    {
+                        "hits": {
+                            "hits": [
+                                {
+                                    "_id": "container1",
+                                    "_source": {
+                                        "container_id": "container1",
+                                        "status": "ON",
+                                        "uptime": 60,
+                                        "last_updated": "` + lastUpdated.Format(time.RFC3339) + `"
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                ]
+            }`)),
+		}
+		return response, nil
+	}).Times(2)
+
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, req esapi.Request) (*esapi.Response, error) {
+		bulk, ok := req.(esapi.BulkRequest)
+		s.True(ok)
+		body, _ := io.ReadAll(bulk.Body)
+		s.Contains(string(body), `{"create":{"_id":"container1_1","_index":"sms_container"}}`)
+		s.Contains(string(body), `"action":"restart"`)
+		s.NotContains(string(body), `"update"`)
+		return &esapi.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"took":1,"errors":false}`)),
+		}, nil
+	}).Times(1)
+
+	s.mockLogger.EXPECT().Info("elasticsearch status retrieved successfully", gomock.Any()).Times(2)
+	s.mockLogger.EXPECT().Info("elasticsearch status indexed successfully").Times(1)
+
+	err := s.healthcheckService.RecordAction(s.ctx, "container1", entities.ContainerOn, dto.ActionRestart)
+	s.NoError(err)
+}
+
 func (s *HealthcheckServiceSuite) TestGetEsStatus() {
 	ids := []string{"container1", "container2"}
 	limit := 10
@@ -349,3 +398,211 @@ func (f *failingReadCloser) Read(p []byte) (n int, err error) {
 func (f *failingReadCloser) Close() error {
 	return nil
 }
+
+func (s *HealthcheckServiceSuite) mockLatestStatus(source string) {
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, req esapi.Request) (*esapi.Response, error) {
+		_, ok := req.(esapi.MsearchRequest)
+		s.True(ok)
+		hits := `[]`
+		if source != "" {
+			hits = `[{"_id": "container1_3", "_source": ` + source + `}]`
+		}
+		return &esapi.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"responses": [{"hits": {"hits": ` + hits + `}}]}`)),
+		}, nil
+	})
+	s.mockLogger.EXPECT().Info("elasticsearch status retrieved successfully", gomock.Any())
+}
+
+func (s *HealthcheckServiceSuite) TestRecordTransition() {
+	lastUpdated := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := lastUpdated.Add(90 * time.Second)
+	s.mockLatestStatus(`{"container_id": "container1", "status": "ON", "uptime": 60, "counter": 3, "last_updated": "` + lastUpdated.Format(time.RFC3339) + `"}`)
+
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, req esapi.Request) (*esapi.Response, error) {
+		bulk, ok := req.(esapi.BulkRequest)
+		s.True(ok)
+		body, _ := io.ReadAll(bulk.Body)
+		s.Equal(`{"update":{"_id":"container1_3","_index":"sms_container"}}
+{"doc":{"last_updated":"2025-01-01T00:01:30Z","uptime":150}}
+{"create":{"_id":"container1_4","_index":"sms_container"}}
+{"container_id":"container1","status":"EXITED","uptime":0,"last_updated":"2025-01-01T00:01:30Z","counter":4}
+`, string(body))
+		return &esapi.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"took":1,"errors":false}`)),
+		}, nil
+	})
+	s.mockLogger.EXPECT().Info("elasticsearch status transition recorded", gomock.Any(), gomock.Any())
+
+	err := s.healthcheckService.RecordTransition(s.ctx, "container1", entities.ContainerExited, at)
+	s.NoError(err)
+}
+
+func (s *HealthcheckServiceSuite) TestRecordTransitionFirstDocument() {
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.mockLatestStatus("")
+
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, req esapi.Request) (*esapi.Response, error) {
+		bulk, ok := req.(esapi.BulkRequest)
+		s.True(ok)
+		body, _ := io.ReadAll(bulk.Body)
+		s.Equal(`{"create":{"_id":"container1_0","_index":"sms_container"}}
+{"container_id":"container1","status":"ON","uptime":0,"last_updated":"2025-01-01T00:00:00Z","counter":0}
+`, string(body))
+		return &esapi.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"took":1,"errors":false}`)),
+		}, nil
+	})
+	s.mockLogger.EXPECT().Info("elasticsearch status transition recorded", gomock.Any(), gomock.Any())
+
+	err := s.healthcheckService.RecordTransition(s.ctx, "container1", entities.ContainerOn, at)
+	s.NoError(err)
+}
+
+func (s *HealthcheckServiceSuite) TestRecordTransitionSameStatus() {
+	lastUpdated := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.mockLatestStatus(`{"container_id": "container1", "status": "ON", "uptime": 60, "counter": 3, "last_updated": "` + lastUpdated.Format(time.RFC3339) + `"}`)
+
+	err := s.healthcheckService.RecordTransition(s.ctx, "container1", entities.ContainerOn, lastUpdated.Add(time.Minute))
+	s.NoError(err)
+}
+
+func (s *HealthcheckServiceSuite) TestRecordTransitionOutOfOrder() {
+	lastUpdated := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.mockLatestStatus(`{"container_id": "container1", "status": "ON", "uptime": 60, "counter": 3, "last_updated": "` + lastUpdated.Format(time.RFC3339) + `"}`)
+
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, req esapi.Request) (*esapi.Response, error) {
+		bulk, ok := req.(esapi.BulkRequest)
+		s.True(ok)
+		body, _ := io.ReadAll(bulk.Body)
+		s.Contains(string(body), `{"doc":{"last_updated":"2025-01-01T00:00:00Z","uptime":60}}`)
+		s.Contains(string(body), `"status":"OFF","uptime":0,"last_updated":"2025-01-01T00:00:00Z"`)
+		return nil, errors.New("bulk error")
+	})
+	s.mockLogger.EXPECT().Error("failed to bulk elasticsearch status", gomock.Any())
+
+	err := s.healthcheckService.RecordTransition(s.ctx, "container1", entities.ContainerOff, lastUpdated.Add(-time.Minute))
+	s.ErrorContains(err, "bulk error")
+}
+
+func (s *HealthcheckServiceSuite) TestRecordTransitionGetEsStatusError() {
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).Return(nil, errors.New("msearch error"))
+	s.mockLogger.EXPECT().Error("failed to msearch elasticsearch status", gomock.Any())
+
+	err := s.healthcheckService.RecordTransition(s.ctx, "container1", entities.ContainerOn, time.Now())
+	s.ErrorContains(err, "msearch error")
+}
+
+func (s *HealthcheckServiceSuite) mockBulk(response string, check func(body string)) {
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, req esapi.Request) (*esapi.Response, error) {
+		bulk, ok := req.(esapi.BulkRequest)
+		s.True(ok)
+		s.Equal("wait_for", bulk.Refresh)
+		body, _ := io.ReadAll(bulk.Body)
+		check(string(body))
+		return &esapi.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(response)),
+		}, nil
+	})
+}
+
+func (s *HealthcheckServiceSuite) TestRecordTransitionRetriesConflict() {
+	lastUpdated := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := lastUpdated.Add(90 * time.Second)
+
+	// Another writer created container1_4 between the search and the write, so it is read again and written after it.
+	s.mockLatestStatus(`{"container_id": "container1", "status": "ON", "uptime": 60, "counter": 3, "last_updated": "` + lastUpdated.Format(time.RFC3339) + `"}`)
+	s.mockBulk(`{"errors":true,"items":[{"update":{"status":200}},{"create":{"status":409}}]}`, func(body string) {
+		s.Contains(body, `{"create":{"_id":"container1_4","_index":"sms_container"}}`)
+	})
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, req esapi.Request) (*esapi.Response, error) {
+		return &esapi.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"responses": [{"hits": {"hits": [{"_id": "container1_4", "_source": {"container_id": "container1", "status": "ON", "uptime": 0, "counter": 4, "action": "restart", "last_updated": "` + at.Format(time.RFC3339) + `"}}]}}]}`)),
+		}, nil
+	})
+	s.mockLogger.EXPECT().Info("elasticsearch status retrieved successfully", gomock.Any())
+	s.mockBulk(`{"errors":false,"items":[{"update":{"status":200}},{"create":{"status":201}}]}`, func(body string) {
+		s.Contains(body, `{"update":{"_id":"container1_4","_index":"sms_container"}}`)
+		s.Contains(body, `{"create":{"_id":"container1_5","_index":"sms_container"}}`)
+	})
+	s.mockLogger.EXPECT().Info("elasticsearch status transition recorded", gomock.Any(), gomock.Any())
+
+	err := s.healthcheckService.RecordTransition(s.ctx, "container1", entities.ContainerExited, at)
+	s.NoError(err)
+}
+
+func (s *HealthcheckServiceSuite) TestRecordTransitionKeepsConflicting() {
+	lastUpdated := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for range statusWriteAttempts {
+		s.mockLatestStatus(`{"container_id": "container1", "status": "ON", "uptime": 60, "counter": 3, "last_updated": "` + lastUpdated.Format(time.RFC3339) + `"}`)
+		s.mockBulk(`{"errors":true,"items":[{"update":{"status":200}},{"create":{"status":409}}]}`, func(string) {})
+	}
+	s.mockLogger.EXPECT().Error("failed to bulk elasticsearch status", gomock.Any())
+
+	err := s.healthcheckService.RecordTransition(s.ctx, "container1", entities.ContainerExited, lastUpdated.Add(time.Minute))
+	s.ErrorContains(err, "kept conflicting")
+}
+
+func (s *HealthcheckServiceSuite) TestUpdateStatusRetriesOnlyConflicting() {
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, req esapi.Request) (*esapi.Response, error) {
+		return &esapi.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"responses": [{"hits": {"hits": []}}, {"hits": {"hits": []}}]}`)),
+		}, nil
+	}).Times(2)
+	s.mockBulk(`{"errors":true,"items":[{"create":{"status":201}},{"create":{"status":409}}]}`, func(body string) {
+		s.Contains(body, `{"create":{"_id":"container1_0","_index":"sms_container"}}`)
+		s.Contains(body, `{"create":{"_id":"container2_0","_index":"sms_container"}}`)
+	})
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, req esapi.Request) (*esapi.Response, error) {
+		msearch, ok := req.(esapi.MsearchRequest)
+		s.True(ok)
+		body, _ := io.ReadAll(msearch.Body)
+		s.NotContains(string(body), "container1")
+		return &esapi.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"responses": [{"hits": {"hits": [{"_id": "container2_0", "_source": {"container_id": "container2", "status": "OFF", "uptime": 0, "counter": 0, "action": "stop", "last_updated": "` + time.Now().Format(time.RFC3339) + `"}}]}}]}`)),
+		}, nil
+	})
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, req esapi.Request) (*esapi.Response, error) {
+		return &esapi.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"responses": [{"hits": {"hits": []}}]}`)),
+		}, nil
+	})
+	s.mockBulk(`{"errors":false,"items":[{"update":{"status":200}}]}`, func(body string) {
+		s.Equal(1, strings.Count(body, "\n")/2)
+		s.Contains(body, `{"update":{"_id":"container2_0","_index":"sms_container"}}`)
+	})
+	s.mockLogger.EXPECT().Info("elasticsearch status retrieved successfully", gomock.Any()).Times(4)
+	s.mockLogger.EXPECT().Info("elasticsearch status indexed successfully")
+
+	err := s.healthcheckService.UpdateStatus(s.ctx, []dto.EsStatusUpdate{
+		{ContainerId: "container1", Status: entities.ContainerOn},
+		{ContainerId: "container2", Status: entities.ContainerOff},
+	}, time.Minute)
+	s.NoError(err)
+}
+
+func (s *HealthcheckServiceSuite) TestUpdateStatusBulkResponseError() {
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, req esapi.Request) (*esapi.Response, error) {
+		return &esapi.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"responses": [{"hits": {"hits": []}}]}`)),
+		}, nil
+	}).Times(2)
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).Return(&esapi.Response{
+		StatusCode: 503,
+		Body:       io.NopCloser(strings.NewReader(`{"error":"unavailable"}`)),
+	}, nil)
+	s.mockLogger.EXPECT().Info("elasticsearch status retrieved successfully", gomock.Any()).Times(2)
+	s.mockLogger.EXPECT().Error("failed to bulk elasticsearch status", gomock.Any())
+
+	err := s.healthcheckService.UpdateStatus(s.ctx, []dto.EsStatusUpdate{{ContainerId: "container1", Status: entities.ContainerOn}}, time.Minute)
+	s.ErrorContains(err, "503")
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/containerd/errdefs"
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/image"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/pkg/docker"
+	"github.com/vnFuhung2903/vcs-sms/pkg/env"
+	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type IImageService interface {
+	List(ctx context.Context) ([]dto.ImageSummary, error)
+	Inspect(ctx context.Context, imageName string) (*dto.ImageInspect, error)
+	Pull(ctx context.Context, imageName string) (io.ReadCloser, error)
+	Remove(ctx context.Context, imageName string, force bool) ([]string, error)
+	Prune(ctx context.Context, all bool) (*dto.ImagePruneResponse, error)
+	CheckPolicy(imageName string) error
+}
+
+type ImageService struct {
+	dockerClient      docker.IDockerClient
+	allowedRegistries []string
+	deniedTags        []string
+	requireDigest     bool
+	logger            logger.ILogger
+}
+
+func NewImageService(dockerClient docker.IDockerClient, logger logger.ILogger, env env.ImageEnv) IImageService {
+	return &ImageService{
+		dockerClient:      dockerClient,
+		allowedRegistries: env.AllowedRegistries,
+		deniedTags:        env.DeniedTags,
+		requireDigest:     env.RequireDigest,
+		logger:            logger,
+	}
+}
+
+func (s *ImageService) List(ctx context.Context) ([]dto.ImageSummary, error) {
+	images, err := s.dockerClient.ListImages(ctx)
+	if err != nil {
+		s.logger.Error("failed to list docker images", zap.Error(err))
+		return nil, err
+	}
+
+	summaries := make([]dto.ImageSummary, 0, len(images))
+	for _, img := range images {
+		summaries = append(summaries, dto.ImageSummary{
+			Id:          img.ID,
+			RepoTags:    img.RepoTags,
+			RepoDigests: img.RepoDigests,
+			Size:        img.Size,
+			Containers:  img.Containers,
+			Created:     time.Unix(img.Created, 0),
+		})
+	}
+	s.logger.Info("images listed successfully", zap.Int("count", len(summaries)))
+	return summaries, nil
+}
+
+func (s *ImageService) Inspect(ctx context.Context, imageName string) (*dto.ImageInspect, error) {
+	inspect, err := s.dockerClient.InspectImage(ctx, imageName)
+	if errdefs.IsNotFound(err) {
+		return nil, fmt.Errorf("%w: %s", ErrImageNotFound, imageName)
+	}
+	if err != nil {
+		s.logger.Error("failed to inspect docker image", zap.Error(err))
+		return nil, err
+	}
+
+	result := &dto.ImageInspect{
+		Id:           inspect.ID,
+		RepoTags:     inspect.RepoTags,
+		RepoDigests:  inspect.RepoDigests,
+		Size:         inspect.Size,
+		Created:      inspect.Created,
+		Architecture: inspect.Architecture,
+		Os:           inspect.Os,
+	}
+	if inspect.Config != nil {
+		result.Labels = inspect.Config.Labels
+	}
+	return result, nil
+}
+
+// Pull checks the image against the registry policy and returns the docker progress stream, one JSON message per line.
+func (s *ImageService) Pull(ctx context.Context, imageName string) (io.ReadCloser, error) {
+	if err := s.CheckPolicy(imageName); err != nil {
+		return nil, err
+	}
+
+	progress, err := s.dockerClient.Pull(ctx, imageName)
+	if errdefs.IsNotFound(err) {
+		return nil, fmt.Errorf("%w: %s", ErrImageNotFound, imageName)
+	}
+	if err != nil {
+		s.logger.Error("failed to pull docker image", zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info("image pull started", zap.String("image", imageName))
+	return progress, nil
+}
+
+func (s *ImageService) Remove(ctx context.Context, imageName string, force bool) ([]string, error) {
+	responses, err := s.dockerClient.RemoveImage(ctx, imageName, force)
+	if errdefs.IsNotFound(err) {
+		return nil, fmt.Errorf("%w: %s", ErrImageNotFound, imageName)
+	}
+	if errdefs.IsConflict(err) {
+		return nil, fmt.Errorf("%w: %v", ErrImageInUse, err)
+	}
+	if err != nil {
+		s.logger.Error("failed to remove docker image", zap.Error(err))
+		return nil, err
+	}
+
+	removed := toRemovedImages(responses)
+	s.logger.Info("image removed successfully", zap.String("image", imageName))
+	return removed, nil
+}
+
+func (s *ImageService) Prune(ctx context.Context, all bool) (*dto.ImagePruneResponse, error) {
+	report, err := s.dockerClient.PruneImages(ctx, all)
+	if err != nil {
+		s.logger.Error("failed to prune docker images", zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("images pruned successfully", zap.Int("count", len(report.ImagesDeleted)))
+	return &dto.ImagePruneResponse{
+		Deleted:        toRemovedImages(report.ImagesDeleted),
+		SpaceReclaimed: report.SpaceReclaimed,
+	}, nil
+}
+
+// CheckPolicy rejects images from a registry outside the allow list, with a denied tag, or not pinned by digest when required.
+// An image without tag nor digest is checked as :latest, the tag docker would pull.
+func (s *ImageService) CheckPolicy(imageName string) error {
+	named, err := reference.ParseNormalizedNamed(imageName)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errdefs.ErrInvalidArgument, err)
+	}
+
+	registry := reference.Domain(named)
+	if len(s.allowedRegistries) > 0 && !slices.Contains(s.allowedRegistries, registry) {
+		return fmt.Errorf("%w: registry %s is not allowed", ErrImageNotAllowed, registry)
+	}
+
+	_, digested := named.(reference.Digested)
+	if s.requireDigest && !digested {
+		return fmt.Errorf("%w: %s is not pinned by digest", ErrImageNotAllowed, imageName)
+	}
+
+	tag := ""
+	if tagged, ok := named.(reference.Tagged); ok {
+		tag = tagged.Tag()
+	} else if !digested {
+		tag = "latest"
+	}
+	if tag != "" && slices.Contains(s.deniedTags, tag) {
+		return fmt.Errorf("%w: tag %s is denied", ErrImageNotAllowed, tag)
+	}
+	return nil
+}
+
+func toRemovedImages(responses []image.DeleteResponse) []string {
+	removed := make([]string, 0, len(responses))
+	for _, response := range responses {
+		if response.Untagged != "" {
+			removed = append(removed, response.Untagged)
+		}
+		if response.Deleted != "" {
+			removed = append(removed, response.Deleted)
+		}
+	}
+	return removed
+}
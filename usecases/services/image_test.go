@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/image"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/mocks/docker"
+	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
+	"github.com/vnFuhung2903/vcs-sms/pkg/env"
+)
+
+type ImageServiceSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	imageService IImageService
+	dockerClient *docker.MockIDockerClient
+	logger       *logger.MockILogger
+	ctx          context.Context
+}
+
+func (s *ImageServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.dockerClient = docker.NewMockIDockerClient(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.imageService = NewImageService(s.dockerClient, s.logger, env.ImageEnv{
+		AllowedRegistries: []string{"docker.io", "ghcr.io"},
+		DeniedTags:        []string{"latest"},
+	})
+	s.ctx = context.Background()
+}
+
+func (s *ImageServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestImageServiceSuite(t *testing.T) {
+	suite.Run(t, new(ImageServiceSuite))
+}
+
+func (s *ImageServiceSuite) TestCheckPolicy() {
+	s.NoError(s.imageService.CheckPolicy("nginx:1.27"))
+	s.NoError(s.imageService.CheckPolicy("ghcr.io/org/app:v1"))
+	s.NoError(s.imageService.CheckPolicy("nginx@sha256:0000000000000000000000000000000000000000000000000000000000000000"))
+
+	err := s.imageService.CheckPolicy("quay.io/org/app:v1")
+	s.ErrorIs(err, ErrImageNotAllowed)
+	s.ErrorContains(err, "registry quay.io is not allowed")
+
+	err = s.imageService.CheckPolicy("nginx:latest")
+	s.ErrorIs(err, ErrImageNotAllowed)
+	err = s.imageService.CheckPolicy("nginx")
+	s.ErrorIs(err, ErrImageNotAllowed)
+	s.ErrorContains(err, "tag latest is denied")
+
+	err = s.imageService.CheckPolicy("Invalid Image")
+	s.True(errdefs.IsInvalidArgument(err))
+}
+
+func (s *ImageServiceSuite) TestCheckPolicyRequireDigest() {
+	imageService := NewImageService(s.dockerClient, s.logger, env.ImageEnv{RequireDigest: true})
+
+	s.NoError(imageService.CheckPolicy("quay.io/org/app@sha256:0000000000000000000000000000000000000000000000000000000000000000"))
+	s.ErrorIs(imageService.CheckPolicy("quay.io/org/app:v1"), ErrImageNotAllowed)
+}
+
+func (s *ImageServiceSuite) TestList() {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.dockerClient.EXPECT().ListImages(s.ctx).Return([]image.Summary{
+		{ID: "sha256:abc", RepoTags: []string{"nginx:1.27"}, Size: 1024, Containers: 2, Created: created.Unix()},
+	}, nil)
+	s.logger.EXPECT().Info("images listed successfully", gomock.Any())
+
+	images, err := s.imageService.List(s.ctx)
+	s.NoError(err)
+	s.Equal([]dto.ImageSummary{{Id: "sha256:abc", RepoTags: []string{"nginx:1.27"}, Size: 1024, Containers: 2, Created: time.Unix(created.Unix(), 0)}}, images)
+}
+
+func (s *ImageServiceSuite) TestListError() {
+	s.dockerClient.EXPECT().ListImages(s.ctx).Return(nil, errors.New("daemon error"))
+	s.logger.EXPECT().Error("failed to list docker images", gomock.Any())
+
+	_, err := s.imageService.List(s.ctx)
+	s.ErrorContains(err, "daemon error")
+}
+
+func (s *ImageServiceSuite) TestInspect() {
+	s.dockerClient.EXPECT().InspectImage(s.ctx, "nginx:1.27").Return(image.InspectResponse{ID: "sha256:abc", Os: "linux", Architecture: "amd64"}, nil)
+
+	inspect, err := s.imageService.Inspect(s.ctx, "nginx:1.27")
+	s.NoError(err)
+	s.Equal(&dto.ImageInspect{Id: "sha256:abc", Os: "linux", Architecture: "amd64"}, inspect)
+}
+
+func (s *ImageServiceSuite) TestInspectNotFound() {
+	s.dockerClient.EXPECT().InspectImage(s.ctx, "nginx:1.27").Return(image.InspectResponse{}, errdefs.ErrNotFound)
+
+	_, err := s.imageService.Inspect(s.ctx, "nginx:1.27")
+	s.ErrorIs(err, ErrImageNotFound)
+}
+
+func (s *ImageServiceSuite) TestPull() {
+	s.dockerClient.EXPECT().Pull(s.ctx, "nginx:1.27").Return(io.NopCloser(strings.NewReader(`{"status":"Pulling"}`)), nil)
+	s.logger.EXPECT().Info("image pull started", gomock.Any())
+
+	progress, err := s.imageService.Pull(s.ctx, "nginx:1.27")
+	s.NoError(err)
+	body, _ := io.ReadAll(progress)
+	s.Equal(`{"status":"Pulling"}`, string(body))
+}
+
+func (s *ImageServiceSuite) TestPullNotAllowed() {
+	_, err := s.imageService.Pull(s.ctx, "nginx")
+	s.ErrorIs(err, ErrImageNotAllowed)
+}
+
+func (s *ImageServiceSuite) TestPullError() {
+	s.dockerClient.EXPECT().Pull(s.ctx, "nginx:1.27").Return(nil, errors.New("pull error"))
+	s.logger.EXPECT().Error("failed to pull docker image", gomock.Any())
+
+	_, err := s.imageService.Pull(s.ctx, "nginx:1.27")
+	s.ErrorContains(err, "pull error")
+}
+
+func (s *ImageServiceSuite) TestRemove() {
+	s.dockerClient.EXPECT().RemoveImage(s.ctx, "nginx:1.27", true).Return([]image.DeleteResponse{
+		{Untagged: "nginx:1.27"},
+		{Deleted: "sha256:abc"},
+	}, nil)
+	s.logger.EXPECT().Info("image removed successfully", gomock.Any())
+
+	removed, err := s.imageService.Remove(s.ctx, "nginx:1.27", true)
+	s.NoError(err)
+	s.Equal([]string{"nginx:1.27", "sha256:abc"}, removed)
+}
+
+func (s *ImageServiceSuite) TestRemoveErrors() {
+	s.dockerClient.EXPECT().RemoveImage(s.ctx, "missing", false).Return(nil, errdefs.ErrNotFound)
+	s.dockerClient.EXPECT().RemoveImage(s.ctx, "used", false).Return(nil, errdefs.ErrConflict)
+	s.dockerClient.EXPECT().RemoveImage(s.ctx, "broken", false).Return(nil, errors.New("daemon error"))
+	s.logger.EXPECT().Error("failed to remove docker image", gomock.Any())
+
+	_, err := s.imageService.Remove(s.ctx, "missing", false)
+	s.ErrorIs(err, ErrImageNotFound)
+	_, err = s.imageService.Remove(s.ctx, "used", false)
+	s.ErrorIs(err, ErrImageInUse)
+	_, err = s.imageService.Remove(s.ctx, "broken", false)
+	s.ErrorContains(err, "daemon error")
+}
+
+func (s *ImageServiceSuite) TestPrune() {
+	s.dockerClient.EXPECT().PruneImages(s.ctx, true).Return(image.PruneReport{
+		ImagesDeleted:  []image.DeleteResponse{{Deleted: "sha256:abc"}},
+		SpaceReclaimed: 2048,
+	}, nil)
+	s.logger.EXPECT().Info("images pruned successfully", gomock.Any())
+
+	report, err := s.imageService.Prune(s.ctx, true)
+	s.NoError(err)
+	s.Equal(&dto.ImagePruneResponse{Deleted: []string{"sha256:abc"}, SpaceReclaimed: 2048}, report)
+}
+
+func (s *ImageServiceSuite) TestPruneError() {
+	s.dockerClient.EXPECT().PruneImages(s.ctx, false).Return(image.PruneReport{}, errors.New("prune error"))
+	s.logger.EXPECT().Error("failed to prune docker images", gomock.Any())
+
+	_, err := s.imageService.Prune(s.ctx, false)
+	s.ErrorContains(err, "prune error")
+}
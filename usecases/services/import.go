@@ -0,0 +1,291 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containerd/errdefs"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// containerNamePattern is the name format accepted by the docker daemon.
+var containerNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+
+// ReadImport decodes the import file and validates every row without touching docker.
+// Spreadsheets and CSV files need the Container Name and Image Name columns, the optional Ports, Env and Labels columns
+// hold entries separated by ";" or new lines: "8080:80/tcp", "KEY=value" and "key=value", the optional Memory column
+// the memory limit in bytes.
+// Rows naming a container that already exists with the same image and owner are marked skipped so that re-importing a file is harmless.
+func (s *ContainerService) ReadImport(ctx context.Context, file multipart.File, format dto.FileFormat, ownerId string) ([]dto.ImportRow, error) {
+	records, err := decodeImport(file, format)
+	if err != nil {
+		s.logger.Error("failed to import containers", zap.String("format", string(format)), zap.Error(err))
+		return nil, err
+	}
+
+	importRows := make([]dto.ImportRow, 0, len(records))
+	seen := make(map[string]int, len(records))
+	valid := int64(0)
+	validMemory := int64(0)
+	for _, record := range records {
+		row := parseImportRow(record)
+		if row.ContainerName != "" {
+			if first, ok := seen[row.ContainerName]; ok {
+				row.Errors = append(row.Errors, dto.ImportError{
+					Row: row.Row, Column: nameColumn, Code: dto.ImportDuplicateName,
+					Message: fmt.Sprintf("container name already used on row %d", first),
+				})
+			} else {
+				seen[row.ContainerName] = row.Row
+			}
+		}
+		if row.ImageName != "" {
+			if err := s.imageService.CheckPolicy(row.ImageName); errdefs.IsInvalidArgument(err) {
+				row.Errors = append(row.Errors, dto.ImportError{Row: row.Row, Column: imageColumn, Code: dto.ImportInvalidImage, Message: err.Error()})
+			} else if err != nil {
+				row.Errors = append(row.Errors, dto.ImportError{Row: row.Row, Column: imageColumn, Code: dto.ImportImageNotAllowed, Message: err.Error()})
+			}
+		}
+		if len(row.Errors) > 0 {
+			importRows = append(importRows, row)
+			continue
+		}
+
+		existing, err := s.containerRepo.FindByName(row.ContainerName)
+		if err == nil {
+			if existing.DeletedAt.Valid {
+				row.Errors = append(row.Errors, dto.ImportError{
+					Row: row.Row, Column: nameColumn, Code: dto.ImportNameConflict,
+					Message: "a container in the trash uses this name",
+				})
+			} else if existing.OwnerId == ownerId && existing.ImageName == row.ImageName {
+				row.Skipped = true
+			} else {
+				row.Errors = append(row.Errors, dto.ImportError{
+					Row: row.Row, Column: nameColumn, Code: dto.ImportNameConflict,
+					Message: "a different container already uses this name",
+				})
+			}
+			importRows = append(importRows, row)
+			continue
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Error("failed to import containers", zap.Error(err))
+			return nil, err
+		}
+
+		// The rows before count towards the quota too, a row without a memory limit still being checked without one.
+		memory := row.Spec.Resources.Memory
+		if memory > 0 {
+			memory += validMemory
+		}
+		if err := s.quotaService.Check(ctx, ownerId, valid+1, memory); errors.Is(err, ErrQuotaExceeded) {
+			row.Errors = append(row.Errors, dto.ImportError{Row: row.Row, Code: dto.ImportQuotaExceeded, Message: err.Error()})
+		} else if err != nil {
+			s.logger.Error("failed to import containers", zap.Error(err))
+			return nil, err
+		} else {
+			valid++
+			validMemory += row.Spec.Resources.Memory
+		}
+		importRows = append(importRows, row)
+	}
+	return importRows, nil
+}
+
+// Import creates the containers of every valid row, each one on its own so that a failing row never undoes the others.
+// With dryRun set, the report only tells what would be created.
+func (s *ContainerService) Import(ctx context.Context, file multipart.File, format dto.FileFormat, ownerId string, dryRun bool) (*dto.ImportResponse, error) {
+	rows, err := s.ReadImport(ctx, file, format, ownerId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.ImportResponse{
+		DryRun:            dryRun,
+		SuccessContainers: []string{},
+		FailedContainers:  []string{},
+		SkippedContainers: []string{},
+		Errors:            []dto.ImportError{},
+	}
+	for _, row := range rows {
+		if row.Skipped {
+			result.SkippedCount++
+			result.SkippedContainers = append(result.SkippedContainers, row.ContainerName)
+			continue
+		}
+		if len(row.Errors) == 0 && !dryRun {
+			if _, err := s.create(ctx, row.ContainerName, row.ImageName, row.Spec, ownerId, row.ExpiresAt); err != nil {
+				row.Errors = append(row.Errors, dto.ImportError{Row: row.Row, Code: dto.ImportCreateFailed, Message: err.Error()})
+			}
+		}
+		if len(row.Errors) > 0 {
+			result.FailedCount++
+			result.FailedContainers = append(result.FailedContainers, row.ContainerName)
+			result.Errors = append(result.Errors, row.Errors...)
+			continue
+		}
+		result.SuccessCount++
+		result.SuccessContainers = append(result.SuccessContainers, row.ContainerName)
+	}
+
+	s.logger.Info("containers imported successfully", zap.Bool("dryRun", dryRun), zap.Int("success", result.SuccessCount), zap.Int("failed", result.FailedCount), zap.Int("skipped", result.SkippedCount))
+	return result, nil
+}
+
+func parseImportRow(record importRecord) dto.ImportRow {
+	row := dto.ImportRow{
+		Row:           record.Row,
+		ContainerName: record.ContainerName,
+		ImageName:     record.ImageName,
+	}
+	fail := func(column string, code dto.ImportErrorCode, message string) {
+		row.Errors = append(row.Errors, dto.ImportError{Row: record.Row, Column: column, Code: code, Message: message})
+	}
+
+	if row.ContainerName == "" {
+		fail(nameColumn, dto.ImportMissingValue, "container name is required")
+	} else if !containerNamePattern.MatchString(row.ContainerName) {
+		fail(nameColumn, dto.ImportInvalidName, "container name must match "+containerNamePattern.String())
+	}
+	if row.ImageName == "" {
+		fail(imageColumn, dto.ImportMissingValue, "image name is required")
+	}
+
+	var err error
+	if row.Spec.Ports, err = parsePorts(record.Ports); err != nil {
+		fail(portsColumn, dto.ImportInvalidPorts, err.Error())
+	}
+	if row.Spec.Env, err = parseEnv(record.Env); err != nil {
+		fail(envColumn, dto.ImportInvalidEnv, err.Error())
+	}
+	if row.Spec.Labels, err = parseLabels(record.Labels); err != nil {
+		fail(labelsColumn, dto.ImportInvalidLabels, err.Error())
+	}
+	if row.ExpiresAt, err = parseExpiry(record.ExpiresAt, record.TTL, time.Now()); err != nil {
+		column := expiresAtColumn
+		if record.ExpiresAt == "" {
+			column = ttlColumn
+		}
+		fail(column, dto.ImportInvalidExpiry, err.Error())
+	}
+	if row.Spec.Resources.Memory, err = parseMemory(record.Memory); err != nil {
+		fail(memoryColumn, dto.ImportInvalidMemory, err.Error())
+	}
+	return row
+}
+
+// minMemory is the smallest memory limit the docker daemon accepts.
+const minMemory = 6 << 20
+
+// parseMemory reads a memory limit in bytes, an empty value meaning none.
+func parseMemory(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	memory, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || memory < minMemory {
+		return 0, fmt.Errorf("invalid memory %q, expected at least %d bytes", value, minMemory)
+	}
+	return memory, nil
+}
+
+// parseExpiry reads an RFC 3339 expiry time or a time to live from now, a row setting both being rejected.
+func parseExpiry(expiresAt string, ttl string, now time.Time) (*time.Time, error) {
+	expiry := dto.Expiry{TTL: ttl}
+	if expiresAt != "" {
+		if ttl != "" {
+			return nil, errors.New("expires at and ttl cannot both be set")
+		}
+		t, err := time.Parse(time.RFC3339, expiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expiry %q, expected an RFC 3339 time", expiresAt)
+		}
+		expiry.ExpiresAt = &t
+	}
+	return expiry.Resolve(now)
+}
+
+// parsePorts reads [[host_ip:]host_port:]container_port[/protocol] entries.
+func parsePorts(entries []string) ([]entities.PortBinding, error) {
+	var ports []entities.PortBinding
+	for _, entry := range entries {
+		binding := entities.PortBinding{}
+		spec, protocol, hasProtocol := strings.Cut(entry, "/")
+		if hasProtocol {
+			if protocol != "tcp" && protocol != "udp" && protocol != "sctp" {
+				return nil, fmt.Errorf("invalid protocol in %q", entry)
+			}
+			binding.Protocol = protocol
+		}
+
+		parts := strings.Split(spec, ":")
+		if len(parts) > 3 {
+			return nil, fmt.Errorf("invalid port binding %q", entry)
+		}
+		if len(parts) == 3 {
+			if net.ParseIP(parts[0]) == nil {
+				return nil, fmt.Errorf("invalid host ip in %q", entry)
+			}
+			binding.HostIp = parts[0]
+			parts = parts[1:]
+		}
+
+		containerPort, err := parsePort(parts[len(parts)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid container port in %q", entry)
+		}
+		binding.ContainerPort = containerPort
+		if len(parts) == 2 {
+			if binding.HostPort, err = parsePort(parts[0]); err != nil {
+				return nil, fmt.Errorf("invalid host port in %q", entry)
+			}
+		}
+		ports = append(ports, binding)
+	}
+	return ports, nil
+}
+
+func parsePort(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, errors.New("invalid port")
+	}
+	return port, nil
+}
+
+func parseEnv(entries []string) ([]string, error) {
+	var env []string
+	for _, entry := range entries {
+		if key, _, ok := strings.Cut(entry, "="); !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid variable %q, expected KEY=value", entry)
+		}
+		env = append(env, entry)
+	}
+	return env, nil
+}
+
+func parseLabels(entries []string) (map[string]string, error) {
+	var labels map[string]string
+	for _, entry := range entries {
+		key, val, ok := strings.Cut(entry, "=")
+		if key = strings.TrimSpace(key); !ok || key == "" {
+			return nil, fmt.Errorf("invalid label %q, expected key=value", entry)
+		}
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[key] = strings.TrimSpace(val)
+	}
+	return labels, nil
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/containerd/errdefs"
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/container"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"go.uber.org/zap"
+)
+
+// Inspect reads the docker inspect data of the container along with the registry digest of its image.
+func (s *ContainerService) Inspect(ctx context.Context, containerId string) (*dto.InspectResponse, error) {
+	inspect, err := s.dockerClient.Inspect(ctx, containerId)
+	if errdefs.IsNotFound(err) {
+		return nil, fmt.Errorf("%w: %s", ErrContainerNotFound, containerId)
+	}
+	if err != nil {
+		s.logger.Error("failed to inspect docker container", zap.Error(err))
+		return nil, err
+	}
+
+	resp := toInspectResponse(inspect)
+	if inspect.ContainerJSONBase != nil && inspect.Image != "" {
+		image, err := s.dockerClient.InspectImage(ctx, inspect.Image)
+		if err != nil {
+			s.logger.Warn("failed to inspect container image", zap.String("containerId", containerId), zap.Error(err))
+		} else {
+			resp.ImageDigest = imageDigest(resp.Image, image.RepoDigests)
+		}
+	}
+	return resp, nil
+}
+
+// Top lists the processes running in the container.
+func (s *ContainerService) Top(ctx context.Context, containerId string) (*dto.TopResponse, error) {
+	top, err := s.dockerClient.Top(ctx, containerId)
+	if errdefs.IsNotFound(err) {
+		return nil, fmt.Errorf("%w: %s", ErrContainerNotFound, containerId)
+	}
+	if errdefs.IsConflict(err) {
+		return nil, fmt.Errorf("%w: %s", ErrContainerNotRunning, containerId)
+	}
+	if err != nil {
+		s.logger.Error("failed to list container processes", zap.Error(err))
+		return nil, err
+	}
+	return &dto.TopResponse{Titles: top.Titles, Processes: top.Processes}, nil
+}
+
+func toInspectResponse(inspect container.InspectResponse) *dto.InspectResponse {
+	resp := &dto.InspectResponse{
+		Command:  []string{},
+		Mounts:   []dto.InspectMount{},
+		Networks: []dto.InspectNetwork{},
+	}
+	if inspect.ContainerJSONBase != nil {
+		resp.ContainerId = inspect.ID
+		resp.ImageId = inspect.Image
+		resp.RestartCount = inspect.RestartCount
+		if inspect.Path != "" {
+			resp.Command = append([]string{inspect.Path}, inspect.Args...)
+		}
+		if inspect.State != nil {
+			resp.State = string(inspect.State.Status)
+			resp.ExitCode = inspect.State.ExitCode
+			resp.OOMKilled = inspect.State.OOMKilled
+			resp.StartedAt = parseDockerTime(inspect.State.StartedAt)
+			resp.FinishedAt = parseDockerTime(inspect.State.FinishedAt)
+		}
+	}
+	if inspect.Config != nil {
+		resp.Image = inspect.Config.Image
+	}
+
+	for _, mount := range inspect.Mounts {
+		resp.Mounts = append(resp.Mounts, dto.InspectMount{
+			Type:        string(mount.Type),
+			Name:        mount.Name,
+			Source:      mount.Source,
+			Destination: mount.Destination,
+			ReadOnly:    !mount.RW,
+		})
+	}
+
+	if inspect.NetworkSettings != nil {
+		for _, name := range slices.Sorted(maps.Keys(inspect.NetworkSettings.Networks)) {
+			network := inspect.NetworkSettings.Networks[name]
+			if network == nil {
+				continue
+			}
+			resp.Networks = append(resp.Networks, dto.InspectNetwork{
+				Name:       name,
+				IPAddress:  network.IPAddress,
+				Gateway:    network.Gateway,
+				MacAddress: network.MacAddress,
+				Aliases:    network.Aliases,
+			})
+		}
+	}
+	return resp
+}
+
+// parseDockerTime reads a time of the container state, docker reporting the zero time for what did not happen yet.
+func parseDockerTime(value string) *time.Time {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil || t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// imageDigest picks the repository digest of the image the container was created from, the first one when the
+// image was pulled under another name.
+func imageDigest(imageName string, repoDigests []string) string {
+	if len(repoDigests) == 0 {
+		return ""
+	}
+	named, err := reference.ParseNormalizedNamed(imageName)
+	if err != nil {
+		return repoDigests[0]
+	}
+	for _, repoDigest := range repoDigests {
+		digested, err := reference.ParseNormalizedNamed(repoDigest)
+		if err == nil && digested.Name() == named.Name() {
+			return repoDigest
+		}
+	}
+	return repoDigests[0]
+}
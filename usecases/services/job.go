@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/containerd/errdefs"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/google/uuid"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
+	"github.com/vnFuhung2903/vcs-sms/usecases/repositories"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// jobLease is how long a job stays claimed by a worker that stopped renewing it before another one may recover it.
+const jobLease = time.Minute
+
+// pullProgressInterval is how often a running pull saves its latest progress message, for the job to be polled.
+const pullProgressInterval = time.Second
+
+type IJobService interface {
+	Submit(ctx context.Context, jobType entities.JobType, ownerId string, items []entities.JobItem) (*entities.Job, error)
+	FindById(ctx context.Context, jobId string) (*entities.Job, error)
+	View(ctx context.Context, ownerId string) ([]*entities.Job, error)
+	Cancel(ctx context.Context, jobId string) error
+	Recover(ctx context.Context) error
+	RunNext(ctx context.Context) (bool, error)
+}
+
+type JobService struct {
+	jobRepo          repositories.IJobRepository
+	containerService IContainerService
+	imageService     IImageService
+	instanceId       string
+	lease            time.Duration
+	logger           logger.ILogger
+}
+
+func NewJobService(jobRepo repositories.IJobRepository, containerService IContainerService, imageService IImageService, logger logger.ILogger) IJobService {
+	return &JobService{
+		jobRepo:          jobRepo,
+		containerService: containerService,
+		imageService:     imageService,
+		instanceId:       uuid.New().String(),
+		lease:            jobLease,
+		logger:           logger,
+	}
+}
+
+// Submit queues the items for the job workers, items without an image name, or without a container name unless the
+// job pulls images, fail right away.
+func (s *JobService) Submit(ctx context.Context, jobType entities.JobType, ownerId string, items []entities.JobItem) (*entities.Job, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: job has no items", errdefs.ErrInvalidArgument)
+	}
+	for i := range items {
+		switch {
+		case items[i].Status == entities.JobSkipped:
+		case items[i].Error != "":
+			items[i].Status = entities.JobFailed
+		case jobType == entities.JobPull && items[i].ImageName == "":
+			items[i].Status = entities.JobFailed
+			items[i].Error = "image name is required"
+		case jobType != entities.JobPull && (items[i].ContainerName == "" || items[i].ImageName == ""):
+			items[i].Status = entities.JobFailed
+			items[i].Error = "container name and image name are required"
+		default:
+			items[i].Status = entities.JobPending
+		}
+	}
+
+	job, err := s.jobRepo.Create(jobType, ownerId, items)
+	if err != nil {
+		s.logger.Error("failed to create job", zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info("job submitted successfully", zap.String("jobId", job.ID), zap.Int("items", len(items)))
+	return job, nil
+}
+
+func (s *JobService) FindById(ctx context.Context, jobId string) (*entities.Job, error) {
+	job, err := s.jobRepo.FindById(jobId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, jobId)
+	}
+	if err != nil {
+		s.logger.Error("failed to find job by id", zap.Error(err))
+		return nil, err
+	}
+	return job, nil
+}
+
+// View lists the jobs of an owner, or every job when ownerId is empty.
+func (s *JobService) View(ctx context.Context, ownerId string) ([]*entities.Job, error) {
+	jobs, err := s.jobRepo.View(ownerId, "")
+	if err != nil {
+		s.logger.Error("failed to view jobs", zap.Error(err))
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// Cancel cancels a pending job at once; a running job stops before its next item, the current one still completes.
+func (s *JobService) Cancel(ctx context.Context, jobId string) error {
+	requested, err := s.jobRepo.RequestCancel(jobId)
+	if err != nil {
+		s.logger.Error("failed to cancel job", zap.Error(err))
+		return err
+	}
+	if !requested {
+		if _, err := s.FindById(ctx, jobId); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %s", ErrJobFinished, jobId)
+	}
+
+	if _, err := s.jobRepo.CancelPending(jobId, time.Now()); err != nil {
+		s.logger.Error("failed to cancel job", zap.Error(err))
+		return err
+	}
+	s.logger.Info("job cancellation requested", zap.String("jobId", jobId))
+	return nil
+}
+
+// Recover requeues the running jobs whose lease expired, their worker having crashed or been shut down.
+// The item in progress when it stopped may or may not have created its container, so it is failed rather than retried.
+func (s *JobService) Recover(ctx context.Context) error {
+	claimedBefore := time.Now().Add(-s.lease)
+	jobs, err := s.jobRepo.ViewExpired(claimedBefore)
+	if err != nil {
+		s.logger.Error("failed to view jobs", zap.Error(err))
+		return err
+	}
+
+	for _, job := range jobs {
+		for i := range job.Items {
+			if job.Items[i].Status == entities.JobRunning {
+				job.Items[i].Status = entities.JobFailed
+				job.Items[i].Error = "interrupted by a restart"
+			}
+		}
+		requeued, err := s.jobRepo.Requeue(job, claimedBefore)
+		if err != nil {
+			s.logger.Error("failed to requeue job", zap.String("jobId", job.ID), zap.Error(err))
+			return err
+		}
+		if requeued {
+			s.logger.Warn("interrupted job requeued", zap.String("jobId", job.ID))
+		}
+	}
+	return nil
+}
+
+// RunNext claims the oldest pending job and processes it, reporting whether there was one.
+func (s *JobService) RunNext(ctx context.Context) (bool, error) {
+	job, err := s.jobRepo.Claim(s.instanceId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		s.logger.Error("failed to claim job", zap.Error(err))
+		return false, err
+	}
+
+	s.logger.Info("job started", zap.String("jobId", job.ID))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go s.renew(ctx, cancel, job.ID)
+	s.run(ctx, job)
+	return true, nil
+}
+
+// renew keeps the lease on the job while it runs, and stops the run once the job was requeued away from it.
+func (s *JobService) renew(ctx context.Context, stop context.CancelFunc, jobId string) {
+	ticker := time.NewTicker(s.lease / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			renewed, err := s.jobRepo.Renew(jobId, s.instanceId, time.Now())
+			if err != nil {
+				s.logger.Error("failed to renew job lease", zap.String("jobId", jobId), zap.Error(err))
+				continue
+			}
+			if !renewed {
+				s.logger.Warn("job lease lost", zap.String("jobId", jobId))
+				stop()
+				return
+			}
+		}
+	}
+}
+
+func (s *JobService) run(ctx context.Context, job *entities.Job) {
+	for i := range job.Items {
+		item := &job.Items[i]
+		if item.Status.IsFinished() {
+			continue
+		}
+		// On shutdown the job stays running, Recover requeues it once its lease expires.
+		if ctx.Err() != nil {
+			return
+		}
+		if current, err := s.jobRepo.FindById(job.ID); err != nil {
+			s.logger.Error("failed to find job by id", zap.Error(err))
+		} else if current.CancelRequested {
+			s.finish(job, entities.JobCanceled)
+			return
+		}
+
+		item.Status = entities.JobRunning
+		s.save(job)
+
+		err := s.runItem(ctx, job, item)
+		if err != nil && ctx.Err() != nil {
+			item.Status = entities.JobPending
+			s.save(job)
+			return
+		}
+		if err != nil {
+			item.Status = entities.JobFailed
+			item.Error = err.Error()
+		} else {
+			item.Status = entities.JobSucceeded
+		}
+		s.save(job)
+	}
+
+	status := entities.JobFailed
+	for _, item := range job.Items {
+		if item.Status != entities.JobFailed {
+			status = entities.JobSucceeded
+			break
+		}
+	}
+	s.finish(job, status)
+}
+
+// runItem pulls the image of the item for pull jobs, and creates the container of the item otherwise.
+func (s *JobService) runItem(ctx context.Context, job *entities.Job, item *entities.JobItem) error {
+	if job.Type == entities.JobPull {
+		return s.pull(ctx, job, item)
+	}
+
+	container, err := s.containerService.Create(ctx, item.ContainerName, item.ImageName, item.Spec, job.OwnerId, item.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	item.ContainerId = container.ContainerId
+	return nil
+}
+
+// pull reads the docker progress stream of the pull to its end, keeping its latest message on the item. Docker reports
+// a failed pull as an error message in the stream rather than as an error of the request.
+func (s *JobService) pull(ctx context.Context, job *entities.Job, item *entities.JobItem) error {
+	progress, err := s.imageService.Pull(ctx, item.ImageName)
+	if err != nil {
+		return err
+	}
+	defer progress.Close()
+
+	decoder := json.NewDecoder(progress)
+	saved := time.Now()
+	for {
+		var message jsonmessage.JSONMessage
+		if err := decoder.Decode(&message); err == io.EOF {
+			break
+		} else if err != nil {
+			s.logger.Error("failed to decode pull progress", zap.Error(err))
+			return err
+		}
+		if message.Error != nil {
+			return message.Error
+		}
+
+		item.Progress = pullProgress(message)
+		if time.Since(saved) >= pullProgressInterval {
+			s.save(job)
+			saved = time.Now()
+		}
+	}
+	s.logger.Info("image pulled successfully", zap.String("image", item.ImageName))
+	return nil
+}
+
+// pullProgress formats a progress message the way the docker CLI shows it, the layer first and the percentage last.
+func pullProgress(message jsonmessage.JSONMessage) string {
+	text := message.Status
+	if message.ID != "" {
+		text = message.ID + ": " + text
+	}
+	if p := message.Progress; p != nil && p.Total > 0 {
+		text += fmt.Sprintf(" %d%%", p.Current*100/p.Total)
+	}
+	return text
+}
+
+func (s *JobService) finish(job *entities.Job, status entities.JobStatus) {
+	for i := range job.Items {
+		if !job.Items[i].Status.IsFinished() {
+			job.Items[i].Status = entities.JobCanceled
+		}
+	}
+	finishedAt := time.Now()
+	job.Status = status
+	job.FinishedAt = &finishedAt
+	s.save(job)
+	s.logger.Info("job finished", zap.String("jobId", job.ID), zap.String("status", string(status)))
+}
+
+func (s *JobService) save(job *entities.Job) {
+	if err := s.jobRepo.Update(job); err != nil {
+		s.logger.Error("failed to update job", zap.String("jobId", job.ID), zap.Error(err))
+	}
+}
@@ -0,0 +1,455 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containerd/errdefs"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
+	"github.com/vnFuhung2903/vcs-sms/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+)
+
+type JobServiceSuite struct {
+	suite.Suite
+	ctrl                 *gomock.Controller
+	jobService           IJobService
+	mockJobRepo          *repositories.MockIJobRepository
+	mockContainerService *services.MockIContainerService
+	mockImageService     *services.MockIImageService
+	logger               *logger.MockILogger
+	ctx                  context.Context
+}
+
+func (s *JobServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockJobRepo = repositories.NewMockIJobRepository(s.ctrl)
+	s.mockContainerService = services.NewMockIContainerService(s.ctrl)
+	s.mockImageService = services.NewMockIImageService(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.jobService = NewJobService(s.mockJobRepo, s.mockContainerService, s.mockImageService, s.logger)
+	s.ctx = context.Background()
+}
+
+func (s *JobServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestJobServiceSuite(t *testing.T) {
+	suite.Run(t, new(JobServiceSuite))
+}
+
+func (s *JobServiceSuite) TestSubmit() {
+	s.mockJobRepo.EXPECT().
+		Create(entities.JobImport, "user-id", []entities.JobItem{
+			{ContainerName: "web", ImageName: "nginx", Status: entities.JobPending},
+			{ContainerName: "", ImageName: "nginx", Status: entities.JobFailed, Error: "container name and image name are required"},
+			{ContainerName: "db", ImageName: "postgres", Status: entities.JobSkipped},
+			{ContainerName: "bad name", ImageName: "nginx", Status: entities.JobFailed, Error: "invalid container name"},
+		}).
+		Return(&entities.Job{ID: "job-id"}, nil)
+	s.logger.EXPECT().Info("job submitted successfully", gomock.Any(), gomock.Any()).Times(1)
+
+	job, err := s.jobService.Submit(s.ctx, entities.JobImport, "user-id", []entities.JobItem{
+		{ContainerName: "web", ImageName: "nginx"},
+		{ImageName: "nginx"},
+		{ContainerName: "db", ImageName: "postgres", Status: entities.JobSkipped},
+		{ContainerName: "bad name", ImageName: "nginx", Error: "invalid container name"},
+	})
+	s.NoError(err)
+	s.Equal("job-id", job.ID)
+}
+
+func (s *JobServiceSuite) TestSubmitPull() {
+	s.mockJobRepo.EXPECT().
+		Create(entities.JobPull, "user-id", []entities.JobItem{
+			{ImageName: "nginx:1.27", Status: entities.JobPending},
+			{Status: entities.JobFailed, Error: "image name is required"},
+		}).
+		Return(&entities.Job{ID: "job-id"}, nil)
+	s.logger.EXPECT().Info("job submitted successfully", gomock.Any(), gomock.Any()).Times(1)
+
+	_, err := s.jobService.Submit(s.ctx, entities.JobPull, "user-id", []entities.JobItem{{ImageName: "nginx:1.27"}, {}})
+	s.NoError(err)
+}
+
+func (s *JobServiceSuite) TestSubmitNoItems() {
+	_, err := s.jobService.Submit(s.ctx, entities.JobImport, "user-id", nil)
+	s.True(errdefs.IsInvalidArgument(err))
+}
+
+func (s *JobServiceSuite) TestSubmitRepoError() {
+	s.mockJobRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to create job", gomock.Any()).Times(1)
+
+	_, err := s.jobService.Submit(s.ctx, entities.JobCreate, "user-id", []entities.JobItem{{ContainerName: "web", ImageName: "nginx"}})
+	s.Error(err)
+}
+
+func (s *JobServiceSuite) TestFindById() {
+	s.mockJobRepo.EXPECT().FindById("job-id").Return(&entities.Job{ID: "job-id"}, nil)
+
+	job, err := s.jobService.FindById(s.ctx, "job-id")
+	s.NoError(err)
+	s.Equal("job-id", job.ID)
+}
+
+func (s *JobServiceSuite) TestFindByIdNotFound() {
+	s.mockJobRepo.EXPECT().FindById("job-id").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := s.jobService.FindById(s.ctx, "job-id")
+	s.ErrorIs(err, ErrJobNotFound)
+}
+
+func (s *JobServiceSuite) TestFindByIdRepoError() {
+	s.mockJobRepo.EXPECT().FindById("job-id").Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find job by id", gomock.Any()).Times(1)
+
+	_, err := s.jobService.FindById(s.ctx, "job-id")
+	s.Error(err)
+}
+
+func (s *JobServiceSuite) TestView() {
+	s.mockJobRepo.EXPECT().View("user-id", entities.JobStatus("")).Return([]*entities.Job{{ID: "job-id"}}, nil)
+
+	jobs, err := s.jobService.View(s.ctx, "user-id")
+	s.NoError(err)
+	s.Len(jobs, 1)
+}
+
+func (s *JobServiceSuite) TestViewRepoError() {
+	s.mockJobRepo.EXPECT().View("", entities.JobStatus("")).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to view jobs", gomock.Any()).Times(1)
+
+	_, err := s.jobService.View(s.ctx, "")
+	s.Error(err)
+}
+
+func (s *JobServiceSuite) TestCancel() {
+	s.mockJobRepo.EXPECT().RequestCancel("job-id").Return(true, nil)
+	s.mockJobRepo.EXPECT().CancelPending("job-id", gomock.Any()).Return(true, nil)
+	s.logger.EXPECT().Info("job cancellation requested", gomock.Any()).Times(1)
+
+	err := s.jobService.Cancel(s.ctx, "job-id")
+	s.NoError(err)
+}
+
+func (s *JobServiceSuite) TestCancelFinished() {
+	s.mockJobRepo.EXPECT().RequestCancel("job-id").Return(false, nil)
+	s.mockJobRepo.EXPECT().FindById("job-id").Return(&entities.Job{ID: "job-id", Status: entities.JobSucceeded}, nil)
+
+	err := s.jobService.Cancel(s.ctx, "job-id")
+	s.ErrorIs(err, ErrJobFinished)
+}
+
+func (s *JobServiceSuite) TestCancelNotFound() {
+	s.mockJobRepo.EXPECT().RequestCancel("job-id").Return(false, nil)
+	s.mockJobRepo.EXPECT().FindById("job-id").Return(nil, gorm.ErrRecordNotFound)
+
+	err := s.jobService.Cancel(s.ctx, "job-id")
+	s.ErrorIs(err, ErrJobNotFound)
+}
+
+func (s *JobServiceSuite) TestCancelRepoError() {
+	s.mockJobRepo.EXPECT().RequestCancel("job-id").Return(false, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to cancel job", gomock.Any()).Times(1)
+
+	err := s.jobService.Cancel(s.ctx, "job-id")
+	s.Error(err)
+}
+
+func (s *JobServiceSuite) TestRecover() {
+	s.mockJobRepo.EXPECT().ViewExpired(gomock.Any()).Return([]*entities.Job{{
+		ID:     "job-id",
+		Status: entities.JobRunning,
+		Items: []entities.JobItem{
+			{ContainerName: "web", Status: entities.JobSucceeded},
+			{ContainerName: "db", Status: entities.JobRunning},
+			{ContainerName: "cache", Status: entities.JobPending},
+		},
+	}}, nil)
+	s.mockJobRepo.EXPECT().Requeue(gomock.Any(), gomock.Any()).DoAndReturn(func(job *entities.Job, claimedBefore time.Time) (bool, error) {
+		s.WithinDuration(time.Now().Add(-jobLease), claimedBefore, time.Second)
+		s.Equal(entities.JobSucceeded, job.Items[0].Status)
+		s.Equal(entities.JobFailed, job.Items[1].Status)
+		s.Equal("interrupted by a restart", job.Items[1].Error)
+		s.Equal(entities.JobPending, job.Items[2].Status)
+		return true, nil
+	})
+	s.logger.EXPECT().Warn("interrupted job requeued", gomock.Any()).Times(1)
+
+	err := s.jobService.Recover(s.ctx)
+	s.NoError(err)
+}
+
+func (s *JobServiceSuite) TestRecoverRenewedMeanwhile() {
+	s.mockJobRepo.EXPECT().ViewExpired(gomock.Any()).Return([]*entities.Job{{ID: "job-id", Status: entities.JobRunning}}, nil)
+	s.mockJobRepo.EXPECT().Requeue(gomock.Any(), gomock.Any()).Return(false, nil)
+
+	err := s.jobService.Recover(s.ctx)
+	s.NoError(err)
+}
+
+func (s *JobServiceSuite) TestRecoverRequeueError() {
+	s.mockJobRepo.EXPECT().ViewExpired(gomock.Any()).Return([]*entities.Job{{ID: "job-id", Status: entities.JobRunning}}, nil)
+	s.mockJobRepo.EXPECT().Requeue(gomock.Any(), gomock.Any()).Return(false, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to requeue job", gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.jobService.Recover(s.ctx)
+	s.Error(err)
+}
+
+func (s *JobServiceSuite) TestRecoverRepoError() {
+	s.mockJobRepo.EXPECT().ViewExpired(gomock.Any()).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to view jobs", gomock.Any()).Times(1)
+
+	err := s.jobService.Recover(s.ctx)
+	s.Error(err)
+}
+
+func (s *JobServiceSuite) TestRunNextEmptyQueue() {
+	s.mockJobRepo.EXPECT().Claim(gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+
+	ran, err := s.jobService.RunNext(s.ctx)
+	s.NoError(err)
+	s.False(ran)
+}
+
+func (s *JobServiceSuite) TestRunNextClaimError() {
+	s.mockJobRepo.EXPECT().Claim(gomock.Any()).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to claim job", gomock.Any()).Times(1)
+
+	ran, err := s.jobService.RunNext(s.ctx)
+	s.Error(err)
+	s.False(ran)
+}
+
+func (s *JobServiceSuite) TestRunNext() {
+	expiresAt := time.Now().Add(time.Hour)
+	job := &entities.Job{
+		ID:      "job-id",
+		Status:  entities.JobRunning,
+		OwnerId: "user-id",
+		Items: []entities.JobItem{
+			{ContainerName: "web", ImageName: "nginx", ExpiresAt: &expiresAt, Status: entities.JobPending},
+			{ContainerName: "bad", ImageName: "nginx", Status: entities.JobPending},
+			{ContainerName: "", ImageName: "nginx", Status: entities.JobFailed},
+		},
+	}
+	s.mockJobRepo.EXPECT().Claim(gomock.Any()).Return(job, nil)
+	s.mockJobRepo.EXPECT().FindById("job-id").Return(&entities.Job{ID: "job-id"}, nil).Times(2)
+	s.mockJobRepo.EXPECT().Update(job).Return(nil).Times(5)
+	s.mockContainerService.EXPECT().
+		Create(gomock.Any(), "web", "nginx", entities.ContainerSpec{}, "user-id", &expiresAt).
+		Return(&entities.Container{ContainerId: "container-id"}, nil)
+	s.mockContainerService.EXPECT().
+		Create(gomock.Any(), "bad", "nginx", entities.ContainerSpec{}, "user-id", (*time.Time)(nil)).
+		Return(nil, ErrQuotaExceeded)
+	s.logger.EXPECT().Info("job started", gomock.Any()).Times(1)
+	s.logger.EXPECT().Info("job finished", gomock.Any(), gomock.Any()).Times(1)
+
+	ran, err := s.jobService.RunNext(s.ctx)
+	s.NoError(err)
+	s.True(ran)
+	s.Equal(entities.JobSucceeded, job.Status)
+	s.NotNil(job.FinishedAt)
+	s.Equal(entities.JobSucceeded, job.Items[0].Status)
+	s.Equal("container-id", job.Items[0].ContainerId)
+	s.Equal(entities.JobFailed, job.Items[1].Status)
+	s.Equal(ErrQuotaExceeded.Error(), job.Items[1].Error)
+}
+
+func (s *JobServiceSuite) TestRunNextAllFailed() {
+	job := &entities.Job{
+		ID:    "job-id",
+		Items: []entities.JobItem{{ContainerName: "web", ImageName: "nginx", Status: entities.JobPending}},
+	}
+	s.mockJobRepo.EXPECT().Claim(gomock.Any()).Return(job, nil)
+	s.mockJobRepo.EXPECT().FindById("job-id").Return(&entities.Job{ID: "job-id"}, nil)
+	s.mockJobRepo.EXPECT().Update(job).Return(nil).Times(3)
+	s.mockContainerService.EXPECT().Create(gomock.Any(), "web", "nginx", gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("docker error"))
+	s.logger.EXPECT().Info("job started", gomock.Any()).Times(1)
+	s.logger.EXPECT().Info("job finished", gomock.Any(), gomock.Any()).Times(1)
+
+	_, err := s.jobService.RunNext(s.ctx)
+	s.NoError(err)
+	s.Equal(entities.JobFailed, job.Status)
+}
+
+func (s *JobServiceSuite) TestRunNextPull() {
+	job := &entities.Job{
+		ID:    "job-id",
+		Type:  entities.JobPull,
+		Items: []entities.JobItem{{ImageName: "nginx:1.27", Status: entities.JobPending}},
+	}
+	progress := `{"status":"Pulling from library/nginx","id":"1.27"}
+{"status":"Downloading","progressDetail":{"current":512,"total":2048},"id":"a1b2c3"}
+{"status":"Status: Downloaded newer image for nginx:1.27"}
+`
+	s.mockJobRepo.EXPECT().Claim(gomock.Any()).Return(job, nil)
+	s.mockJobRepo.EXPECT().FindById("job-id").Return(&entities.Job{ID: "job-id"}, nil)
+	s.mockJobRepo.EXPECT().Update(job).Return(nil).MinTimes(3)
+	s.mockImageService.EXPECT().Pull(gomock.Any(), "nginx:1.27").Return(io.NopCloser(strings.NewReader(progress)), nil)
+	s.logger.EXPECT().Info("job started", gomock.Any()).Times(1)
+	s.logger.EXPECT().Info("image pulled successfully", gomock.Any()).Times(1)
+	s.logger.EXPECT().Info("job finished", gomock.Any(), gomock.Any()).Times(1)
+
+	_, err := s.jobService.RunNext(s.ctx)
+	s.NoError(err)
+	s.Equal(entities.JobSucceeded, job.Status)
+	s.Equal(entities.JobSucceeded, job.Items[0].Status)
+	s.Equal("Status: Downloaded newer image for nginx:1.27", job.Items[0].Progress)
+}
+
+func (s *JobServiceSuite) TestRunNextPullFailed() {
+	cases := []struct {
+		progress io.ReadCloser
+		err      error
+		message  string
+	}{
+		{nil, fmt.Errorf("%w: nginx:1.27", ErrImageNotFound), ErrImageNotFound.Error() + ": nginx:1.27"},
+		{io.NopCloser(strings.NewReader(`{"status":"Pulling from library/nginx"}` + "\n" + `{"errorDetail":{"message":"manifest unknown"},"error":"manifest unknown"}` + "\n")), nil, "manifest unknown"},
+	}
+	for _, tc := range cases {
+		job := &entities.Job{
+			ID:    "job-id",
+			Type:  entities.JobPull,
+			Items: []entities.JobItem{{ImageName: "nginx:1.27", Status: entities.JobPending}},
+		}
+		s.mockJobRepo.EXPECT().Claim(gomock.Any()).Return(job, nil)
+		s.mockJobRepo.EXPECT().FindById("job-id").Return(&entities.Job{ID: "job-id"}, nil)
+		s.mockJobRepo.EXPECT().Update(job).Return(nil).MinTimes(3)
+		s.mockImageService.EXPECT().Pull(gomock.Any(), "nginx:1.27").Return(tc.progress, tc.err)
+		s.logger.EXPECT().Info("job started", gomock.Any()).Times(1)
+		s.logger.EXPECT().Info("job finished", gomock.Any(), gomock.Any()).Times(1)
+
+		_, err := s.jobService.RunNext(s.ctx)
+		s.NoError(err)
+		s.Equal(entities.JobFailed, job.Status)
+		s.Equal(tc.message, job.Items[0].Error)
+	}
+}
+
+func (s *JobServiceSuite) TestRunNextPullDecodeError() {
+	job := &entities.Job{
+		ID:    "job-id",
+		Type:  entities.JobPull,
+		Items: []entities.JobItem{{ImageName: "nginx:1.27", Status: entities.JobPending}},
+	}
+	s.mockJobRepo.EXPECT().Claim(gomock.Any()).Return(job, nil)
+	s.mockJobRepo.EXPECT().FindById("job-id").Return(&entities.Job{ID: "job-id"}, nil)
+	s.mockJobRepo.EXPECT().Update(job).Return(nil).MinTimes(3)
+	s.mockImageService.EXPECT().Pull(gomock.Any(), "nginx:1.27").Return(io.NopCloser(strings.NewReader("not json")), nil)
+	s.logger.EXPECT().Info("job started", gomock.Any()).Times(1)
+	s.logger.EXPECT().Error("failed to decode pull progress", gomock.Any()).Times(1)
+	s.logger.EXPECT().Info("job finished", gomock.Any(), gomock.Any()).Times(1)
+
+	_, err := s.jobService.RunNext(s.ctx)
+	s.NoError(err)
+	s.Equal(entities.JobFailed, job.Items[0].Status)
+}
+
+func (s *JobServiceSuite) TestRunNextCanceled() {
+	job := &entities.Job{
+		ID: "job-id",
+		Items: []entities.JobItem{
+			{ContainerName: "web", ImageName: "nginx", Status: entities.JobSucceeded},
+			{ContainerName: "db", ImageName: "postgres", Status: entities.JobPending},
+		},
+	}
+	s.mockJobRepo.EXPECT().Claim(gomock.Any()).Return(job, nil)
+	s.mockJobRepo.EXPECT().FindById("job-id").Return(&entities.Job{ID: "job-id", CancelRequested: true}, nil)
+	s.mockJobRepo.EXPECT().Update(job).Return(nil)
+	s.logger.EXPECT().Info("job started", gomock.Any()).Times(1)
+	s.logger.EXPECT().Info("job finished", gomock.Any(), gomock.Any()).Times(1)
+
+	_, err := s.jobService.RunNext(s.ctx)
+	s.NoError(err)
+	s.Equal(entities.JobCanceled, job.Status)
+	s.Equal(entities.JobSucceeded, job.Items[0].Status)
+	s.Equal(entities.JobCanceled, job.Items[1].Status)
+}
+
+func (s *JobServiceSuite) TestRunNextInterrupted() {
+	ctx, cancel := context.WithCancel(s.ctx)
+	job := &entities.Job{
+		ID:     "job-id",
+		Status: entities.JobRunning,
+		Items:  []entities.JobItem{{ContainerName: "web", ImageName: "nginx", Status: entities.JobPending}},
+	}
+	s.mockJobRepo.EXPECT().Claim(gomock.Any()).Return(job, nil)
+	s.mockJobRepo.EXPECT().FindById("job-id").Return(&entities.Job{ID: "job-id"}, nil)
+	s.mockJobRepo.EXPECT().Update(job).Return(nil).Times(2)
+	s.mockContainerService.EXPECT().
+		Create(gomock.Any(), "web", "nginx", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, string, string, entities.ContainerSpec, string, *time.Time) (*entities.Container, error) {
+			cancel()
+			return nil, context.Canceled
+		})
+	s.logger.EXPECT().Info("job started", gomock.Any()).Times(1)
+
+	_, err := s.jobService.RunNext(ctx)
+	s.NoError(err)
+	s.Equal(entities.JobRunning, job.Status)
+	s.Equal(entities.JobPending, job.Items[0].Status)
+}
+
+func (s *JobServiceSuite) TestRunNextLeaseLost() {
+	jobService := &JobService{jobRepo: s.mockJobRepo, containerService: s.mockContainerService, instanceId: "instance-id", lease: 30 * time.Millisecond, logger: s.logger}
+	job := &entities.Job{
+		ID:     "job-id",
+		Status: entities.JobRunning,
+		Items:  []entities.JobItem{{ContainerName: "web", ImageName: "nginx", Status: entities.JobPending}},
+	}
+	s.mockJobRepo.EXPECT().Claim("instance-id").Return(job, nil)
+	s.mockJobRepo.EXPECT().FindById("job-id").Return(&entities.Job{ID: "job-id"}, nil)
+	s.mockJobRepo.EXPECT().Update(job).Return(nil).Times(2)
+	s.mockJobRepo.EXPECT().Renew("job-id", "instance-id", gomock.Any()).Return(false, nil)
+	s.mockContainerService.EXPECT().
+		Create(gomock.Any(), "web", "nginx", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ string, _ string, _ entities.ContainerSpec, _ string, _ *time.Time) (*entities.Container, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	s.logger.EXPECT().Info("job started", gomock.Any()).Times(1)
+	s.logger.EXPECT().Warn("job lease lost", gomock.Any()).Times(1)
+
+	ran, err := jobService.RunNext(s.ctx)
+	s.NoError(err)
+	s.True(ran)
+	s.Equal(entities.JobPending, job.Items[0].Status)
+}
+
+func (s *JobServiceSuite) TestRunNextRenewsLease() {
+	jobService := &JobService{jobRepo: s.mockJobRepo, containerService: s.mockContainerService, instanceId: "instance-id", lease: 30 * time.Millisecond, logger: s.logger}
+	job := &entities.Job{
+		ID:    "job-id",
+		Items: []entities.JobItem{{ContainerName: "web", ImageName: "nginx", Status: entities.JobPending}},
+	}
+	s.mockJobRepo.EXPECT().Claim("instance-id").Return(job, nil)
+	s.mockJobRepo.EXPECT().FindById("job-id").Return(&entities.Job{ID: "job-id"}, nil)
+	s.mockJobRepo.EXPECT().Update(job).Return(nil).Times(3)
+	s.mockJobRepo.EXPECT().Renew("job-id", "instance-id", gomock.Any()).Return(true, nil).MinTimes(1)
+	s.mockContainerService.EXPECT().
+		Create(gomock.Any(), "web", "nginx", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, string, string, entities.ContainerSpec, string, *time.Time) (*entities.Container, error) {
+			time.Sleep(50 * time.Millisecond)
+			return &entities.Container{ContainerId: "container-id"}, nil
+		})
+	s.logger.EXPECT().Info("job started", gomock.Any()).Times(1)
+	s.logger.EXPECT().Info("job finished", gomock.Any(), gomock.Any()).Times(1)
+
+	_, err := jobService.RunNext(s.ctx)
+	s.NoError(err)
+	s.Equal(entities.JobSucceeded, job.Status)
+}
@@ -0,0 +1,269 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/elastic/go-elasticsearch/esapi"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/interfaces"
+	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const metricsIndexName = "sms_container_metrics"
+
+type IMetricsService interface {
+	IndexMetrics(ctx context.Context, metricsList []dto.EsMetricsUpdate) error
+	GetMetrics(ctx context.Context, containerId string, from time.Time, to time.Time, step time.Duration) ([]dto.MetricsPoint, error)
+}
+
+type MetricsService struct {
+	esClient interfaces.IElasticsearchClient
+	logger   logger.ILogger
+}
+
+func NewMetricsService(esClient interfaces.IElasticsearchClient, logger logger.ILogger) IMetricsService {
+	return &MetricsService{
+		esClient: esClient,
+		logger:   logger,
+	}
+}
+
+func (s *MetricsService) IndexMetrics(ctx context.Context, metricsList []dto.EsMetricsUpdate) error {
+	var buf bytes.Buffer
+	timestamp := time.Now()
+
+	for _, metrics := range metricsList {
+		meta := map[string]map[string]string{
+			"index": {"_index": metricsIndexName},
+		}
+		metaLine, err := json.Marshal(meta)
+		if err != nil {
+			s.logger.Error("failed to marshal meta", zap.Error(err))
+			continue
+		}
+
+		docLine, err := json.Marshal(toEsMetrics(metrics.ContainerId, metrics.Stats, timestamp))
+		if err != nil {
+			s.logger.Error("failed to marshal doc", zap.Error(err))
+			continue
+		}
+
+		buf.Write(metaLine)
+		buf.WriteByte('\n')
+		buf.Write(docLine)
+		buf.WriteByte('\n')
+	}
+
+	req := esapi.BulkRequest{
+		Body: bytes.NewReader(buf.Bytes()),
+	}
+	res, err := s.esClient.Do(ctx, req)
+	if err != nil {
+		s.logger.Error("failed to bulk elasticsearch metrics", zap.Error(err))
+		return err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		err := fmt.Errorf("elasticsearch bulk failed: %s", res.Status())
+		s.logger.Error("failed to bulk elasticsearch metrics", zap.Error(err))
+		return err
+	}
+
+	// A bulk request succeeds as a whole even when some of its documents are rejected.
+	var parsed struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int `json:"status"`
+			Error  struct {
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&parsed); err != nil {
+		s.logger.Error("failed to decode response body", zap.Error(err))
+		return err
+	}
+	if parsed.Errors {
+		failed, reason := 0, ""
+		for _, item := range parsed.Items {
+			for _, result := range item {
+				if result.Status < http.StatusMultipleChoices {
+					continue
+				}
+				failed++
+				if reason == "" {
+					reason = result.Error.Reason
+				}
+			}
+		}
+		err := fmt.Errorf("elasticsearch bulk failed for %d of %d metrics documents: %s", failed, len(parsed.Items), reason)
+		s.logger.Error("failed to bulk elasticsearch metrics", zap.Error(err))
+		return err
+	}
+	s.logger.Info("elasticsearch metrics indexed successfully", zap.Int("containers_count", len(metricsList)))
+	return nil
+}
+
+func (s *MetricsService) GetMetrics(ctx context.Context, containerId string, from time.Time, to time.Time, step time.Duration) ([]dto.MetricsPoint, error) {
+	query := map[string]interface{}{
+		"size": 0,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": []interface{}{
+					map[string]interface{}{"term": map[string]string{"container_id.keyword": containerId}},
+					map[string]interface{}{
+						"range": map[string]interface{}{
+							"timestamp": map[string]string{
+								"gte": from.Format(time.RFC3339),
+								"lt":  to.Format(time.RFC3339),
+							},
+						},
+					},
+				},
+			},
+		},
+		"aggs": map[string]interface{}{
+			"series": map[string]interface{}{
+				"date_histogram": map[string]interface{}{
+					"field":          "timestamp",
+					"fixed_interval": fmt.Sprintf("%ds", int64(step.Seconds())),
+				},
+				"aggs": map[string]interface{}{
+					"cpu_percent":  map[string]interface{}{"avg": map[string]string{"field": "cpu_percent"}},
+					"memory_usage": map[string]interface{}{"avg": map[string]string{"field": "memory_usage"}},
+					"memory_limit": map[string]interface{}{"max": map[string]string{"field": "memory_limit"}},
+					"network_rx":   map[string]interface{}{"max": map[string]string{"field": "network_rx"}},
+					"network_tx":   map[string]interface{}{"max": map[string]string{"field": "network_tx"}},
+					"block_read":   map[string]interface{}{"max": map[string]string{"field": "block_read"}},
+					"block_write":  map[string]interface{}{"max": map[string]string{"field": "block_write"}},
+				},
+			},
+		},
+	}
+	queryBody, _ := json.Marshal(query)
+
+	req := esapi.SearchRequest{
+		Index: []string{metricsIndexName},
+		Body:  strings.NewReader(string(queryBody)),
+	}
+	res, err := s.esClient.Do(ctx, req)
+	if err != nil {
+		s.logger.Error("failed to search elasticsearch metrics", zap.Error(err))
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		err := fmt.Errorf("elasticsearch search failed: %s", res.Status())
+		s.logger.Error("failed to search elasticsearch metrics", zap.Error(err))
+		return nil, err
+	}
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		s.logger.Error("failed to read response body", zap.Error(err))
+		return nil, err
+	}
+
+	type aggValue struct {
+		Value *float64 `json:"value"`
+	}
+	var parsed struct {
+		Aggregations struct {
+			Series struct {
+				Buckets []struct {
+					Key         int64    `json:"key"`
+					DocCount    int64    `json:"doc_count"`
+					CpuPercent  aggValue `json:"cpu_percent"`
+					MemoryUsage aggValue `json:"memory_usage"`
+					MemoryLimit aggValue `json:"memory_limit"`
+					NetworkRx   aggValue `json:"network_rx"`
+					NetworkTx   aggValue `json:"network_tx"`
+					BlockRead   aggValue `json:"block_read"`
+					BlockWrite  aggValue `json:"block_write"`
+				} `json:"buckets"`
+			} `json:"series"`
+		} `json:"aggregations"`
+	}
+	if err := json.Unmarshal(bodyBytes, &parsed); err != nil {
+		s.logger.Error("failed to decode response body", zap.Error(err))
+		return nil, err
+	}
+
+	value := func(v aggValue) float64 {
+		if v.Value == nil {
+			return 0
+		}
+		return *v.Value
+	}
+
+	points := make([]dto.MetricsPoint, 0, len(parsed.Aggregations.Series.Buckets))
+	for _, bucket := range parsed.Aggregations.Series.Buckets {
+		if bucket.DocCount == 0 {
+			continue
+		}
+		points = append(points, dto.MetricsPoint{
+			Timestamp:   time.UnixMilli(bucket.Key).UTC(),
+			Samples:     bucket.DocCount,
+			CpuPercent:  value(bucket.CpuPercent),
+			MemoryUsage: value(bucket.MemoryUsage),
+			MemoryLimit: value(bucket.MemoryLimit),
+			NetworkRx:   value(bucket.NetworkRx),
+			NetworkTx:   value(bucket.NetworkTx),
+			BlockRead:   value(bucket.BlockRead),
+			BlockWrite:  value(bucket.BlockWrite),
+		})
+	}
+	s.logger.Info("elasticsearch metrics retrieved successfully", zap.String("containerId", containerId), zap.Int("points_count", len(points)))
+	return points, nil
+}
+
+// toEsMetrics derives a sample from the Docker stats API the same way `docker stats` does.
+func toEsMetrics(containerId string, stats container.StatsResponse, timestamp time.Time) dto.EsMetrics {
+	metrics := dto.EsMetrics{
+		ContainerId: containerId,
+		Timestamp:   timestamp,
+		MemoryLimit: stats.MemoryStats.Limit,
+	}
+
+	cpuDelta := float64(stats.CPUStats.CPUUsage.TotalUsage) - float64(stats.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(stats.CPUStats.SystemUsage) - float64(stats.PreCPUStats.SystemUsage)
+	onlineCpus := float64(stats.CPUStats.OnlineCPUs)
+	if onlineCpus == 0 {
+		onlineCpus = float64(len(stats.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if cpuDelta > 0 && systemDelta > 0 {
+		metrics.CpuPercent = cpuDelta / systemDelta * onlineCpus * 100
+	}
+
+	metrics.MemoryUsage = stats.MemoryStats.Usage
+	for _, key := range []string{"inactive_file", "total_inactive_file"} {
+		if inactive, ok := stats.MemoryStats.Stats[key]; ok && inactive < metrics.MemoryUsage {
+			metrics.MemoryUsage -= inactive
+			break
+		}
+	}
+
+	for _, network := range stats.Networks {
+		metrics.NetworkRx += network.RxBytes
+		metrics.NetworkTx += network.TxBytes
+	}
+
+	for _, entry := range stats.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			metrics.BlockRead += entry.Value
+		case "write":
+			metrics.BlockWrite += entry.Value
+		}
+	}
+	return metrics
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/elastic/go-elasticsearch/esapi"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/mocks/interfaces"
+	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
+)
+
+type MetricsServiceSuite struct {
+	suite.Suite
+	ctrl           *gomock.Controller
+	metricsService IMetricsService
+	mockEsClient   *interfaces.MockIElasticsearchClient
+	mockLogger     *logger.MockILogger
+	ctx            context.Context
+}
+
+func (s *MetricsServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockEsClient = interfaces.NewMockIElasticsearchClient(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
+	s.metricsService = NewMetricsService(s.mockEsClient, s.mockLogger)
+	s.ctx = context.Background()
+}
+
+func (s *MetricsServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestMetricsServiceSuite(t *testing.T) {
+	suite.Run(t, new(MetricsServiceSuite))
+}
+
+func (s *MetricsServiceSuite) TestIndexMetrics() {
+	metricsList := []dto.EsMetricsUpdate{
+		{ContainerId: "container1", Stats: container.StatsResponse{MemoryStats: container.MemoryStats{Usage: 1024, Limit: 4096}}},
+	}
+
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, req esapi.Request) (*esapi.Response, error) {
+		bulk, ok := req.(esapi.BulkRequest)
+		s.True(ok)
+		body, _ := io.ReadAll(bulk.Body)
+		s.Contains(string(body), `"_index":"sms_container_metrics"`)
+		s.Contains(string(body), `"container_id":"container1"`)
+		s.Contains(string(body), `"memory_usage":1024`)
+		return &esapi.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`{"took":1,"errors":false}`)),
+		}, nil
+	})
+	s.mockLogger.EXPECT().Info("elasticsearch metrics indexed successfully", gomock.Any())
+
+	err := s.metricsService.IndexMetrics(s.ctx, metricsList)
+	s.NoError(err)
+}
+
+func (s *MetricsServiceSuite) TestIndexMetricsBulkError() {
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).Return(nil, errors.New("bulk error"))
+	s.mockLogger.EXPECT().Error("failed to bulk elasticsearch metrics", gomock.Any())
+
+	err := s.metricsService.IndexMetrics(s.ctx, []dto.EsMetricsUpdate{{ContainerId: "container1"}})
+	s.ErrorContains(err, "bulk error")
+}
+
+func (s *MetricsServiceSuite) TestIndexMetricsResponseError() {
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).Return(&esapi.Response{
+		StatusCode: 400,
+		Body:       io.NopCloser(strings.NewReader(`{"error":"bad request"}`)),
+	}, nil)
+	s.mockLogger.EXPECT().Error("failed to bulk elasticsearch metrics", gomock.Any())
+
+	err := s.metricsService.IndexMetrics(s.ctx, []dto.EsMetricsUpdate{{ContainerId: "container1"}})
+	s.ErrorContains(err, "400")
+}
+
+func (s *MetricsServiceSuite) TestIndexMetricsItemErrors() {
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).Return(&esapi.Response{
+		StatusCode: 200,
+		Body: io.NopCloser(strings.NewReader(`{
+			"took": 1,
+			"errors": true,
+			"items": [
+				{"index": {"status": 201}},
+				{"index": {"status": 400, "error": {"type": "mapper_parsing_exception", "reason": "failed to parse field [cpu_percent]"}}}
+			]
+		}`)),
+	}, nil)
+	s.mockLogger.EXPECT().Error("failed to bulk elasticsearch metrics", gomock.Any())
+
+	err := s.metricsService.IndexMetrics(s.ctx, []dto.EsMetricsUpdate{{ContainerId: "container1"}, {ContainerId: "container2"}})
+	s.EqualError(err, "elasticsearch bulk failed for 1 of 2 metrics documents: failed to parse field [cpu_percent]")
+}
+
+func (s *MetricsServiceSuite) TestIndexMetricsDecodeError() {
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).Return(&esapi.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(strings.NewReader(`not json`)),
+	}, nil)
+	s.mockLogger.EXPECT().Error("failed to decode response body", gomock.Any())
+
+	err := s.metricsService.IndexMetrics(s.ctx, []dto.EsMetricsUpdate{{ContainerId: "container1"}})
+	s.Error(err)
+}
+
+func (s *MetricsServiceSuite) TestGetMetrics() {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, req esapi.Request) (*esapi.Response, error) {
+		search, ok := req.(esapi.SearchRequest)
+		s.True(ok)
+		s.Equal([]string{"sms_container_metrics"}, search.Index)
+		body, _ := io.ReadAll(search.Body)
+		s.Contains(string(body), `"fixed_interval":"300s"`)
+		return &esapi.Response{
+			StatusCode: 200,
+			Body: io.NopCloser(strings.NewReader(`{
+				"aggregations": {
+					"series": {
+						"buckets": [
+							{
+								"key": 1735689600000,
+								"doc_count": 30,
+								"cpu_percent": {"value": 12.5},
+								"memory_usage": {"value": 2048},
+								"memory_limit": {"value": 4096},
+								"network_rx": {"value": 100},
+								"network_tx": {"value": 200},
+								"block_read": {"value": 300},
+								"block_write": {"value": 400}
+							},
+							{
+								"key": 1735689900000,
+								"doc_count": 0,
+								"cpu_percent": {"value": null}
+							}
+						]
+					}
+				}
+			}`)),
+		}, nil
+	})
+	s.mockLogger.EXPECT().Info("elasticsearch metrics retrieved successfully", gomock.Any(), gomock.Any())
+
+	points, err := s.metricsService.GetMetrics(s.ctx, "container1", from, to, 5*time.Minute)
+	s.NoError(err)
+	s.Equal([]dto.MetricsPoint{{
+		Timestamp:   from,
+		Samples:     30,
+		CpuPercent:  12.5,
+		MemoryUsage: 2048,
+		MemoryLimit: 4096,
+		NetworkRx:   100,
+		NetworkTx:   200,
+		BlockRead:   300,
+		BlockWrite:  400,
+	}}, points)
+}
+
+func (s *MetricsServiceSuite) TestGetMetricsSearchError() {
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).Return(nil, errors.New("search error"))
+	s.mockLogger.EXPECT().Error("failed to search elasticsearch metrics", gomock.Any())
+
+	_, err := s.metricsService.GetMetrics(s.ctx, "container1", time.Now().Add(-time.Hour), time.Now(), time.Minute)
+	s.ErrorContains(err, "search error")
+}
+
+func (s *MetricsServiceSuite) TestGetMetricsResponseError() {
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).Return(&esapi.Response{
+		StatusCode: 404,
+		Body:       io.NopCloser(strings.NewReader(`{"error":"index_not_found_exception"}`)),
+	}, nil)
+	s.mockLogger.EXPECT().Error("failed to search elasticsearch metrics", gomock.Any())
+
+	_, err := s.metricsService.GetMetrics(s.ctx, "container1", time.Now().Add(-time.Hour), time.Now(), time.Minute)
+	s.ErrorContains(err, "404")
+}
+
+func (s *MetricsServiceSuite) TestGetMetricsDecodeError() {
+	s.mockEsClient.EXPECT().Do(s.ctx, gomock.Any()).Return(&esapi.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(strings.NewReader(`invalid`)),
+	}, nil)
+	s.mockLogger.EXPECT().Error("failed to decode response body", gomock.Any())
+
+	_, err := s.metricsService.GetMetrics(s.ctx, "container1", time.Now().Add(-time.Hour), time.Now(), time.Minute)
+	s.Error(err)
+}
+
+func (s *MetricsServiceSuite) TestToEsMetrics() {
+	timestamp := time.Now()
+	stats := container.StatsResponse{
+		CPUStats: container.CPUStats{
+			CPUUsage:    container.CPUUsage{TotalUsage: 300},
+			SystemUsage: 2000,
+			OnlineCPUs:  2,
+		},
+		PreCPUStats: container.CPUStats{
+			CPUUsage:    container.CPUUsage{TotalUsage: 100},
+			SystemUsage: 1000,
+		},
+		MemoryStats: container.MemoryStats{
+			Usage: 1000,
+			Limit: 4000,
+			Stats: map[string]uint64{"inactive_file": 200},
+		},
+		Networks: map[string]container.NetworkStats{
+			"eth0": {RxBytes: 10, TxBytes: 20},
+			"eth1": {RxBytes: 1, TxBytes: 2},
+		},
+		BlkioStats: container.BlkioStats{
+			IoServiceBytesRecursive: []container.BlkioStatEntry{
+				{Op: "read", Value: 5},
+				{Op: "Write", Value: 7},
+				{Op: "total", Value: 12},
+			},
+		},
+	}
+
+	metrics := toEsMetrics("container1", stats, timestamp)
+	s.Equal(dto.EsMetrics{
+		ContainerId: "container1",
+		Timestamp:   timestamp,
+		CpuPercent:  40,
+		MemoryUsage: 800,
+		MemoryLimit: 4000,
+		NetworkRx:   11,
+		NetworkTx:   22,
+		BlockRead:   5,
+		BlockWrite:  7,
+	}, metrics)
+
+	metrics = toEsMetrics("container1", container.StatsResponse{}, timestamp)
+	s.Zero(metrics.CpuPercent)
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+
+	"github.com/vnFuhung2903/vcs-sms/pkg/env"
+	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
+	"github.com/vnFuhung2903/vcs-sms/usecases/repositories"
+	"github.com/vnFuhung2903/vcs-sms/utils"
+	"go.uber.org/zap"
+)
+
+// IMigrationService brings the rows written by earlier versions up to date with the current scopes and ownership.
+type IMigrationService interface {
+	Run(ctx context.Context) error
+}
+
+type migrationService struct {
+	userRepo      repositories.IUserRepository
+	containerRepo repositories.IContainerRepository
+	logger        logger.ILogger
+	defaultOwner  string
+}
+
+func NewMigrationService(userRepo repositories.IUserRepository, containerRepo repositories.IContainerRepository, logger logger.ILogger, env env.MigrationEnv) IMigrationService {
+	return &migrationService{
+		userRepo:      userRepo,
+		containerRepo: containerRepo,
+		logger:        logger,
+		defaultOwner:  env.DefaultOwner,
+	}
+}
+
+func (s *migrationService) Run(ctx context.Context) error {
+	if err := s.migrateScopes(); err != nil {
+		return err
+	}
+	return s.assignUnowned()
+}
+
+// migrateScopes grants every user the role defaults among the scopes added since the user's scopes were granted,
+// keeping the scopes that were removed from or added to the user since.
+func (s *migrationService) migrateScopes() error {
+	version := utils.NumberOfScopes()
+	users, err := s.userRepo.ViewOutdatedScopes(version)
+	if err != nil {
+		s.logger.Error("failed to view users with outdated scopes", zap.Error(err))
+		return err
+	}
+
+	for _, user := range users {
+		granted := int64(1)<<user.ScopeVersion - 1
+		added := utils.ScopesToHashMap(utils.UserRoleToDefaultScopes(user.Role, nil)) &^ granted
+		if err := s.userRepo.UpgradeScopes(user, user.Scopes|added, version); err != nil {
+			s.logger.Error("failed to upgrade user's scopes", zap.String("user_id", user.ID), zap.Error(err))
+			return err
+		}
+	}
+
+	if len(users) > 0 {
+		s.logger.Info("users' scopes upgraded successfully", zap.Int("count", len(users)))
+	}
+	return nil
+}
+
+// assignUnowned gives the containers recorded before containers had owners to the default owner, if one is set.
+func (s *migrationService) assignUnowned() error {
+	if s.defaultOwner == "" {
+		return nil
+	}
+
+	owner, err := s.userRepo.FindByName(s.defaultOwner)
+	if err != nil {
+		s.logger.Error("failed to find default owner by name", zap.Error(err))
+		return err
+	}
+	count, err := s.containerRepo.AssignUnowned(owner.ID)
+	if err != nil {
+		s.logger.Error("failed to assign unowned containers", zap.Error(err))
+		return err
+	}
+
+	if count > 0 {
+		s.logger.Info("unowned containers assigned successfully", zap.Int64("count", count))
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
+	"github.com/vnFuhung2903/vcs-sms/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-sms/pkg/env"
+	"github.com/vnFuhung2903/vcs-sms/utils"
+)
+
+type MigrationServiceSuite struct {
+	suite.Suite
+	ctrl              *gomock.Controller
+	mockUserRepo      *repositories.MockIUserRepository
+	mockContainerRepo *repositories.MockIContainerRepository
+	logger            *logger.MockILogger
+	ctx               context.Context
+}
+
+func (s *MigrationServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockUserRepo = repositories.NewMockIUserRepository(s.ctrl)
+	s.mockContainerRepo = repositories.NewMockIContainerRepository(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.ctx = context.Background()
+}
+
+func (s *MigrationServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestMigrationServiceSuite(t *testing.T) {
+	suite.Run(t, new(MigrationServiceSuite))
+}
+
+func (s *MigrationServiceSuite) newService(defaultOwner string) IMigrationService {
+	return NewMigrationService(s.mockUserRepo, s.mockContainerRepo, s.logger, env.MigrationEnv{DefaultOwner: defaultOwner})
+}
+
+func (s *MigrationServiceSuite) TestRunUpgradesScopes() {
+	// The developer had container:view removed and was registered when only the seven original scopes existed.
+	developerScopes := utils.ScopesToHashMap([]string{"user:modify", "container:create", "container:update", "container:delete", "report:mail"})
+	developer := &entities.User{ID: "developer-id", Role: entities.Developer, Scopes: developerScopes, ScopeVersion: 7}
+	manager := &entities.User{ID: "manager-id", Role: entities.Manager, Scopes: utils.ScopesToHashMap([]string{"user:modify", "user:manager", "container:view", "report:mail"}), ScopeVersion: 7}
+
+	version := utils.NumberOfScopes()
+	s.mockUserRepo.EXPECT().ViewOutdatedScopes(version).Return([]*entities.User{developer, manager}, nil)
+	s.mockUserRepo.EXPECT().
+		UpgradeScopes(developer, developerScopes|utils.ScopesToHashMap([]string{"container:logs", "container:exec", "image:view", "image:pull", "container:files"}), version).
+		Return(nil)
+	s.mockUserRepo.EXPECT().
+		UpgradeScopes(manager, utils.ScopesToHashMap(utils.UserRoleToDefaultScopes(entities.Manager, nil)), version).
+		Return(nil)
+	s.logger.EXPECT().Info("users' scopes upgraded successfully", gomock.Any())
+
+	err := s.newService("").Run(s.ctx)
+	s.NoError(err)
+}
+
+func (s *MigrationServiceSuite) TestRunNothingOutdated() {
+	s.mockUserRepo.EXPECT().ViewOutdatedScopes(utils.NumberOfScopes()).Return(nil, nil)
+
+	err := s.newService("").Run(s.ctx)
+	s.NoError(err)
+}
+
+func (s *MigrationServiceSuite) TestRunViewOutdatedError() {
+	s.mockUserRepo.EXPECT().ViewOutdatedScopes(gomock.Any()).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to view users with outdated scopes", gomock.Any())
+
+	err := s.newService("admin").Run(s.ctx)
+	s.ErrorContains(err, "db error")
+}
+
+func (s *MigrationServiceSuite) TestRunUpgradeError() {
+	user := &entities.User{ID: "user-id", Role: entities.Developer, ScopeVersion: 7}
+	s.mockUserRepo.EXPECT().ViewOutdatedScopes(gomock.Any()).Return([]*entities.User{user}, nil)
+	s.mockUserRepo.EXPECT().UpgradeScopes(user, gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+	s.logger.EXPECT().Error("failed to upgrade user's scopes", gomock.Any(), gomock.Any())
+
+	err := s.newService("").Run(s.ctx)
+	s.ErrorContains(err, "db error")
+}
+
+func (s *MigrationServiceSuite) TestRunAssignsUnowned() {
+	s.mockUserRepo.EXPECT().ViewOutdatedScopes(gomock.Any()).Return(nil, nil)
+	s.mockUserRepo.EXPECT().FindByName("admin").Return(&entities.User{ID: "admin-id"}, nil)
+	s.mockContainerRepo.EXPECT().AssignUnowned("admin-id").Return(int64(3), nil)
+	s.logger.EXPECT().Info("unowned containers assigned successfully", gomock.Any())
+
+	err := s.newService("admin").Run(s.ctx)
+	s.NoError(err)
+}
+
+func (s *MigrationServiceSuite) TestRunDefaultOwnerNotFound() {
+	s.mockUserRepo.EXPECT().ViewOutdatedScopes(gomock.Any()).Return(nil, nil)
+	s.mockUserRepo.EXPECT().FindByName("admin").Return(nil, errors.New("record not found"))
+	s.logger.EXPECT().Error("failed to find default owner by name", gomock.Any())
+
+	err := s.newService("admin").Run(s.ctx)
+	s.ErrorContains(err, "record not found")
+}
+
+func (s *MigrationServiceSuite) TestRunAssignUnownedError() {
+	s.mockUserRepo.EXPECT().ViewOutdatedScopes(gomock.Any()).Return(nil, nil)
+	s.mockUserRepo.EXPECT().FindByName("admin").Return(&entities.User{ID: "admin-id"}, nil)
+	s.mockContainerRepo.EXPECT().AssignUnowned("admin-id").Return(int64(0), errors.New("db error"))
+	s.logger.EXPECT().Error("failed to assign unowned containers", gomock.Any())
+
+	err := s.newService("admin").Run(s.ctx)
+	s.ErrorContains(err, "db error")
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
+	"github.com/vnFuhung2903/vcs-sms/usecases/repositories"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type IQuotaService interface {
+	Check(ctx context.Context, userId string, containers int64, memory int64) error
+	Reserve(ctx context.Context, userId string, containers int64, memory int64, record func(tx *gorm.DB) error) error
+	View(ctx context.Context) ([]*entities.Quota, error)
+	Set(ctx context.Context, quota *entities.Quota) error
+	Delete(ctx context.Context, subjectType entities.QuotaSubjectType, subject string) error
+}
+
+type QuotaService struct {
+	quotaRepo     repositories.IQuotaRepository
+	containerRepo repositories.IContainerRepository
+	userRepo      repositories.IUserRepository
+	logger        logger.ILogger
+}
+
+func NewQuotaService(quotaRepo repositories.IQuotaRepository, containerRepo repositories.IContainerRepository, userRepo repositories.IUserRepository, logger logger.ILogger) IQuotaService {
+	return &QuotaService{
+		quotaRepo:     quotaRepo,
+		containerRepo: containerRepo,
+		userRepo:      userRepo,
+		logger:        logger,
+	}
+}
+
+// Check uses the user's own quota if set, otherwise its role's; no quota means no limit.
+func (s *QuotaService) Check(ctx context.Context, userId string, containers int64, memory int64) error {
+	user, err := s.userRepo.FindByIdForUpdate(userId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: %s", ErrUserNotFound, userId)
+	}
+	if err != nil {
+		s.logger.Error("failed to find user by id", zap.Error(err))
+		return err
+	}
+
+	quota, err := s.quotaRepo.Find(entities.QuotaUser, user.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		quota, err = s.quotaRepo.Find(entities.QuotaRole, string(user.Role))
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		s.logger.Error("failed to find quota", zap.Error(err))
+		return err
+	}
+
+	totalContainers, totalMemory, err := s.containerRepo.Usage(user.ID)
+	if err != nil {
+		s.logger.Error("failed to retrieve quota usage", zap.Error(err))
+		return err
+	}
+
+	if quota.MaxContainers > 0 && totalContainers+containers > quota.MaxContainers {
+		return fmt.Errorf("%w: at most %d containers allowed", ErrQuotaExceeded, quota.MaxContainers)
+	}
+	if quota.MaxMemory > 0 {
+		if memory <= 0 {
+			return fmt.Errorf("%w: a memory limit is required", ErrQuotaExceeded)
+		}
+		if totalMemory+memory > quota.MaxMemory {
+			return fmt.Errorf("%w: at most %d bytes of memory allowed", ErrQuotaExceeded, quota.MaxMemory)
+		}
+	}
+	return nil
+}
+
+// Reserve checks the quota like Check and runs record in the same transaction, the user's row staying locked until
+// it commits. Concurrent creations for the same user are thus checked one after the other, each seeing the containers
+// recorded before. The transaction is rolled back if record fails. As the lock holds up every creation for the user,
+// record only writes the database, any docker work being left until Reserve returned.
+func (s *QuotaService) Reserve(ctx context.Context, userId string, containers int64, memory int64, record func(tx *gorm.DB) error) error {
+	tx, err := s.userRepo.BeginTransaction(ctx)
+	if err != nil {
+		s.logger.Error("failed to begin transaction", zap.Error(err))
+		return err
+	}
+	defer tx.Rollback()
+
+	quota := &QuotaService{
+		quotaRepo:     s.quotaRepo.WithTransaction(tx),
+		containerRepo: s.containerRepo.WithTransaction(tx),
+		userRepo:      s.userRepo.WithTransaction(tx),
+		logger:        s.logger,
+	}
+	if err := quota.Check(ctx, userId, containers, memory); err != nil {
+		s.logger.Error("failed to check quota", zap.Error(err))
+		return err
+	}
+	if err := record(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit().Error; err != nil {
+		s.logger.Error("failed to commit quota reservation", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (s *QuotaService) View(ctx context.Context) ([]*entities.Quota, error) {
+	quotas, err := s.quotaRepo.View()
+	if err != nil {
+		s.logger.Error("failed to view quotas", zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info("quotas listed successfully", zap.Int("count", len(quotas)))
+	return quotas, nil
+}
+
+func (s *QuotaService) Set(ctx context.Context, quota *entities.Quota) error {
+	if err := s.quotaRepo.Upsert(quota); err != nil {
+		s.logger.Error("failed to set quota", zap.Error(err))
+		return err
+	}
+	s.logger.Info("quota set successfully", zap.String("subjectType", string(quota.SubjectType)), zap.String("subject", quota.Subject))
+	return nil
+}
+
+func (s *QuotaService) Delete(ctx context.Context, subjectType entities.QuotaSubjectType, subject string) error {
+	if err := s.quotaRepo.Delete(subjectType, subject); err != nil {
+		s.logger.Error("failed to delete quota", zap.Error(err))
+		return err
+	}
+	s.logger.Info("quota deleted successfully", zap.String("subjectType", string(subjectType)), zap.String("subject", subject))
+	return nil
+}
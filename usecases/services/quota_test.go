@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
+	"github.com/vnFuhung2903/vcs-sms/mocks/repositories"
+)
+
+type QuotaServiceSuite struct {
+	suite.Suite
+	ctrl              *gomock.Controller
+	quotaService      IQuotaService
+	mockQuotaRepo     *repositories.MockIQuotaRepository
+	mockContainerRepo *repositories.MockIContainerRepository
+	mockUserRepo      *repositories.MockIUserRepository
+	logger            *logger.MockILogger
+	ctx               context.Context
+}
+
+func (s *QuotaServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockQuotaRepo = repositories.NewMockIQuotaRepository(s.ctrl)
+	s.mockContainerRepo = repositories.NewMockIContainerRepository(s.ctrl)
+	s.mockUserRepo = repositories.NewMockIUserRepository(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.quotaService = NewQuotaService(s.mockQuotaRepo, s.mockContainerRepo, s.mockUserRepo, s.logger)
+	s.ctx = context.Background()
+}
+
+func (s *QuotaServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestQuotaServiceSuite(t *testing.T) {
+	suite.Run(t, new(QuotaServiceSuite))
+}
+
+func (s *QuotaServiceSuite) TestCheckUserQuota() {
+	s.mockUserRepo.EXPECT().FindByIdForUpdate("user-id").Return(&entities.User{ID: "user-id", Role: entities.Developer}, nil)
+	s.mockQuotaRepo.EXPECT().Find(entities.QuotaUser, "user-id").Return(&entities.Quota{MaxContainers: 3, MaxMemory: 1 << 30}, nil)
+	s.mockContainerRepo.EXPECT().Usage("user-id").Return(int64(2), int64(512<<20), nil)
+
+	err := s.quotaService.Check(s.ctx, "user-id", 1, 256<<20)
+	s.NoError(err)
+}
+
+func (s *QuotaServiceSuite) TestCheckFallsBackToRoleQuota() {
+	s.mockUserRepo.EXPECT().FindByIdForUpdate("user-id").Return(&entities.User{ID: "user-id", Role: entities.Developer}, nil)
+	s.mockQuotaRepo.EXPECT().Find(entities.QuotaUser, "user-id").Return(nil, gorm.ErrRecordNotFound)
+	s.mockQuotaRepo.EXPECT().Find(entities.QuotaRole, "developer").Return(&entities.Quota{MaxContainers: 2}, nil)
+	s.mockContainerRepo.EXPECT().Usage("user-id").Return(int64(2), int64(0), nil)
+
+	err := s.quotaService.Check(s.ctx, "user-id", 1, 0)
+	s.ErrorIs(err, ErrQuotaExceeded)
+}
+
+func (s *QuotaServiceSuite) TestCheckNoQuota() {
+	s.mockUserRepo.EXPECT().FindByIdForUpdate("user-id").Return(&entities.User{ID: "user-id", Role: entities.Developer}, nil)
+	s.mockQuotaRepo.EXPECT().Find(entities.QuotaUser, "user-id").Return(nil, gorm.ErrRecordNotFound)
+	s.mockQuotaRepo.EXPECT().Find(entities.QuotaRole, "developer").Return(nil, gorm.ErrRecordNotFound)
+
+	err := s.quotaService.Check(s.ctx, "user-id", 1, 0)
+	s.NoError(err)
+}
+
+func (s *QuotaServiceSuite) TestCheckMemoryExceeded() {
+	s.mockUserRepo.EXPECT().FindByIdForUpdate("user-id").Return(&entities.User{ID: "user-id", Role: entities.Developer}, nil)
+	s.mockQuotaRepo.EXPECT().Find(entities.QuotaUser, "user-id").Return(&entities.Quota{MaxMemory: 1 << 30}, nil)
+	s.mockContainerRepo.EXPECT().Usage("user-id").Return(int64(1), int64(1<<30), nil)
+
+	err := s.quotaService.Check(s.ctx, "user-id", 1, 256<<20)
+	s.ErrorIs(err, ErrQuotaExceeded)
+}
+
+func (s *QuotaServiceSuite) TestCheckMemoryLimitRequired() {
+	s.mockUserRepo.EXPECT().FindByIdForUpdate("user-id").Return(&entities.User{ID: "user-id", Role: entities.Developer}, nil)
+	s.mockQuotaRepo.EXPECT().Find(entities.QuotaUser, "user-id").Return(&entities.Quota{MaxMemory: 1 << 30}, nil)
+	s.mockContainerRepo.EXPECT().Usage("user-id").Return(int64(0), int64(0), nil)
+
+	err := s.quotaService.Check(s.ctx, "user-id", 1, 0)
+	s.ErrorIs(err, ErrQuotaExceeded)
+	s.ErrorContains(err, "memory limit is required")
+}
+
+func (s *QuotaServiceSuite) TestCheckUserNotFound() {
+	s.mockUserRepo.EXPECT().FindByIdForUpdate("user-id").Return(nil, gorm.ErrRecordNotFound)
+
+	err := s.quotaService.Check(s.ctx, "user-id", 1, 0)
+	s.ErrorIs(err, ErrUserNotFound)
+}
+
+func (s *QuotaServiceSuite) TestCheckFindUserError() {
+	s.mockUserRepo.EXPECT().FindByIdForUpdate("user-id").Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find user by id", gomock.Any()).Times(1)
+
+	err := s.quotaService.Check(s.ctx, "user-id", 1, 0)
+	s.ErrorContains(err, "db error")
+}
+
+func (s *QuotaServiceSuite) TestCheckFindQuotaError() {
+	s.mockUserRepo.EXPECT().FindByIdForUpdate("user-id").Return(&entities.User{ID: "user-id", Role: entities.Developer}, nil)
+	s.mockQuotaRepo.EXPECT().Find(entities.QuotaUser, "user-id").Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find quota", gomock.Any()).Times(1)
+
+	err := s.quotaService.Check(s.ctx, "user-id", 1, 0)
+	s.ErrorContains(err, "db error")
+}
+
+func (s *QuotaServiceSuite) TestCheckUsageError() {
+	s.mockUserRepo.EXPECT().FindByIdForUpdate("user-id").Return(&entities.User{ID: "user-id", Role: entities.Developer}, nil)
+	s.mockQuotaRepo.EXPECT().Find(entities.QuotaUser, "user-id").Return(&entities.Quota{MaxContainers: 1}, nil)
+	s.mockContainerRepo.EXPECT().Usage("user-id").Return(int64(0), int64(0), errors.New("db error"))
+	s.logger.EXPECT().Error("failed to retrieve quota usage", gomock.Any()).Times(1)
+
+	err := s.quotaService.Check(s.ctx, "user-id", 1, 0)
+	s.ErrorContains(err, "db error")
+}
+
+// expectTransaction hands out a transaction of an in-memory database, the mocked repositories standing in for their
+// own transactional copies.
+func (s *QuotaServiceSuite) expectTransaction() *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+	tx := db.Begin()
+	s.mockUserRepo.EXPECT().BeginTransaction(s.ctx).Return(tx, nil)
+	s.mockUserRepo.EXPECT().WithTransaction(tx).Return(s.mockUserRepo)
+	s.mockQuotaRepo.EXPECT().WithTransaction(tx).Return(s.mockQuotaRepo)
+	s.mockContainerRepo.EXPECT().WithTransaction(tx).Return(s.mockContainerRepo)
+	return tx
+}
+
+func (s *QuotaServiceSuite) TestReserve() {
+	tx := s.expectTransaction()
+	s.mockUserRepo.EXPECT().FindByIdForUpdate("user-id").Return(&entities.User{ID: "user-id", Role: entities.Developer}, nil)
+	s.mockQuotaRepo.EXPECT().Find(entities.QuotaUser, "user-id").Return(&entities.Quota{MaxContainers: 3}, nil)
+	s.mockContainerRepo.EXPECT().Usage("user-id").Return(int64(2), int64(0), nil)
+
+	recorded := false
+	err := s.quotaService.Reserve(s.ctx, "user-id", 1, 0, func(recordTx *gorm.DB) error {
+		s.Equal(tx, recordTx)
+		recorded = true
+		return nil
+	})
+	s.NoError(err)
+	s.True(recorded)
+}
+
+func (s *QuotaServiceSuite) TestReserveExceeded() {
+	s.expectTransaction()
+	s.mockUserRepo.EXPECT().FindByIdForUpdate("user-id").Return(&entities.User{ID: "user-id", Role: entities.Developer}, nil)
+	s.mockQuotaRepo.EXPECT().Find(entities.QuotaUser, "user-id").Return(&entities.Quota{MaxContainers: 2}, nil)
+	s.mockContainerRepo.EXPECT().Usage("user-id").Return(int64(2), int64(0), nil)
+	s.logger.EXPECT().Error("failed to check quota", gomock.Any()).Times(1)
+
+	err := s.quotaService.Reserve(s.ctx, "user-id", 1, 0, func(*gorm.DB) error {
+		s.Fail("record must not run when the quota is exceeded")
+		return nil
+	})
+	s.ErrorIs(err, ErrQuotaExceeded)
+}
+
+func (s *QuotaServiceSuite) TestReserveRecordError() {
+	s.expectTransaction()
+	s.mockUserRepo.EXPECT().FindByIdForUpdate("user-id").Return(&entities.User{ID: "user-id", Role: entities.Developer}, nil)
+	s.mockQuotaRepo.EXPECT().Find(entities.QuotaUser, "user-id").Return(nil, gorm.ErrRecordNotFound)
+	s.mockQuotaRepo.EXPECT().Find(entities.QuotaRole, "developer").Return(nil, gorm.ErrRecordNotFound)
+
+	err := s.quotaService.Reserve(s.ctx, "user-id", 1, 0, func(*gorm.DB) error {
+		return errors.New("db error")
+	})
+	s.ErrorContains(err, "db error")
+}
+
+func (s *QuotaServiceSuite) TestReserveBeginTransactionError() {
+	s.mockUserRepo.EXPECT().BeginTransaction(s.ctx).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to begin transaction", gomock.Any()).Times(1)
+
+	err := s.quotaService.Reserve(s.ctx, "user-id", 1, 0, func(*gorm.DB) error { return nil })
+	s.ErrorContains(err, "db error")
+}
+
+func (s *QuotaServiceSuite) TestView() {
+	quotas := []*entities.Quota{{SubjectType: entities.QuotaRole, Subject: "developer", MaxContainers: 5}}
+	s.mockQuotaRepo.EXPECT().View().Return(quotas, nil)
+	s.logger.EXPECT().Info("quotas listed successfully", gomock.Any()).Times(1)
+
+	result, err := s.quotaService.View(s.ctx)
+	s.NoError(err)
+	s.Equal(quotas, result)
+}
+
+func (s *QuotaServiceSuite) TestViewError() {
+	s.mockQuotaRepo.EXPECT().View().Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to view quotas", gomock.Any()).Times(1)
+
+	_, err := s.quotaService.View(s.ctx)
+	s.ErrorContains(err, "db error")
+}
+
+func (s *QuotaServiceSuite) TestSet() {
+	quota := &entities.Quota{SubjectType: entities.QuotaUser, Subject: "user-id", MaxContainers: 5}
+	s.mockQuotaRepo.EXPECT().Upsert(quota).Return(nil)
+	s.logger.EXPECT().Info("quota set successfully", gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.quotaService.Set(s.ctx, quota)
+	s.NoError(err)
+}
+
+func (s *QuotaServiceSuite) TestSetError() {
+	quota := &entities.Quota{SubjectType: entities.QuotaUser, Subject: "user-id", MaxContainers: 5}
+	s.mockQuotaRepo.EXPECT().Upsert(quota).Return(errors.New("db error"))
+	s.logger.EXPECT().Error("failed to set quota", gomock.Any()).Times(1)
+
+	err := s.quotaService.Set(s.ctx, quota)
+	s.ErrorContains(err, "db error")
+}
+
+func (s *QuotaServiceSuite) TestDelete() {
+	s.mockQuotaRepo.EXPECT().Delete(entities.QuotaRole, "developer").Return(nil)
+	s.logger.EXPECT().Info("quota deleted successfully", gomock.Any(), gomock.Any()).Times(1)
+
+	err := s.quotaService.Delete(s.ctx, entities.QuotaRole, "developer")
+	s.NoError(err)
+}
+
+func (s *QuotaServiceSuite) TestDeleteError() {
+	s.mockQuotaRepo.EXPECT().Delete(entities.QuotaRole, "developer").Return(errors.New("db error"))
+	s.logger.EXPECT().Error("failed to delete quota", gomock.Any()).Times(1)
+
+	err := s.quotaService.Delete(s.ctx, entities.QuotaRole, "developer")
+	s.ErrorContains(err, "db error")
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/pkg/docker"
+	"github.com/vnFuhung2903/vcs-sms/pkg/env"
+	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
+	"github.com/vnFuhung2903/vcs-sms/usecases/repositories"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type IReconcileService interface {
+	Reconcile(ctx context.Context, dryRun bool) (*dto.ReconcileReport, error)
+}
+
+type ReconcileService struct {
+	containerRepo   repositories.IContainerRepository
+	userRepo        repositories.IUserRepository
+	dockerClient    docker.IDockerClient
+	managedOnly     bool
+	untrackedPolicy dto.ReconcileAction
+	missingPolicy   dto.ReconcileAction
+	gracePeriod     time.Duration
+	adoptOwner      string
+	logger          logger.ILogger
+}
+
+func NewReconcileService(repo repositories.IContainerRepository, userRepo repositories.IUserRepository, dockerClient docker.IDockerClient, logger logger.ILogger, env env.ReconcileEnv) IReconcileService {
+	return &ReconcileService{
+		containerRepo:   repo,
+		userRepo:        userRepo,
+		dockerClient:    dockerClient,
+		managedOnly:     env.ManagedOnly,
+		untrackedPolicy: dto.ReconcileAction(env.UntrackedPolicy),
+		missingPolicy:   dto.ReconcileAction(env.MissingPolicy),
+		gracePeriod:     env.GracePeriod,
+		adoptOwner:      env.AdoptOwner,
+		logger:          logger,
+	}
+}
+
+// Reconcile diffs the daemon against the containers table and applies the configured policy to every drift.
+// With dryRun set, the report only tells what would be done.
+// The table is read before the daemon is listed so that a container created in between is never taken for missing,
+// and drifts younger than the grace period are left alone as their insert may not be committed yet.
+// Adopted containers are given to the configured owner, so that they count against a quota and show up for a user;
+// nothing is reconciled when that user does not exist.
+func (s *ReconcileService) Reconcile(ctx context.Context, dryRun bool) (*dto.ReconcileReport, error) {
+	adoptOwnerId := ""
+	if s.untrackedPolicy == dto.ReconcileAdopt && !dryRun {
+		owner, err := s.userRepo.FindByName(s.adoptOwner)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrUserNotFound, s.adoptOwner)
+		}
+		if err != nil {
+			s.logger.Error("failed to find adopt owner by name", zap.Error(err))
+			return nil, err
+		}
+		adoptOwnerId = owner.ID
+	}
+
+	containers, _, err := s.containerRepo.View(dto.ContainerFilter{}, 1, -1, dto.ContainerSort{
+		Field: "created_at", Order: dto.Asc,
+	})
+	if err != nil {
+		s.logger.Error("failed to view containers", zap.Error(err))
+		return nil, err
+	}
+
+	// Containers in the trash keep their stopped docker container until purged, so they are tracked too.
+	deleted, err := s.containerRepo.ViewDeleted("", time.Time{})
+	if err != nil {
+		s.logger.Error("failed to view trash", zap.Error(err))
+		return nil, err
+	}
+
+	summaries, err := s.dockerClient.List(ctx)
+	if err != nil {
+		s.logger.Error("failed to list docker containers", zap.Error(err))
+		return nil, err
+	}
+
+	settled := time.Now().Add(-s.gracePeriod)
+
+	tracked := make(map[string]struct{}, len(containers)+len(deleted))
+	for _, container := range slices.Concat(containers, deleted) {
+		tracked[container.ContainerId] = struct{}{}
+	}
+	existing := make(map[string]struct{}, len(summaries))
+	for _, summary := range summaries {
+		existing[summary.ID] = struct{}{}
+	}
+
+	report := &dto.ReconcileReport{
+		DryRun:    dryRun,
+		Untracked: []dto.ReconcileItem{},
+		Missing:   []dto.ReconcileItem{},
+	}
+
+	for _, summary := range summaries {
+		if _, ok := tracked[summary.ID]; ok || time.Unix(summary.Created, 0).After(settled) {
+			continue
+		}
+		managed := summary.Labels[docker.ManagedLabel] == "true"
+		if s.managedOnly && !managed {
+			continue
+		}
+
+		item := dto.ReconcileItem{
+			ContainerId:   summary.ID,
+			ContainerName: toContainerName(summary),
+			Action:        s.untrackedPolicy,
+		}
+		// Never remove a container the API did not create.
+		if item.Action == dto.ReconcileDelete && !managed {
+			item.Action = dto.ReconcileIgnore
+		}
+		if !dryRun {
+			if err := s.reconcileUntracked(ctx, summary, item, adoptOwnerId); err != nil {
+				item.Error = err.Error()
+			}
+		}
+		report.Untracked = append(report.Untracked, item)
+	}
+
+	for _, container := range containers {
+		if _, ok := existing[container.ContainerId]; ok || container.CreatedAt.After(settled) {
+			continue
+		}
+
+		item := dto.ReconcileItem{
+			ContainerId:   container.ContainerId,
+			ContainerName: container.ContainerName,
+			Action:        s.missingPolicy,
+		}
+		if !dryRun {
+			if err := s.reconcileMissing(container, item); err != nil {
+				item.Error = err.Error()
+			}
+		}
+		report.Missing = append(report.Missing, item)
+	}
+
+	s.logger.Info("containers reconciled successfully", zap.Bool("dryRun", dryRun), zap.Int("untracked", len(report.Untracked)), zap.Int("missing", len(report.Missing)))
+	return report, nil
+}
+
+func (s *ReconcileService) reconcileUntracked(ctx context.Context, summary container.Summary, item dto.ReconcileItem, ownerId string) error {
+	switch item.Action {
+	case dto.ReconcileAdopt:
+		labels := maps.Clone(summary.Labels)
+		delete(labels, docker.ManagedLabel)
+		container := &entities.Container{
+			ContainerId:   summary.ID,
+			ContainerName: item.ContainerName,
+			Status:        s.dockerClient.GetStatus(ctx, summary.ID),
+			Ipv4:          s.dockerClient.GetIpv4(ctx, summary.ID),
+			ImageName:     summary.Image,
+			OwnerId:       ownerId,
+			Spec:          entities.ContainerSpec{Labels: labels},
+		}
+		if err := s.containerRepo.Create(container); err != nil {
+			s.logger.Error("failed to adopt container", zap.String("containerId", summary.ID), zap.Error(err))
+			return err
+		}
+	case dto.ReconcileDelete:
+		if err := s.dockerClient.Delete(ctx, summary.ID); err != nil {
+			s.logger.Error("failed to delete docker container", zap.String("containerId", summary.ID), zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ReconcileService) reconcileMissing(container *entities.Container, item dto.ReconcileItem) error {
+	switch item.Action {
+	case dto.ReconcileOrphan:
+		if container.Status == entities.ContainerMissing {
+			return nil
+		}
+		if err := s.containerRepo.Update(container.ContainerId, entities.ContainerMissing, ""); err != nil {
+			s.logger.Error("failed to orphan container", zap.String("containerId", container.ContainerId), zap.Error(err))
+			return err
+		}
+	case dto.ReconcileDelete:
+		if err := s.containerRepo.Delete(container.ContainerId); err != nil {
+			s.logger.Error("failed to delete container", zap.String("containerId", container.ContainerId), zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+func toContainerName(summary container.Summary) string {
+	if len(summary.Names) == 0 {
+		return summary.ID
+	}
+	return strings.TrimPrefix(summary.Names[0], "/")
+}
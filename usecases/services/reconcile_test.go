@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/docker"
+	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
+	"github.com/vnFuhung2903/vcs-sms/mocks/repositories"
+	pkgdocker "github.com/vnFuhung2903/vcs-sms/pkg/docker"
+	"github.com/vnFuhung2903/vcs-sms/pkg/env"
+)
+
+type ReconcileServiceSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	mockRepo     *repositories.MockIContainerRepository
+	mockUserRepo *repositories.MockIUserRepository
+	dockerClient *docker.MockIDockerClient
+	logger       *logger.MockILogger
+	ctx          context.Context
+	summaries    []container.Summary
+	containers   []*entities.Container
+}
+
+func (s *ReconcileServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = repositories.NewMockIContainerRepository(s.ctrl)
+	s.mockUserRepo = repositories.NewMockIUserRepository(s.ctrl)
+	s.dockerClient = docker.NewMockIDockerClient(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.ctx = context.Background()
+
+	s.summaries = []container.Summary{
+		{ID: "tracked", Names: []string{"/tracked"}, Labels: map[string]string{pkgdocker.ManagedLabel: "true"}},
+		{ID: "managed", Names: []string{"/managed"}, Image: "nginx", Labels: map[string]string{pkgdocker.ManagedLabel: "true", "app": "web"}},
+		{ID: "foreign", Names: []string{"/foreign"}, Image: "redis"},
+		{ID: "trashed", Names: []string{"/trashed"}, Labels: map[string]string{pkgdocker.ManagedLabel: "true"}},
+	}
+	s.containers = []*entities.Container{
+		{ContainerId: "tracked", ContainerName: "tracked", Status: entities.ContainerOn},
+		{ContainerId: "gone", ContainerName: "gone", Status: entities.ContainerOff},
+	}
+}
+
+func (s *ReconcileServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestReconcileServiceSuite(t *testing.T) {
+	suite.Run(t, new(ReconcileServiceSuite))
+}
+
+func (s *ReconcileServiceSuite) newService(reconcileEnv env.ReconcileEnv) IReconcileService {
+	return NewReconcileService(s.mockRepo, s.mockUserRepo, s.dockerClient, s.logger, reconcileEnv)
+}
+
+func (s *ReconcileServiceSuite) expectList() {
+	gomock.InOrder(
+		s.mockRepo.EXPECT().View(dto.ContainerFilter{}, 1, -1, dto.ContainerSort{Field: "created_at", Order: dto.Asc}).Return(s.containers, int64(len(s.containers)), nil),
+		s.mockRepo.EXPECT().ViewDeleted("", time.Time{}).Return([]*entities.Container{{ContainerId: "trashed", ContainerName: "trashed", Status: entities.ContainerOff}}, nil),
+		s.dockerClient.EXPECT().List(s.ctx).Return(s.summaries, nil),
+	)
+}
+
+func (s *ReconcileServiceSuite) TestReconcileDryRun() {
+	s.expectList()
+	s.logger.EXPECT().Info("containers reconciled successfully", gomock.Any(), gomock.Any(), gomock.Any())
+
+	service := s.newService(env.ReconcileEnv{ManagedOnly: false, UntrackedPolicy: "delete", MissingPolicy: "delete"})
+	report, err := service.Reconcile(s.ctx, true)
+	s.NoError(err)
+	s.Equal(&dto.ReconcileReport{
+		DryRun: true,
+		Untracked: []dto.ReconcileItem{
+			{ContainerId: "managed", ContainerName: "managed", Action: dto.ReconcileDelete},
+			{ContainerId: "foreign", ContainerName: "foreign", Action: dto.ReconcileIgnore},
+		},
+		Missing: []dto.ReconcileItem{
+			{ContainerId: "gone", ContainerName: "gone", Action: dto.ReconcileDelete},
+		},
+	}, report)
+}
+
+func (s *ReconcileServiceSuite) TestReconcileAdoptAndOrphan() {
+	s.mockUserRepo.EXPECT().FindByName("operator").Return(&entities.User{ID: "operator-id", Username: "operator"}, nil)
+	s.expectList()
+	s.dockerClient.EXPECT().GetStatus(s.ctx, "managed").Return(entities.ContainerOn)
+	s.dockerClient.EXPECT().GetIpv4(s.ctx, "managed").Return("172.17.0.2")
+	s.mockRepo.EXPECT().Create(&entities.Container{
+		ContainerId:   "managed",
+		ContainerName: "managed",
+		Status:        entities.ContainerOn,
+		Ipv4:          "172.17.0.2",
+		ImageName:     "nginx",
+		OwnerId:       "operator-id",
+		Spec:          entities.ContainerSpec{Labels: map[string]string{"app": "web"}},
+	}).Return(nil)
+	s.mockRepo.EXPECT().Update("gone", entities.ContainerMissing, "").Return(nil)
+	s.logger.EXPECT().Info("containers reconciled successfully", gomock.Any(), gomock.Any(), gomock.Any())
+
+	service := s.newService(env.ReconcileEnv{ManagedOnly: true, UntrackedPolicy: "adopt", MissingPolicy: "orphan", AdoptOwner: "operator"})
+	report, err := service.Reconcile(s.ctx, false)
+	s.NoError(err)
+	s.Equal([]dto.ReconcileItem{{ContainerId: "managed", ContainerName: "managed", Action: dto.ReconcileAdopt}}, report.Untracked)
+	s.Equal([]dto.ReconcileItem{{ContainerId: "gone", ContainerName: "gone", Action: dto.ReconcileOrphan}}, report.Missing)
+}
+
+func (s *ReconcileServiceSuite) TestReconcileOrphanAlreadyMissing() {
+	s.containers[1].Status = entities.ContainerMissing
+	s.expectList()
+	s.logger.EXPECT().Info("containers reconciled successfully", gomock.Any(), gomock.Any(), gomock.Any())
+
+	service := s.newService(env.ReconcileEnv{ManagedOnly: true, UntrackedPolicy: "ignore", MissingPolicy: "orphan"})
+	report, err := service.Reconcile(s.ctx, false)
+	s.NoError(err)
+	s.Len(report.Missing, 1)
+	s.Empty(report.Missing[0].Error)
+}
+
+func (s *ReconcileServiceSuite) TestReconcileGarbageCollect() {
+	s.expectList()
+	s.dockerClient.EXPECT().Delete(s.ctx, "managed").Return(nil)
+	s.mockRepo.EXPECT().Delete("gone").Return(errors.New("delete failed"))
+	s.logger.EXPECT().Error("failed to delete container", gomock.Any(), gomock.Any())
+	s.logger.EXPECT().Info("containers reconciled successfully", gomock.Any(), gomock.Any(), gomock.Any())
+
+	service := s.newService(env.ReconcileEnv{ManagedOnly: false, UntrackedPolicy: "delete", MissingPolicy: "delete"})
+	report, err := service.Reconcile(s.ctx, false)
+	s.NoError(err)
+	s.Equal(dto.ReconcileIgnore, report.Untracked[1].Action)
+	s.Empty(report.Untracked[0].Error)
+	s.Equal("delete failed", report.Missing[0].Error)
+}
+
+func (s *ReconcileServiceSuite) TestReconcileAdoptError() {
+	s.mockUserRepo.EXPECT().FindByName("operator").Return(&entities.User{ID: "operator-id", Username: "operator"}, nil)
+	s.expectList()
+	s.dockerClient.EXPECT().GetStatus(s.ctx, "managed").Return(entities.ContainerOn)
+	s.dockerClient.EXPECT().GetIpv4(s.ctx, "managed").Return("")
+	s.mockRepo.EXPECT().Create(gomock.Any()).Return(errors.New("duplicate name"))
+	s.logger.EXPECT().Error("failed to adopt container", gomock.Any(), gomock.Any())
+	s.logger.EXPECT().Info("containers reconciled successfully", gomock.Any(), gomock.Any(), gomock.Any())
+
+	service := s.newService(env.ReconcileEnv{ManagedOnly: true, UntrackedPolicy: "adopt", MissingPolicy: "ignore", AdoptOwner: "operator"})
+	report, err := service.Reconcile(s.ctx, false)
+	s.NoError(err)
+	s.Equal("duplicate name", report.Untracked[0].Error)
+	s.Equal(dto.ReconcileIgnore, report.Missing[0].Action)
+}
+
+func (s *ReconcileServiceSuite) TestReconcileAdoptOwnerNotFound() {
+	s.mockUserRepo.EXPECT().FindByName("operator").Return(nil, gorm.ErrRecordNotFound)
+
+	service := s.newService(env.ReconcileEnv{ManagedOnly: true, UntrackedPolicy: "adopt", MissingPolicy: "orphan", AdoptOwner: "operator"})
+	report, err := service.Reconcile(s.ctx, false)
+	s.ErrorIs(err, ErrUserNotFound)
+	s.Nil(report)
+}
+
+func (s *ReconcileServiceSuite) TestReconcileAdoptOwnerError() {
+	s.mockUserRepo.EXPECT().FindByName("operator").Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find adopt owner by name", gomock.Any())
+
+	service := s.newService(env.ReconcileEnv{ManagedOnly: true, UntrackedPolicy: "adopt", MissingPolicy: "orphan", AdoptOwner: "operator"})
+	_, err := service.Reconcile(s.ctx, false)
+	s.ErrorContains(err, "db error")
+}
+
+func (s *ReconcileServiceSuite) TestReconcileWithinGracePeriod() {
+	s.summaries[1].Created = time.Now().Unix()
+	s.containers[1].CreatedAt = time.Now()
+	s.expectList()
+	s.logger.EXPECT().Info("containers reconciled successfully", gomock.Any(), gomock.Any(), gomock.Any())
+
+	service := s.newService(env.ReconcileEnv{ManagedOnly: false, UntrackedPolicy: "delete", MissingPolicy: "delete", GracePeriod: time.Minute})
+	report, err := service.Reconcile(s.ctx, false)
+	s.NoError(err)
+	s.Equal([]dto.ReconcileItem{{ContainerId: "foreign", ContainerName: "foreign", Action: dto.ReconcileIgnore}}, report.Untracked)
+	s.Empty(report.Missing)
+}
+
+func (s *ReconcileServiceSuite) TestReconcileListError() {
+	s.mockRepo.EXPECT().View(gomock.Any(), 1, -1, gomock.Any()).Return(s.containers, int64(len(s.containers)), nil)
+	s.mockRepo.EXPECT().ViewDeleted("", time.Time{}).Return(nil, nil)
+	s.dockerClient.EXPECT().List(s.ctx).Return(nil, errors.New("daemon unavailable"))
+	s.logger.EXPECT().Error("failed to list docker containers", gomock.Any())
+
+	service := s.newService(env.ReconcileEnv{UntrackedPolicy: "ignore", MissingPolicy: "ignore"})
+	_, err := service.Reconcile(s.ctx, true)
+	s.ErrorContains(err, "daemon unavailable")
+}
+
+func (s *ReconcileServiceSuite) TestReconcileViewError() {
+	s.mockRepo.EXPECT().View(gomock.Any(), 1, -1, gomock.Any()).Return(nil, int64(0), errors.New("db error"))
+	s.logger.EXPECT().Error("failed to view containers", gomock.Any())
+
+	service := s.newService(env.ReconcileEnv{UntrackedPolicy: "ignore", MissingPolicy: "ignore"})
+	_, err := service.Reconcile(s.ctx, true)
+	s.ErrorContains(err, "db error")
+}
+
+func (s *ReconcileServiceSuite) TestReconcileViewDeletedError() {
+	s.mockRepo.EXPECT().View(gomock.Any(), 1, -1, gomock.Any()).Return(s.containers, int64(len(s.containers)), nil)
+	s.mockRepo.EXPECT().ViewDeleted("", time.Time{}).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to view trash", gomock.Any())
+
+	service := s.newService(env.ReconcileEnv{UntrackedPolicy: "ignore", MissingPolicy: "ignore"})
+	_, err := service.Reconcile(s.ctx, true)
+	s.ErrorContains(err, "db error")
+}
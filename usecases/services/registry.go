@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/docker/docker/api/types/registry"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/pkg/env"
+	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
+	"github.com/vnFuhung2903/vcs-sms/usecases/repositories"
+	"github.com/vnFuhung2903/vcs-sms/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type IRegistryService interface {
+	View(ctx context.Context) ([]dto.RegistryResponse, error)
+	Set(ctx context.Context, req dto.RegistryRequest) error
+	Delete(ctx context.Context, registryName string) error
+	RegistryAuth(ctx context.Context, registryName string) (string, error)
+}
+
+type RegistryService struct {
+	registryRepo repositories.IRegistryRepository
+	secretKey    string
+	logger       logger.ILogger
+}
+
+func NewRegistryService(registryRepo repositories.IRegistryRepository, logger logger.ILogger, env env.RegistryEnv) IRegistryService {
+	return &RegistryService{
+		registryRepo: registryRepo,
+		secretKey:    env.SecretKey,
+		logger:       logger,
+	}
+}
+
+// View lists the registries with credentials, only telling which secrets are set.
+func (s *RegistryService) View(ctx context.Context) ([]dto.RegistryResponse, error) {
+	credentials, err := s.registryRepo.View()
+	if err != nil {
+		s.logger.Error("failed to view registry credentials", zap.Error(err))
+		return nil, err
+	}
+
+	registries := make([]dto.RegistryResponse, 0, len(credentials))
+	for _, credential := range credentials {
+		username, err := utils.Decrypt(s.secretKey, credential.Username)
+		if err != nil {
+			s.logger.Error("failed to decrypt registry credentials", zap.String("registry", credential.Registry), zap.Error(err))
+			return nil, err
+		}
+		registries = append(registries, dto.RegistryResponse{
+			Registry:    credential.Registry,
+			Username:    username,
+			HasPassword: credential.Password != "",
+			HasToken:    credential.Token != "",
+			UpdatedAt:   credential.UpdatedAt,
+		})
+	}
+	s.logger.Info("registry credentials listed successfully", zap.Int("count", len(registries)))
+	return registries, nil
+}
+
+func (s *RegistryService) Set(ctx context.Context, req dto.RegistryRequest) error {
+	if s.secretKey == "" {
+		return ErrRegistryKeyMissing
+	}
+
+	credential := &entities.RegistryCredential{
+		Registry: req.Registry,
+		Username: req.Username,
+		Password: req.Password,
+		Token:    req.Token,
+	}
+	if err := s.crypt(utils.Encrypt, &credential.Username, &credential.Password, &credential.Token); err != nil {
+		s.logger.Error("failed to encrypt registry credentials", zap.Error(err))
+		return err
+	}
+
+	if err := s.registryRepo.Upsert(credential); err != nil {
+		s.logger.Error("failed to set registry credentials", zap.Error(err))
+		return err
+	}
+	s.logger.Info("registry credentials set successfully", zap.String("registry", req.Registry))
+	return nil
+}
+
+func (s *RegistryService) Delete(ctx context.Context, registryName string) error {
+	if err := s.registryRepo.Delete(registryName); err != nil {
+		s.logger.Error("failed to delete registry credentials", zap.Error(err))
+		return err
+	}
+	s.logger.Info("registry credentials deleted successfully", zap.String("registry", registryName))
+	return nil
+}
+
+// RegistryAuth returns the encoded auth header docker expects for the registry, or an empty one when no credentials are stored.
+func (s *RegistryService) RegistryAuth(ctx context.Context, registryName string) (string, error) {
+	credential, err := s.registryRepo.Find(registryName)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		s.logger.Error("failed to find registry credentials", zap.Error(err))
+		return "", err
+	}
+
+	authConfig := registry.AuthConfig{
+		Username:      credential.Username,
+		Password:      credential.Password,
+		IdentityToken: credential.Token,
+		ServerAddress: registryName,
+	}
+	if err := s.crypt(utils.Decrypt, &authConfig.Username, &authConfig.Password, &authConfig.IdentityToken); err != nil {
+		s.logger.Error("failed to decrypt registry credentials", zap.String("registry", registryName), zap.Error(err))
+		return "", err
+	}
+	return registry.EncodeAuthConfig(authConfig)
+}
+
+// crypt replaces every field in place with its encrypted or decrypted value.
+func (s *RegistryService) crypt(fn func(secret string, value string) (string, error), fields ...*string) error {
+	for _, field := range fields {
+		value, err := fn(s.secretKey, *field)
+		if err != nil {
+			return err
+		}
+		*field = value
+	}
+	return nil
+}
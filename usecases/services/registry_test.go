@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/docker/docker/api/types/registry"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
+	"github.com/vnFuhung2903/vcs-sms/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-sms/pkg/env"
+	"github.com/vnFuhung2903/vcs-sms/utils"
+)
+
+type RegistryServiceSuite struct {
+	suite.Suite
+	ctrl             *gomock.Controller
+	registryService  IRegistryService
+	mockRegistryRepo *repositories.MockIRegistryRepository
+	logger           *logger.MockILogger
+	ctx              context.Context
+}
+
+func (s *RegistryServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRegistryRepo = repositories.NewMockIRegistryRepository(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.registryService = NewRegistryService(s.mockRegistryRepo, s.logger, env.RegistryEnv{SecretKey: "secret"})
+	s.ctx = context.Background()
+}
+
+func (s *RegistryServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestRegistryServiceSuite(t *testing.T) {
+	suite.Run(t, new(RegistryServiceSuite))
+}
+
+func (s *RegistryServiceSuite) encrypt(value string) string {
+	ciphertext, err := utils.Encrypt("secret", value)
+	s.Require().NoError(err)
+	return ciphertext
+}
+
+func (s *RegistryServiceSuite) TestView() {
+	s.mockRegistryRepo.EXPECT().View().Return([]*entities.RegistryCredential{
+		{Registry: "ghcr.io", Token: s.encrypt("token")},
+		{Registry: "registry.example.com", Username: s.encrypt("user"), Password: s.encrypt("password")},
+	}, nil)
+	s.logger.EXPECT().Info("registry credentials listed successfully", gomock.Any()).Times(1)
+
+	registries, err := s.registryService.View(s.ctx)
+	s.NoError(err)
+	s.Equal([]dto.RegistryResponse{
+		{Registry: "ghcr.io", HasToken: true},
+		{Registry: "registry.example.com", Username: "user", HasPassword: true},
+	}, registries)
+}
+
+func (s *RegistryServiceSuite) TestViewRepoError() {
+	s.mockRegistryRepo.EXPECT().View().Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to view registry credentials", gomock.Any()).Times(1)
+
+	_, err := s.registryService.View(s.ctx)
+	s.Error(err)
+}
+
+func (s *RegistryServiceSuite) TestViewDecryptError() {
+	s.mockRegistryRepo.EXPECT().View().Return([]*entities.RegistryCredential{
+		{Registry: "ghcr.io", Username: "tampered"},
+	}, nil)
+	s.logger.EXPECT().Error("failed to decrypt registry credentials", gomock.Any(), gomock.Any()).Times(1)
+
+	_, err := s.registryService.View(s.ctx)
+	s.Error(err)
+}
+
+func (s *RegistryServiceSuite) TestSet() {
+	s.mockRegistryRepo.EXPECT().Upsert(gomock.Any()).DoAndReturn(func(credential *entities.RegistryCredential) error {
+		s.Equal("ghcr.io", credential.Registry)
+		s.NotEqual("user", credential.Username)
+		s.NotEqual("password", credential.Password)
+		s.Empty(credential.Token)
+
+		password, err := utils.Decrypt("secret", credential.Password)
+		s.NoError(err)
+		s.Equal("password", password)
+		return nil
+	})
+	s.logger.EXPECT().Info("registry credentials set successfully", gomock.Any()).Times(1)
+
+	err := s.registryService.Set(s.ctx, dto.RegistryRequest{Registry: "ghcr.io", Username: "user", Password: "password"})
+	s.NoError(err)
+}
+
+func (s *RegistryServiceSuite) TestSetKeyMissing() {
+	registryService := NewRegistryService(s.mockRegistryRepo, s.logger, env.RegistryEnv{})
+
+	err := registryService.Set(s.ctx, dto.RegistryRequest{Registry: "ghcr.io", Token: "token"})
+	s.ErrorIs(err, ErrRegistryKeyMissing)
+}
+
+func (s *RegistryServiceSuite) TestSetRepoError() {
+	s.mockRegistryRepo.EXPECT().Upsert(gomock.Any()).Return(errors.New("db error"))
+	s.logger.EXPECT().Error("failed to set registry credentials", gomock.Any()).Times(1)
+
+	err := s.registryService.Set(s.ctx, dto.RegistryRequest{Registry: "ghcr.io", Token: "token"})
+	s.Error(err)
+}
+
+func (s *RegistryServiceSuite) TestDelete() {
+	s.mockRegistryRepo.EXPECT().Delete("ghcr.io").Return(nil)
+	s.logger.EXPECT().Info("registry credentials deleted successfully", gomock.Any()).Times(1)
+
+	err := s.registryService.Delete(s.ctx, "ghcr.io")
+	s.NoError(err)
+}
+
+func (s *RegistryServiceSuite) TestDeleteRepoError() {
+	s.mockRegistryRepo.EXPECT().Delete("ghcr.io").Return(errors.New("db error"))
+	s.logger.EXPECT().Error("failed to delete registry credentials", gomock.Any()).Times(1)
+
+	err := s.registryService.Delete(s.ctx, "ghcr.io")
+	s.Error(err)
+}
+
+func (s *RegistryServiceSuite) TestRegistryAuth() {
+	s.mockRegistryRepo.EXPECT().Find("ghcr.io").Return(&entities.RegistryCredential{
+		Registry: "ghcr.io",
+		Username: s.encrypt("user"),
+		Password: s.encrypt("password"),
+	}, nil)
+
+	auth, err := s.registryService.RegistryAuth(s.ctx, "ghcr.io")
+	s.NoError(err)
+
+	authConfig, err := registry.DecodeAuthConfig(auth)
+	s.NoError(err)
+	s.Equal("user", authConfig.Username)
+	s.Equal("password", authConfig.Password)
+	s.Empty(authConfig.IdentityToken)
+	s.Equal("ghcr.io", authConfig.ServerAddress)
+}
+
+func (s *RegistryServiceSuite) TestRegistryAuthNotFound() {
+	s.mockRegistryRepo.EXPECT().Find("docker.io").Return(nil, gorm.ErrRecordNotFound)
+
+	auth, err := s.registryService.RegistryAuth(s.ctx, "docker.io")
+	s.NoError(err)
+	s.Empty(auth)
+}
+
+func (s *RegistryServiceSuite) TestRegistryAuthRepoError() {
+	s.mockRegistryRepo.EXPECT().Find("ghcr.io").Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find registry credentials", gomock.Any()).Times(1)
+
+	_, err := s.registryService.RegistryAuth(s.ctx, "ghcr.io")
+	s.Error(err)
+}
+
+func (s *RegistryServiceSuite) TestRegistryAuthDecryptError() {
+	registryService := NewRegistryService(s.mockRegistryRepo, s.logger, env.RegistryEnv{SecretKey: "rotated"})
+	s.mockRegistryRepo.EXPECT().Find("ghcr.io").Return(&entities.RegistryCredential{Registry: "ghcr.io", Token: s.encrypt("token")}, nil)
+	s.logger.EXPECT().Error("failed to decrypt registry credentials", gomock.Any(), gomock.Any()).Times(1)
+
+	_, err := registryService.RegistryAuth(s.ctx, "ghcr.io")
+	s.Error(err)
+}
@@ -18,6 +18,7 @@ import (
 
 type IReportService interface {
 	SendEmail(ctx context.Context, to string, totalCount int, onCount int, offCount int, totalUptime float64, startTime time.Time, endTime time.Time) error
+	SendExpiryNotice(ctx context.Context, to string, containers []*entities.Container) error
 	CalculateReportStatistic(statusList map[string][]dto.EsStatus, overlapStatusList map[string][]dto.EsStatus, startTime time.Time, endTime time.Time) (int, int, float64)
 }
 
@@ -69,12 +70,54 @@ func (s *ReportService) SendEmail(ctx context.Context, to string, totalCount int
 	}
 
 	msg := fmt.Sprintf("Container Management System Report from %s to %s", startTime.Format(time.RFC822), endTime.Format(time.RFC822))
+	if err := s.send(to, msg, buf.String()); err != nil {
+		return err
+	}
 
+	s.logger.Info("Report sent successfully", zap.String("emailTo", to), zap.String("subject", msg))
+	return nil
+}
+
+// SendExpiryNotice warns the owner that their ephemeral containers are about to be deleted.
+func (s *ReportService) SendExpiryNotice(ctx context.Context, to string, containers []*entities.Container) error {
+	emailTemplate, err := os.ReadFile("html/expiry.html")
+	if err != nil {
+		s.logger.Error("failed to read email template", zap.Error(err))
+		return err
+	}
+
+	funcMap := template.FuncMap{
+		"formatTime": func(t time.Time) string {
+			return t.UTC().Format("2006-01-02 15:04 MST")
+		},
+	}
+	temp, err := template.New("expiry").Funcs(funcMap).Parse(string(emailTemplate))
+	if err != nil {
+		s.logger.Error("failed to parse template", zap.Error(err))
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := temp.Execute(&buf, containers); err != nil {
+		s.logger.Error("failed to execute template", zap.Error(err))
+		return err
+	}
+
+	msg := fmt.Sprintf("Container Management System: %d container(s) expiring soon", len(containers))
+	if err := s.send(to, msg, buf.String()); err != nil {
+		return err
+	}
+
+	s.logger.Info("expiry notice sent successfully", zap.String("emailTo", to), zap.Int("count", len(containers)))
+	return nil
+}
+
+func (s *ReportService) send(to string, subject string, body string) error {
 	message := gomail.NewMessage()
 	message.SetHeader("From", s.mailUsername)
 	message.SetHeader("To", to)
-	message.SetHeader("Subject", msg)
-	message.SetBody("text/html", buf.String())
+	message.SetHeader("Subject", subject)
+	message.SetBody("text/html", body)
 
 	dial := gomail.NewDialer(
 		"smtp.gmail.com",
@@ -87,8 +130,6 @@ func (s *ReportService) SendEmail(ctx context.Context, to string, totalCount int
 		s.logger.Error("failed to send email", zap.Error(err))
 		return err
 	}
-
-	s.logger.Info("Report sent successfully", zap.String("emailTo", to), zap.String("subject", msg))
 	return nil
 }
 
@@ -101,7 +142,7 @@ func (s *ReportService) CalculateReportStatistic(statusList map[string][]dto.EsS
 	for containerId, containerStatus := range statusList {
 		previousTime := startTime
 		for _, status := range containerStatus {
-			if status.Status == entities.ContainerOn {
+			if status.Status.IsUp() {
 				totalUptime += min(status.LastUpdated.Sub(startTime).Hours(), float64(status.Uptime)/3600)
 				isOnline = 1
 			} else {
@@ -111,7 +152,7 @@ func (s *ReportService) CalculateReportStatistic(statusList map[string][]dto.EsS
 		}
 
 		if len(overlapStatusList[containerId]) > 0 {
-			if overlapStatusList[containerId][0].Status == entities.ContainerOn {
+			if overlapStatusList[containerId][0].Status.IsUp() {
 				onCount++
 				totalUptime += min(endTime.Sub(previousTime).Hours(), float64(overlapStatusList[containerId][0].Uptime)/3600)
 			} else {
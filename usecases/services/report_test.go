@@ -119,6 +119,22 @@ func (s *ReportServiceSuite) TestSendEmailTemplateExecutionError() {
 	s.Error(err)
 }
 
+func (s *ReportServiceSuite) TestSendExpiryNoticeError() {
+	expiresAt := time.Now().Add(time.Hour)
+	err := os.WriteFile("html/expiry.html", []byte(`{{ range . }}<p>{{ .ContainerName }} {{ .ExpiresAt | formatTime }}</p>{{ end }}`), 0644)
+	s.NoError(err)
+
+	s.logger.EXPECT().Error("failed to send email", gomock.Any()).Times(1)
+	err = s.reportService.SendExpiryNotice(s.ctx, "recipient@example.com", []*entities.Container{{ContainerName: "web", ExpiresAt: &expiresAt}})
+	s.Error(err)
+}
+
+func (s *ReportServiceSuite) TestSendExpiryNoticeTemplateNotFound() {
+	s.logger.EXPECT().Error("failed to read email template", gomock.Any()).Times(1)
+	err := s.reportService.SendExpiryNotice(s.ctx, "recipient@example.com", []*entities.Container{{ContainerName: "web"}})
+	s.Error(err)
+}
+
 func (s *ReportServiceSuite) TestCalculateReportStatistic() {
 	baseTime := time.Now()
 	endTime := baseTime
@@ -151,3 +167,25 @@ func (s *ReportServiceSuite) TestCalculateReportStatistic() {
 	s.Equal(2, offCount)
 	s.Equal(float64(2), totalUptime)
 }
+
+func (s *ReportServiceSuite) TestCalculateReportStatisticUnhealthyStates() {
+	baseTime := time.Now()
+	endTime := baseTime
+	startTime := endTime.Add(-4 * time.Hour)
+	statusList := map[string][]dto.EsStatus{
+		"container1": {
+			{ContainerId: "container1", Status: entities.ContainerHealthy, Uptime: int64(3600), LastUpdated: baseTime.Add(-3 * time.Hour)},
+			{ContainerId: "container1", Status: entities.ContainerRestarting, Uptime: int64(3600), LastUpdated: baseTime.Add(-2 * time.Hour)},
+		},
+		"container2": {
+			{ContainerId: "container2", Status: entities.ContainerUnhealthy, Uptime: int64(7200), LastUpdated: baseTime.Add(-1 * time.Minute)},
+		},
+	}
+	overlapStatusList := map[string][]dto.EsStatus{}
+
+	onCount, offCount, totalUptime := s.reportService.CalculateReportStatistic(statusList, overlapStatusList, startTime, endTime)
+
+	s.Equal(0, onCount)
+	s.Equal(2, offCount)
+	s.Equal(float64(1), totalUptime)
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/containerd/errdefs"
+	"github.com/robfig/cron"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
+	"github.com/vnFuhung2903/vcs-sms/usecases/repositories"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const scheduleRunsLimit = 50
+
+type IScheduleService interface {
+	Create(ctx context.Context, containerId string, req dto.ScheduleRequest) (*entities.Schedule, error)
+	View(ctx context.Context, containerId string) ([]*entities.Schedule, error)
+	FindById(ctx context.Context, containerId string, scheduleId string) (*entities.Schedule, error)
+	Update(ctx context.Context, containerId string, scheduleId string, req dto.ScheduleRequest) (*entities.Schedule, error)
+	Delete(ctx context.Context, containerId string, scheduleId string) error
+	Runs(ctx context.Context, containerId string, scheduleId string) ([]*entities.ScheduleRun, error)
+	RunDue(ctx context.Context) (int, error)
+}
+
+type ScheduleService struct {
+	scheduleRepo     repositories.IScheduleRepository
+	containerService IContainerService
+	logger           logger.ILogger
+}
+
+func NewScheduleService(scheduleRepo repositories.IScheduleRepository, containerService IContainerService, logger logger.ILogger) IScheduleService {
+	return &ScheduleService{
+		scheduleRepo:     scheduleRepo,
+		containerService: containerService,
+		logger:           logger,
+	}
+}
+
+// nextRun is the first time after t the cron expression fires in the timezone.
+func nextRun(spec string, timezone string, t time.Time) (time.Time, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: unknown timezone %q", errdefs.ErrInvalidArgument, timezone)
+	}
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %v", errdefs.ErrInvalidArgument, err)
+	}
+	next := schedule.Next(t.In(location))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("%w: cron expression %q never fires", errdefs.ErrInvalidArgument, spec)
+	}
+	return next.UTC(), nil
+}
+
+// apply validates the request into the schedule and plans its next run from now.
+func (s *ScheduleService) apply(schedule *entities.Schedule, req dto.ScheduleRequest) error {
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	next, err := nextRun(req.Cron, timezone, time.Now())
+	if err != nil {
+		return err
+	}
+
+	schedule.Action = req.Action
+	schedule.Cron = req.Cron
+	schedule.Timezone = timezone
+	schedule.Enabled = req.Enabled == nil || *req.Enabled
+	schedule.NextRunAt = next
+	return nil
+}
+
+// Create schedules an action on the container, on behalf of its owner.
+func (s *ScheduleService) Create(ctx context.Context, containerId string, req dto.ScheduleRequest) (*entities.Schedule, error) {
+	container, err := s.containerService.FindById(ctx, containerId)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &entities.Schedule{ContainerId: containerId, OwnerId: container.OwnerId}
+	if err := s.apply(schedule, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.scheduleRepo.Create(schedule); err != nil {
+		s.logger.Error("failed to create schedule", zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info("schedule created successfully", zap.String("scheduleId", schedule.ID), zap.String("containerId", containerId))
+	return schedule, nil
+}
+
+func (s *ScheduleService) View(ctx context.Context, containerId string) ([]*entities.Schedule, error) {
+	schedules, err := s.scheduleRepo.View(containerId)
+	if err != nil {
+		s.logger.Error("failed to view schedules", zap.Error(err))
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// FindById looks the schedule up among the ones of the container.
+func (s *ScheduleService) FindById(ctx context.Context, containerId string, scheduleId string) (*entities.Schedule, error) {
+	schedule, err := s.scheduleRepo.FindById(scheduleId)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && schedule.ContainerId != containerId) {
+		return nil, fmt.Errorf("%w: %s", ErrScheduleNotFound, scheduleId)
+	}
+	if err != nil {
+		s.logger.Error("failed to find schedule by id", zap.Error(err))
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (s *ScheduleService) Update(ctx context.Context, containerId string, scheduleId string, req dto.ScheduleRequest) (*entities.Schedule, error) {
+	schedule, err := s.FindById(ctx, containerId, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(schedule, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.scheduleRepo.Update(schedule); err != nil {
+		s.logger.Error("failed to update schedule", zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info("schedule updated successfully", zap.String("scheduleId", scheduleId))
+	return schedule, nil
+}
+
+func (s *ScheduleService) Delete(ctx context.Context, containerId string, scheduleId string) error {
+	if _, err := s.FindById(ctx, containerId, scheduleId); err != nil {
+		return err
+	}
+
+	if err := s.scheduleRepo.Delete(scheduleId); err != nil {
+		s.logger.Error("failed to delete schedule", zap.Error(err))
+		return err
+	}
+	s.logger.Info("schedule deleted successfully", zap.String("scheduleId", scheduleId))
+	return nil
+}
+
+// Runs lists the latest runs of the schedule, newest first.
+func (s *ScheduleService) Runs(ctx context.Context, containerId string, scheduleId string) ([]*entities.ScheduleRun, error) {
+	if _, err := s.FindById(ctx, containerId, scheduleId); err != nil {
+		return nil, err
+	}
+
+	runs, err := s.scheduleRepo.ViewRuns(scheduleId, scheduleRunsLimit)
+	if err != nil {
+		s.logger.Error("failed to view schedule runs", zap.Error(err))
+		return nil, err
+	}
+	return runs, nil
+}
+
+// RunDue executes the schedules due by now and records their outcome, returning how many ran. Runs missed while
+// the scheduler was down are caught up once, the schedule then moving on to its next run after now.
+func (s *ScheduleService) RunDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	schedules, err := s.scheduleRepo.ViewDue(now)
+	if err != nil {
+		s.logger.Error("failed to view due schedules", zap.Error(err))
+		return 0, err
+	}
+
+	ran := 0
+	for _, schedule := range schedules {
+		next, err := nextRun(schedule.Cron, schedule.Timezone, now)
+		if err != nil {
+			s.logger.Error("failed to plan next schedule run", zap.String("scheduleId", schedule.ID), zap.Error(err))
+			continue
+		}
+		claimed, err := s.scheduleRepo.Claim(schedule, next, now)
+		if err != nil {
+			s.logger.Error("failed to claim schedule", zap.String("scheduleId", schedule.ID), zap.Error(err))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		run := &entities.ScheduleRun{
+			ScheduleId:  schedule.ID,
+			ContainerId: schedule.ContainerId,
+			Action:      schedule.Action,
+			Status:      entities.ScheduleRunSucceeded,
+			RanAt:       now,
+		}
+		if err := s.execute(ctx, schedule); err != nil {
+			run.Status = entities.ScheduleRunFailed
+			run.Error = err.Error()
+		}
+		if err := s.scheduleRepo.CreateRun(run); err != nil {
+			s.logger.Error("failed to create schedule run", zap.String("scheduleId", schedule.ID), zap.Error(err))
+		}
+		ran++
+	}
+	s.logger.Info("due schedules run successfully", zap.Int("count", ran))
+	return ran, nil
+}
+
+// execute performs the action of the schedule, as long as its container is not in the trash.
+func (s *ScheduleService) execute(ctx context.Context, schedule *entities.Schedule) error {
+	if _, err := s.containerService.FindById(ctx, schedule.ContainerId); err != nil {
+		return err
+	}
+
+	switch schedule.Action {
+	case entities.ScheduleStart:
+		return s.containerService.Update(ctx, schedule.ContainerId, dto.ContainerUpdate{Status: entities.ContainerOn})
+	case entities.ScheduleStop:
+		return s.containerService.Update(ctx, schedule.ContainerId, dto.ContainerUpdate{Status: entities.ContainerOff})
+	case entities.ScheduleRestart:
+		if err := s.containerService.Update(ctx, schedule.ContainerId, dto.ContainerUpdate{Status: entities.ContainerOff}); err != nil {
+			return err
+		}
+		return s.containerService.Update(ctx, schedule.ContainerId, dto.ContainerUpdate{Status: entities.ContainerOn})
+	}
+	return fmt.Errorf("unknown schedule action: %s", schedule.Action)
+}
@@ -0,0 +1,281 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/containerd/errdefs"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
+	"github.com/vnFuhung2903/vcs-sms/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+)
+
+type ScheduleServiceSuite struct {
+	suite.Suite
+	ctrl                 *gomock.Controller
+	scheduleService      IScheduleService
+	mockRepo             *repositories.MockIScheduleRepository
+	mockContainerService *services.MockIContainerService
+	logger               *logger.MockILogger
+	ctx                  context.Context
+}
+
+func (s *ScheduleServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = repositories.NewMockIScheduleRepository(s.ctrl)
+	s.mockContainerService = services.NewMockIContainerService(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.scheduleService = NewScheduleService(s.mockRepo, s.mockContainerService, s.logger)
+	s.ctx = context.Background()
+}
+
+func (s *ScheduleServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestScheduleServiceSuite(t *testing.T) {
+	suite.Run(t, new(ScheduleServiceSuite))
+}
+
+func (s *ScheduleServiceSuite) TestNextRun() {
+	// Monday 2024-01-01 12:00 UTC is 19:00 in Ho Chi Minh City, the next 20:00 there being 13:00 UTC.
+	next, err := nextRun("0 20 * * 1-5", "Asia/Ho_Chi_Minh", time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	s.NoError(err)
+	s.Equal(time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC), next)
+
+	// Friday 20:00 is followed by Monday 08:00.
+	next, err = nextRun("0 8 * * 1-5", "Asia/Ho_Chi_Minh", time.Date(2024, 1, 5, 13, 0, 0, 0, time.UTC))
+	s.NoError(err)
+	s.Equal(time.Date(2024, 1, 8, 1, 0, 0, 0, time.UTC), next)
+}
+
+func (s *ScheduleServiceSuite) TestNextRunInvalid() {
+	_, err := nextRun("0 20 * *", "UTC", time.Now())
+	s.True(errdefs.IsInvalidArgument(err))
+
+	_, err = nextRun("0 20 * * *", "Mars/Olympus_Mons", time.Now())
+	s.True(errdefs.IsInvalidArgument(err))
+
+	_, err = nextRun("0 0 30 2 *", "UTC", time.Now())
+	s.True(errdefs.IsInvalidArgument(err))
+}
+
+func (s *ScheduleServiceSuite) TestCreate() {
+	disabled := false
+	s.mockContainerService.EXPECT().FindById(s.ctx, "test-id").Return(&entities.Container{ContainerId: "test-id", OwnerId: "user-id"}, nil)
+	s.mockRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(schedule *entities.Schedule) error {
+		s.Equal("test-id", schedule.ContainerId)
+		s.Equal("user-id", schedule.OwnerId)
+		s.Equal(entities.ScheduleStop, schedule.Action)
+		s.Equal("UTC", schedule.Timezone)
+		s.False(schedule.Enabled)
+		s.True(schedule.NextRunAt.After(time.Now()))
+		schedule.ID = "schedule-id"
+		return nil
+	})
+	s.logger.EXPECT().Info("schedule created successfully", gomock.Any(), gomock.Any()).Times(1)
+
+	schedule, err := s.scheduleService.Create(s.ctx, "test-id", dto.ScheduleRequest{Action: entities.ScheduleStop, Cron: "0 20 * * 1-5", Enabled: &disabled})
+	s.NoError(err)
+	s.Equal("schedule-id", schedule.ID)
+}
+
+func (s *ScheduleServiceSuite) TestCreateContainerNotFound() {
+	s.mockContainerService.EXPECT().FindById(s.ctx, "test-id").Return(nil, ErrContainerNotFound)
+
+	_, err := s.scheduleService.Create(s.ctx, "test-id", dto.ScheduleRequest{Action: entities.ScheduleStop, Cron: "@daily"})
+	s.ErrorIs(err, ErrContainerNotFound)
+}
+
+func (s *ScheduleServiceSuite) TestCreateInvalidCron() {
+	s.mockContainerService.EXPECT().FindById(s.ctx, "test-id").Return(&entities.Container{ContainerId: "test-id"}, nil)
+
+	_, err := s.scheduleService.Create(s.ctx, "test-id", dto.ScheduleRequest{Action: entities.ScheduleStop, Cron: "every evening"})
+	s.True(errdefs.IsInvalidArgument(err))
+}
+
+func (s *ScheduleServiceSuite) TestCreateRepoError() {
+	s.mockContainerService.EXPECT().FindById(s.ctx, "test-id").Return(&entities.Container{ContainerId: "test-id"}, nil)
+	s.mockRepo.EXPECT().Create(gomock.Any()).Return(errors.New("db error"))
+	s.logger.EXPECT().Error("failed to create schedule", gomock.Any()).Times(1)
+
+	_, err := s.scheduleService.Create(s.ctx, "test-id", dto.ScheduleRequest{Action: entities.ScheduleStop, Cron: "@daily"})
+	s.ErrorContains(err, "db error")
+}
+
+func (s *ScheduleServiceSuite) TestView() {
+	expected := []*entities.Schedule{{ID: "schedule-id"}}
+	s.mockRepo.EXPECT().View("test-id").Return(expected, nil)
+
+	schedules, err := s.scheduleService.View(s.ctx, "test-id")
+	s.NoError(err)
+	s.Equal(expected, schedules)
+
+	s.mockRepo.EXPECT().View("test-id").Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to view schedules", gomock.Any()).Times(1)
+
+	_, err = s.scheduleService.View(s.ctx, "test-id")
+	s.ErrorContains(err, "db error")
+}
+
+func (s *ScheduleServiceSuite) TestFindByIdNotFound() {
+	s.mockRepo.EXPECT().FindById("missing").Return(nil, gorm.ErrRecordNotFound)
+	s.mockRepo.EXPECT().FindById("foreign").Return(&entities.Schedule{ID: "foreign", ContainerId: "other-id"}, nil)
+
+	_, err := s.scheduleService.FindById(s.ctx, "test-id", "missing")
+	s.ErrorIs(err, ErrScheduleNotFound)
+	_, err = s.scheduleService.FindById(s.ctx, "test-id", "foreign")
+	s.ErrorIs(err, ErrScheduleNotFound)
+}
+
+func (s *ScheduleServiceSuite) TestFindByIdError() {
+	s.mockRepo.EXPECT().FindById("schedule-id").Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find schedule by id", gomock.Any()).Times(1)
+
+	_, err := s.scheduleService.FindById(s.ctx, "test-id", "schedule-id")
+	s.ErrorContains(err, "db error")
+}
+
+func (s *ScheduleServiceSuite) TestUpdate() {
+	s.mockRepo.EXPECT().FindById("schedule-id").Return(&entities.Schedule{ID: "schedule-id", ContainerId: "test-id", Action: entities.ScheduleStop, Enabled: false}, nil)
+	s.mockRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(schedule *entities.Schedule) error {
+		s.Equal(entities.ScheduleStart, schedule.Action)
+		s.Equal("Europe/Paris", schedule.Timezone)
+		s.True(schedule.Enabled)
+		return nil
+	})
+	s.logger.EXPECT().Info("schedule updated successfully", gomock.Any()).Times(1)
+
+	_, err := s.scheduleService.Update(s.ctx, "test-id", "schedule-id", dto.ScheduleRequest{Action: entities.ScheduleStart, Cron: "0 8 * * 1-5", Timezone: "Europe/Paris"})
+	s.NoError(err)
+}
+
+func (s *ScheduleServiceSuite) TestUpdateInvalidTimezone() {
+	s.mockRepo.EXPECT().FindById("schedule-id").Return(&entities.Schedule{ID: "schedule-id", ContainerId: "test-id"}, nil)
+
+	_, err := s.scheduleService.Update(s.ctx, "test-id", "schedule-id", dto.ScheduleRequest{Action: entities.ScheduleStart, Cron: "0 8 * * *", Timezone: "Nowhere"})
+	s.True(errdefs.IsInvalidArgument(err))
+}
+
+func (s *ScheduleServiceSuite) TestDelete() {
+	s.mockRepo.EXPECT().FindById("schedule-id").Return(&entities.Schedule{ID: "schedule-id", ContainerId: "test-id"}, nil)
+	s.mockRepo.EXPECT().Delete("schedule-id").Return(nil)
+	s.logger.EXPECT().Info("schedule deleted successfully", gomock.Any()).Times(1)
+
+	err := s.scheduleService.Delete(s.ctx, "test-id", "schedule-id")
+	s.NoError(err)
+}
+
+func (s *ScheduleServiceSuite) TestDeleteError() {
+	s.mockRepo.EXPECT().FindById("schedule-id").Return(&entities.Schedule{ID: "schedule-id", ContainerId: "test-id"}, nil)
+	s.mockRepo.EXPECT().Delete("schedule-id").Return(errors.New("db error"))
+	s.logger.EXPECT().Error("failed to delete schedule", gomock.Any()).Times(1)
+
+	err := s.scheduleService.Delete(s.ctx, "test-id", "schedule-id")
+	s.ErrorContains(err, "db error")
+}
+
+func (s *ScheduleServiceSuite) TestRuns() {
+	expected := []*entities.ScheduleRun{{ID: "run-id", Status: entities.ScheduleRunSucceeded}}
+	s.mockRepo.EXPECT().FindById("schedule-id").Return(&entities.Schedule{ID: "schedule-id", ContainerId: "test-id"}, nil)
+	s.mockRepo.EXPECT().ViewRuns("schedule-id", scheduleRunsLimit).Return(expected, nil)
+
+	runs, err := s.scheduleService.Runs(s.ctx, "test-id", "schedule-id")
+	s.NoError(err)
+	s.Equal(expected, runs)
+}
+
+func (s *ScheduleServiceSuite) TestRunsError() {
+	s.mockRepo.EXPECT().FindById("schedule-id").Return(&entities.Schedule{ID: "schedule-id", ContainerId: "test-id"}, nil)
+	s.mockRepo.EXPECT().ViewRuns("schedule-id", scheduleRunsLimit).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to view schedule runs", gomock.Any()).Times(1)
+
+	_, err := s.scheduleService.Runs(s.ctx, "test-id", "schedule-id")
+	s.ErrorContains(err, "db error")
+}
+
+func (s *ScheduleServiceSuite) TestRunDue() {
+	stop := &entities.Schedule{ID: "stop", ContainerId: "c1", Action: entities.ScheduleStop, Cron: "0 20 * * 1-5", Timezone: "UTC"}
+	restart := &entities.Schedule{ID: "restart", ContainerId: "c2", Action: entities.ScheduleRestart, Cron: "@hourly", Timezone: "UTC"}
+	taken := &entities.Schedule{ID: "taken", ContainerId: "c3", Action: entities.ScheduleStart, Cron: "@hourly", Timezone: "UTC"}
+	s.mockRepo.EXPECT().ViewDue(gomock.Any()).Return([]*entities.Schedule{stop, restart, taken}, nil)
+
+	s.mockRepo.EXPECT().Claim(stop, gomock.Any(), gomock.Any()).DoAndReturn(func(schedule *entities.Schedule, nextRunAt time.Time, ranAt time.Time) (bool, error) {
+		s.True(nextRunAt.After(ranAt))
+		s.Equal(20, nextRunAt.Hour())
+		return true, nil
+	})
+	s.mockContainerService.EXPECT().FindById(s.ctx, "c1").Return(&entities.Container{ContainerId: "c1"}, nil)
+	s.mockContainerService.EXPECT().Update(s.ctx, "c1", dto.ContainerUpdate{Status: entities.ContainerOff}).Return(nil)
+	s.mockRepo.EXPECT().CreateRun(gomock.Any()).DoAndReturn(func(run *entities.ScheduleRun) error {
+		s.Equal("stop", run.ScheduleId)
+		s.Equal(entities.ScheduleRunSucceeded, run.Status)
+		return nil
+	})
+
+	s.mockRepo.EXPECT().Claim(restart, gomock.Any(), gomock.Any()).Return(true, nil)
+	s.mockContainerService.EXPECT().FindById(s.ctx, "c2").Return(&entities.Container{ContainerId: "c2"}, nil)
+	gomock.InOrder(
+		s.mockContainerService.EXPECT().Update(s.ctx, "c2", dto.ContainerUpdate{Status: entities.ContainerOff}).Return(nil),
+		s.mockContainerService.EXPECT().Update(s.ctx, "c2", dto.ContainerUpdate{Status: entities.ContainerOn}).Return(errors.New("daemon error")),
+	)
+	s.mockRepo.EXPECT().CreateRun(gomock.Any()).DoAndReturn(func(run *entities.ScheduleRun) error {
+		s.Equal("restart", run.ScheduleId)
+		s.Equal(entities.ScheduleRunFailed, run.Status)
+		s.Equal("daemon error", run.Error)
+		return errors.New("db error")
+	})
+	s.logger.EXPECT().Error("failed to create schedule run", gomock.Any(), gomock.Any()).Times(1)
+
+	s.mockRepo.EXPECT().Claim(taken, gomock.Any(), gomock.Any()).Return(false, nil)
+	s.logger.EXPECT().Info("due schedules run successfully", gomock.Any()).Times(1)
+
+	ran, err := s.scheduleService.RunDue(s.ctx)
+	s.NoError(err)
+	s.Equal(2, ran)
+}
+
+func (s *ScheduleServiceSuite) TestRunDueTrashedContainer() {
+	schedule := &entities.Schedule{ID: "start", ContainerId: "c1", Action: entities.ScheduleStart, Cron: "@daily", Timezone: "UTC"}
+	s.mockRepo.EXPECT().ViewDue(gomock.Any()).Return([]*entities.Schedule{schedule}, nil)
+	s.mockRepo.EXPECT().Claim(schedule, gomock.Any(), gomock.Any()).Return(true, nil)
+	s.mockContainerService.EXPECT().FindById(s.ctx, "c1").Return(nil, ErrContainerNotFound)
+	s.mockRepo.EXPECT().CreateRun(gomock.Any()).DoAndReturn(func(run *entities.ScheduleRun) error {
+		s.Equal(entities.ScheduleRunFailed, run.Status)
+		s.Equal("container not found", run.Error)
+		return nil
+	})
+	s.logger.EXPECT().Info("due schedules run successfully", gomock.Any()).Times(1)
+
+	ran, err := s.scheduleService.RunDue(s.ctx)
+	s.NoError(err)
+	s.Equal(1, ran)
+}
+
+func (s *ScheduleServiceSuite) TestRunDueClaimError() {
+	schedule := &entities.Schedule{ID: "start", ContainerId: "c1", Action: entities.ScheduleStart, Cron: "@daily", Timezone: "UTC"}
+	s.mockRepo.EXPECT().ViewDue(gomock.Any()).Return([]*entities.Schedule{schedule}, nil)
+	s.mockRepo.EXPECT().Claim(schedule, gomock.Any(), gomock.Any()).Return(false, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to claim schedule", gomock.Any(), gomock.Any()).Times(1)
+	s.logger.EXPECT().Info("due schedules run successfully", gomock.Any()).Times(1)
+
+	ran, err := s.scheduleService.RunDue(s.ctx)
+	s.NoError(err)
+	s.Zero(ran)
+}
+
+func (s *ScheduleServiceSuite) TestRunDueViewError() {
+	s.mockRepo.EXPECT().ViewDue(gomock.Any()).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to view due schedules", gomock.Any()).Times(1)
+
+	_, err := s.scheduleService.RunDue(s.ctx)
+	s.ErrorContains(err, "db error")
+}
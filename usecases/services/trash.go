@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/containerd/errdefs"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/pkg/docker"
+	"github.com/vnFuhung2903/vcs-sms/pkg/env"
+	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
+	"github.com/vnFuhung2903/vcs-sms/usecases/repositories"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type ITrashService interface {
+	View(ctx context.Context, ownerId string) ([]*entities.Container, error)
+	FindById(ctx context.Context, containerId string) (*entities.Container, error)
+	Restore(ctx context.Context, containerId string) error
+	Purge(ctx context.Context) (int, error)
+}
+
+type TrashService struct {
+	containerRepo repositories.IContainerRepository
+	dockerClient  docker.IDockerClient
+	quotaService  IQuotaService
+	retention     time.Duration
+	logger        logger.ILogger
+}
+
+func NewTrashService(repo repositories.IContainerRepository, dockerClient docker.IDockerClient, quotaService IQuotaService, logger logger.ILogger, env env.TrashEnv) ITrashService {
+	return &TrashService{
+		containerRepo: repo,
+		dockerClient:  dockerClient,
+		quotaService:  quotaService,
+		retention:     env.Retention,
+		logger:        logger,
+	}
+}
+
+// View lists the deleted containers of the owner, or of everyone when ownerId is empty.
+func (s *TrashService) View(ctx context.Context, ownerId string) ([]*entities.Container, error) {
+	containers, err := s.containerRepo.ViewDeleted(ownerId, time.Time{})
+	if err != nil {
+		s.logger.Error("failed to view trash", zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info("trash listed successfully", zap.Int("count", len(containers)))
+	return containers, nil
+}
+
+func (s *TrashService) FindById(ctx context.Context, containerId string) (*entities.Container, error) {
+	container, err := s.containerRepo.FindDeletedById(containerId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrContainerNotFound, containerId)
+	}
+	if err != nil {
+		s.logger.Error("failed to find deleted container", zap.Error(err))
+		return nil, err
+	}
+	return container, nil
+}
+
+// Restore takes a container out of the trash, still stopped, provided its owner has the quota for it again.
+func (s *TrashService) Restore(ctx context.Context, containerId string) error {
+	container, err := s.FindById(ctx, containerId)
+	if err != nil {
+		return err
+	}
+
+	err = s.quotaService.Reserve(ctx, container.OwnerId, 1, container.Spec.Resources.Memory, func(tx *gorm.DB) error {
+		if err := s.containerRepo.WithTransaction(tx).Restore(containerId); err != nil {
+			s.logger.Error("failed to restore container", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.logger.Info("container restored successfully", zap.String("containerId", containerId))
+	return nil
+}
+
+// Purge removes for good the containers deleted longer than the retention period ago, docker container included.
+// A container failing to be removed is left for the next purge.
+func (s *TrashService) Purge(ctx context.Context) (int, error) {
+	containers, err := s.containerRepo.ViewDeleted("", time.Now().Add(-s.retention))
+	if err != nil {
+		s.logger.Error("failed to view expired trash", zap.Error(err))
+		return 0, err
+	}
+
+	purged := 0
+	for _, container := range containers {
+		if err := s.dockerClient.Delete(ctx, container.ContainerId); err != nil && !errdefs.IsNotFound(err) {
+			s.logger.Error("failed to delete docker container", zap.String("containerId", container.ContainerId), zap.Error(err))
+			continue
+		}
+		if err := s.containerRepo.Purge(container.ContainerId); err != nil {
+			s.logger.Error("failed to purge container", zap.String("containerId", container.ContainerId), zap.Error(err))
+			continue
+		}
+		purged++
+	}
+	s.logger.Info("trash purged successfully", zap.Int("count", purged))
+	return purged, nil
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/containerd/errdefs"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/docker"
+	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
+	"github.com/vnFuhung2903/vcs-sms/mocks/repositories"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+	"github.com/vnFuhung2903/vcs-sms/pkg/env"
+)
+
+type TrashServiceSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	trashService ITrashService
+	mockRepo     *repositories.MockIContainerRepository
+	dockerClient *docker.MockIDockerClient
+	quotaService *services.MockIQuotaService
+	logger       *logger.MockILogger
+	ctx          context.Context
+}
+
+func (s *TrashServiceSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockRepo = repositories.NewMockIContainerRepository(s.ctrl)
+	s.dockerClient = docker.NewMockIDockerClient(s.ctrl)
+	s.quotaService = services.NewMockIQuotaService(s.ctrl)
+	s.logger = logger.NewMockILogger(s.ctrl)
+	s.trashService = NewTrashService(s.mockRepo, s.dockerClient, s.quotaService, s.logger, env.TrashEnv{Retention: time.Hour})
+	s.ctx = context.Background()
+}
+
+func (s *TrashServiceSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestTrashServiceSuite(t *testing.T) {
+	suite.Run(t, new(TrashServiceSuite))
+}
+
+// expectReserve stands in for the quota reservation of the restored container, running its record in a transaction
+// of an in-memory database unless the check fails with checkErr.
+func (s *TrashServiceSuite) expectReserve(ownerId string, memory int64, checkErr error) {
+	s.quotaService.EXPECT().
+		Reserve(s.ctx, ownerId, int64(1), memory, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ int64, _ int64, record func(tx *gorm.DB) error) error {
+			if checkErr != nil {
+				return checkErr
+			}
+			db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+			s.Require().NoError(err)
+			return record(db.Begin())
+		})
+	if checkErr == nil {
+		s.mockRepo.EXPECT().WithTransaction(gomock.Any()).Return(s.mockRepo)
+	}
+}
+
+func (s *TrashServiceSuite) TestView() {
+	expected := []*entities.Container{{ContainerId: "test-id"}}
+	s.mockRepo.EXPECT().ViewDeleted("user-id", time.Time{}).Return(expected, nil)
+	s.logger.EXPECT().Info("trash listed successfully", gomock.Any()).Times(1)
+
+	containers, err := s.trashService.View(s.ctx, "user-id")
+	s.NoError(err)
+	s.Equal(expected, containers)
+}
+
+func (s *TrashServiceSuite) TestViewError() {
+	s.mockRepo.EXPECT().ViewDeleted("", time.Time{}).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to view trash", gomock.Any()).Times(1)
+
+	_, err := s.trashService.View(s.ctx, "")
+	s.ErrorContains(err, "db error")
+}
+
+func (s *TrashServiceSuite) TestFindByIdNotFound() {
+	s.mockRepo.EXPECT().FindDeletedById("test-id").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := s.trashService.FindById(s.ctx, "test-id")
+	s.ErrorIs(err, ErrContainerNotFound)
+}
+
+func (s *TrashServiceSuite) TestFindByIdError() {
+	s.mockRepo.EXPECT().FindDeletedById("test-id").Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to find deleted container", gomock.Any()).Times(1)
+
+	_, err := s.trashService.FindById(s.ctx, "test-id")
+	s.ErrorContains(err, "db error")
+}
+
+func (s *TrashServiceSuite) TestRestore() {
+	container := &entities.Container{ContainerId: "test-id", OwnerId: "user-id", Spec: entities.ContainerSpec{Resources: entities.Resources{Memory: 1024}}}
+	s.mockRepo.EXPECT().FindDeletedById("test-id").Return(container, nil)
+	s.expectReserve("user-id", int64(1024), nil)
+	s.mockRepo.EXPECT().Restore("test-id").Return(nil)
+	s.logger.EXPECT().Info("container restored successfully", gomock.Any()).Times(1)
+
+	err := s.trashService.Restore(s.ctx, "test-id")
+	s.NoError(err)
+}
+
+func (s *TrashServiceSuite) TestRestoreQuotaExceeded() {
+	s.mockRepo.EXPECT().FindDeletedById("test-id").Return(&entities.Container{ContainerId: "test-id", OwnerId: "user-id"}, nil)
+	s.expectReserve("user-id", int64(0), ErrQuotaExceeded)
+
+	err := s.trashService.Restore(s.ctx, "test-id")
+	s.ErrorIs(err, ErrQuotaExceeded)
+}
+
+func (s *TrashServiceSuite) TestRestoreError() {
+	s.mockRepo.EXPECT().FindDeletedById("test-id").Return(&entities.Container{ContainerId: "test-id"}, nil)
+	s.expectReserve("", int64(0), nil)
+	s.mockRepo.EXPECT().Restore("test-id").Return(errors.New("db error"))
+	s.logger.EXPECT().Error("failed to restore container", gomock.Any()).Times(1)
+
+	err := s.trashService.Restore(s.ctx, "test-id")
+	s.ErrorContains(err, "db error")
+}
+
+func (s *TrashServiceSuite) TestPurge() {
+	s.mockRepo.EXPECT().ViewDeleted("", gomock.Any()).DoAndReturn(func(ownerId string, deletedBefore time.Time) ([]*entities.Container, error) {
+		s.WithinDuration(time.Now().Add(-time.Hour), deletedBefore, time.Minute)
+		return []*entities.Container{{ContainerId: "gone"}, {ContainerId: "stuck"}, {ContainerId: "expired"}}, nil
+	})
+	s.dockerClient.EXPECT().Delete(s.ctx, "gone").Return(errdefs.ErrNotFound)
+	s.mockRepo.EXPECT().Purge("gone").Return(nil)
+	s.dockerClient.EXPECT().Delete(s.ctx, "stuck").Return(errors.New("daemon error"))
+	s.logger.EXPECT().Error("failed to delete docker container", gomock.Any(), gomock.Any()).Times(1)
+	s.dockerClient.EXPECT().Delete(s.ctx, "expired").Return(nil)
+	s.mockRepo.EXPECT().Purge("expired").Return(nil)
+	s.logger.EXPECT().Info("trash purged successfully", gomock.Any()).Times(1)
+
+	purged, err := s.trashService.Purge(s.ctx)
+	s.NoError(err)
+	s.Equal(2, purged)
+}
+
+func (s *TrashServiceSuite) TestPurgeRepoError() {
+	s.mockRepo.EXPECT().ViewDeleted("", gomock.Any()).Return([]*entities.Container{{ContainerId: "expired"}}, nil)
+	s.dockerClient.EXPECT().Delete(s.ctx, "expired").Return(nil)
+	s.mockRepo.EXPECT().Purge("expired").Return(errors.New("db error"))
+	s.logger.EXPECT().Error("failed to purge container", gomock.Any(), gomock.Any()).Times(1)
+	s.logger.EXPECT().Info("trash purged successfully", gomock.Any()).Times(1)
+
+	purged, err := s.trashService.Purge(s.ctx)
+	s.NoError(err)
+	s.Zero(purged)
+}
+
+func (s *TrashServiceSuite) TestPurgeViewError() {
+	s.mockRepo.EXPECT().ViewDeleted("", gomock.Any()).Return(nil, errors.New("db error"))
+	s.logger.EXPECT().Error("failed to view expired trash", gomock.Any()).Times(1)
+
+	_, err := s.trashService.Purge(s.ctx)
+	s.ErrorContains(err, "db error")
+}
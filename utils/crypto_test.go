@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CryptoSuite struct {
+	suite.Suite
+}
+
+func TestCryptoSuite(t *testing.T) {
+	suite.Run(t, new(CryptoSuite))
+}
+
+func (suite *CryptoSuite) TestEncryptDecrypt() {
+	ciphertext, err := Encrypt("secret", "password")
+	suite.NoError(err)
+	suite.NotEqual("password", ciphertext)
+
+	other, err := Encrypt("secret", "password")
+	suite.NoError(err)
+	suite.NotEqual(ciphertext, other)
+
+	plaintext, err := Decrypt("secret", ciphertext)
+	suite.NoError(err)
+	suite.Equal("password", plaintext)
+}
+
+func (suite *CryptoSuite) TestEmptyValue() {
+	ciphertext, err := Encrypt("secret", "")
+	suite.NoError(err)
+	suite.Empty(ciphertext)
+
+	plaintext, err := Decrypt("secret", "")
+	suite.NoError(err)
+	suite.Empty(plaintext)
+}
+
+func (suite *CryptoSuite) TestDecryptWrongSecret() {
+	ciphertext, err := Encrypt("secret", "password")
+	suite.NoError(err)
+
+	_, err = Decrypt("other", ciphertext)
+	suite.Error(err)
+}
+
+func (suite *CryptoSuite) TestInvalidInput() {
+	_, err := Encrypt("", "password")
+	suite.Error(err)
+
+	_, err = Decrypt("secret", "not base64!")
+	suite.Error(err)
+
+	_, err = Decrypt("secret", "c2hvcnQ=")
+	suite.Error(err)
+}
@@ -6,7 +6,10 @@ import (
 	"github.com/vnFuhung2903/vcs-sms/entities"
 )
 
-var scopeHashMap = []string{"user:modify", "user:manager", "container:create", "container:view", "container:update", "container:delete", "report:mail"}
+var scopeHashMap = []string{"user:modify", "user:manager", "container:create", "container:view", "container:update", "container:delete", "report:mail", "container:admin", "container:logs", "container:exec", "image:view", "image:pull", "image:manage", "registry:manage", "container:files"}
+
+// containerAdminScopes are the scopes container:admin implies, so that an admin can act on the containers it sees.
+var containerAdminScopes = []string{"container:view", "container:update", "container:delete", "container:logs", "container:exec", "container:files"}
 
 func NumberOfScopes() int {
 	return len(scopeHashMap)
@@ -20,12 +23,14 @@ func UserRoleToDefaultScopes(role entities.UserRole, specialScopes *int64) []str
 	switch role {
 	case entities.Developer:
 		{
-			return scopeHashMap
+			return slices.DeleteFunc(slices.Clone(scopeHashMap), func(scope string) bool {
+				return scope == "container:admin" || scope == "image:manage" || scope == "registry:manage"
+			})
 			// return []string{"user:modify", "container:create", "container:view", "container:update", "container:delete", "report:mail"}
 		}
 	case entities.Manager:
 		{
-			return []string{"user:modify", "user:manager", "container:view", "report:mail"}
+			return []string{"user:modify", "user:manager", "container:view", "report:mail", "container:admin", "image:view", "image:manage", "registry:manage"}
 		}
 	default:
 		{
@@ -53,3 +58,11 @@ func HashMapToScopes(scopes int64) []string {
 	}
 	return userScopes
 }
+
+// HasScope reports whether the scopes grant the required one, directly or through container:admin.
+func HasScope(scopes []string, requiredScope string) bool {
+	if requiredScope == "" || slices.Contains(scopes, requiredScope) {
+		return true
+	}
+	return slices.Contains(containerAdminScopes, requiredScope) && slices.Contains(scopes, "container:admin")
+}
@@ -18,14 +18,24 @@ func TestScopeSuite(t *testing.T) {
 
 func (suite *ScopeSuite) TestNumberOfScope() {
 	num := NumberOfScopes()
-	assert.Equal(suite.T(), num, 7)
+	assert.Equal(suite.T(), num, 15)
 }
 
 func (suite *ScopeSuite) TestRoleToDefaultScope() {
 	scopes := UserRoleToDefaultScopes(entities.Developer, nil)
-	assert.Equal(suite.T(), len(scopes), 7)
+	assert.Equal(suite.T(), len(scopes), 12)
+	assert.Contains(suite.T(), scopes, "container:logs")
+	assert.Contains(suite.T(), scopes, "container:exec")
+	assert.Contains(suite.T(), scopes, "container:files")
+	assert.Contains(suite.T(), scopes, "image:pull")
+	assert.NotContains(suite.T(), scopes, "container:admin")
+	assert.NotContains(suite.T(), scopes, "image:manage")
+	assert.NotContains(suite.T(), scopes, "registry:manage")
 	scopes = UserRoleToDefaultScopes(entities.Manager, nil)
-	assert.Equal(suite.T(), len(scopes), 4)
+	assert.Equal(suite.T(), len(scopes), 8)
+	assert.Contains(suite.T(), scopes, "container:admin")
+	assert.Contains(suite.T(), scopes, "image:manage")
+	assert.Contains(suite.T(), scopes, "registry:manage")
 	scopes = UserRoleToDefaultScopes(entities.UserRole("Not-valid"), nil)
 	assert.Equal(suite.T(), len(scopes), 2)
 }
@@ -47,3 +57,13 @@ func (suite *ScopeSuite) TestHashmapToScopes() {
 	scopes := HashMapToScopes(scopeHashmap)
 	assert.Equal(suite.T(), len(scopes), 2)
 }
+
+func (suite *ScopeSuite) TestHasScope() {
+	assert.True(suite.T(), HasScope([]string{"container:view"}, "container:view"))
+	assert.True(suite.T(), HasScope(nil, ""))
+	assert.False(suite.T(), HasScope([]string{"container:view"}, "container:delete"))
+	assert.True(suite.T(), HasScope([]string{"container:admin"}, "container:delete"))
+	assert.True(suite.T(), HasScope([]string{"container:admin"}, "container:exec"))
+	assert.False(suite.T(), HasScope([]string{"container:admin"}, "container:create"))
+	assert.False(suite.T(), HasScope([]string{"container:admin"}, "registry:manage"))
+}
@@ -0,0 +1,128 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/pkg/docker"
+	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
+	"github.com/vnFuhung2903/vcs-sms/usecases/services"
+	"go.uber.org/zap"
+)
+
+type IEventWorker interface {
+	Start(numWorkers int)
+	Stop()
+}
+
+// EventWorker follows the docker events stream and records every status transition as it happens.
+// HealthcheckWorker keeps polling at a lower rate to resynchronise whatever the stream missed.
+type EventWorker struct {
+	dockerClient       docker.IDockerClient
+	containerService   services.IContainerService
+	healthcheckService services.IHealthcheckService
+	logger             logger.ILogger
+	retryInterval      time.Duration
+	ctx                context.Context
+	cancel             context.CancelFunc
+	wg                 *sync.WaitGroup
+}
+
+func NewEventWorker(
+	dockerClient docker.IDockerClient,
+	containerService services.IContainerService,
+	healthcheckService services.IHealthcheckService,
+	logger logger.ILogger,
+	retryInterval time.Duration,
+) IEventWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &EventWorker{
+		dockerClient:       dockerClient,
+		containerService:   containerService,
+		healthcheckService: healthcheckService,
+		logger:             logger,
+		retryInterval:      retryInterval,
+		ctx:                ctx,
+		cancel:             cancel,
+		wg:                 &sync.WaitGroup{},
+	}
+}
+
+func (w *EventWorker) Start(numWorkers int) {
+	w.wg.Add(numWorkers)
+	go w.run()
+}
+
+func (w *EventWorker) Stop() {
+	w.cancel()
+	w.wg.Wait()
+}
+
+func (w *EventWorker) run() {
+	defer w.wg.Done()
+
+	for {
+		messages, errs := w.dockerClient.Events(w.ctx)
+		if !w.consume(messages, errs) {
+			w.logger.Info("docker events workers stopped")
+			return
+		}
+
+		select {
+		case <-w.ctx.Done():
+			w.logger.Info("docker events workers stopped")
+			return
+		case <-time.After(w.retryInterval):
+		}
+	}
+}
+
+// consume handles messages until the stream fails, and reports whether the worker should resubscribe.
+func (w *EventWorker) consume(messages <-chan events.Message, errs <-chan error) bool {
+	for {
+		select {
+		case <-w.ctx.Done():
+			return false
+		case message := <-messages:
+			w.handleEvent(message)
+		case err := <-errs:
+			if errors.Is(err, context.Canceled) {
+				return false
+			}
+			w.logger.Error("docker events stream failed", zap.Error(err))
+			return true
+		}
+	}
+}
+
+func (w *EventWorker) handleEvent(message events.Message) {
+	containerId := message.Actor.ID
+	container, err := w.containerService.FindById(w.ctx, containerId)
+	if errors.Is(err, services.ErrContainerNotFound) {
+		return
+	}
+	if err != nil {
+		w.logger.Error("failed to find container", zap.String("container_id", containerId), zap.Error(err))
+		return
+	}
+
+	status := entities.ContainerMissing
+	if message.Action != events.ActionDestroy {
+		status = w.dockerClient.GetStatus(w.ctx, containerId)
+	}
+	// Several events report the same transition (e.g. die then stop), only the first one is recorded.
+	if status == entities.ContainerUnknown || status == container.Status {
+		return
+	}
+
+	if err := w.containerService.SyncStatus(w.ctx, containerId, status); err != nil {
+		w.logger.Error("failed to update container", zap.String("container_id", containerId))
+	}
+	if err := w.healthcheckService.RecordTransition(w.ctx, containerId, status, time.Unix(0, message.TimeNano)); err != nil {
+		w.logger.Error("failed to record status transition", zap.String("container_id", containerId), zap.Error(err))
+	}
+}
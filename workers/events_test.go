@@ -0,0 +1,118 @@
+package workers
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vnFuhung2903/vcs-sms/entities"
+	"github.com/vnFuhung2903/vcs-sms/mocks/docker"
+	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+	usecases "github.com/vnFuhung2903/vcs-sms/usecases/services"
+)
+
+type EventWorkerSuite struct {
+	suite.Suite
+	ctrl                   *gomock.Controller
+	eventWorker            IEventWorker
+	mockDockerClient       *docker.MockIDockerClient
+	mockContainerService   *services.MockIContainerService
+	mockHealthcheckService *services.MockIHealthcheckService
+	mockLogger             *logger.MockILogger
+	messages               chan events.Message
+	errs                   chan error
+}
+
+func (s *EventWorkerSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockDockerClient = docker.NewMockIDockerClient(s.ctrl)
+	s.mockContainerService = services.NewMockIContainerService(s.ctrl)
+	s.mockHealthcheckService = services.NewMockIHealthcheckService(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
+	s.messages = make(chan events.Message, 10)
+	s.errs = make(chan error, 1)
+
+	s.eventWorker = NewEventWorker(
+		s.mockDockerClient,
+		s.mockContainerService,
+		s.mockHealthcheckService,
+		s.mockLogger,
+		100*time.Millisecond,
+	)
+	s.mockLogger.EXPECT().Info("docker events workers stopped").AnyTimes()
+}
+
+func (s *EventWorkerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestEventWorkerSuite(t *testing.T) {
+	suite.Run(t, new(EventWorkerSuite))
+}
+
+func (s *EventWorkerSuite) runEvents(messages ...events.Message) {
+	for _, message := range messages {
+		s.messages <- message
+	}
+	s.eventWorker.Start(1)
+	time.Sleep(300 * time.Millisecond)
+	s.eventWorker.Stop()
+}
+
+func (s *EventWorkerSuite) TestEventTransition() {
+	timestamp := time.Unix(0, 1735689600000000123)
+
+	s.mockDockerClient.EXPECT().Events(gomock.Any()).Return(s.messages, s.errs)
+	s.mockContainerService.EXPECT().FindById(gomock.Any(), "1").Return(&entities.Container{ContainerId: "1", Status: entities.ContainerOn}, nil)
+	s.mockDockerClient.EXPECT().GetStatus(gomock.Any(), "1").Return(entities.ContainerExited)
+	s.mockContainerService.EXPECT().SyncStatus(gomock.Any(), "1", entities.ContainerExited).Return(nil)
+	s.mockHealthcheckService.EXPECT().RecordTransition(gomock.Any(), "1", entities.ContainerExited, timestamp).Return(nil)
+
+	s.runEvents(events.Message{
+		Type:     events.ContainerEventType,
+		Action:   events.ActionDie,
+		Actor:    events.Actor{ID: "1"},
+		TimeNano: timestamp.UnixNano(),
+	})
+}
+
+func (s *EventWorkerSuite) TestEventDestroy() {
+	s.mockDockerClient.EXPECT().Events(gomock.Any()).Return(s.messages, s.errs)
+	s.mockContainerService.EXPECT().FindById(gomock.Any(), "1").Return(&entities.Container{ContainerId: "1", Status: entities.ContainerOff}, nil)
+	s.mockContainerService.EXPECT().SyncStatus(gomock.Any(), "1", entities.ContainerMissing).Return(errors.New("db error"))
+	s.mockLogger.EXPECT().Error("failed to update container", gomock.Any())
+	s.mockHealthcheckService.EXPECT().RecordTransition(gomock.Any(), "1", entities.ContainerMissing, gomock.Any()).Return(errors.New("es error"))
+	s.mockLogger.EXPECT().Error("failed to record status transition", gomock.Any(), gomock.Any())
+
+	s.runEvents(events.Message{Action: events.ActionDestroy, Actor: events.Actor{ID: "1"}})
+}
+
+func (s *EventWorkerSuite) TestEventSkipped() {
+	s.mockDockerClient.EXPECT().Events(gomock.Any()).Return(s.messages, s.errs)
+	s.mockContainerService.EXPECT().FindById(gomock.Any(), "untracked").Return(nil, fmt.Errorf("%w: untracked", usecases.ErrContainerNotFound))
+	s.mockContainerService.EXPECT().FindById(gomock.Any(), "1").Return(&entities.Container{ContainerId: "1", Status: entities.ContainerOff}, nil).Times(2)
+	s.mockDockerClient.EXPECT().GetStatus(gomock.Any(), "1").Return(entities.ContainerOff)
+	s.mockDockerClient.EXPECT().GetStatus(gomock.Any(), "1").Return(entities.ContainerUnknown)
+	s.mockContainerService.EXPECT().FindById(gomock.Any(), "2").Return(nil, errors.New("db error"))
+	s.mockLogger.EXPECT().Error("failed to find container", gomock.Any(), gomock.Any())
+
+	s.runEvents(
+		events.Message{Action: events.ActionStart, Actor: events.Actor{ID: "untracked"}},
+		events.Message{Action: events.ActionStop, Actor: events.Actor{ID: "1"}},
+		events.Message{Action: events.ActionStop, Actor: events.Actor{ID: "1"}},
+		events.Message{Action: events.ActionStart, Actor: events.Actor{ID: "2"}},
+	)
+}
+
+func (s *EventWorkerSuite) TestEventStreamResubscribe() {
+	s.errs <- errors.New("stream closed")
+	s.mockDockerClient.EXPECT().Events(gomock.Any()).Return(s.messages, s.errs).MinTimes(2)
+	s.mockLogger.EXPECT().Error("docker events stream failed", gomock.Any()).Times(1)
+
+	s.runEvents()
+}
@@ -6,12 +6,16 @@ import (
 	"time"
 
 	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/entities"
 	"github.com/vnFuhung2903/vcs-sms/pkg/docker"
 	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
 	"github.com/vnFuhung2903/vcs-sms/usecases/services"
 	"go.uber.org/zap"
 )
 
+// statsConcurrency bounds how many containers are sampled for stats at once.
+const statsConcurrency = 8
+
 type IHealthcheckWorker interface {
 	Start(numWorkers int)
 	Stop()
@@ -21,6 +25,7 @@ type HealthcheckWorker struct {
 	dockerClient       docker.IDockerClient
 	containerService   services.IContainerService
 	healthcheckService services.IHealthcheckService
+	metricsService     services.IMetricsService
 	logger             logger.ILogger
 	interval           time.Duration
 	ctx                context.Context
@@ -32,6 +37,7 @@ func NewHealthcheckWorker(
 	dockerClient docker.IDockerClient,
 	containerService services.IContainerService,
 	healthcheckService services.IHealthcheckService,
+	metricsService services.IMetricsService,
 	logger logger.ILogger,
 	interval time.Duration,
 ) IHealthcheckWorker {
@@ -40,6 +46,7 @@ func NewHealthcheckWorker(
 		dockerClient:       dockerClient,
 		containerService:   containerService,
 		healthcheckService: healthcheckService,
+		metricsService:     metricsService,
 		logger:             logger,
 		interval:           interval,
 		ctx:                ctx,
@@ -85,11 +92,16 @@ func (w *HealthcheckWorker) updateHealthcheck() {
 	}
 
 	statusList := make([]dto.EsStatusUpdate, 0, total)
+	running := make([]string, 0, total)
 
 	for _, container := range containers {
+		// A container still being created has no docker container yet.
+		if container.Status == entities.ContainerCreating {
+			continue
+		}
 		status := w.dockerClient.GetStatus(w.ctx, container.ContainerId)
-		if status != container.Status {
-			if err := w.containerService.Update(w.ctx, container.ContainerId, dto.ContainerUpdate{Status: status}); err != nil {
+		if status != container.Status && status != entities.ContainerUnknown {
+			if err := w.containerService.SyncStatus(w.ctx, container.ContainerId, status); err != nil {
 				w.logger.Error("failed to update container", zap.String("container_id", container.ContainerId))
 			}
 		}
@@ -97,6 +109,16 @@ func (w *HealthcheckWorker) updateHealthcheck() {
 			ContainerId: container.ContainerId,
 			Status:      status,
 		})
+
+		if status.IsRunning() {
+			running = append(running, container.ContainerId)
+		}
+	}
+
+	if metricsList := w.sampleStats(running); len(metricsList) > 0 {
+		if err := w.metricsService.IndexMetrics(w.ctx, metricsList); err != nil {
+			w.logger.Error("failed to index container metrics", zap.Error(err))
+		}
 	}
 
 	if err := w.healthcheckService.UpdateStatus(w.ctx, statusList, w.interval); err != nil {
@@ -105,3 +127,39 @@ func (w *HealthcheckWorker) updateHealthcheck() {
 	}
 	w.logger.Info("elasticsearch status updated successfully")
 }
+
+// sampleStats reads the stats of the containers, statsConcurrency at a time and within one interval so that a slow
+// daemon cannot hold up the next tick. Containers whose stats could not be read are left out.
+func (w *HealthcheckWorker) sampleStats(containerIds []string) []dto.EsMetricsUpdate {
+	ctx, cancel := context.WithTimeout(w.ctx, w.interval)
+	defer cancel()
+
+	samples := make([]*dto.EsMetricsUpdate, len(containerIds))
+	slots := make(chan struct{}, statsConcurrency)
+	var wg sync.WaitGroup
+	for i, containerId := range containerIds {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			stats, err := w.dockerClient.Stats(ctx, containerId)
+			if err != nil {
+				w.logger.Error("failed to get container stats", zap.String("container_id", containerId), zap.Error(err))
+				return
+			}
+			samples[i] = &dto.EsMetricsUpdate{ContainerId: containerId, Stats: *stats}
+		}()
+	}
+	wg.Wait()
+
+	metricsList := make([]dto.EsMetricsUpdate, 0, len(samples))
+	for _, sample := range samples {
+		if sample != nil {
+			metricsList = append(metricsList, *sample)
+		}
+	}
+	return metricsList
+}
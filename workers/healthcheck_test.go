@@ -1,10 +1,13 @@
 package workers
 
 import (
+	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/docker/docker/api/types/container"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
 	"github.com/vnFuhung2903/vcs-sms/dto"
@@ -21,6 +24,7 @@ type HealthcheckWorkerSuite struct {
 	mockDockerClient       *docker.MockIDockerClient
 	mockContainerService   *services.MockIContainerService
 	mockHealthcheckService *services.MockIHealthcheckService
+	mockMetricsService     *services.MockIMetricsService
 	mockLogger             *logger.MockILogger
 }
 
@@ -29,12 +33,14 @@ func (s *HealthcheckWorkerSuite) SetupTest() {
 	s.mockDockerClient = docker.NewMockIDockerClient(s.ctrl)
 	s.mockContainerService = services.NewMockIContainerService(s.ctrl)
 	s.mockHealthcheckService = services.NewMockIHealthcheckService(s.ctrl)
+	s.mockMetricsService = services.NewMockIMetricsService(s.ctrl)
 	s.mockLogger = logger.NewMockILogger(s.ctrl)
 
 	s.healthcheckWorker = NewHealthcheckWorker(
 		s.mockDockerClient,
 		s.mockContainerService,
 		s.mockHealthcheckService,
+		s.mockMetricsService,
 		s.mockLogger,
 		2*time.Second,
 	)
@@ -66,7 +72,7 @@ func (s *HealthcheckWorkerSuite) TestHealthcheckWorkerStatusChange() {
 		Return(entities.ContainerOff)
 
 	s.mockContainerService.EXPECT().
-		Update(gomock.Any(), "1", dto.ContainerUpdate{Status: entities.ContainerOff}).
+		SyncStatus(gomock.Any(), "1", entities.ContainerOff).
 		Return(nil)
 
 	s.mockHealthcheckService.EXPECT().
@@ -96,7 +102,7 @@ func (s *HealthcheckWorkerSuite) TestHealthcheckWorkerContainerViewError() {
 	s.healthcheckWorker.Stop()
 }
 
-func (s *HealthcheckWorkerSuite) TestHealthcheckWorkerContainerUpdateError() {
+func (s *HealthcheckWorkerSuite) TestHealthcheckWorkerContainerSyncStatusError() {
 	containers := []*entities.Container{
 		{ContainerId: "1", ContainerName: "container1", Status: entities.ContainerOn},
 	}
@@ -110,8 +116,8 @@ func (s *HealthcheckWorkerSuite) TestHealthcheckWorkerContainerUpdateError() {
 		Return(entities.ContainerOff)
 
 	s.mockContainerService.EXPECT().
-		Update(gomock.Any(), "1", dto.ContainerUpdate{Status: entities.ContainerOff}).
-		Return(errors.New("update error"))
+		SyncStatus(gomock.Any(), "1", entities.ContainerOff).
+		Return(errors.New("sync error"))
 
 	s.mockHealthcheckService.EXPECT().
 		UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -140,6 +146,14 @@ func (s *HealthcheckWorkerSuite) TestHealthcheckWorkerNoStatusChange() {
 		GetStatus(gomock.Any(), "1").
 		Return(entities.ContainerOn)
 
+	s.mockDockerClient.EXPECT().
+		Stats(gomock.Any(), "1").
+		Return(&container.StatsResponse{}, nil)
+
+	s.mockMetricsService.EXPECT().
+		IndexMetrics(gomock.Any(), []dto.EsMetricsUpdate{{ContainerId: "1"}}).
+		Return(nil)
+
 	s.mockHealthcheckService.EXPECT().
 		UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil)
@@ -166,6 +180,14 @@ func (s *HealthcheckWorkerSuite) TestHealthcheckWorkerUpdateStatusError() {
 		GetStatus(gomock.Any(), "1").
 		Return(entities.ContainerOn)
 
+	s.mockDockerClient.EXPECT().
+		Stats(gomock.Any(), "1").
+		Return(&container.StatsResponse{}, nil)
+
+	s.mockMetricsService.EXPECT().
+		IndexMetrics(gomock.Any(), []dto.EsMetricsUpdate{{ContainerId: "1"}}).
+		Return(nil)
+
 	s.mockHealthcheckService.EXPECT().
 		UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(errors.New("update status error"))
@@ -178,3 +200,171 @@ func (s *HealthcheckWorkerSuite) TestHealthcheckWorkerUpdateStatusError() {
 
 	s.healthcheckWorker.Stop()
 }
+
+func (s *HealthcheckWorkerSuite) TestHealthcheckWorkerStatsError() {
+	containers := []*entities.Container{
+		{ContainerId: "1", ContainerName: "container1", Status: entities.ContainerOn},
+	}
+
+	s.mockContainerService.EXPECT().
+		View(gomock.Any(), gomock.Any(), 1, -1, gomock.Any()).
+		Return(containers, int64(1), nil)
+
+	s.mockDockerClient.EXPECT().
+		GetStatus(gomock.Any(), "1").
+		Return(entities.ContainerOn)
+
+	s.mockDockerClient.EXPECT().
+		Stats(gomock.Any(), "1").
+		Return(nil, errors.New("stats error"))
+
+	s.mockHealthcheckService.EXPECT().
+		UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	s.mockLogger.EXPECT().Error("failed to get container stats", gomock.Any()).AnyTimes()
+	s.mockLogger.EXPECT().Info("elasticsearch status updated successfully").AnyTimes()
+	s.mockLogger.EXPECT().Info("elasticsearch status workers stopped").AnyTimes()
+
+	s.healthcheckWorker.Start(1)
+	time.Sleep(3 * time.Second)
+
+	s.healthcheckWorker.Stop()
+}
+
+func (s *HealthcheckWorkerSuite) TestHealthcheckWorkerIndexMetricsError() {
+	containers := []*entities.Container{
+		{ContainerId: "1", ContainerName: "container1", Status: entities.ContainerOn},
+	}
+
+	s.mockContainerService.EXPECT().
+		View(gomock.Any(), gomock.Any(), 1, -1, gomock.Any()).
+		Return(containers, int64(1), nil)
+
+	s.mockDockerClient.EXPECT().
+		GetStatus(gomock.Any(), "1").
+		Return(entities.ContainerOn)
+
+	s.mockDockerClient.EXPECT().
+		Stats(gomock.Any(), "1").
+		Return(&container.StatsResponse{}, nil)
+
+	s.mockMetricsService.EXPECT().
+		IndexMetrics(gomock.Any(), gomock.Any()).
+		Return(errors.New("index error"))
+
+	s.mockHealthcheckService.EXPECT().
+		UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	s.mockLogger.EXPECT().Error("failed to index container metrics", gomock.Any()).AnyTimes()
+	s.mockLogger.EXPECT().Info("elasticsearch status updated successfully").AnyTimes()
+	s.mockLogger.EXPECT().Info("elasticsearch status workers stopped").AnyTimes()
+
+	s.healthcheckWorker.Start(1)
+	time.Sleep(3 * time.Second)
+
+	s.healthcheckWorker.Stop()
+}
+
+func (s *HealthcheckWorkerSuite) TestHealthcheckWorkerUnknownStatus() {
+	containers := []*entities.Container{
+		{ContainerId: "1", ContainerName: "container1", Status: entities.ContainerOn},
+	}
+
+	s.mockContainerService.EXPECT().
+		View(gomock.Any(), gomock.Any(), 1, -1, gomock.Any()).
+		Return(containers, int64(1), nil)
+
+	s.mockDockerClient.EXPECT().
+		GetStatus(gomock.Any(), "1").
+		Return(entities.ContainerUnknown)
+
+	s.mockHealthcheckService.EXPECT().
+		UpdateStatus(gomock.Any(), []dto.EsStatusUpdate{{ContainerId: "1", Status: entities.ContainerUnknown}}, gomock.Any()).
+		Return(nil)
+
+	s.mockLogger.EXPECT().Info("elasticsearch status updated successfully").AnyTimes()
+	s.mockLogger.EXPECT().Info("elasticsearch status workers stopped").AnyTimes()
+
+	s.healthcheckWorker.Start(1)
+	time.Sleep(3 * time.Second)
+
+	s.healthcheckWorker.Stop()
+}
+
+func (s *HealthcheckWorkerSuite) TestHealthcheckWorkerSkipsCreating() {
+	containers := []*entities.Container{
+		{ContainerId: "pending-1", ContainerName: "container1", Status: entities.ContainerCreating},
+	}
+
+	s.mockContainerService.EXPECT().
+		View(gomock.Any(), gomock.Any(), 1, -1, gomock.Any()).
+		Return(containers, int64(1), nil)
+
+	s.mockHealthcheckService.EXPECT().
+		UpdateStatus(gomock.Any(), []dto.EsStatusUpdate{}, gomock.Any()).
+		Return(nil)
+
+	s.mockLogger.EXPECT().Info("elasticsearch status updated successfully").AnyTimes()
+	s.mockLogger.EXPECT().Info("elasticsearch status workers stopped").AnyTimes()
+
+	s.healthcheckWorker.Start(1)
+	time.Sleep(3 * time.Second)
+
+	s.healthcheckWorker.Stop()
+}
+
+func (s *HealthcheckWorkerSuite) TestHealthcheckWorkerSamplesStatsConcurrently() {
+	containers := []*entities.Container{
+		{ContainerId: "1", ContainerName: "container1", Status: entities.ContainerOn},
+		{ContainerId: "2", ContainerName: "container2", Status: entities.ContainerOn},
+		{ContainerId: "3", ContainerName: "container3", Status: entities.ContainerOn},
+	}
+
+	s.mockContainerService.EXPECT().
+		View(gomock.Any(), gomock.Any(), 1, -1, gomock.Any()).
+		Return(containers, int64(3), nil)
+
+	s.mockDockerClient.EXPECT().
+		GetStatus(gomock.Any(), gomock.Any()).
+		Return(entities.ContainerOn).
+		Times(3)
+
+	// Every sample waits for the others to start, which only a concurrent sampling gets past before the deadline.
+	var mu sync.Mutex
+	started := 0
+	allStarted := make(chan struct{})
+	s.mockDockerClient.EXPECT().
+		Stats(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ string) (*container.StatsResponse, error) {
+			mu.Lock()
+			if started++; started == len(containers) {
+				close(allStarted)
+			}
+			mu.Unlock()
+			select {
+			case <-allStarted:
+				return &container.StatsResponse{}, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}).
+		Times(3)
+
+	s.mockMetricsService.EXPECT().
+		IndexMetrics(gomock.Any(), []dto.EsMetricsUpdate{{ContainerId: "1"}, {ContainerId: "2"}, {ContainerId: "3"}}).
+		Return(nil)
+
+	s.mockHealthcheckService.EXPECT().
+		UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	s.mockLogger.EXPECT().Info("elasticsearch status updated successfully").AnyTimes()
+	s.mockLogger.EXPECT().Info("elasticsearch status workers stopped").AnyTimes()
+
+	s.healthcheckWorker.Start(1)
+	time.Sleep(3 * time.Second)
+
+	s.healthcheckWorker.Stop()
+}
@@ -0,0 +1,114 @@
+package workers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
+	"github.com/vnFuhung2903/vcs-sms/usecases/services"
+	"go.uber.org/zap"
+)
+
+// jobRecoverInterval is how often the workers look for jobs whose lease expired.
+const jobRecoverInterval = time.Minute
+
+type IJobWorker interface {
+	Start(numWorkers int)
+	Stop()
+}
+
+type JobWorker struct {
+	jobService services.IJobService
+	logger     logger.ILogger
+	interval   time.Duration
+	ctx        context.Context
+	cancel     context.CancelFunc
+	wg         *sync.WaitGroup
+}
+
+func NewJobWorker(
+	jobService services.IJobService,
+	logger logger.ILogger,
+	interval time.Duration,
+) IJobWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &JobWorker{
+		jobService: jobService,
+		logger:     logger,
+		interval:   interval,
+		ctx:        ctx,
+		cancel:     cancel,
+		wg:         &sync.WaitGroup{},
+	}
+}
+
+// Start requeues the jobs whose worker went away before polling the queue, and keeps doing so in the background as
+// the jobs of a replica that stopped only become recoverable once their lease expired.
+func (w *JobWorker) Start(numWorkers int) {
+	w.requeue()
+
+	w.wg.Add(numWorkers + 1)
+	for range numWorkers {
+		go w.run()
+	}
+	go w.recoverExpired()
+}
+
+func (w *JobWorker) Stop() {
+	w.cancel()
+	w.wg.Wait()
+}
+
+func (w *JobWorker) run() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			w.logger.Info("job workers stopped")
+			return
+		case <-ticker.C:
+			w.drain()
+		}
+	}
+}
+
+// drain runs queued jobs back to back until the queue is empty.
+func (w *JobWorker) drain() {
+	for w.ctx.Err() == nil {
+		ran, err := w.jobService.RunNext(w.ctx)
+		if err != nil {
+			w.logger.Error("failed to run job", zap.Error(err))
+			return
+		}
+		if !ran {
+			return
+		}
+	}
+}
+
+func (w *JobWorker) recoverExpired() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(jobRecoverInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			w.requeue()
+		}
+	}
+}
+
+func (w *JobWorker) requeue() {
+	if err := w.jobService.Recover(w.ctx); err != nil {
+		w.logger.Error("failed to recover jobs", zap.Error(err))
+	}
+}
@@ -0,0 +1,74 @@
+package workers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+)
+
+type JobWorkerSuite struct {
+	suite.Suite
+	ctrl           *gomock.Controller
+	jobWorker      IJobWorker
+	mockJobService *services.MockIJobService
+	mockLogger     *logger.MockILogger
+}
+
+func (s *JobWorkerSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockJobService = services.NewMockIJobService(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
+
+	s.jobWorker = NewJobWorker(s.mockJobService, s.mockLogger, time.Second)
+}
+
+func (s *JobWorkerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestJobWorkerSuite(t *testing.T) {
+	suite.Run(t, new(JobWorkerSuite))
+}
+
+func (s *JobWorkerSuite) TestDrainQueue() {
+	s.mockJobService.EXPECT().Recover(gomock.Any()).Return(nil)
+	gomock.InOrder(
+		s.mockJobService.EXPECT().RunNext(gomock.Any()).Return(true, nil).Times(2),
+		s.mockJobService.EXPECT().RunNext(gomock.Any()).Return(false, nil).AnyTimes(),
+	)
+	s.mockLogger.EXPECT().Info("job workers stopped").Times(2)
+
+	s.jobWorker.Start(2)
+	time.Sleep(1500 * time.Millisecond)
+
+	s.jobWorker.Stop()
+}
+
+func (s *JobWorkerSuite) TestRecoverError() {
+	s.mockJobService.EXPECT().Recover(gomock.Any()).Return(errors.New("db error"))
+	s.mockJobService.EXPECT().RunNext(gomock.Any()).Return(false, nil).AnyTimes()
+	s.mockLogger.EXPECT().Error("failed to recover jobs", gomock.Any()).Times(1)
+	s.mockLogger.EXPECT().Info("job workers stopped").Times(1)
+
+	s.jobWorker.Start(1)
+	time.Sleep(1500 * time.Millisecond)
+
+	s.jobWorker.Stop()
+}
+
+func (s *JobWorkerSuite) TestRunNextError() {
+	s.mockJobService.EXPECT().Recover(gomock.Any()).Return(nil)
+	s.mockJobService.EXPECT().RunNext(gomock.Any()).Return(false, errors.New("db error"))
+	s.mockLogger.EXPECT().Error("failed to run job", gomock.Any()).Times(1)
+	s.mockLogger.EXPECT().Info("job workers stopped").Times(1)
+
+	s.jobWorker.Start(1)
+	time.Sleep(1500 * time.Millisecond)
+
+	s.jobWorker.Stop()
+}
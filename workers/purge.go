@@ -0,0 +1,74 @@
+package workers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
+	"github.com/vnFuhung2903/vcs-sms/usecases/services"
+	"go.uber.org/zap"
+)
+
+type IPurgeWorker interface {
+	Start(numWorkers int)
+	Stop()
+}
+
+type PurgeWorker struct {
+	trashService services.ITrashService
+	logger       logger.ILogger
+	interval     time.Duration
+	ctx          context.Context
+	cancel       context.CancelFunc
+	wg           *sync.WaitGroup
+}
+
+func NewPurgeWorker(
+	trashService services.ITrashService,
+	logger logger.ILogger,
+	interval time.Duration,
+) IPurgeWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &PurgeWorker{
+		trashService: trashService,
+		logger:       logger,
+		interval:     interval,
+		ctx:          ctx,
+		cancel:       cancel,
+		wg:           &sync.WaitGroup{},
+	}
+}
+
+func (w *PurgeWorker) Start(numWorkers int) {
+	w.wg.Add(numWorkers)
+	go w.run()
+}
+
+func (w *PurgeWorker) Stop() {
+	w.cancel()
+	w.wg.Wait()
+}
+
+func (w *PurgeWorker) run() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			w.logger.Info("purge workers stopped")
+			return
+		case <-ticker.C:
+			w.purge()
+		}
+	}
+}
+
+func (w *PurgeWorker) purge() {
+	if _, err := w.trashService.Purge(w.ctx); err != nil {
+		w.logger.Error("failed to purge trash", zap.Error(err))
+	}
+}
@@ -0,0 +1,58 @@
+package workers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+)
+
+type PurgeWorkerSuite struct {
+	suite.Suite
+	ctrl             *gomock.Controller
+	purgeWorker      IPurgeWorker
+	mockTrashService *services.MockITrashService
+	mockLogger       *logger.MockILogger
+}
+
+func (s *PurgeWorkerSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockTrashService = services.NewMockITrashService(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
+
+	s.purgeWorker = NewPurgeWorker(s.mockTrashService, s.mockLogger, 2*time.Second)
+}
+
+func (s *PurgeWorkerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestPurgeWorkerSuite(t *testing.T) {
+	suite.Run(t, new(PurgeWorkerSuite))
+}
+
+func (s *PurgeWorkerSuite) TestPurge() {
+	s.mockTrashService.EXPECT().Purge(gomock.Any()).Return(2, nil).Times(1)
+	s.mockLogger.EXPECT().Info("purge workers stopped").AnyTimes()
+
+	s.purgeWorker.Start(1)
+	time.Sleep(3 * time.Second)
+
+	s.purgeWorker.Stop()
+}
+
+func (s *PurgeWorkerSuite) TestPurgeServiceError() {
+	s.mockTrashService.EXPECT().Purge(gomock.Any()).Return(0, errors.New("db error"))
+
+	s.mockLogger.EXPECT().Error("failed to purge trash", gomock.Any()).Times(1)
+	s.mockLogger.EXPECT().Info("purge workers stopped").AnyTimes()
+
+	s.purgeWorker.Start(1)
+	time.Sleep(3 * time.Second)
+
+	s.purgeWorker.Stop()
+}
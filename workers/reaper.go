@@ -0,0 +1,74 @@
+package workers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
+	"github.com/vnFuhung2903/vcs-sms/usecases/services"
+	"go.uber.org/zap"
+)
+
+type IReaperWorker interface {
+	Start(numWorkers int)
+	Stop()
+}
+
+type ReaperWorker struct {
+	expiryService services.IExpiryService
+	logger        logger.ILogger
+	interval      time.Duration
+	ctx           context.Context
+	cancel        context.CancelFunc
+	wg            *sync.WaitGroup
+}
+
+func NewReaperWorker(
+	expiryService services.IExpiryService,
+	logger logger.ILogger,
+	interval time.Duration,
+) IReaperWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ReaperWorker{
+		expiryService: expiryService,
+		logger:        logger,
+		interval:      interval,
+		ctx:           ctx,
+		cancel:        cancel,
+		wg:            &sync.WaitGroup{},
+	}
+}
+
+func (w *ReaperWorker) Start(numWorkers int) {
+	w.wg.Add(numWorkers)
+	go w.run()
+}
+
+func (w *ReaperWorker) Stop() {
+	w.cancel()
+	w.wg.Wait()
+}
+
+func (w *ReaperWorker) run() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			w.logger.Info("reaper workers stopped")
+			return
+		case <-ticker.C:
+			w.reap()
+		}
+	}
+}
+
+func (w *ReaperWorker) reap() {
+	if _, err := w.expiryService.Reap(w.ctx); err != nil {
+		w.logger.Error("failed to reap expired containers", zap.Error(err))
+	}
+}
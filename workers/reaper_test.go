@@ -0,0 +1,58 @@
+package workers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+)
+
+type ReaperWorkerSuite struct {
+	suite.Suite
+	ctrl              *gomock.Controller
+	reaperWorker      IReaperWorker
+	mockExpiryService *services.MockIExpiryService
+	mockLogger        *logger.MockILogger
+}
+
+func (s *ReaperWorkerSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockExpiryService = services.NewMockIExpiryService(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
+
+	s.reaperWorker = NewReaperWorker(s.mockExpiryService, s.mockLogger, 2*time.Second)
+}
+
+func (s *ReaperWorkerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestReaperWorkerSuite(t *testing.T) {
+	suite.Run(t, new(ReaperWorkerSuite))
+}
+
+func (s *ReaperWorkerSuite) TestReap() {
+	s.mockExpiryService.EXPECT().Reap(gomock.Any()).Return(2, nil).Times(1)
+	s.mockLogger.EXPECT().Info("reaper workers stopped").AnyTimes()
+
+	s.reaperWorker.Start(1)
+	time.Sleep(3 * time.Second)
+
+	s.reaperWorker.Stop()
+}
+
+func (s *ReaperWorkerSuite) TestReapServiceError() {
+	s.mockExpiryService.EXPECT().Reap(gomock.Any()).Return(0, errors.New("db error"))
+
+	s.mockLogger.EXPECT().Error("failed to reap expired containers", gomock.Any()).Times(1)
+	s.mockLogger.EXPECT().Info("reaper workers stopped").AnyTimes()
+
+	s.reaperWorker.Start(1)
+	time.Sleep(3 * time.Second)
+
+	s.reaperWorker.Stop()
+}
@@ -0,0 +1,82 @@
+package workers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
+	"github.com/vnFuhung2903/vcs-sms/usecases/services"
+	"go.uber.org/zap"
+)
+
+type IReconcileWorker interface {
+	Start(numWorkers int)
+	Stop()
+}
+
+type ReconcileWorker struct {
+	reconcileService services.IReconcileService
+	logger           logger.ILogger
+	interval         time.Duration
+	ctx              context.Context
+	cancel           context.CancelFunc
+	wg               *sync.WaitGroup
+}
+
+func NewReconcileWorker(
+	reconcileService services.IReconcileService,
+	logger logger.ILogger,
+	interval time.Duration,
+) IReconcileWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ReconcileWorker{
+		reconcileService: reconcileService,
+		logger:           logger,
+		interval:         interval,
+		ctx:              ctx,
+		cancel:           cancel,
+		wg:               &sync.WaitGroup{},
+	}
+}
+
+func (w *ReconcileWorker) Start(numWorkers int) {
+	w.wg.Add(numWorkers)
+	go w.run()
+}
+
+func (w *ReconcileWorker) Stop() {
+	w.cancel()
+	w.wg.Wait()
+}
+
+func (w *ReconcileWorker) run() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			w.logger.Info("reconcile workers stopped")
+			return
+		case <-ticker.C:
+			w.reconcile()
+		}
+	}
+}
+
+func (w *ReconcileWorker) reconcile() {
+	report, err := w.reconcileService.Reconcile(w.ctx, false)
+	if err != nil {
+		w.logger.Error("failed to reconcile containers", zap.Error(err))
+		return
+	}
+
+	for _, item := range append(report.Untracked, report.Missing...) {
+		if item.Error != "" {
+			w.logger.Warn("container drift left unresolved", zap.String("container_id", item.ContainerId), zap.String("action", string(item.Action)), zap.String("error", item.Error))
+		}
+	}
+}
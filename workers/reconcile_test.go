@@ -0,0 +1,68 @@
+package workers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vnFuhung2903/vcs-sms/dto"
+	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+)
+
+type ReconcileWorkerSuite struct {
+	suite.Suite
+	ctrl                 *gomock.Controller
+	reconcileWorker      IReconcileWorker
+	mockReconcileService *services.MockIReconcileService
+	mockLogger           *logger.MockILogger
+}
+
+func (s *ReconcileWorkerSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockReconcileService = services.NewMockIReconcileService(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
+
+	s.reconcileWorker = NewReconcileWorker(s.mockReconcileService, s.mockLogger, 2*time.Second)
+}
+
+func (s *ReconcileWorkerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestReconcileWorkerSuite(t *testing.T) {
+	suite.Run(t, new(ReconcileWorkerSuite))
+}
+
+func (s *ReconcileWorkerSuite) TestReconcile() {
+	s.mockReconcileService.EXPECT().
+		Reconcile(gomock.Any(), false).
+		Return(&dto.ReconcileReport{
+			Untracked: []dto.ReconcileItem{{ContainerId: "managed", Action: dto.ReconcileAdopt}},
+			Missing:   []dto.ReconcileItem{{ContainerId: "gone", Action: dto.ReconcileDelete, Error: "delete failed"}},
+		}, nil)
+
+	s.mockLogger.EXPECT().Warn("container drift left unresolved", gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+	s.mockLogger.EXPECT().Info("reconcile workers stopped").AnyTimes()
+
+	s.reconcileWorker.Start(1)
+	time.Sleep(3 * time.Second)
+
+	s.reconcileWorker.Stop()
+}
+
+func (s *ReconcileWorkerSuite) TestReconcileServiceError() {
+	s.mockReconcileService.EXPECT().
+		Reconcile(gomock.Any(), false).
+		Return(nil, errors.New("daemon unavailable"))
+
+	s.mockLogger.EXPECT().Error("failed to reconcile containers", gomock.Any()).Times(1)
+	s.mockLogger.EXPECT().Info("reconcile workers stopped").AnyTimes()
+
+	s.reconcileWorker.Start(1)
+	time.Sleep(3 * time.Second)
+
+	s.reconcileWorker.Stop()
+}
@@ -0,0 +1,74 @@
+package workers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vnFuhung2903/vcs-sms/pkg/logger"
+	"github.com/vnFuhung2903/vcs-sms/usecases/services"
+	"go.uber.org/zap"
+)
+
+type IScheduleWorker interface {
+	Start(numWorkers int)
+	Stop()
+}
+
+type ScheduleWorker struct {
+	scheduleService services.IScheduleService
+	logger          logger.ILogger
+	interval        time.Duration
+	ctx             context.Context
+	cancel          context.CancelFunc
+	wg              *sync.WaitGroup
+}
+
+func NewScheduleWorker(
+	scheduleService services.IScheduleService,
+	logger logger.ILogger,
+	interval time.Duration,
+) IScheduleWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ScheduleWorker{
+		scheduleService: scheduleService,
+		logger:          logger,
+		interval:        interval,
+		ctx:             ctx,
+		cancel:          cancel,
+		wg:              &sync.WaitGroup{},
+	}
+}
+
+func (w *ScheduleWorker) Start(numWorkers int) {
+	w.wg.Add(numWorkers)
+	go w.run()
+}
+
+func (w *ScheduleWorker) Stop() {
+	w.cancel()
+	w.wg.Wait()
+}
+
+func (w *ScheduleWorker) run() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			w.logger.Info("schedule workers stopped")
+			return
+		case <-ticker.C:
+			w.runDue()
+		}
+	}
+}
+
+func (w *ScheduleWorker) runDue() {
+	if _, err := w.scheduleService.RunDue(w.ctx); err != nil {
+		w.logger.Error("failed to run due schedules", zap.Error(err))
+	}
+}
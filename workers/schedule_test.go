@@ -0,0 +1,58 @@
+package workers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vnFuhung2903/vcs-sms/mocks/logger"
+	"github.com/vnFuhung2903/vcs-sms/mocks/services"
+)
+
+type ScheduleWorkerSuite struct {
+	suite.Suite
+	ctrl                *gomock.Controller
+	scheduleWorker      IScheduleWorker
+	mockScheduleService *services.MockIScheduleService
+	mockLogger          *logger.MockILogger
+}
+
+func (s *ScheduleWorkerSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockScheduleService = services.NewMockIScheduleService(s.ctrl)
+	s.mockLogger = logger.NewMockILogger(s.ctrl)
+
+	s.scheduleWorker = NewScheduleWorker(s.mockScheduleService, s.mockLogger, 2*time.Second)
+}
+
+func (s *ScheduleWorkerSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func TestScheduleWorkerSuite(t *testing.T) {
+	suite.Run(t, new(ScheduleWorkerSuite))
+}
+
+func (s *ScheduleWorkerSuite) TestRunDue() {
+	s.mockScheduleService.EXPECT().RunDue(gomock.Any()).Return(2, nil).Times(1)
+	s.mockLogger.EXPECT().Info("schedule workers stopped").AnyTimes()
+
+	s.scheduleWorker.Start(1)
+	time.Sleep(3 * time.Second)
+
+	s.scheduleWorker.Stop()
+}
+
+func (s *ScheduleWorkerSuite) TestRunDueServiceError() {
+	s.mockScheduleService.EXPECT().RunDue(gomock.Any()).Return(0, errors.New("db error"))
+
+	s.mockLogger.EXPECT().Error("failed to run due schedules", gomock.Any()).Times(1)
+	s.mockLogger.EXPECT().Info("schedule workers stopped").AnyTimes()
+
+	s.scheduleWorker.Start(1)
+	time.Sleep(3 * time.Second)
+
+	s.scheduleWorker.Stop()
+}